@@ -42,6 +42,9 @@ func New(httpClient *http.Client) (client *Client, err error) {
 		Policy: &OrganizationPolicyService{
 			client: client,
 		},
+		Directory: &OrganizationDirectoryService{client: client},
+		Group:     &OrganizationGroupService{client: client},
+		Workspace: &OrganizationWorkspaceService{client: client},
 	}
 
 	client.User = &UserService{
@@ -113,6 +116,7 @@ func transformTheHTTPResponse(response *http.Response, structure interface{}) (r
 	responseTransformed.Code = response.StatusCode
 	responseTransformed.Endpoint = response.Request.URL.String()
 	responseTransformed.Method = response.Request.Method
+	responseTransformed.Headers = response.Header
 
 	var wasSuccess = response.StatusCode >= 200 && response.StatusCode < 300
 	if !wasSuccess {
@@ -94,6 +94,9 @@ func startMockClient(site string) (*Client, error) {
 		Policy: &OrganizationPolicyService{
 			client: client,
 		},
+		Directory: &OrganizationDirectoryService{client: client},
+		Group:     &OrganizationGroupService{client: client},
+		Workspace: &OrganizationWorkspaceService{client: client},
 	}
 
 	client.User = &UserService{
@@ -0,0 +1,125 @@
+package auditexport
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Checkpoint is the progress of the exporter. The events are exported by windows, from the time of the last event
+// exported to the time the window is opened, and the pages of a window are read by cursor, so an export
+// interrupted resumes on the page after the last page saved.
+type Checkpoint struct {
+
+	// LastEventTime is the time of the newest event of the windows completed, and LastEventIDs the ids of the
+	// events on that second, they're skipped by the next window as the windows start on it.
+	LastEventTime time.Time `json:"lastEventTime"`
+	LastEventIDs  []string  `json:"lastEventIds,omitempty"`
+
+	// WindowFrom and WindowTo are the window in progress, WindowTo is zero when there's none.
+	WindowFrom time.Time `json:"windowFrom"`
+	WindowTo   time.Time `json:"windowTo"`
+
+	// Cursor is the cursor of the next page of the window in progress.
+	Cursor string `json:"cursor,omitempty"`
+
+	// WindowLastTime and WindowLastIDs are the newest events of the window in progress.
+	WindowLastTime time.Time `json:"windowLastTime"`
+	WindowLastIDs  []string  `json:"windowLastIds,omitempty"`
+
+	// Position is the position of the sink after the last page saved, when the sink implements Positioner.
+	// Positioned is set once it's saved, the sink isn't truncated to the position of an empty checkpoint.
+	Position   int64 `json:"position"`
+	Positioned bool  `json:"positioned,omitempty"`
+}
+
+// CheckpointStore persists the checkpoint, Load returns an empty checkpoint when none was saved.
+type CheckpointStore interface {
+	Load(ctx context.Context) (*Checkpoint, error)
+	Save(ctx context.Context, checkpoint *Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory, e.g. for the tests or the exports not resumed.
+type MemoryCheckpointStore struct {
+	mu         sync.Mutex
+	checkpoint Checkpoint
+}
+
+func (m *MemoryCheckpointStore) Load(ctx context.Context) (*Checkpoint, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	checkpoint := m.checkpoint
+	return &checkpoint, nil
+}
+
+func (m *MemoryCheckpointStore) Save(ctx context.Context, checkpoint *Checkpoint) error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.checkpoint = *checkpoint
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoint on a JSON file, replaced atomically on every save.
+type FileCheckpointStore struct {
+	Path string
+}
+
+func (f *FileCheckpointStore) Load(ctx context.Context) (*Checkpoint, error) {
+
+	checkpoint := &Checkpoint{}
+
+	content, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, checkpoint); err != nil {
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
+
+func (f *FileCheckpointStore) Save(ctx context.Context, checkpoint *Checkpoint) error {
+
+	content, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	temporary, err := ioutil.TempFile(filepath.Dir(f.Path), filepath.Base(f.Path)+".*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := temporary.Write(content); err != nil {
+		temporary.Close()
+		os.Remove(temporary.Name())
+		return err
+	}
+
+	if err := temporary.Sync(); err != nil {
+		temporary.Close()
+		os.Remove(temporary.Name())
+		return err
+	}
+
+	if err := temporary.Close(); err != nil {
+		os.Remove(temporary.Name())
+		return err
+	}
+
+	return os.Rename(temporary.Name(), f.Path)
+}
@@ -0,0 +1,265 @@
+// Package auditexport exports the audit events of an organization to a sink, e.g. a JSON lines file read by a SIEM,
+// checkpointing its progress so an export interrupted resumes without duplicating the events.
+package auditexport
+
+import (
+	"context"
+	"errors"
+	"github.com/chrisccoy/go-atlassian/admin"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoOrganizationID  = errors.New("auditexport: no organization id set")
+	ErrNoSink            = errors.New("auditexport: no sink set")
+	ErrNoCheckpointStore = errors.New("auditexport: no checkpoint store set")
+)
+
+// Event is an audit event, with the display names of its action when the events are enriched.
+type Event struct {
+	*model.OrganizationEventModelScheme
+	ActionDisplayName string `json:"actionDisplayName,omitempty"`
+	ActionGroup       string `json:"actionGroup,omitempty"`
+}
+
+// Time returns the time of the event, it's zero when the time can't be parsed.
+func (e *Event) Time() time.Time {
+
+	if e.Attributes == nil {
+		return time.Time{}
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, e.Attributes.Time)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return parsed
+}
+
+type Options struct {
+	OrganizationID string
+	Sink           Sink
+	Checkpoints    CheckpointStore
+
+	// Since is the start of the first window, the events are exported from the oldest one when it's zero.
+	Since time.Time
+
+	// Query and Action filter the events, see model.OrganizationEventOptScheme.
+	Query  string
+	Action string
+
+	// Enrich adds the display names of the actions to the events, from OrganizationService.Actions.
+	Enrich bool
+
+	// PollInterval is the pause of Run between two exports, a minute by default.
+	PollInterval time.Duration
+}
+
+type Exporter struct {
+	client  *admin.Client
+	options *Options
+	now     func() time.Time
+	actions map[string]*model.OrganizationEventActionModelAttributesScheme
+}
+
+func NewExporter(client *admin.Client, options *Options) (*Exporter, error) {
+
+	if options == nil || options.OrganizationID == "" {
+		return nil, ErrNoOrganizationID
+	}
+
+	if options.Sink == nil {
+		return nil, ErrNoSink
+	}
+
+	if options.Checkpoints == nil {
+		return nil, ErrNoCheckpointStore
+	}
+
+	if options.PollInterval <= 0 {
+		options.PollInterval = time.Minute
+	}
+
+	return &Exporter{client: client, options: options, now: time.Now}, nil
+}
+
+// Run exports the events until the context is done, polling for new events every PollInterval.
+// It returns the context error, or the first export error.
+func (e *Exporter) Run(ctx context.Context) error {
+
+	for {
+
+		if _, err := e.Export(ctx); err != nil {
+			return err
+		}
+
+		timer := time.NewTimer(e.options.PollInterval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Export writes the events after the checkpoint to the sink, resuming the window in progress if any, or opening
+// a window up to now, and returns the number of events written.
+func (e *Exporter) Export(ctx context.Context) (int, error) {
+
+	checkpoint, err := e.options.Checkpoints.Load(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if positioner, ok := e.options.Sink.(Positioner); ok {
+
+		position, err := positioner.Position()
+		if err != nil {
+			return 0, err
+		}
+
+		// The events written after the last checkpoint are written again. A new, or lost, checkpoint wasn't saved
+		// against the sink, the sink is appended to, e.g. an existing file isn't emptied.
+		if checkpoint.Positioned && position > checkpoint.Position {
+			if err := positioner.Truncate(checkpoint.Position); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if e.options.Enrich && e.actions == nil {
+		if err := e.loadActions(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	if checkpoint.WindowTo.IsZero() {
+
+		checkpoint.WindowFrom = checkpoint.LastEventTime
+		if checkpoint.WindowFrom.IsZero() {
+			checkpoint.WindowFrom = e.options.Since
+		}
+
+		checkpoint.WindowTo = e.now().Truncate(time.Second)
+		checkpoint.Cursor, checkpoint.WindowLastTime, checkpoint.WindowLastIDs = "", time.Time{}, nil
+	}
+
+	options := &model.OrganizationEventOptScheme{
+		Q:      e.options.Query,
+		From:   checkpoint.WindowFrom,
+		To:     checkpoint.WindowTo,
+		Action: e.options.Action,
+	}
+
+	skipped := make(map[string]bool, len(checkpoint.LastEventIDs))
+	for _, id := range checkpoint.LastEventIDs {
+		skipped[id] = true
+	}
+
+	written := 0
+
+	for {
+
+		page, _, err := e.client.Organization.Events(ctx, e.options.OrganizationID, options, checkpoint.Cursor)
+		if err != nil {
+			return written, err
+		}
+
+		var events []*Event
+		for _, data := range page.Data {
+
+			event := &Event{OrganizationEventModelScheme: data}
+			eventTime := event.Time().Truncate(time.Second)
+
+			// The window starts on the second of the last event exported, its events are exported already.
+			if skipped[data.ID] && eventTime.Equal(checkpoint.LastEventTime.Truncate(time.Second)) {
+				continue
+			}
+
+			switch {
+			case eventTime.After(checkpoint.WindowLastTime):
+				checkpoint.WindowLastTime, checkpoint.WindowLastIDs = eventTime, []string{data.ID}
+			case eventTime.Equal(checkpoint.WindowLastTime):
+				checkpoint.WindowLastIDs = append(checkpoint.WindowLastIDs, data.ID)
+			}
+
+			e.enrich(event)
+			events = append(events, event)
+		}
+
+		if len(events) != 0 {
+
+			if err := e.options.Sink.WriteEvents(events); err != nil {
+				return written, err
+			}
+
+			written += len(events)
+		}
+
+		checkpoint.Cursor = page.Meta.Next
+
+		// The window is completed, the next one starts on its newest event.
+		if checkpoint.Cursor == "" {
+
+			switch {
+			case checkpoint.WindowLastTime.After(checkpoint.LastEventTime):
+				checkpoint.LastEventTime, checkpoint.LastEventIDs = checkpoint.WindowLastTime, checkpoint.WindowLastIDs
+			case !checkpoint.WindowLastTime.IsZero() && checkpoint.WindowLastTime.Equal(checkpoint.LastEventTime):
+				checkpoint.LastEventIDs = append(checkpoint.LastEventIDs, checkpoint.WindowLastIDs...)
+			}
+
+			checkpoint.WindowFrom, checkpoint.WindowTo = time.Time{}, time.Time{}
+			checkpoint.WindowLastTime, checkpoint.WindowLastIDs = time.Time{}, nil
+		}
+
+		if positioner, ok := e.options.Sink.(Positioner); ok {
+			if checkpoint.Position, err = positioner.Position(); err != nil {
+				return written, err
+			}
+
+			checkpoint.Positioned = true
+		}
+
+		if err := e.options.Checkpoints.Save(ctx, checkpoint); err != nil {
+			return written, err
+		}
+
+		if checkpoint.Cursor == "" {
+			return written, nil
+		}
+	}
+}
+
+func (e *Exporter) loadActions(ctx context.Context) error {
+
+	actions, _, err := e.client.Organization.Actions(ctx, e.options.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	e.actions = map[string]*model.OrganizationEventActionModelAttributesScheme{}
+	for _, action := range actions.Data {
+		if action.Attributes != nil {
+			e.actions[strings.ToLower(action.ID)] = action.Attributes
+		}
+	}
+
+	return nil
+}
+
+// enrich adds the display names of the action, the action ids are compared case-insensitively.
+func (e *Exporter) enrich(event *Event) {
+
+	if e.actions == nil || event.Attributes == nil {
+		return
+	}
+
+	if action, ok := e.actions[strings.ToLower(event.Attributes.Action)]; ok {
+		event.ActionDisplayName, event.ActionGroup = action.DisplayName, action.GroupDisplayName
+	}
+}
@@ -0,0 +1,292 @@
+package auditexport
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/chrisccoy/go-atlassian/admin"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeEvent struct {
+	id     string
+	time   time.Time
+	action string
+}
+
+// fakeOrganization serves the events of an organization, the newest first, two per page.
+type fakeOrganization struct {
+	mu     sync.Mutex
+	events []*fakeEvent
+	pages  int
+}
+
+func (f *fakeOrganization) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path == "/admin/v1/orgs/org/event-actions" {
+		_, _ = w.Write([]byte(`{"data":[{"id":"USER_ADDED","type":"eventActions",` +
+			`"attributes":{"displayName":"User added","groupDisplayName":"Users"}}]}`))
+		return
+	}
+
+	if r.URL.Path != "/admin/v1/orgs/org/events" {
+		http.Error(w, "unexpected request "+r.URL.String(), http.StatusNotImplemented)
+		return
+	}
+
+	f.pages++
+	query := r.URL.Query()
+
+	var matched []*fakeEvent
+	for _, event := range f.events {
+
+		if from, err := strconv.ParseInt(query.Get("from"), 10, 64); err == nil && event.time.Unix() < from {
+			continue
+		}
+
+		if to, err := strconv.ParseInt(query.Get("to"), 10, 64); err == nil && event.time.Unix() > to {
+			continue
+		}
+
+		matched = append(matched, event)
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].time.After(matched[j].time) })
+
+	offset, _ := strconv.Atoi(query.Get("cursor"))
+	end := offset + 2
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	data := []interface{}{}
+	for _, event := range matched[offset:end] {
+		data = append(data, map[string]interface{}{
+			"id":         event.id,
+			"type":       "events",
+			"attributes": map[string]interface{}{"time": event.time.Format(time.RFC3339Nano), "action": event.action},
+		})
+	}
+
+	meta := map[string]interface{}{"page_size": 2}
+	if end < len(matched) {
+		meta["next"] = strconv.Itoa(end)
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data, "meta": meta})
+}
+
+func (f *fakeOrganization) add(id string, eventTime time.Time) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.events = append(f.events, &fakeEvent{id: id, time: eventTime, action: "user_added"})
+}
+
+func startClient(t *testing.T, handler http.Handler) (*admin.Client, func()) {
+
+	server := httptest.NewServer(handler)
+
+	client, err := admin.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.Site, err = url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, server.Close
+}
+
+func readIDs(t *testing.T, path string) []string {
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+
+		event := &Event{}
+		if err := json.Unmarshal(scanner.Bytes(), event); err != nil {
+			t.Fatal(err)
+		}
+
+		ids = append(ids, event.ID)
+	}
+
+	return ids
+}
+
+// pageFailingSink fails on a page, the pages before it are written.
+type pageFailingSink struct {
+	*FileSink
+	page, written int
+}
+
+func (p *pageFailingSink) WriteEvents(events []*Event) error {
+
+	if p.written++; p.written == p.page {
+		return errors.New("sink unavailable")
+	}
+
+	return p.FileSink.WriteEvents(events)
+}
+
+func TestExporter_Export(t *testing.T) {
+
+	start := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	fake := &fakeOrganization{}
+	for index, id := range []string{"e1", "e2", "e3", "e4", "e5"} {
+		fake.add(id, start.Add(time.Duration(index)*time.Minute))
+	}
+
+	client, closeServer := startClient(t, fake)
+	defer closeServer()
+
+	directory, err := ioutil.TempDir("", "auditexport")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	eventsPath := filepath.Join(directory, "events.jsonl")
+	sink, err := NewFileSink(eventsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	store := &FileCheckpointStore{Path: filepath.Join(directory, "checkpoint.json")}
+
+	_, err = NewExporter(client, &Options{Sink: sink, Checkpoints: store})
+	assert.Equal(t, ErrNoOrganizationID, err)
+
+	now := start.Add(time.Hour)
+	exporter, err := NewExporter(client, &Options{OrganizationID: "org", Sink: sink, Checkpoints: store, Enrich: true})
+	assert.NoError(t, err)
+	exporter.now = func() time.Time { return now }
+
+	written, err := exporter.Export(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 5, written)
+	assert.Equal(t, 3, fake.pages)
+	assert.Equal(t, []string{"e5", "e4", "e3", "e2", "e1"}, readIDs(t, eventsPath))
+
+	checkpoint, err := store.Load(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, start.Add(4*time.Minute), checkpoint.LastEventTime)
+	assert.Equal(t, []string{"e5"}, checkpoint.LastEventIDs)
+	assert.True(t, checkpoint.WindowTo.IsZero())
+
+	content, err := ioutil.ReadFile(eventsPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"actionDisplayName":"User added","actionGroup":"Users"`)
+
+	// An event on the second of the last event exported is skipped unless its id differs.
+	fake.add("e6", start.Add(4*time.Minute))
+	fake.add("e7", start.Add(70*time.Minute))
+	fake.add("e8", start.Add(71*time.Minute))
+	fake.add("e9", start.Add(72*time.Minute))
+	now = start.Add(2 * time.Hour)
+
+	written, err = exporter.Export(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 4, written)
+	assert.Equal(t, []string{"e5", "e4", "e3", "e2", "e1", "e9", "e8", "e7", "e6"}, readIDs(t, eventsPath))
+
+	// The second page fails after a partial write, the export resumes on the cursor of the second page and the
+	// partial write is truncated.
+	fake.add("e10", start.Add(150*time.Minute))
+	fake.add("e11", start.Add(151*time.Minute))
+	fake.add("e12", start.Add(152*time.Minute))
+	now = start.Add(3 * time.Hour)
+
+	// The first page is written and checkpointed before the failure.
+	exporter.options.Sink = &pageFailingSink{FileSink: sink, page: 2}
+	written, err = exporter.Export(context.Background())
+	assert.EqualError(t, err, "sink unavailable")
+	assert.Equal(t, 2, written)
+
+	checkpoint, err = store.Load(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "2", checkpoint.Cursor)
+
+	partial, err := os.OpenFile(eventsPath, os.O_WRONLY|os.O_APPEND, 0644)
+	assert.NoError(t, err)
+	_, err = partial.WriteString(`{"id":"e10","type":"ev`)
+	assert.NoError(t, err)
+	assert.NoError(t, partial.Close())
+
+	exporter.options.Sink = sink
+	written, err = exporter.Export(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, written)
+	assert.Equal(t, []string{"e5", "e4", "e3", "e2", "e1", "e9", "e8", "e7", "e6", "e12", "e11", "e10"},
+		readIDs(t, eventsPath))
+
+	// Nothing new.
+	written, err = exporter.Export(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, written)
+}
+
+func TestExporter_Export_ExistingFile(t *testing.T) {
+
+	start := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	fake := &fakeOrganization{}
+	fake.add("e2", start)
+
+	client, closeServer := startClient(t, fake)
+	defer closeServer()
+
+	directory, err := ioutil.TempDir("", "auditexport")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	// The file has events exported before, the checkpoint is new.
+	eventsPath := filepath.Join(directory, "events.jsonl")
+	if err := ioutil.WriteFile(eventsPath, []byte(`{"id":"e1"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sink, err := NewFileSink(eventsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	exporter, err := NewExporter(client, &Options{OrganizationID: "org", Sink: sink, Checkpoints: &MemoryCheckpointStore{}})
+	assert.NoError(t, err)
+	exporter.now = func() time.Time { return start.Add(time.Hour) }
+
+	written, err := exporter.Export(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, written)
+	assert.Equal(t, []string{"e1", "e2"}, readIDs(t, eventsPath))
+}
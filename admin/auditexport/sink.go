@@ -0,0 +1,93 @@
+package auditexport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// Sink receives the events of every page, the newest events first.
+type Sink interface {
+	WriteEvents(events []*Event) error
+}
+
+// Positioner is implemented by the sinks able to roll back to a position, the exporter truncates them to the
+// position of the checkpoint before resuming, so the events written after the last checkpoint aren't duplicated.
+// The sinks are never truncated on a checkpoint not saved yet.
+// The events are delivered at least once to the sinks not implementing it.
+type Positioner interface {
+	Position() (int64, error)
+	Truncate(position int64) error
+}
+
+type jsonLinesSink struct{ writer io.Writer }
+
+// NewJSONLinesSink returns a sink writing the events as JSON lines (NDJSON), a page on every write.
+func NewJSONLinesSink(writer io.Writer) Sink {
+	return &jsonLinesSink{writer: writer}
+}
+
+func (j *jsonLinesSink) WriteEvents(events []*Event) error {
+	return writeLines(j.writer, events)
+}
+
+func writeLines(writer io.Writer, events []*Event) error {
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+
+	_, err := writer.Write(buffer.Bytes())
+	return err
+}
+
+// FileSink appends the events to a JSON lines file, it implements Positioner.
+type FileSink struct {
+	file *os.File
+}
+
+// NewFileSink opens, or creates, the file the events are appended to.
+func NewFileSink(path string) (*FileSink, error) {
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileSink{file: file}, nil
+}
+
+func (f *FileSink) WriteEvents(events []*Event) error {
+
+	if err := writeLines(f.file, events); err != nil {
+		return err
+	}
+
+	return f.file.Sync()
+}
+
+// Position returns the size of the file.
+func (f *FileSink) Position() (int64, error) {
+
+	info, err := f.file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	return info.Size(), nil
+}
+
+// Truncate removes the events written after the position.
+func (f *FileSink) Truncate(position int64) error {
+	return f.file.Truncate(position)
+}
+
+func (f *FileSink) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,267 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"github.com/chrisccoy/go-atlassian/admin"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"net/http"
+	"time"
+)
+
+var ErrNoOrganizationID = errors.New("lifecycle: no organization id set")
+
+type Options struct {
+	OrganizationID string
+
+	// InactiveDays and StaleTokenDays are the days without activity flagging a user, or a token, 90 by default.
+	InactiveDays   int
+	StaleTokenDays int
+
+	// Remediation disables the inactive users and deletes the stale tokens when it's set.
+	Remediation *Remediation
+}
+
+type Remediation struct {
+	DisableInactive   bool
+	DeleteStaleTokens bool
+
+	// DisableMessage is shown to the users disabled on their next login, the default message is used when empty.
+	DisableMessage string
+
+	// Approved are the account ids the remediations are applied to, the other accounts are reported only.
+	Approved []string
+
+	// DryRun reports the remediations planned without applying them.
+	DryRun bool
+}
+
+// Reporter builds the lifecycle report of an organization.
+type Reporter struct {
+	client  *admin.Client
+	options *Options
+	now     func() time.Time
+}
+
+func NewReporter(client *admin.Client, options *Options) (*Reporter, error) {
+
+	if options == nil || options.OrganizationID == "" {
+		return nil, ErrNoOrganizationID
+	}
+
+	if options.InactiveDays <= 0 {
+		options.InactiveDays = 90
+	}
+
+	if options.StaleTokenDays <= 0 {
+		options.StaleTokenDays = 90
+	}
+
+	return &Reporter{client: client, options: options, now: time.Now}, nil
+}
+
+// Report reads every user of the organization, the managed accounts are detected by their management permissions,
+// and their profile and API tokens are read. The remediations are applied when they're set.
+func (r *Reporter) Report(ctx context.Context) (*Report, error) {
+
+	now := r.now()
+	report := &Report{
+		OrganizationID: r.options.OrganizationID,
+		Generated:      now,
+		InactiveDays:   r.options.InactiveDays,
+		StaleTokenDays: r.options.StaleTokenDays,
+		DryRun:         r.options.Remediation != nil && r.options.Remediation.DryRun,
+	}
+
+	inactiveBefore := now.AddDate(0, 0, -r.options.InactiveDays)
+	staleBefore := now.AddDate(0, 0, -r.options.StaleTokenDays)
+
+	approved := map[string]bool{}
+	if r.options.Remediation != nil {
+		for _, accountID := range r.options.Remediation.Approved {
+			approved[accountID] = true
+		}
+	}
+
+	for cursor := ""; ; {
+
+		page, _, err := r.client.Organization.Users(ctx, r.options.OrganizationID, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range page.Data {
+
+			user, err := r.user(ctx, member, inactiveBefore, staleBefore, approved[member.AccountID])
+			if err != nil {
+				return nil, err
+			}
+
+			report.Users = append(report.Users, user)
+		}
+
+		if cursor = admin.NextCursor(page.Links); cursor == "" {
+			break
+		}
+	}
+
+	return report, nil
+}
+
+func (r *Reporter) user(ctx context.Context, member *model.AdminOrganizationUserScheme, inactiveBefore, staleBefore time.Time,
+	approved bool) (*UserReport, error) {
+
+	user := &UserReport{
+		AccountID:     member.AccountID,
+		Name:          member.Name,
+		Email:         member.Email,
+		AccountStatus: member.AccountStatus,
+		LastActive:    parseTime(member.LastActive),
+		Products:      []*Product{},
+	}
+
+	for _, access := range member.ProductAccess {
+
+		product := &Product{Key: access.Key, Name: access.Name, LastActive: parseTime(access.LastActive)}
+		if product.LastActive.After(user.LastActive) {
+			user.LastActive = product.LastActive
+		}
+
+		user.Products = append(user.Products, product)
+	}
+
+	if user.LastActive.Before(inactiveBefore) {
+		user.Findings = append(user.Findings, FindingInactive)
+	}
+
+	// The management permissions are only granted on the managed accounts.
+	permissions, response, err := r.client.User.Permissions(ctx, member.AccountID, nil)
+	if err != nil {
+
+		if notManaged(response) {
+			return user, nil
+		}
+
+		return nil, err
+	}
+
+	user.Managed = true
+
+	profile, _, err := r.client.User.Get(ctx, member.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile.Account != nil && profile.Account.AccountStatus != "" {
+		user.AccountStatus = profile.Account.AccountStatus
+	}
+
+	if len(user.Products) == 0 {
+		user.Findings = append(user.Findings, FindingNoProductAccess)
+	}
+
+	if allowed(permissions.APITokenRead) {
+
+		tokens, _, err := r.client.User.Token.Gets(ctx, member.AccountID)
+		if err != nil {
+			return nil, err
+		}
+
+		// The tokens are nil when the body is null.
+		if tokens != nil {
+			for _, token := range *tokens {
+
+				entry := &Token{ID: token.ID, Label: token.Label, CreatedAt: token.CreatedAt, LastAccess: token.LastAccess}
+
+				// The tokens never used are stale once they're older than the stale token days.
+				lastUsed := token.LastAccess
+				if lastUsed.IsZero() {
+					lastUsed = token.CreatedAt
+				}
+
+				entry.Stale = lastUsed.Before(staleBefore)
+				user.Tokens = append(user.Tokens, entry)
+			}
+		}
+
+		for _, token := range user.Tokens {
+			if token.Stale {
+				user.Findings = append(user.Findings, FindingStaleToken)
+				break
+			}
+		}
+	}
+
+	r.remediate(ctx, user, permissions, approved)
+	return user, nil
+}
+
+// remediate disables the inactive users and deletes their stale tokens, the errors are reported on the actions.
+func (r *Reporter) remediate(ctx context.Context, user *UserReport, permissions *model.AdminUserPermissionScheme, approved bool) {
+
+	remediation := r.options.Remediation
+	if remediation == nil {
+		return
+	}
+
+	apply := func(action *Action, permitted bool, call func() error) {
+
+		switch {
+		case !approved:
+			action.Status = StatusNotApproved
+		case !permitted:
+			action.Status = StatusNotPermitted
+		case remediation.DryRun:
+			action.Status = StatusPlanned
+		default:
+			action.Status = StatusApplied
+			if err := call(); err != nil {
+				action.Status, action.Error = StatusFailed, err.Error()
+			}
+		}
+
+		user.Actions = append(user.Actions, action)
+	}
+
+	if remediation.DeleteStaleTokens {
+		for _, token := range user.Tokens {
+
+			if !token.Stale {
+				continue
+			}
+
+			tokenID := token.ID
+			apply(&Action{Type: ActionDeleteToken, Target: tokenID}, allowed(permissions.APITokenDelete), func() error {
+				_, err := r.client.User.Token.Delete(ctx, user.AccountID, tokenID)
+				return err
+			})
+		}
+	}
+
+	if remediation.DisableInactive && user.has(FindingInactive) && user.AccountStatus == "active" {
+		apply(&Action{Type: ActionDisable, Target: user.AccountID}, allowed(permissions.LifecycleEnablement), func() error {
+			_, err := r.client.User.Disable(ctx, user.AccountID, remediation.DisableMessage)
+			return err
+		})
+	}
+}
+
+func allowed(grant *model.AdminUserPermissionGrantScheme) bool {
+	return grant != nil && grant.Allowed
+}
+
+func notManaged(response *admin.ResponseScheme) bool {
+	return response != nil && (response.Code == http.StatusForbidden || response.Code == http.StatusNotFound)
+}
+
+// parseTime parses the last active dates, formatted as dates or as RFC 3339 times, it's zero when it can't be parsed.
+func parseTime(value string) time.Time {
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed
+		}
+	}
+
+	return time.Time{}
+}
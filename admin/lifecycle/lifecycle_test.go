@@ -0,0 +1,172 @@
+package lifecycle
+
+import (
+	"bytes"
+	"context"
+	"github.com/chrisccoy/go-atlassian/admin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeOrganization serves three users on two pages: alice is managed and inactive with a stale token, bob is
+// managed and active without product access, his tokens are null, and carol isn't managed.
+type fakeOrganization struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+var fakeResponses = map[string]string{
+	"/admin/v1/orgs/org/users": `{"data":[` +
+		`{"account_id":"alice","name":"Alice","email":"alice@example.com","account_status":"active",` +
+		`"product_access":[{"key":"jira-software","name":"Jira Software","last_active":"2021-01-10"}]},` +
+		`{"account_id":"bob","name":"Bob","email":"bob@example.com","account_status":"active","last_active":"2021-06-20"}],` +
+		`"links":{"next":"https://api.atlassian.com/admin/v1/orgs/org/users?cursor=page-2"}}`,
+	"/admin/v1/orgs/org/users?cursor=page-2": `{"data":[` +
+		`{"account_id":"carol","name":"Carol","account_status":"active",` +
+		`"product_access":[{"key":"confluence","last_active":"2021-06-29T10:00:00Z"}]}]}`,
+	"/users/alice/manage":            `{"lifecycle.enablement":{"allowed":true},"apiToken.read":{"allowed":true},"apiToken.delete":{"allowed":true}}`,
+	"/users/alice/manage/profile":    `{"account":{"account_id":"alice","account_status":"active"}}`,
+	"/users/alice/manage/api-tokens": `[{"id":"t1","label":"ci","createdAt":"2020-01-01T00:00:00Z","lastAccess":"2021-01-01T00:00:00Z"},{"id":"t2","label":"cli","createdAt":"2021-06-01T00:00:00Z"}]`,
+	"/users/bob/manage":              `{"lifecycle.enablement":{"allowed":true},"apiToken.read":{"allowed":true}}`,
+	"/users/bob/manage/profile":      `{"account":{"account_id":"bob","account_status":"active"}}`,
+	"/users/bob/manage/api-tokens":   `null`,
+}
+
+func (f *fakeOrganization) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Method != http.MethodGet {
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	response, ok := fakeResponses[r.URL.RequestURI()]
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(response))
+}
+
+func newReporter(t *testing.T, fake *fakeOrganization, remediation *Remediation) *Reporter {
+
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := admin.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.Site, _ = url.Parse(server.URL)
+
+	reporter, err := NewReporter(client, &Options{OrganizationID: "org", InactiveDays: 90, StaleTokenDays: 60,
+		Remediation: remediation})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reporter.now = func() time.Time { return time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC) }
+	return reporter
+}
+
+func TestReporter_Report(t *testing.T) {
+
+	fake := &fakeOrganization{}
+	report, err := newReporter(t, fake, nil).Report(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, fake.requests)
+
+	if assert.Len(t, report.Users, 3) {
+
+		alice, bob, carol := report.Users[0], report.Users[1], report.Users[2]
+
+		assert.True(t, alice.Managed)
+		assert.Equal(t, []Finding{FindingInactive, FindingStaleToken}, alice.Findings)
+		if assert.Len(t, alice.Tokens, 2) {
+			assert.True(t, alice.Tokens[0].Stale)
+			assert.False(t, alice.Tokens[1].Stale)
+		}
+
+		assert.True(t, bob.Managed)
+		assert.Equal(t, []Finding{FindingNoProductAccess}, bob.Findings)
+		assert.Empty(t, bob.Tokens)
+
+		assert.False(t, carol.Managed)
+		assert.Empty(t, carol.Findings)
+		assert.Equal(t, time.Date(2021, 6, 29, 10, 0, 0, 0, time.UTC), carol.LastActive)
+	}
+
+	assert.Len(t, report.Flagged(), 2)
+	assert.True(t, strings.HasPrefix(report.String(),
+		"3 users, 1 inactive for 90 days, 1 with stale tokens, 1 without product access\n"))
+
+	var buffer bytes.Buffer
+	assert.NoError(t, report.WriteCSV(&buffer))
+	assert.Contains(t, buffer.String(), "alice,Alice,alice@example.com,active,true,2021-01-10,jira-software,2,1,inactive stale-token,\n")
+}
+
+func TestReporter_Remediation(t *testing.T) {
+
+	testCases := []struct {
+		name        string
+		remediation *Remediation
+		want        []string
+		actions     []*Action
+	}{
+		{
+			name:        "when the account is approved",
+			remediation: &Remediation{DisableInactive: true, DeleteStaleTokens: true, Approved: []string{"alice"}},
+			want:        []string{"DELETE /users/alice/manage/api-tokens/t1", "POST /users/alice/manage/lifecycle/disable"},
+			actions: []*Action{
+				{Type: ActionDeleteToken, Target: "t1", Status: StatusApplied},
+				{Type: ActionDisable, Target: "alice", Status: StatusApplied},
+			},
+		},
+
+		{
+			name:        "when it's a dry run",
+			remediation: &Remediation{DisableInactive: true, DeleteStaleTokens: true, Approved: []string{"alice"}, DryRun: true},
+			actions: []*Action{
+				{Type: ActionDeleteToken, Target: "t1", Status: StatusPlanned},
+				{Type: ActionDisable, Target: "alice", Status: StatusPlanned},
+			},
+		},
+
+		{
+			name:        "when the account is not approved",
+			remediation: &Remediation{DisableInactive: true, Approved: []string{"bob"}},
+			actions:     []*Action{{Type: ActionDisable, Target: "alice", Status: StatusNotApproved}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			fake := &fakeOrganization{}
+			report, err := newReporter(t, fake, testCase.remediation).Report(context.Background())
+			assert.NoError(t, err)
+
+			assert.Equal(t, testCase.want, fake.requests)
+			assert.Equal(t, testCase.actions, report.Users[0].Actions)
+			assert.Empty(t, report.Users[1].Actions)
+		})
+	}
+}
+
+func TestNewReporter(t *testing.T) {
+
+	_, err := NewReporter(nil, &Options{})
+	assert.EqualError(t, err, "lifecycle: no organization id set")
+}
@@ -0,0 +1,218 @@
+// Package lifecycle reports the users of an organization inactive for a number of days, holding stale API tokens
+// or managed without product access, and optionally disables them or deletes their tokens.
+package lifecycle
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type Finding string
+
+const (
+	// FindingInactive is a user without activity on any product for the inactivity days, or never active.
+	FindingInactive Finding = "inactive"
+
+	// FindingStaleToken is a user with an API token not used for the stale token days, or never used.
+	FindingStaleToken Finding = "stale-token"
+
+	// FindingNoProductAccess is a managed account without access to any product.
+	FindingNoProductAccess Finding = "no-product-access"
+)
+
+type ActionType string
+
+const (
+	ActionDisable     ActionType = "disable"
+	ActionDeleteToken ActionType = "delete-token"
+)
+
+type ActionStatus string
+
+const (
+	StatusPlanned      ActionStatus = "planned" // on a dry run
+	StatusApplied      ActionStatus = "applied"
+	StatusFailed       ActionStatus = "failed"
+	StatusNotApproved  ActionStatus = "not-approved"  // the account isn't on the approval list
+	StatusNotPermitted ActionStatus = "not-permitted" // the account permissions don't allow it
+)
+
+// Action is a remediation of a finding, Target is the account id of the users disabled, and the token id of the
+// tokens deleted.
+type Action struct {
+	Type   ActionType   `json:"type"`
+	Target string       `json:"target"`
+	Status ActionStatus `json:"status"`
+	Error  string       `json:"error,omitempty"`
+}
+
+type Product struct {
+	Key        string    `json:"key"`
+	Name       string    `json:"name,omitempty"`
+	LastActive time.Time `json:"lastActive"`
+}
+
+type Token struct {
+	ID         string    `json:"id"`
+	Label      string    `json:"label,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastAccess time.Time `json:"lastAccess"`
+	Stale      bool      `json:"stale"`
+}
+
+type UserReport struct {
+	AccountID     string `json:"accountId"`
+	Name          string `json:"name,omitempty"`
+	Email         string `json:"email,omitempty"`
+	AccountStatus string `json:"accountStatus,omitempty"`
+
+	// Managed is true when the account is managed by the organization, the tokens are only read from the managed
+	// accounts, and the remediations are only applied to them.
+	Managed bool `json:"managed"`
+
+	// LastActive is the latest activity on the products, zero when the user was never active.
+	LastActive time.Time  `json:"lastActive"`
+	Products   []*Product `json:"products"`
+	Tokens     []*Token   `json:"tokens,omitempty"`
+
+	Findings []Finding `json:"findings"`
+	Actions  []*Action `json:"actions,omitempty"`
+}
+
+func (u *UserReport) has(finding Finding) bool {
+
+	for _, candidate := range u.Findings {
+		if candidate == finding {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Report struct {
+	OrganizationID string        `json:"organizationId"`
+	Generated      time.Time     `json:"generated"`
+	InactiveDays   int           `json:"inactiveDays"`
+	StaleTokenDays int           `json:"staleTokenDays"`
+	DryRun         bool          `json:"dryRun"`
+	Users          []*UserReport `json:"users"`
+}
+
+// Flagged returns the users with findings.
+func (r *Report) Flagged() []*UserReport {
+
+	var users []*UserReport
+	for _, user := range r.Users {
+		if len(user.Findings) != 0 {
+			users = append(users, user)
+		}
+	}
+
+	return users
+}
+
+// Count returns the number of users with the finding.
+func (r *Report) Count(finding Finding) int {
+
+	count := 0
+	for _, user := range r.Users {
+		if user.has(finding) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// String returns a summary and a line per user flagged.
+func (r *Report) String() string {
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%v users, %v inactive for %v days, %v with stale tokens, %v without product access\n",
+		len(r.Users), r.Count(FindingInactive), r.InactiveDays, r.Count(FindingStaleToken), r.Count(FindingNoProductAccess)))
+
+	for _, user := range r.Flagged() {
+
+		findings := make([]string, len(user.Findings))
+		for index, finding := range user.Findings {
+			findings[index] = string(finding)
+		}
+
+		builder.WriteString(fmt.Sprintf("%v %v: %v", user.AccountID, user.Email, strings.Join(findings, ", ")))
+		for _, action := range user.Actions {
+			builder.WriteString(fmt.Sprintf(" [%v %v %v]", action.Type, action.Target, action.Status))
+		}
+
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+// WriteJSON writes the report as an indented JSON document.
+func (r *Report) WriteJSON(writer io.Writer) error {
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+var csvHeader = []string{"account_id", "name", "email", "account_status", "managed", "last_active", "products",
+	"tokens", "stale_tokens", "findings", "actions"}
+
+// WriteCSV writes the report as CSV, a row per user, the dates are formatted as 2006-01-02 and empty when zero.
+func (r *Report) WriteCSV(writer io.Writer) error {
+
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, user := range r.Users {
+
+		var products, findings, actions []string
+		for _, product := range user.Products {
+			products = append(products, product.Key)
+		}
+
+		for _, finding := range user.Findings {
+			findings = append(findings, string(finding))
+		}
+
+		for _, action := range user.Actions {
+			actions = append(actions, fmt.Sprintf("%v:%v:%v", action.Type, action.Target, action.Status))
+		}
+
+		stale := 0
+		for _, token := range user.Tokens {
+			if token.Stale {
+				stale++
+			}
+		}
+
+		row := []string{user.AccountID, user.Name, user.Email, user.AccountStatus, fmt.Sprint(user.Managed),
+			formatDate(user.LastActive), strings.Join(products, " "), fmt.Sprint(len(user.Tokens)), fmt.Sprint(stale),
+			strings.Join(findings, " "), strings.Join(actions, " ")}
+
+		if err := csvWriter.Write(row); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func formatDate(date time.Time) string {
+
+	if date.IsZero() {
+		return ""
+	}
+
+	return date.Format("2006-01-02")
+}
@@ -11,8 +11,11 @@ import (
 )
 
 type OrganizationService struct {
-	client *Client
-	Policy *OrganizationPolicyService
+	client    *Client
+	Policy    *OrganizationPolicyService
+	Directory *OrganizationDirectoryService
+	Group     *OrganizationGroupService
+	Workspace *OrganizationWorkspaceService
 }
 
 // Gets returns a list of your organizations
@@ -288,3 +291,20 @@ func (o *OrganizationService) Actions(ctx context.Context, organizationID string
 
 	return
 }
+
+// NextCursor returns the cursor of the next page of the organization APIs, it's empty on the last page.
+// The next link is a URL with a cursor parameter on the v1 APIs, and the cursor on some of the v2 APIs.
+func NextCursor(links *model.LinkPageModelScheme) string {
+
+	if links == nil || links.Next == "" {
+		return ""
+	}
+
+	if next, err := url.Parse(links.Next); err == nil {
+		if cursor := next.Query().Get("cursor"); cursor != "" {
+			return cursor
+		}
+	}
+
+	return links.Next
+}
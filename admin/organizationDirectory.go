@@ -0,0 +1,193 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type OrganizationDirectoryService struct {
+	client *Client
+}
+
+// Gets returns the directories of an organization one page at a time
+// Docs: https://developer.atlassian.com/cloud/admin/organization/rest/api-group-directory/#api-v2-orgs-orgid-directories-get
+func (o *OrganizationDirectoryService) Gets(ctx context.Context, organizationID, cursor string) (
+	result *model.OrganizationDirectoryPageScheme, response *ResponseScheme, err error) {
+
+	if len(organizationID) == 0 {
+		return nil, nil, model.ErrNoAdminOrganizationError
+	}
+
+	params := url.Values{}
+	if cursor != "" {
+		params.Add("cursor", cursor)
+	}
+
+	var endpoint strings.Builder
+	endpoint.WriteString(fmt.Sprintf("/admin/v2/orgs/%v/directories", organizationID))
+
+	if params.Encode() != "" {
+		endpoint.WriteString(fmt.Sprintf("?%v", params.Encode()))
+	}
+
+	request, err := o.client.newRequest(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err = o.client.call(request, &result)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Users returns the users of a directory one page at a time, with their claim and membership status
+// Docs: https://developer.atlassian.com/cloud/admin/organization/rest/api-group-users/#api-v2-orgs-orgid-directories-directoryid-users-get
+func (o *OrganizationDirectoryService) Users(ctx context.Context, organizationID, directoryID, cursor string) (
+	result *model.OrganizationDirectoryUserPageScheme, response *ResponseScheme, err error) {
+
+	if len(organizationID) == 0 {
+		return nil, nil, model.ErrNoAdminOrganizationError
+	}
+
+	if len(directoryID) == 0 {
+		return nil, nil, model.ErrNoAdminDirectoryIDError
+	}
+
+	params := url.Values{}
+	if cursor != "" {
+		params.Add("cursor", cursor)
+	}
+
+	var endpoint strings.Builder
+	endpoint.WriteString(fmt.Sprintf("/admin/v2/orgs/%v/directories/%v/users", organizationID, directoryID))
+
+	if params.Encode() != "" {
+		endpoint.WriteString(fmt.Sprintf("?%v", params.Encode()))
+	}
+
+	request, err := o.client.newRequest(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err = o.client.call(request, &result)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Activity returns the product access of a user and its last active date on each product
+// Docs: https://developer.atlassian.com/cloud/admin/organization/rest/api-group-directory/#api-v1-orgs-orgid-directory-users-accountid-last-active-dates-get
+func (o *OrganizationDirectoryService) Activity(ctx context.Context, organizationID, accountID string) (
+	result *model.OrganizationUserActivityScheme, response *ResponseScheme, err error) {
+
+	if len(organizationID) == 0 {
+		return nil, nil, model.ErrNoAdminOrganizationError
+	}
+
+	if len(accountID) == 0 {
+		return nil, nil, model.ErrNoAdminAccountIDError
+	}
+
+	var endpoint = fmt.Sprintf("/admin/v1/orgs/%v/directory/users/%v/last-active-dates", organizationID, accountID)
+
+	request, err := o.client.newRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err = o.client.call(request, &result)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Remove removes a user from the directory, the user loses access to the products of the organization.
+// It's only available for the accounts claimed by the organization.
+// Docs: https://developer.atlassian.com/cloud/admin/organization/rest/api-group-directory/#api-v1-orgs-orgid-directory-users-accountid-delete
+func (o *OrganizationDirectoryService) Remove(ctx context.Context, organizationID, accountID string) (
+	response *ResponseScheme, err error) {
+
+	if len(organizationID) == 0 {
+		return nil, model.ErrNoAdminOrganizationError
+	}
+
+	if len(accountID) == 0 {
+		return nil, model.ErrNoAdminAccountIDError
+	}
+
+	var endpoint = fmt.Sprintf("/admin/v1/orgs/%v/directory/users/%v", organizationID, accountID)
+
+	request, err := o.client.newRequest(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err = o.client.call(request, nil)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Suspend suspends the access of a user to the products of the organization, the account isn't deactivated.
+// Docs: https://developer.atlassian.com/cloud/admin/organization/rest/api-group-directory/#api-v1-orgs-orgid-directory-users-accountid-suspend-access-post
+func (o *OrganizationDirectoryService) Suspend(ctx context.Context, organizationID, accountID string) (
+	result *model.GenericActionSuccessScheme, response *ResponseScheme, err error) {
+	return o.access(ctx, organizationID, accountID, "suspend-access")
+}
+
+// Restore restores the access of a user suspended
+// Docs: https://developer.atlassian.com/cloud/admin/organization/rest/api-group-directory/#api-v1-orgs-orgid-directory-users-accountid-restore-access-post
+func (o *OrganizationDirectoryService) Restore(ctx context.Context, organizationID, accountID string) (
+	result *model.GenericActionSuccessScheme, response *ResponseScheme, err error) {
+	return o.access(ctx, organizationID, accountID, "restore-access")
+}
+
+func (o *OrganizationDirectoryService) access(ctx context.Context, organizationID, accountID, action string) (
+	result *model.GenericActionSuccessScheme, response *ResponseScheme, err error) {
+
+	if len(organizationID) == 0 {
+		return nil, nil, model.ErrNoAdminOrganizationError
+	}
+
+	if len(accountID) == 0 {
+		return nil, nil, model.ErrNoAdminAccountIDError
+	}
+
+	var endpoint = fmt.Sprintf("/admin/v1/orgs/%v/directory/users/%v/%v", organizationID, accountID, action)
+
+	request, err := o.client.newRequest(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err = o.client.call(request, &result)
+	if err != nil {
+		return
+	}
+
+	return
+}
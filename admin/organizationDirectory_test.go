@@ -0,0 +1,952 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestOrganizationDirectoryService_Gets(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		organizationID     string
+		cursor             string
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+	}{
+		{
+			name:               "GetDirectoriesWhenTheParametersAreCorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			cursor:             "eyJwYWdlIjoyfQ",
+			mockFile:           "./mocks/get-organization-directories.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories?cursor=eyJwYWdlIjoyfQ",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            false,
+		},
+
+		{
+			name:               "GetDirectoriesWhenTheOrganizationIDIsNotSet",
+			organizationID:     "",
+			cursor:             "eyJwYWdlIjoyfQ",
+			mockFile:           "./mocks/get-organization-directories.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories?cursor=eyJwYWdlIjoyfQ",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetDirectoriesWhenTheRequestMethodIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			cursor:             "eyJwYWdlIjoyfQ",
+			mockFile:           "./mocks/get-organization-directories.json",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories?cursor=eyJwYWdlIjoyfQ",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetDirectoriesWhenTheStatusCodeIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			cursor:             "eyJwYWdlIjoyfQ",
+			mockFile:           "./mocks/get-organization-directories.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories?cursor=eyJwYWdlIjoyfQ",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetDirectoriesWhenTheContextIsNil",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			cursor:             "eyJwYWdlIjoyfQ",
+			mockFile:           "./mocks/get-organization-directories.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories?cursor=eyJwYWdlIjoyfQ",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &OrganizationDirectoryService{client: mockClient}
+			gotResult, gotResponse, err := service.Gets(testCase.context, testCase.organizationID, testCase.cursor)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+				assert.Error(t, err)
+
+				if gotResponse != nil {
+					t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				}
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+
+				apiEndpoint, err := url.Parse(gotResponse.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var endpointToAssert string
+
+				if apiEndpoint.Query().Encode() != "" {
+					endpointToAssert = fmt.Sprintf("%v?%v", apiEndpoint.Path, apiEndpoint.Query().Encode())
+				} else {
+					endpointToAssert = apiEndpoint.Path
+				}
+
+				t.Logf("HTTP Endpoint Wanted: %v, HTTP Endpoint Returned: %v", testCase.endpoint, endpointToAssert)
+				assert.Equal(t, testCase.endpoint, endpointToAssert)
+
+				t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				assert.Equal(t, gotResponse.Code, testCase.wantHTTPCodeReturn)
+
+				for _, directory := range gotResult.Data {
+					t.Log(directory.DirectoryID, directory.Name)
+				}
+
+				assert.Equal(t, "eyJwYWdlIjoyfQ==", NextCursor(gotResult.Links))
+			}
+
+		})
+	}
+
+}
+
+func TestOrganizationDirectoryService_Users(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		organizationID     string
+		directoryID        string
+		cursor             string
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+	}{
+		{
+			name:               "GetDirectoryUsersWhenTheParametersAreCorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			cursor:             "",
+			mockFile:           "./mocks/get-organization-directory-users.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/users",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            false,
+		},
+
+		{
+			name:               "GetDirectoryUsersWhenTheOrganizationIDIsNotSet",
+			organizationID:     "",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			cursor:             "",
+			mockFile:           "./mocks/get-organization-directory-users.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/users",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetDirectoryUsersWhenTheDirectoryIDIsNotSet",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "",
+			cursor:             "",
+			mockFile:           "./mocks/get-organization-directory-users.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/users",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetDirectoryUsersWhenTheRequestMethodIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			cursor:             "",
+			mockFile:           "./mocks/get-organization-directory-users.json",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/users",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetDirectoryUsersWhenTheStatusCodeIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			cursor:             "",
+			mockFile:           "./mocks/get-organization-directory-users.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/users",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetDirectoryUsersWhenTheContextIsNil",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			cursor:             "",
+			mockFile:           "./mocks/get-organization-directory-users.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/users",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &OrganizationDirectoryService{client: mockClient}
+			gotResult, gotResponse, err := service.Users(testCase.context, testCase.organizationID, testCase.directoryID, testCase.cursor)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+				assert.Error(t, err)
+
+				if gotResponse != nil {
+					t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				}
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+
+				apiEndpoint, err := url.Parse(gotResponse.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var endpointToAssert string
+
+				if apiEndpoint.Query().Encode() != "" {
+					endpointToAssert = fmt.Sprintf("%v?%v", apiEndpoint.Path, apiEndpoint.Query().Encode())
+				} else {
+					endpointToAssert = apiEndpoint.Path
+				}
+
+				t.Logf("HTTP Endpoint Wanted: %v, HTTP Endpoint Returned: %v", testCase.endpoint, endpointToAssert)
+				assert.Equal(t, testCase.endpoint, endpointToAssert)
+
+				t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				assert.Equal(t, gotResponse.Code, testCase.wantHTTPCodeReturn)
+
+				for _, user := range gotResult.Data {
+					t.Log(user.AccountID, user.ClaimStatus, user.MembershipStatus)
+				}
+			}
+
+		})
+	}
+
+}
+
+func TestOrganizationDirectoryService_Activity(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		organizationID     string
+		accountID          string
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+	}{
+		{
+			name:               "GetUserActivityWhenTheParametersAreCorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/get-organization-user-activity.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/last-active-dates",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            false,
+		},
+
+		{
+			name:               "GetUserActivityWhenTheOrganizationIDIsNotSet",
+			organizationID:     "",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/get-organization-user-activity.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/last-active-dates",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetUserActivityWhenTheAccountIDIsNotSet",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "",
+			mockFile:           "./mocks/get-organization-user-activity.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/last-active-dates",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetUserActivityWhenTheRequestMethodIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/get-organization-user-activity.json",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/last-active-dates",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetUserActivityWhenTheStatusCodeIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/get-organization-user-activity.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/last-active-dates",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetUserActivityWhenTheContextIsNil",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/get-organization-user-activity.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/last-active-dates",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &OrganizationDirectoryService{client: mockClient}
+			gotResult, gotResponse, err := service.Activity(testCase.context, testCase.organizationID, testCase.accountID)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+				assert.Error(t, err)
+
+				if gotResponse != nil {
+					t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				}
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+
+				apiEndpoint, err := url.Parse(gotResponse.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var endpointToAssert string
+
+				if apiEndpoint.Query().Encode() != "" {
+					endpointToAssert = fmt.Sprintf("%v?%v", apiEndpoint.Path, apiEndpoint.Query().Encode())
+				} else {
+					endpointToAssert = apiEndpoint.Path
+				}
+
+				t.Logf("HTTP Endpoint Wanted: %v, HTTP Endpoint Returned: %v", testCase.endpoint, endpointToAssert)
+				assert.Equal(t, testCase.endpoint, endpointToAssert)
+
+				t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				assert.Equal(t, gotResponse.Code, testCase.wantHTTPCodeReturn)
+
+				for _, product := range gotResult.Data.ProductAccess {
+					t.Log(product.Key, product.LastActive)
+				}
+			}
+
+		})
+	}
+
+}
+
+func TestOrganizationDirectoryService_Remove(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		organizationID     string
+		accountID          string
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+	}{
+		{
+			name:               "RemoveUserWhenTheParametersAreCorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            false,
+		},
+
+		{
+			name:               "RemoveUserWhenTheOrganizationIDIsNotSet",
+			organizationID:     "",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+
+		{
+			name:               "RemoveUserWhenTheAccountIDIsNotSet",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+
+		{
+			name:               "RemoveUserWhenTheRequestMethodIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+
+		{
+			name:               "RemoveUserWhenTheStatusCodeIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+		},
+
+		{
+			name:               "RemoveUserWhenTheContextIsNil",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &OrganizationDirectoryService{client: mockClient}
+			gotResponse, err := service.Remove(testCase.context, testCase.organizationID, testCase.accountID)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+				assert.Error(t, err)
+
+				if gotResponse != nil {
+					t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				}
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+
+				apiEndpoint, err := url.Parse(gotResponse.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var endpointToAssert string
+
+				if apiEndpoint.Query().Encode() != "" {
+					endpointToAssert = fmt.Sprintf("%v?%v", apiEndpoint.Path, apiEndpoint.Query().Encode())
+				} else {
+					endpointToAssert = apiEndpoint.Path
+				}
+
+				t.Logf("HTTP Endpoint Wanted: %v, HTTP Endpoint Returned: %v", testCase.endpoint, endpointToAssert)
+				assert.Equal(t, testCase.endpoint, endpointToAssert)
+
+				t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				assert.Equal(t, gotResponse.Code, testCase.wantHTTPCodeReturn)
+			}
+
+		})
+	}
+
+}
+
+func TestOrganizationDirectoryService_Suspend(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		organizationID     string
+		accountID          string
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+	}{
+		{
+			name:               "SuspendUserWhenTheParametersAreCorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/organization-directory-action.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/suspend-access",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            false,
+		},
+
+		{
+			name:               "SuspendUserWhenTheOrganizationIDIsNotSet",
+			organizationID:     "",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/organization-directory-action.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/suspend-access",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "SuspendUserWhenTheAccountIDIsNotSet",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "",
+			mockFile:           "./mocks/organization-directory-action.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/suspend-access",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "SuspendUserWhenTheRequestMethodIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/organization-directory-action.json",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/suspend-access",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "SuspendUserWhenTheStatusCodeIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/organization-directory-action.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/suspend-access",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+		},
+
+		{
+			name:               "SuspendUserWhenTheContextIsNil",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/organization-directory-action.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/suspend-access",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &OrganizationDirectoryService{client: mockClient}
+			gotResult, gotResponse, err := service.Suspend(testCase.context, testCase.organizationID, testCase.accountID)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+				assert.Error(t, err)
+
+				if gotResponse != nil {
+					t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				}
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+
+				apiEndpoint, err := url.Parse(gotResponse.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var endpointToAssert string
+
+				if apiEndpoint.Query().Encode() != "" {
+					endpointToAssert = fmt.Sprintf("%v?%v", apiEndpoint.Path, apiEndpoint.Query().Encode())
+				} else {
+					endpointToAssert = apiEndpoint.Path
+				}
+
+				t.Logf("HTTP Endpoint Wanted: %v, HTTP Endpoint Returned: %v", testCase.endpoint, endpointToAssert)
+				assert.Equal(t, testCase.endpoint, endpointToAssert)
+
+				t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				assert.Equal(t, gotResponse.Code, testCase.wantHTTPCodeReturn)
+
+				t.Log(gotResult.Message)
+			}
+
+		})
+	}
+
+}
+
+func TestOrganizationDirectoryService_Restore(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		organizationID     string
+		accountID          string
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+	}{
+		{
+			name:               "RestoreUserWhenTheParametersAreCorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/organization-directory-action.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/restore-access",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            false,
+		},
+
+		{
+			name:               "RestoreUserWhenTheOrganizationIDIsNotSet",
+			organizationID:     "",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/organization-directory-action.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/restore-access",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "RestoreUserWhenTheAccountIDIsNotSet",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "",
+			mockFile:           "./mocks/organization-directory-action.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/restore-access",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "RestoreUserWhenTheRequestMethodIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/organization-directory-action.json",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/restore-access",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "RestoreUserWhenTheStatusCodeIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/organization-directory-action.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/restore-access",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+		},
+
+		{
+			name:               "RestoreUserWhenTheContextIsNil",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			mockFile:           "./mocks/organization-directory-action.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/users/5b10ac8d82e05b22cc7d4ef5/restore-access",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &OrganizationDirectoryService{client: mockClient}
+			gotResult, gotResponse, err := service.Restore(testCase.context, testCase.organizationID, testCase.accountID)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+				assert.Error(t, err)
+
+				if gotResponse != nil {
+					t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				}
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+
+				apiEndpoint, err := url.Parse(gotResponse.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var endpointToAssert string
+
+				if apiEndpoint.Query().Encode() != "" {
+					endpointToAssert = fmt.Sprintf("%v?%v", apiEndpoint.Path, apiEndpoint.Query().Encode())
+				} else {
+					endpointToAssert = apiEndpoint.Path
+				}
+
+				t.Logf("HTTP Endpoint Wanted: %v, HTTP Endpoint Returned: %v", testCase.endpoint, endpointToAssert)
+				assert.Equal(t, testCase.endpoint, endpointToAssert)
+
+				t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				assert.Equal(t, gotResponse.Code, testCase.wantHTTPCodeReturn)
+
+				t.Log(gotResult.Message)
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,244 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type OrganizationGroupService struct {
+	client *Client
+}
+
+// Gets returns the groups of a directory one page at a time
+// Docs: https://developer.atlassian.com/cloud/admin/organization/rest/api-group-groups/#api-v2-orgs-orgid-directories-directoryid-groups-get
+func (o *OrganizationGroupService) Gets(ctx context.Context, organizationID, directoryID, cursor string) (
+	result *model.OrganizationGroupPageScheme, response *ResponseScheme, err error) {
+
+	if len(organizationID) == 0 {
+		return nil, nil, model.ErrNoAdminOrganizationError
+	}
+
+	if len(directoryID) == 0 {
+		return nil, nil, model.ErrNoAdminDirectoryIDError
+	}
+
+	params := url.Values{}
+	if cursor != "" {
+		params.Add("cursor", cursor)
+	}
+
+	var endpoint strings.Builder
+	endpoint.WriteString(fmt.Sprintf("/admin/v2/orgs/%v/directories/%v/groups", organizationID, directoryID))
+
+	if params.Encode() != "" {
+		endpoint.WriteString(fmt.Sprintf("?%v", params.Encode()))
+	}
+
+	request, err := o.client.newRequest(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err = o.client.call(request, &result)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Members returns the members of a group one page at a time
+// Docs: https://developer.atlassian.com/cloud/admin/organization/rest/api-group-groups/#api-v2-orgs-orgid-directories-directoryid-groups-groupid-memberships-get
+func (o *OrganizationGroupService) Members(ctx context.Context, organizationID, directoryID, groupID, cursor string) (
+	result *model.OrganizationGroupMembershipPageScheme, response *ResponseScheme, err error) {
+
+	if len(organizationID) == 0 {
+		return nil, nil, model.ErrNoAdminOrganizationError
+	}
+
+	if len(directoryID) == 0 {
+		return nil, nil, model.ErrNoAdminDirectoryIDError
+	}
+
+	if len(groupID) == 0 {
+		return nil, nil, model.ErrNoAdminGroupIDError
+	}
+
+	params := url.Values{}
+	if cursor != "" {
+		params.Add("cursor", cursor)
+	}
+
+	var endpoint strings.Builder
+	endpoint.WriteString(fmt.Sprintf("/admin/v2/orgs/%v/directories/%v/groups/%v/memberships", organizationID,
+		directoryID, groupID))
+
+	if params.Encode() != "" {
+		endpoint.WriteString(fmt.Sprintf("?%v", params.Encode()))
+	}
+
+	request, err := o.client.newRequest(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err = o.client.call(request, &result)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Create creates a group in the directory of an organization
+// Docs: https://developer.atlassian.com/cloud/admin/organization/rest/api-group-groups/#api-v1-orgs-orgid-directory-groups-post
+func (o *OrganizationGroupService) Create(ctx context.Context, organizationID, groupName string) (
+	result *model.OrganizationGroupCreatedScheme, response *ResponseScheme, err error) {
+
+	if len(organizationID) == 0 {
+		return nil, nil, model.ErrNoAdminOrganizationError
+	}
+
+	if len(groupName) == 0 {
+		return nil, nil, model.ErrNoAdminGroupNameError
+	}
+
+	payload := struct {
+		Name string `json:"name"`
+	}{
+		Name: groupName,
+	}
+
+	payloadAsReader, _ := transformStructToReader(&payload)
+	var endpoint = fmt.Sprintf("/admin/v1/orgs/%v/directory/groups", organizationID)
+
+	request, err := o.client.newRequest(ctx, http.MethodPost, endpoint, payloadAsReader)
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err = o.client.call(request, &result)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Delete deletes a group of the directory of an organization
+// Docs: https://developer.atlassian.com/cloud/admin/organization/rest/api-group-groups/#api-v1-orgs-orgid-directory-groups-groupid-delete
+func (o *OrganizationGroupService) Delete(ctx context.Context, organizationID, groupID string) (response *ResponseScheme,
+	err error) {
+
+	if len(organizationID) == 0 {
+		return nil, model.ErrNoAdminOrganizationError
+	}
+
+	if len(groupID) == 0 {
+		return nil, model.ErrNoAdminGroupIDError
+	}
+
+	var endpoint = fmt.Sprintf("/admin/v1/orgs/%v/directory/groups/%v", organizationID, groupID)
+
+	request, err := o.client.newRequest(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err = o.client.call(request, nil)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Assign adds a user to a group of the directory of an organization
+// Docs: https://developer.atlassian.com/cloud/admin/organization/rest/api-group-groups/#api-v1-orgs-orgid-directory-groups-groupid-memberships-post
+func (o *OrganizationGroupService) Assign(ctx context.Context, organizationID, groupID, accountID string) (
+	response *ResponseScheme, err error) {
+
+	if len(organizationID) == 0 {
+		return nil, model.ErrNoAdminOrganizationError
+	}
+
+	if len(groupID) == 0 {
+		return nil, model.ErrNoAdminGroupIDError
+	}
+
+	if len(accountID) == 0 {
+		return nil, model.ErrNoAdminAccountIDError
+	}
+
+	payload := struct {
+		AccountID string `json:"account_id"`
+	}{
+		AccountID: accountID,
+	}
+
+	payloadAsReader, _ := transformStructToReader(&payload)
+	var endpoint = fmt.Sprintf("/admin/v1/orgs/%v/directory/groups/%v/memberships", organizationID, groupID)
+
+	request, err := o.client.newRequest(ctx, http.MethodPost, endpoint, payloadAsReader)
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err = o.client.call(request, nil)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Remove removes a user from a group of the directory of an organization
+// Docs: https://developer.atlassian.com/cloud/admin/organization/rest/api-group-groups/#api-v1-orgs-orgid-directory-groups-groupid-memberships-accountid-delete
+func (o *OrganizationGroupService) Remove(ctx context.Context, organizationID, groupID, accountID string) (
+	response *ResponseScheme, err error) {
+
+	if len(organizationID) == 0 {
+		return nil, model.ErrNoAdminOrganizationError
+	}
+
+	if len(groupID) == 0 {
+		return nil, model.ErrNoAdminGroupIDError
+	}
+
+	if len(accountID) == 0 {
+		return nil, model.ErrNoAdminAccountIDError
+	}
+
+	var endpoint = fmt.Sprintf("/admin/v1/orgs/%v/directory/groups/%v/memberships/%v", organizationID, groupID,
+		accountID)
+
+	request, err := o.client.newRequest(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err = o.client.call(request, nil)
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,1008 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestOrganizationGroupService_Gets(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		organizationID     string
+		directoryID        string
+		cursor             string
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+	}{
+		{
+			name:               "GetGroupsWhenTheParametersAreCorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			cursor:             "eyJwYWdlIjoyfQ",
+			mockFile:           "./mocks/get-organization-groups.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/groups?cursor=eyJwYWdlIjoyfQ",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            false,
+		},
+
+		{
+			name:               "GetGroupsWhenTheOrganizationIDIsNotSet",
+			organizationID:     "",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			cursor:             "eyJwYWdlIjoyfQ",
+			mockFile:           "./mocks/get-organization-groups.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/groups?cursor=eyJwYWdlIjoyfQ",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetGroupsWhenTheDirectoryIDIsNotSet",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "",
+			cursor:             "eyJwYWdlIjoyfQ",
+			mockFile:           "./mocks/get-organization-groups.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/groups?cursor=eyJwYWdlIjoyfQ",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetGroupsWhenTheRequestMethodIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			cursor:             "eyJwYWdlIjoyfQ",
+			mockFile:           "./mocks/get-organization-groups.json",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/groups?cursor=eyJwYWdlIjoyfQ",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetGroupsWhenTheStatusCodeIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			cursor:             "eyJwYWdlIjoyfQ",
+			mockFile:           "./mocks/get-organization-groups.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/groups?cursor=eyJwYWdlIjoyfQ",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetGroupsWhenTheContextIsNil",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			cursor:             "eyJwYWdlIjoyfQ",
+			mockFile:           "./mocks/get-organization-groups.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/groups?cursor=eyJwYWdlIjoyfQ",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &OrganizationGroupService{client: mockClient}
+			gotResult, gotResponse, err := service.Gets(testCase.context, testCase.organizationID, testCase.directoryID, testCase.cursor)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+				assert.Error(t, err)
+
+				if gotResponse != nil {
+					t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				}
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+
+				apiEndpoint, err := url.Parse(gotResponse.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var endpointToAssert string
+
+				if apiEndpoint.Query().Encode() != "" {
+					endpointToAssert = fmt.Sprintf("%v?%v", apiEndpoint.Path, apiEndpoint.Query().Encode())
+				} else {
+					endpointToAssert = apiEndpoint.Path
+				}
+
+				t.Logf("HTTP Endpoint Wanted: %v, HTTP Endpoint Returned: %v", testCase.endpoint, endpointToAssert)
+				assert.Equal(t, testCase.endpoint, endpointToAssert)
+
+				t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				assert.Equal(t, gotResponse.Code, testCase.wantHTTPCodeReturn)
+
+				for _, group := range gotResult.Data {
+					t.Log(group.ID, group.Name)
+				}
+			}
+
+		})
+	}
+
+}
+
+func TestOrganizationGroupService_Members(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		organizationID     string
+		directoryID        string
+		groupID            string
+		cursor             string
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+	}{
+		{
+			name:               "GetGroupMembersWhenTheParametersAreCorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			cursor:             "",
+			mockFile:           "./mocks/get-organization-group-memberships.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            false,
+		},
+
+		{
+			name:               "GetGroupMembersWhenTheOrganizationIDIsNotSet",
+			organizationID:     "",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			cursor:             "",
+			mockFile:           "./mocks/get-organization-group-memberships.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetGroupMembersWhenTheDirectoryIDIsNotSet",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			cursor:             "",
+			mockFile:           "./mocks/get-organization-group-memberships.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetGroupMembersWhenTheGroupIDIsNotSet",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			groupID:            "",
+			cursor:             "",
+			mockFile:           "./mocks/get-organization-group-memberships.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetGroupMembersWhenTheRequestMethodIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			cursor:             "",
+			mockFile:           "./mocks/get-organization-group-memberships.json",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetGroupMembersWhenTheStatusCodeIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			cursor:             "",
+			mockFile:           "./mocks/get-organization-group-memberships.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetGroupMembersWhenTheContextIsNil",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			directoryID:        "4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			cursor:             "",
+			mockFile:           "./mocks/get-organization-group-memberships.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/directories/4c2b4a11-1a4c-4b9e-9a8c-5d2a8b4b0e1f/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &OrganizationGroupService{client: mockClient}
+			gotResult, gotResponse, err := service.Members(testCase.context, testCase.organizationID, testCase.directoryID, testCase.groupID, testCase.cursor)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+				assert.Error(t, err)
+
+				if gotResponse != nil {
+					t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				}
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+
+				apiEndpoint, err := url.Parse(gotResponse.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var endpointToAssert string
+
+				if apiEndpoint.Query().Encode() != "" {
+					endpointToAssert = fmt.Sprintf("%v?%v", apiEndpoint.Path, apiEndpoint.Query().Encode())
+				} else {
+					endpointToAssert = apiEndpoint.Path
+				}
+
+				t.Logf("HTTP Endpoint Wanted: %v, HTTP Endpoint Returned: %v", testCase.endpoint, endpointToAssert)
+				assert.Equal(t, testCase.endpoint, endpointToAssert)
+
+				t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				assert.Equal(t, gotResponse.Code, testCase.wantHTTPCodeReturn)
+
+				for _, member := range gotResult.Data {
+					t.Log(member.AccountID, member.Email)
+				}
+			}
+
+		})
+	}
+
+}
+
+func TestOrganizationGroupService_Create(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		organizationID     string
+		groupName          string
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+	}{
+		{
+			name:               "CreateGroupWhenTheParametersAreCorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupName:          "jira-users",
+			mockFile:           "./mocks/create-organization-group.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusCreated,
+			wantErr:            false,
+		},
+
+		{
+			name:               "CreateGroupWhenTheOrganizationIDIsNotSet",
+			organizationID:     "",
+			groupName:          "jira-users",
+			mockFile:           "./mocks/create-organization-group.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusCreated,
+			wantErr:            true,
+		},
+
+		{
+			name:               "CreateGroupWhenTheGroupNameIsNotSet",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupName:          "",
+			mockFile:           "./mocks/create-organization-group.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusCreated,
+			wantErr:            true,
+		},
+
+		{
+			name:               "CreateGroupWhenTheRequestMethodIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupName:          "jira-users",
+			mockFile:           "./mocks/create-organization-group.json",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusCreated,
+			wantErr:            true,
+		},
+
+		{
+			name:               "CreateGroupWhenTheStatusCodeIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupName:          "jira-users",
+			mockFile:           "./mocks/create-organization-group.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+		},
+
+		{
+			name:               "CreateGroupWhenTheContextIsNil",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupName:          "jira-users",
+			mockFile:           "./mocks/create-organization-group.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusCreated,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &OrganizationGroupService{client: mockClient}
+			gotResult, gotResponse, err := service.Create(testCase.context, testCase.organizationID, testCase.groupName)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+				assert.Error(t, err)
+
+				if gotResponse != nil {
+					t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				}
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+
+				apiEndpoint, err := url.Parse(gotResponse.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var endpointToAssert string
+
+				if apiEndpoint.Query().Encode() != "" {
+					endpointToAssert = fmt.Sprintf("%v?%v", apiEndpoint.Path, apiEndpoint.Query().Encode())
+				} else {
+					endpointToAssert = apiEndpoint.Path
+				}
+
+				t.Logf("HTTP Endpoint Wanted: %v, HTTP Endpoint Returned: %v", testCase.endpoint, endpointToAssert)
+				assert.Equal(t, testCase.endpoint, endpointToAssert)
+
+				t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				assert.Equal(t, gotResponse.Code, testCase.wantHTTPCodeReturn)
+
+				t.Log(gotResult.ID, gotResult.Name)
+			}
+
+		})
+	}
+
+}
+
+func TestOrganizationGroupService_Delete(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		organizationID     string
+		groupID            string
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+	}{
+		{
+			name:               "DeleteGroupWhenTheParametersAreCorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            false,
+		},
+
+		{
+			name:               "DeleteGroupWhenTheOrganizationIDIsNotSet",
+			organizationID:     "",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+
+		{
+			name:               "DeleteGroupWhenTheGroupIDIsNotSet",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+
+		{
+			name:               "DeleteGroupWhenTheRequestMethodIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+
+		{
+			name:               "DeleteGroupWhenTheStatusCodeIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+		},
+
+		{
+			name:               "DeleteGroupWhenTheContextIsNil",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &OrganizationGroupService{client: mockClient}
+			gotResponse, err := service.Delete(testCase.context, testCase.organizationID, testCase.groupID)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+				assert.Error(t, err)
+
+				if gotResponse != nil {
+					t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				}
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+
+				apiEndpoint, err := url.Parse(gotResponse.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var endpointToAssert string
+
+				if apiEndpoint.Query().Encode() != "" {
+					endpointToAssert = fmt.Sprintf("%v?%v", apiEndpoint.Path, apiEndpoint.Query().Encode())
+				} else {
+					endpointToAssert = apiEndpoint.Path
+				}
+
+				t.Logf("HTTP Endpoint Wanted: %v, HTTP Endpoint Returned: %v", testCase.endpoint, endpointToAssert)
+				assert.Equal(t, testCase.endpoint, endpointToAssert)
+
+				t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				assert.Equal(t, gotResponse.Code, testCase.wantHTTPCodeReturn)
+			}
+
+		})
+	}
+
+}
+
+func TestOrganizationGroupService_Assign(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		organizationID     string
+		groupID            string
+		accountID          string
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+	}{
+		{
+			name:               "AssignUserWhenTheParametersAreCorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            false,
+		},
+
+		{
+			name:               "AssignUserWhenTheOrganizationIDIsNotSet",
+			organizationID:     "",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+
+		{
+			name:               "AssignUserWhenTheGroupIDIsNotSet",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+
+		{
+			name:               "AssignUserWhenTheAccountIDIsNotSet",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			accountID:          "",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+
+		{
+			name:               "AssignUserWhenTheRequestMethodIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+
+		{
+			name:               "AssignUserWhenTheStatusCodeIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+		},
+
+		{
+			name:               "AssignUserWhenTheContextIsNil",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &OrganizationGroupService{client: mockClient}
+			gotResponse, err := service.Assign(testCase.context, testCase.organizationID, testCase.groupID, testCase.accountID)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+				assert.Error(t, err)
+
+				if gotResponse != nil {
+					t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				}
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+
+				apiEndpoint, err := url.Parse(gotResponse.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var endpointToAssert string
+
+				if apiEndpoint.Query().Encode() != "" {
+					endpointToAssert = fmt.Sprintf("%v?%v", apiEndpoint.Path, apiEndpoint.Query().Encode())
+				} else {
+					endpointToAssert = apiEndpoint.Path
+				}
+
+				t.Logf("HTTP Endpoint Wanted: %v, HTTP Endpoint Returned: %v", testCase.endpoint, endpointToAssert)
+				assert.Equal(t, testCase.endpoint, endpointToAssert)
+
+				t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				assert.Equal(t, gotResponse.Code, testCase.wantHTTPCodeReturn)
+			}
+
+		})
+	}
+
+}
+
+func TestOrganizationGroupService_Remove(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		organizationID     string
+		groupID            string
+		accountID          string
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+	}{
+		{
+			name:               "RemoveUserWhenTheParametersAreCorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships/5b10ac8d82e05b22cc7d4ef5",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            false,
+		},
+
+		{
+			name:               "RemoveUserWhenTheOrganizationIDIsNotSet",
+			organizationID:     "",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships/5b10ac8d82e05b22cc7d4ef5",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+
+		{
+			name:               "RemoveUserWhenTheGroupIDIsNotSet",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships/5b10ac8d82e05b22cc7d4ef5",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+
+		{
+			name:               "RemoveUserWhenTheAccountIDIsNotSet",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			accountID:          "",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships/5b10ac8d82e05b22cc7d4ef5",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+
+		{
+			name:               "RemoveUserWhenTheRequestMethodIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships/5b10ac8d82e05b22cc7d4ef5",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+
+		{
+			name:               "RemoveUserWhenTheStatusCodeIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships/5b10ac8d82e05b22cc7d4ef5",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+		},
+
+		{
+			name:               "RemoveUserWhenTheContextIsNil",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			groupID:            "8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a",
+			accountID:          "5b10ac8d82e05b22cc7d4ef5",
+			wantHTTPMethod:     http.MethodDelete,
+			endpoint:           "/admin/v1/orgs/d094d850-d57e-483a-bd03-ca8855919267/directory/groups/8a5b2c7e-0f4d-4c1a-b1b8-7a9c1e2d3f4a/memberships/5b10ac8d82e05b22cc7d4ef5",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusNoContent,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &OrganizationGroupService{client: mockClient}
+			gotResponse, err := service.Remove(testCase.context, testCase.organizationID, testCase.groupID, testCase.accountID)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+				assert.Error(t, err)
+
+				if gotResponse != nil {
+					t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				}
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+
+				apiEndpoint, err := url.Parse(gotResponse.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var endpointToAssert string
+
+				if apiEndpoint.Query().Encode() != "" {
+					endpointToAssert = fmt.Sprintf("%v?%v", apiEndpoint.Path, apiEndpoint.Query().Encode())
+				} else {
+					endpointToAssert = apiEndpoint.Path
+				}
+
+				t.Logf("HTTP Endpoint Wanted: %v, HTTP Endpoint Returned: %v", testCase.endpoint, endpointToAssert)
+				assert.Equal(t, testCase.endpoint, endpointToAssert)
+
+				t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				assert.Equal(t, gotResponse.Code, testCase.wantHTTPCodeReturn)
+			}
+
+		})
+	}
+
+}
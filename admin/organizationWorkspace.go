@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"net/http"
+)
+
+type OrganizationWorkspaceService struct {
+	client *Client
+}
+
+// Gets returns the workspaces (sites and products) of an organization one page at a time, the cursor of the next
+// page is sent on the payload.
+// Docs: https://developer.atlassian.com/cloud/admin/organization/rest/api-group-workspaces/#api-v2-orgs-orgid-workspaces-post
+func (o *OrganizationWorkspaceService) Gets(ctx context.Context, organizationID string,
+	payload *model.OrganizationWorkspaceSearchScheme) (result *model.OrganizationWorkspacePageScheme,
+	response *ResponseScheme, err error) {
+
+	if len(organizationID) == 0 {
+		return nil, nil, model.ErrNoAdminOrganizationError
+	}
+
+	if payload == nil {
+		payload = &model.OrganizationWorkspaceSearchScheme{}
+	}
+
+	payloadAsReader, err := transformStructToReader(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var endpoint = fmt.Sprintf("/admin/v2/orgs/%v/workspaces", organizationID)
+
+	request, err := o.client.newRequest(ctx, http.MethodPost, endpoint, payloadAsReader)
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err = o.client.call(request, &result)
+	if err != nil {
+		return
+	}
+
+	return
+}
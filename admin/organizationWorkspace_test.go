@@ -0,0 +1,159 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestOrganizationWorkspaceService_Gets(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		organizationID     string
+		payload            *model.OrganizationWorkspaceSearchScheme
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+	}{
+		{
+			name:               "GetWorkspacesWhenTheParametersAreCorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			payload:            &model.OrganizationWorkspaceSearchScheme{Limit: 50, Cursor: "eyJwYWdlIjoyfQ"},
+			mockFile:           "./mocks/get-organization-workspaces.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/workspaces",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            false,
+		},
+
+		{
+			name:               "GetWorkspacesWhenTheOrganizationIDIsNotSet",
+			organizationID:     "",
+			payload:            &model.OrganizationWorkspaceSearchScheme{Limit: 50, Cursor: "eyJwYWdlIjoyfQ"},
+			mockFile:           "./mocks/get-organization-workspaces.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/workspaces",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetWorkspacesWhenTheRequestMethodIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			payload:            &model.OrganizationWorkspaceSearchScheme{Limit: 50, Cursor: "eyJwYWdlIjoyfQ"},
+			mockFile:           "./mocks/get-organization-workspaces.json",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/workspaces",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetWorkspacesWhenTheStatusCodeIsIncorrect",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			payload:            &model.OrganizationWorkspaceSearchScheme{Limit: 50, Cursor: "eyJwYWdlIjoyfQ"},
+			mockFile:           "./mocks/get-organization-workspaces.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/workspaces",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+		},
+
+		{
+			name:               "GetWorkspacesWhenTheContextIsNil",
+			organizationID:     "d094d850-d57e-483a-bd03-ca8855919267",
+			payload:            &model.OrganizationWorkspaceSearchScheme{Limit: 50, Cursor: "eyJwYWdlIjoyfQ"},
+			mockFile:           "./mocks/get-organization-workspaces.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/admin/v2/orgs/d094d850-d57e-483a-bd03-ca8855919267/workspaces",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &OrganizationWorkspaceService{client: mockClient}
+			gotResult, gotResponse, err := service.Gets(testCase.context, testCase.organizationID, testCase.payload)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+				assert.Error(t, err)
+
+				if gotResponse != nil {
+					t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				}
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+
+				apiEndpoint, err := url.Parse(gotResponse.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var endpointToAssert string
+
+				if apiEndpoint.Query().Encode() != "" {
+					endpointToAssert = fmt.Sprintf("%v?%v", apiEndpoint.Path, apiEndpoint.Query().Encode())
+				} else {
+					endpointToAssert = apiEndpoint.Path
+				}
+
+				t.Logf("HTTP Endpoint Wanted: %v, HTTP Endpoint Returned: %v", testCase.endpoint, endpointToAssert)
+				assert.Equal(t, testCase.endpoint, endpointToAssert)
+
+				t.Logf("HTTP Code Wanted: %v, HTTP Code Returned: %v", testCase.wantHTTPCodeReturn, gotResponse.Code)
+				assert.Equal(t, gotResponse.Code, testCase.wantHTTPCodeReturn)
+
+				for _, workspace := range gotResult.Data {
+					t.Log(workspace.ID, workspace.Attributes.Name, workspace.Attributes.HostURL)
+				}
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,206 @@
+// Package scim builds the SCIM filter expressions of SCIMUserService.Gets and SCIMGroupService.Gets, and the PATCH
+// payloads of SCIMUserService.Path and SCIMGroupService.Path, following RFC 7644.
+//
+//	filter, err := scim.Or(
+//		scim.Eq("userName", "alice@example.com"),
+//		scim.And(scim.Sw("name.familyName", "Smi"), scim.Pr("title")),
+//		scim.ValuePath("emails", scim.Eq("type", "work")),
+//	).Build()
+//
+//	// userName eq "alice@example.com" or (name.familyName sw "Smi" and title pr) or emails[type eq "work"]
+package scim
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidAttributePath = errors.New("scim: invalid attribute path")
+	ErrInvalidFilterValue   = errors.New("scim: invalid filter value")
+	ErrEmptyFilter          = errors.New("scim: empty filter")
+)
+
+type filterKind int
+
+const (
+	kindComparison filterKind = iota
+	kindAnd
+	kindOr
+	kindNot
+)
+
+// Filter is a filter expression, the errors of the attribute paths and the values are kept until it's built.
+type Filter struct {
+	text string
+	kind filterKind
+	err  error
+}
+
+// String returns the expression, it's empty when the filter isn't valid.
+func (f Filter) String() string {
+
+	if f.err != nil {
+		return ""
+	}
+
+	return f.text
+}
+
+// Err returns the first error of the filter, or of the filters it's composed of.
+func (f Filter) Err() error {
+
+	if f.err == nil && f.text == "" {
+		return ErrEmptyFilter
+	}
+
+	return f.err
+}
+
+// Build returns the expression, or the first error of the filter.
+func (f Filter) Build() (string, error) {
+
+	if err := f.Err(); err != nil {
+		return "", err
+	}
+
+	return f.text, nil
+}
+
+// attributePath is an attribute name, with a sub-attribute, and prefixed by the schema URN on the extensions:
+// name.givenName or urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department.
+var attributePath = regexp.MustCompile(`^(urn:[A-Za-z0-9.:_-]+:)?[A-Za-z][A-Za-z0-9_$-]*(\.[A-Za-z$][A-Za-z0-9_$-]*)?$`)
+
+func validPath(path string) error {
+
+	if !attributePath.MatchString(path) {
+		return fmt.Errorf("%w: %q", ErrInvalidAttributePath, path)
+	}
+
+	return nil
+}
+
+// filterValue returns a value as a filter literal, the strings are JSON quoted without escaping the HTML characters.
+func filterValue(value interface{}) (string, error) {
+
+	switch value.(type) {
+	case nil, string, bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+
+		var buffer bytes.Buffer
+		encoder := json.NewEncoder(&buffer)
+		encoder.SetEscapeHTML(false)
+
+		if err := encoder.Encode(value); err != nil {
+			return "", err
+		}
+
+		return strings.TrimSuffix(buffer.String(), "\n"), nil
+	default:
+		return "", fmt.Errorf("%w: %T", ErrInvalidFilterValue, value)
+	}
+}
+
+func compare(path, operator string, value interface{}) Filter {
+
+	if err := validPath(path); err != nil {
+		return Filter{err: err}
+	}
+
+	literal, err := filterValue(value)
+	if err != nil {
+		return Filter{err: err}
+	}
+
+	return Filter{text: path + " " + operator + " " + literal}
+}
+
+// Eq, and the other comparisons, take a string, a boolean, a number or nil value.
+func Eq(path string, value interface{}) Filter { return compare(path, "eq", value) }
+func Ne(path string, value interface{}) Filter { return compare(path, "ne", value) }
+func Co(path string, value interface{}) Filter { return compare(path, "co", value) }
+func Sw(path string, value interface{}) Filter { return compare(path, "sw", value) }
+func Ew(path string, value interface{}) Filter { return compare(path, "ew", value) }
+func Gt(path string, value interface{}) Filter { return compare(path, "gt", value) }
+func Ge(path string, value interface{}) Filter { return compare(path, "ge", value) }
+func Lt(path string, value interface{}) Filter { return compare(path, "lt", value) }
+func Le(path string, value interface{}) Filter { return compare(path, "le", value) }
+
+// Pr matches when the attribute has a value.
+func Pr(path string) Filter {
+
+	if err := validPath(path); err != nil {
+		return Filter{err: err}
+	}
+
+	return Filter{text: path + " pr"}
+}
+
+func logical(kind filterKind, operator string, filters []Filter) Filter {
+
+	var parts []string
+	var last Filter
+
+	for _, filter := range filters {
+
+		if filter.err != nil {
+			return Filter{err: filter.err}
+		}
+
+		if filter.text == "" {
+			continue
+		}
+
+		// The and binds tighter than the or, so an or is grouped in an and.
+		text := filter.text
+		if filter.kind != kindComparison && filter.kind != kindNot && filter.kind != kind {
+			text = "(" + text + ")"
+		}
+
+		parts = append(parts, text)
+		last = filter
+	}
+
+	switch len(parts) {
+	case 0:
+		return Filter{}
+	case 1:
+		return last
+	}
+
+	return Filter{text: strings.Join(parts, " "+operator+" "), kind: kind}
+}
+
+// And matches when every filter matches, the empty filters are ignored.
+func And(filters ...Filter) Filter { return logical(kindAnd, "and", filters) }
+
+// Or matches when any filter matches, the empty filters are ignored.
+func Or(filters ...Filter) Filter { return logical(kindOr, "or", filters) }
+
+// Not matches when the filter doesn't match.
+func Not(filter Filter) Filter {
+
+	if filter.err != nil || filter.text == "" {
+		return filter
+	}
+
+	return Filter{text: "not (" + filter.text + ")", kind: kindNot}
+}
+
+// ValuePath matches the multi-valued attributes with a value matching the filter, e.g. emails[type eq "work"],
+// the paths of the filter are the sub-attributes of the attribute.
+func ValuePath(attribute string, filter Filter) Filter {
+
+	if err := validPath(attribute); err != nil {
+		return Filter{err: err}
+	}
+
+	if err := filter.Err(); err != nil {
+		return Filter{err: err}
+	}
+
+	return Filter{text: attribute + "[" + filter.text + "]"}
+}
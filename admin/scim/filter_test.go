@@ -0,0 +1,90 @@
+package scim
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFilter_Build(t *testing.T) {
+
+	testCases := []struct {
+		name          string
+		filter        Filter
+		want          string
+		expectedError string
+	}{
+		{
+			name:   "when the filter is a comparison",
+			filter: Eq("userName", `bjensen "the" \admin`),
+			want:   `userName eq "bjensen \"the\" \\admin"`,
+		},
+
+		{
+			name: "when the filters are grouped",
+			filter: Or(
+				Eq("userName", "alice@example.com"),
+				And(Sw("name.familyName", "Smi"), Pr("title")),
+				ValuePath("emails", And(Eq("type", "work"), Co("value", "@example.com"))),
+			),
+			want: `userName eq "alice@example.com" or (name.familyName sw "Smi" and title pr) or ` +
+				`emails[type eq "work" and value co "@example.com"]`,
+		},
+
+		{
+			name:   "when an or is in an and",
+			filter: And(Or(Eq("active", true), Gt("meta.lastModified", "2021-01-01T00:00:00Z")), Not(Ew("userName", ".org"))),
+			want:   `(active eq true or meta.lastModified gt "2021-01-01T00:00:00Z") and not (userName ew ".org")`,
+		},
+
+		{
+			name:   "when the attribute is on an extension",
+			filter: Eq("urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department", "R&D"),
+			want:   `urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department eq "R&D"`,
+		},
+
+		{
+			name:   "when the empty filters are ignored",
+			filter: And(Filter{}, Or(Eq("displayName", "admins")), Filter{}),
+			want:   `displayName eq "admins"`,
+		},
+
+		{
+			name:   "when the value is null or a number",
+			filter: Or(Eq("title", nil), Le("x-count", 3)),
+			want:   `title eq null or x-count le 3`,
+		},
+
+		{
+			name:          "when the attribute path is not valid",
+			filter:        And(Eq("displayName", "admins"), Eq("display name", "admins")),
+			expectedError: `scim: invalid attribute path: "display name"`,
+		},
+
+		{
+			name:          "when the value is not valid",
+			filter:        Eq("displayName", []string{"admins"}),
+			expectedError: "scim: invalid filter value: []string",
+		},
+
+		{
+			name:          "when the filter is empty",
+			filter:        And(),
+			expectedError: "scim: empty filter",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			got, err := testCase.filter.Build()
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				assert.Equal(t, "", testCase.filter.String())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/admin"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"strings"
+)
+
+var (
+	ErrUnknownAttribute   = errors.New("scim: attribute not on the schema")
+	ErrReadOnlyAttribute  = errors.New("scim: read-only attribute")
+	ErrImmutableAttribute = errors.New("scim: immutable attribute")
+	ErrRequiredAttribute  = errors.New("scim: required attribute can't be removed")
+	ErrNoPatchValue       = errors.New("scim: no patch value set")
+	ErrNoPatchOperation   = errors.New("scim: no patch operation set")
+	ErrInvalidGroupValue  = errors.New("scim: group patch values must be members")
+)
+
+const PatchOpSchema = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+
+type Op string
+
+const (
+	OpAdd     Op = "add"
+	OpReplace Op = "replace"
+	OpRemove  Op = "remove"
+)
+
+// urnAliases are the URNs used by the Atlassian payloads for the schemas returned with another URN, the enterprise
+// extension is 2.1 on the users (model.SCIMUserScheme) and 2.0 on the schemas.
+var urnAliases = map[string]string{
+	"urn:ietf:params:scim:schemas:extension:enterprise:2.1:user": "urn:ietf:params:scim:schemas:extension:enterprise:2.0:user",
+}
+
+// Schema is the set of schemas the patch paths are validated against, e.g. the core user schema and the
+// enterprise extension.
+type Schema struct {
+	schemas []*model.SCIMSchemaScheme
+}
+
+func NewSchema(schemas ...*model.SCIMSchemaScheme) *Schema {
+	return &Schema{schemas: schemas}
+}
+
+// UserSchema returns the user schema of a directory, with the enterprise extension.
+func UserSchema(ctx context.Context, client *admin.Client, directoryID string) (*Schema, error) {
+
+	user, _, err := client.SCIM.Scheme.User(ctx, directoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	enterprise, _, err := client.SCIM.Scheme.Enterprise(ctx, directoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSchema(user, enterprise), nil
+}
+
+// GroupSchema returns the group schema of a directory.
+func GroupSchema(ctx context.Context, client *admin.Client, directoryID string) (*Schema, error) {
+
+	group, _, err := client.SCIM.Scheme.Group(ctx, directoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSchema(group), nil
+}
+
+// attribute is the attribute, and the sub-attribute, of a path.
+type attribute struct {
+	name, mutability string
+	required, multi  bool
+	sub              bool // the path targets a sub-attribute
+	filtered         bool // the path has a value filter
+}
+
+// split returns the attribute of a path, its value filter and its sub-attribute:
+// emails[type eq "work"].value is emails, type eq "work" and value.
+func split(path string) (name, filter, sub string, err error) {
+
+	name = path
+	if open := strings.Index(path, "["); open != -1 {
+
+		closing := strings.LastIndex(path, "]")
+		if closing < open || strings.TrimSpace(path[open+1:closing]) == "" {
+			return "", "", "", fmt.Errorf("%w: %q", ErrInvalidAttributePath, path)
+		}
+
+		name, filter, sub = path[:open], path[open+1:closing], path[closing+1:]
+		if sub != "" {
+
+			if !strings.HasPrefix(sub, ".") {
+				return "", "", "", fmt.Errorf("%w: %q", ErrInvalidAttributePath, path)
+			}
+
+			sub = sub[1:]
+		}
+	}
+
+	if err := validPath(name); err != nil {
+		return "", "", "", fmt.Errorf("%w: %q", ErrInvalidAttributePath, path)
+	}
+
+	if sub != "" && strings.Contains(name, ".") {
+		return "", "", "", fmt.Errorf("%w: %q", ErrInvalidAttributePath, path)
+	}
+
+	return name, filter, sub, nil
+}
+
+// resolve returns the attribute of a path, the attribute names are compared case-insensitively. The attributes
+// of the extensions are prefixed by their schema URN, the others are on the core schemas.
+func (s *Schema) resolve(path string) (*attribute, error) {
+
+	name, filter, sub, err := split(path)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := s.schemas
+	lower := strings.ToLower(name)
+
+	if strings.HasPrefix(lower, "urn:") {
+
+		separator := strings.LastIndex(lower, ":")
+		urn := lower[:separator]
+		if alias, ok := urnAliases[urn]; ok {
+			urn = alias
+		}
+
+		candidates = nil
+		for _, schema := range s.schemas {
+			if strings.ToLower(schema.ID) == urn {
+				candidates = append(candidates, schema)
+			}
+		}
+
+		name = name[separator+1:]
+
+	} else {
+
+		var core []*model.SCIMSchemaScheme
+		for _, schema := range s.schemas {
+			if !strings.Contains(schema.ID, ":extension:") {
+				core = append(core, schema)
+			}
+		}
+
+		candidates = core
+	}
+
+	if dot := strings.Index(name, "."); dot != -1 {
+		name, sub = name[:dot], name[dot+1:]
+	}
+
+	for _, schema := range candidates {
+		for _, candidate := range schema.Attributes {
+
+			if !strings.EqualFold(candidate.Name, name) {
+				continue
+			}
+
+			if filter != "" && !candidate.MultiValued {
+				return nil, fmt.Errorf("%w: %q isn't multi-valued", ErrInvalidAttributePath, path)
+			}
+
+			resolved := &attribute{name: candidate.Name, mutability: candidate.Mutability, required: candidate.Required,
+				multi: candidate.MultiValued, filtered: filter != ""}
+
+			if sub == "" {
+				return resolved, nil
+			}
+
+			for _, subAttribute := range candidate.SubAttributes {
+				if strings.EqualFold(subAttribute.Name, sub) {
+					resolved.sub, resolved.mutability, resolved.required = true, subAttribute.Mutability, subAttribute.Required
+					return resolved, nil
+				}
+			}
+
+			return nil, fmt.Errorf("%w: %q", ErrUnknownAttribute, path)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnknownAttribute, path)
+}
+
+// Patch builds the operations of a PATCH request, the paths are validated against the schema when it's set,
+// the first error is returned by the payload methods.
+type Patch struct {
+	schema     *Schema
+	operations []*model.SCIMUserToPathOperationScheme
+	err        error
+}
+
+// NewPatch returns a patch validated against the schema, the paths are only checked syntactically when it's nil.
+func NewPatch(schema *Schema) *Patch {
+	return &Patch{schema: schema}
+}
+
+// Add adds the value to the attribute, the values are appended to the multi-valued attributes.
+func (p *Patch) Add(path string, value interface{}) *Patch {
+	return p.operation(OpAdd, path, value)
+}
+
+// Replace replaces the value of the attribute.
+func (p *Patch) Replace(path string, value interface{}) *Patch {
+	return p.operation(OpReplace, path, value)
+}
+
+// Remove removes the attribute, or the values matching the filter of a value path, e.g. members[value eq "id"].
+func (p *Patch) Remove(path string) *Patch {
+	return p.operation(OpRemove, path, nil)
+}
+
+// RemoveValues removes values from a multi-valued attribute, e.g. the members of a group.
+func (p *Patch) RemoveValues(path string, value interface{}) *Patch {
+	return p.operation(OpRemove, path, value)
+}
+
+func (p *Patch) operation(op Op, path string, value interface{}) *Patch {
+
+	if p.err != nil {
+		return p
+	}
+
+	p.err = p.validate(op, path, value)
+	if p.err == nil {
+		p.operations = append(p.operations, &model.SCIMUserToPathOperationScheme{Op: string(op), Path: path, Value: value})
+	}
+
+	return p
+}
+
+func (p *Patch) validate(op Op, path string, value interface{}) error {
+
+	if op != OpRemove && value == nil {
+		return fmt.Errorf("%w: %v %v", ErrNoPatchValue, op, path)
+	}
+
+	if p.schema == nil {
+		_, _, _, err := split(path)
+		return err
+	}
+
+	resolved, err := p.schema.resolve(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(resolved.mutability) {
+	case "readonly":
+		return fmt.Errorf("%w: %v %v", ErrReadOnlyAttribute, op, path)
+	case "immutable":
+		// The immutable values are set once, the multi-valued ones can still be added or removed.
+		if op == OpReplace || (op == OpRemove && !resolved.multi) {
+			return fmt.Errorf("%w: %v %v", ErrImmutableAttribute, op, path)
+		}
+	}
+
+	if op == OpRemove && resolved.required && !resolved.sub && !resolved.filtered && value == nil {
+		return fmt.Errorf("%w: %v", ErrRequiredAttribute, path)
+	}
+
+	return nil
+}
+
+// Err returns the first error of the operations.
+func (p *Patch) Err() error {
+
+	if p.err == nil && len(p.operations) == 0 {
+		return ErrNoPatchOperation
+	}
+
+	return p.err
+}
+
+// UserPayload returns the payload of SCIMUserService.Path.
+func (p *Patch) UserPayload() (*model.SCIMUserToPathScheme, error) {
+
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+
+	return &model.SCIMUserToPathScheme{Schemas: []string{PatchOpSchema}, Operations: p.operations}, nil
+}
+
+// GroupPayload returns the payload of SCIMGroupService.Path, the values must be members, see Members.
+func (p *Patch) GroupPayload() (*model.SCIMGroupPathScheme, error) {
+
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+
+	payload := &model.SCIMGroupPathScheme{Schemas: []string{PatchOpSchema}}
+	for _, operation := range p.operations {
+
+		values, ok := operation.Value.([]*model.SCIMGroupOperationValueScheme)
+		if !ok && operation.Value != nil {
+			return nil, fmt.Errorf("%w: %v %v", ErrInvalidGroupValue, operation.Op, operation.Path)
+		}
+
+		payload.Operations = append(payload.Operations, &model.SCIMGroupOperationScheme{Op: operation.Op,
+			Path: operation.Path, Value: values})
+	}
+
+	return payload, nil
+}
+
+// Members returns the values of the members of a group, by user id.
+func Members(userIDs ...string) []*model.SCIMGroupOperationValueScheme {
+
+	values := make([]*model.SCIMGroupOperationValueScheme, len(userIDs))
+	for index, id := range userIDs {
+		values[index] = &model.SCIMGroupOperationValueScheme{Value: id}
+	}
+
+	return values
+}
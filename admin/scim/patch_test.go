@@ -0,0 +1,151 @@
+package scim
+
+import (
+	"encoding/json"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+)
+
+func loadSchemas(t *testing.T, paths ...string) *Schema {
+
+	var schemas []*model.SCIMSchemaScheme
+	for _, path := range paths {
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		schema := &model.SCIMSchemaScheme{}
+		if err := json.Unmarshal(content, schema); err != nil {
+			t.Fatal(err)
+		}
+
+		schemas = append(schemas, schema)
+	}
+
+	return NewSchema(schemas...)
+}
+
+func TestPatch_UserPayload(t *testing.T) {
+
+	schema := loadSchemas(t, "../mocks/scim-get-user-schemas.json", "../mocks/scim-get-user-enterprise-schemas.json")
+
+	testCases := []struct {
+		name          string
+		patch         *Patch
+		want          string
+		expectedError string
+	}{
+		{
+			name: "when the operations are valid",
+			patch: NewPatch(schema).
+				Replace("displayName", "Alice Smith").
+				Replace("name.givenName", "Alice").
+				Replace(`emails[type eq "work"].value`, "alice@example.com").
+				Add("phoneNumbers", []*model.SCIMUserComplexOperationScheme{{Value: "555-0100", ValueType: "work"}}).
+				Replace("urn:ietf:params:scim:schemas:extension:enterprise:2.1:User:department", "Research").
+				Remove("title"),
+			want: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"operations":[` +
+				`{"op":"replace","path":"displayName","value":"Alice Smith"},` +
+				`{"op":"replace","path":"name.givenName","value":"Alice"},` +
+				`{"op":"replace","path":"emails[type eq \"work\"].value","value":"alice@example.com"},` +
+				`{"op":"add","path":"phoneNumbers","value":[{"value":"555-0100","type":"work"}]},` +
+				`{"op":"replace","path":"urn:ietf:params:scim:schemas:extension:enterprise:2.1:User:department","value":"Research"},` +
+				`{"op":"remove","path":"title"}]}`,
+		},
+
+		{
+			name:          "when the attribute is not on the schema",
+			patch:         NewPatch(schema).Replace("displayName", "Alice").Replace("nickname.first", "Al"),
+			expectedError: `scim: attribute not on the schema: "nickname.first"`,
+		},
+
+		{
+			name:          "when the attribute is not on the extension",
+			patch:         NewPatch(schema).Replace("urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager", "bob"),
+			expectedError: `scim: attribute not on the schema: "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager"`,
+		},
+
+		{
+			name:          "when the attribute is read-only",
+			patch:         NewPatch(schema).Add("groups", "admins"),
+			expectedError: "scim: read-only attribute: add groups",
+		},
+
+		{
+			name:          "when a required attribute is removed",
+			patch:         NewPatch(schema).Remove("userName"),
+			expectedError: "scim: required attribute can't be removed: userName",
+		},
+
+		{
+			name:          "when the value filter is on a single-valued attribute",
+			patch:         NewPatch(schema).Remove(`name[givenName eq "Al"]`),
+			expectedError: `scim: invalid attribute path: "name[givenName eq \"Al\"]" isn't multi-valued`,
+		},
+
+		{
+			name:          "when the value is not set",
+			patch:         NewPatch(nil).Replace("displayName", nil),
+			expectedError: "scim: no patch value set: replace displayName",
+		},
+
+		{
+			name:          "when the path is not valid without a schema",
+			patch:         NewPatch(nil).Replace("emails[]", "x"),
+			expectedError: `scim: invalid attribute path: "emails[]"`,
+		},
+
+		{
+			name:          "when there is no operation",
+			patch:         NewPatch(schema),
+			expectedError: "scim: no patch operation set",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			payload, err := testCase.patch.UserPayload()
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			encoded, err := json.Marshal(payload)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, string(encoded))
+		})
+	}
+}
+
+func TestPatch_GroupPayload(t *testing.T) {
+
+	schema := loadSchemas(t, "../mocks/scim-get-group-schemas.json")
+
+	payload, err := NewPatch(schema).
+		Add("members", Members("u1", "u2")).
+		Remove(`members[value eq "u3"]`).
+		RemoveValues("members", Members("u4")).
+		GroupPayload()
+	assert.NoError(t, err)
+
+	encoded, err := json.Marshal(payload)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[`+
+		`{"op":"add","path":"members","value":[{"value":"u1"},{"value":"u2"}]},`+
+		`{"op":"remove","path":"members[value eq \"u3\"]"},`+
+		`{"op":"remove","path":"members","value":[{"value":"u4"}]}]}`, string(encoded))
+
+	_, err = NewPatch(schema).Replace("displayName", "admins").GroupPayload()
+	assert.EqualError(t, err, "scim: immutable attribute: replace displayName")
+
+	_, err = NewPatch(nil).Replace("displayName", "admins").GroupPayload()
+	assert.EqualError(t, err, "scim: group patch values must be members: replace displayName")
+}
@@ -0,0 +1,364 @@
+package scimsync
+
+import (
+	"errors"
+	"fmt"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"sort"
+	"strings"
+)
+
+var ErrUnknownMember = errors.New("scimsync: group member not on the source or the directory")
+
+type OperationType string
+
+// The operations are planned, and applied, in the order of the types below, so the users and the groups exist
+// before their memberships are patched.
+const (
+	OperationCreateGroup    OperationType = "create-group"
+	OperationCreateUser     OperationType = "create-user"
+	OperationUpdateUser     OperationType = "update-user"
+	OperationAddMembers     OperationType = "add-members"
+	OperationRemoveMembers  OperationType = "remove-members"
+	OperationDeactivateUser OperationType = "deactivate-user"
+)
+
+var operationOrder = map[OperationType]int{
+	OperationCreateGroup:    0,
+	OperationCreateUser:     1,
+	OperationUpdateUser:     2,
+	OperationAddMembers:     3,
+	OperationRemoveMembers:  4,
+	OperationDeactivateUser: 5,
+}
+
+type Operation struct {
+	Type OperationType
+
+	// Target is the user name of the user operations, and the group name of the group operations.
+	Target string
+
+	// ID is the SCIM id of the user or the group, it's empty when it's created by the plan.
+	ID string
+
+	// Changes are the attributes updated, or the user names added to or removed from the group.
+	Changes []string
+
+	user    *User
+	patches []*model.SCIMUserToPathOperationScheme
+
+	// memberIDs are the ids of the members on the directory by lower-cased user name, the members created by the
+	// plan are resolved when they're created.
+	memberIDs map[string]string
+}
+
+func (o *Operation) String() string {
+
+	text := fmt.Sprintf("%-15v %v", o.Type, o.Target)
+	if o.ID != "" {
+		text += " (" + o.ID + ")"
+	}
+
+	if len(o.Changes) != 0 {
+		text += ": " + strings.Join(o.Changes, ", ")
+	}
+
+	return text
+}
+
+type Plan struct {
+	Operations []*Operation
+}
+
+// Empty returns true when the directory is on the desired state already.
+func (p *Plan) Empty() bool {
+	return len(p.Operations) == 0
+}
+
+// String returns the operations, one per line.
+func (p *Plan) String() string {
+
+	var builder strings.Builder
+	for _, operation := range p.Operations {
+		builder.WriteString(operation.String())
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+// directory is the current state of a directory, the users and the groups are keyed by their lower-cased name.
+type directory struct {
+	users        []*model.SCIMUserScheme
+	byExternalID map[string]*model.SCIMUserScheme
+	byUserName   map[string]*model.SCIMUserScheme
+	userNames    map[string]string // the user names by id
+	groups       map[string]*model.ScimGroupScheme
+}
+
+func newDirectory(users []*model.SCIMUserScheme, groups []*model.ScimGroupScheme) *directory {
+
+	current := &directory{
+		users:        users,
+		byExternalID: map[string]*model.SCIMUserScheme{},
+		byUserName:   map[string]*model.SCIMUserScheme{},
+		userNames:    map[string]string{},
+		groups:       map[string]*model.ScimGroupScheme{},
+	}
+
+	for _, user := range users {
+
+		if user.ExternalID != "" {
+			current.byExternalID[user.ExternalID] = user
+		}
+
+		current.byUserName[strings.ToLower(user.UserName)] = user
+		current.userNames[user.ID] = user.UserName
+	}
+
+	for _, group := range groups {
+		current.groups[strings.ToLower(group.DisplayName)] = group
+	}
+
+	return current
+}
+
+// match returns the directory user of a desired user, by external id, then by user name.
+func (d *directory) match(user *User) *model.SCIMUserScheme {
+
+	if current, ok := d.byExternalID[user.externalID()]; ok {
+		return current
+	}
+
+	return d.byUserName[strings.ToLower(user.UserName)]
+}
+
+const enterpriseDepartmentPath = "urn:ietf:params:scim:schemas:extension:enterprise:2.1:User:department"
+
+// newPlan compares the desired state with the directory. The directory users not on the source are deactivated
+// when deactivateMissing is set, the directory groups not on the source aren't changed.
+func newPlan(state *State, current *directory, deactivateMissing bool) (*Plan, error) {
+
+	plan := &Plan{}
+	desired := map[string]*User{}
+	listed := map[string]bool{} // the ids of the directory users on the source
+
+	for _, user := range state.Users {
+
+		desired[strings.ToLower(user.UserName)] = user
+
+		existing := current.match(user)
+		if existing != nil {
+			listed[existing.ID] = true
+		}
+
+		switch {
+
+		case existing == nil && user.active():
+			plan.Operations = append(plan.Operations, &Operation{Type: OperationCreateUser, Target: user.UserName, user: user})
+
+		case existing != nil && !user.active():
+			if existing.Active {
+				plan.Operations = append(plan.Operations, &Operation{Type: OperationDeactivateUser, Target: user.UserName,
+					ID: existing.ID})
+			}
+
+		case existing != nil:
+			if operation := updateOperation(user, existing); operation != nil {
+				plan.Operations = append(plan.Operations, operation)
+			}
+		}
+	}
+
+	if deactivateMissing {
+		for _, user := range current.users {
+			if user.Active && !listed[user.ID] {
+				plan.Operations = append(plan.Operations, &Operation{Type: OperationDeactivateUser, Target: user.UserName,
+					ID: user.ID})
+			}
+		}
+	}
+
+	for _, group := range state.Groups {
+
+		// The members wanted by their SCIM id, the users matched by external id are renamed by the plan, so they're
+		// compared by id. The users created by the plan aren't members yet, and the inactive users aren't added.
+		members := map[string]string{} // the user names by id
+		var created []string
+		ids := map[string]string{} // the ids of the members added or removed, by lower-cased user name
+
+		for _, name := range group.Members {
+
+			var existing *model.SCIMUserScheme
+			if user, onSource := desired[strings.ToLower(name)]; onSource {
+
+				if !user.active() {
+					continue
+				}
+
+				existing = current.match(user)
+
+			} else if existing = current.byUserName[strings.ToLower(name)]; existing == nil {
+				return nil, fmt.Errorf("%w: %v (%v)", ErrUnknownMember, name, group.Name)
+			}
+
+			if existing == nil {
+				created = append(created, name)
+				continue
+			}
+
+			members[existing.ID] = name
+			ids[strings.ToLower(name)] = existing.ID
+		}
+
+		existing, ok := current.groups[strings.ToLower(group.Name)]
+		if !ok {
+
+			plan.Operations = append(plan.Operations, &Operation{Type: OperationCreateGroup, Target: group.Name})
+			if added := append(sortedValues(members), created...); len(added) != 0 {
+				sort.Strings(added)
+				plan.Operations = append(plan.Operations, &Operation{Type: OperationAddMembers, Target: group.Name,
+					Changes: added, memberIDs: ids})
+			}
+
+			continue
+		}
+
+		present := map[string]bool{}
+		added := created
+		var removed []string
+
+		for _, member := range existing.Members {
+
+			present[member.Value] = true
+			if _, ok := members[member.Value]; ok {
+				continue
+			}
+
+			name, ok := current.userNames[member.Value]
+			if !ok {
+				name = member.Value
+			}
+
+			removed = append(removed, name)
+			ids[strings.ToLower(name)] = member.Value
+		}
+
+		for id, name := range members {
+			if !present[id] {
+				added = append(added, name)
+			}
+		}
+
+		sort.Strings(added)
+		sort.Strings(removed)
+
+		if len(added) != 0 {
+			plan.Operations = append(plan.Operations, &Operation{Type: OperationAddMembers, Target: group.Name,
+				ID: existing.ID, Changes: added, memberIDs: ids})
+		}
+
+		if len(removed) != 0 {
+			plan.Operations = append(plan.Operations, &Operation{Type: OperationRemoveMembers, Target: group.Name,
+				ID: existing.ID, Changes: removed, memberIDs: ids})
+		}
+	}
+
+	sort.SliceStable(plan.Operations, func(i, j int) bool {
+
+		left, right := plan.Operations[i], plan.Operations[j]
+		if left.Type != right.Type {
+			return operationOrder[left.Type] < operationOrder[right.Type]
+		}
+
+		return strings.ToLower(left.Target) < strings.ToLower(right.Target)
+	})
+
+	return plan, nil
+}
+
+// updateOperation returns the patch of the attributes set on the source and different on the directory,
+// the attributes not set on the source aren't cleared.
+func updateOperation(user *User, existing *model.SCIMUserScheme) *Operation {
+
+	operation := &Operation{Type: OperationUpdateUser, Target: user.UserName, ID: existing.ID, user: user}
+
+	replace := func(path string, value interface{}) {
+		operation.Changes = append(operation.Changes, path)
+		operation.patches = append(operation.patches, &model.SCIMUserToPathOperationScheme{Op: "replace", Path: path, Value: value})
+	}
+
+	name := existing.Name
+	if name == nil {
+		name = &model.SCIMUserNameScheme{}
+	}
+
+	department := existing.Department
+	if existing.EnterpriseInfo != nil && existing.EnterpriseInfo.Department != "" {
+		department = existing.EnterpriseInfo.Department
+	}
+
+	if !existing.Active {
+		replace("active", true)
+	}
+
+	// The user is renamed when it's matched by its external id.
+	if !strings.EqualFold(user.UserName, existing.UserName) {
+		replace("userName", user.UserName)
+	}
+
+	if email := user.email(); !strings.EqualFold(email, primaryEmail(existing)) {
+		replace("emails", []*model.SCIMUserComplexOperationScheme{{Value: email, ValueType: "work", Primary: true}})
+	}
+
+	for _, attribute := range []struct{ path, desired, current string }{
+		{"displayName", user.DisplayName, existing.DisplayName},
+		{"name.givenName", user.GivenName, name.GivenName},
+		{"name.familyName", user.FamilyName, name.FamilyName},
+		{"title", user.Title, existing.Title},
+		{enterpriseDepartmentPath, user.Department, department},
+	} {
+		if attribute.desired != "" && attribute.desired != attribute.current {
+			replace(attribute.path, attribute.desired)
+		}
+	}
+
+	if len(operation.patches) == 0 {
+		return nil
+	}
+
+	// The department is shown by its attribute name on the plan.
+	for index, change := range operation.Changes {
+		if change == enterpriseDepartmentPath {
+			operation.Changes[index] = "department"
+		}
+	}
+
+	return operation
+}
+
+func primaryEmail(user *model.SCIMUserScheme) string {
+
+	for _, email := range user.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+
+	if len(user.Emails) != 0 {
+		return user.Emails[0].Value
+	}
+
+	return ""
+}
+
+func sortedValues(values map[string]string) []string {
+
+	sorted := make([]string, 0, len(values))
+	for _, value := range values {
+		sorted = append(sorted, value)
+	}
+
+	sort.Strings(sorted)
+	return sorted
+}
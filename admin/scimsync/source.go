@@ -0,0 +1,216 @@
+// Package scimsync converges the users, groups and memberships of a SCIM directory to a desired state, loaded
+// from a CSV file, a JSON document or any Source.
+package scimsync
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNoDirectoryID    = errors.New("scimsync: no directory id set")
+	ErrNoSource         = errors.New("scimsync: no source set")
+	ErrNoUserName       = errors.New("scimsync: no user name set")
+	ErrDuplicateUser    = errors.New("scimsync: duplicate user name")
+	ErrNoUserNameColumn = errors.New("scimsync: no userName column on the CSV header")
+)
+
+// State is the desired state of a directory, the users listed are active unless Active is false, and the groups
+// listed have exactly the members listed, by user name.
+type State struct {
+	Users  []*User  `json:"users"`
+	Groups []*Group `json:"groups"`
+}
+
+type User struct {
+	UserName    string `json:"userName"`
+	ExternalID  string `json:"externalId,omitempty"` // the user name is used when it's empty
+	Email       string `json:"email,omitempty"`      // the user name is used when it's empty
+	DisplayName string `json:"displayName,omitempty"`
+	GivenName   string `json:"givenName,omitempty"`
+	FamilyName  string `json:"familyName,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Department  string `json:"department,omitempty"`
+	Active      *bool  `json:"active,omitempty"` // nil is active
+}
+
+func (u *User) active() bool {
+	return u.Active == nil || *u.Active
+}
+
+func (u *User) externalID() string {
+
+	if u.ExternalID != "" {
+		return u.ExternalID
+	}
+
+	return u.UserName
+}
+
+func (u *User) email() string {
+
+	if u.Email != "" {
+		return u.Email
+	}
+
+	return u.UserName
+}
+
+type Group struct {
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+// Source loads the desired state, e.g. from the HR system.
+type Source interface {
+	Load(ctx context.Context) (*State, error)
+}
+
+// Load returns the state itself, so a State built in Go is a Source.
+func (s *State) Load(ctx context.Context) (*State, error) {
+	return s, nil
+}
+
+// SourceFunc is a function used as a Source.
+type SourceFunc func(ctx context.Context) (*State, error)
+
+func (f SourceFunc) Load(ctx context.Context) (*State, error) {
+	return f(ctx)
+}
+
+// validate checks the users have a unique user name, the user names are compared case-insensitively.
+func (s *State) validate() error {
+
+	seen := make(map[string]bool, len(s.Users))
+	for _, user := range s.Users {
+
+		if user.UserName == "" {
+			return ErrNoUserName
+		}
+
+		key := strings.ToLower(user.UserName)
+		if seen[key] {
+			return fmt.Errorf("%w: %v", ErrDuplicateUser, user.UserName)
+		}
+
+		seen[key] = true
+	}
+
+	return nil
+}
+
+type jsonSource struct{ reader io.Reader }
+
+// NewJSONSource returns a source reading a State JSON document.
+func NewJSONSource(reader io.Reader) Source {
+	return &jsonSource{reader: reader}
+}
+
+func (s *jsonSource) Load(ctx context.Context) (*State, error) {
+
+	state := &State{}
+	if err := json.NewDecoder(s.reader).Decode(state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+type csvSource struct{ reader io.Reader }
+
+// NewCSVSource returns a source reading a CSV file with a row per user. The header names the columns, userName
+// is required and userName, externalId, email, displayName, givenName, familyName, title, department, active
+// and groups are read, the other columns are ignored. The groups are separated by semicolons, and a group is
+// declared by the users it's listed on, so the groups without members can't be declared on a CSV file.
+func NewCSVSource(reader io.Reader) Source {
+	return &csvSource{reader: reader}
+}
+
+func (s *csvSource) Load(ctx context.Context) (*State, error) {
+
+	reader := csv.NewReader(s.reader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for index, name := range header {
+		columns[strings.TrimSpace(name)] = index
+	}
+
+	if _, ok := columns["userName"]; !ok {
+		return nil, ErrNoUserNameColumn
+	}
+
+	state := &State{}
+	groups := map[string]*Group{}
+
+	for line := 2; ; line++ {
+
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		value := func(column string) string {
+			if index, ok := columns[column]; ok && index < len(row) {
+				return strings.TrimSpace(row[index])
+			}
+			return ""
+		}
+
+		user := &User{
+			UserName:    value("userName"),
+			ExternalID:  value("externalId"),
+			Email:       value("email"),
+			DisplayName: value("displayName"),
+			GivenName:   value("givenName"),
+			FamilyName:  value("familyName"),
+			Title:       value("title"),
+			Department:  value("department"),
+		}
+
+		if active := value("active"); active != "" {
+
+			parsed, err := strconv.ParseBool(active)
+			if err != nil {
+				return nil, fmt.Errorf("scimsync: line %v: invalid active value %q", line, active)
+			}
+
+			user.Active = &parsed
+		}
+
+		state.Users = append(state.Users, user)
+
+		for _, name := range strings.Split(value("groups"), ";") {
+
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+
+			group, ok := groups[name]
+			if !ok {
+				group = &Group{Name: name}
+				groups[name] = group
+				state.Groups = append(state.Groups, group)
+			}
+
+			group.Members = append(group.Members, user.UserName)
+		}
+	}
+
+	return state, nil
+}
@@ -0,0 +1,419 @@
+package scimsync
+
+import (
+	"context"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/admin"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const patchOpSchema = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+
+type Options struct {
+	DirectoryID string
+	Source      Source
+
+	// DeactivateMissing deactivates the active directory users not on the source.
+	DeactivateMissing bool
+
+	// DryRun plans the operations without applying them.
+	DryRun bool
+
+	// BatchSize is the number of operations applied before pausing for BatchInterval, 20 by default.
+	BatchSize     int
+	BatchInterval time.Duration
+
+	// MaxRetries is the number of times an operation rate limited (429) is retried, 3 by default. The retries wait
+	// for the Retry-After header, or for a second doubled on every retry when it's not set.
+	MaxRetries int
+
+	// PageSize is the number of users, or groups, requested per call, 100 by default.
+	PageSize int
+}
+
+// Syncer converges a SCIM directory to the state loaded from a source.
+type Syncer struct {
+	client  *admin.Client
+	options *Options
+	sleep   func(ctx context.Context, duration time.Duration) error
+}
+
+func NewSyncer(client *admin.Client, options *Options) (*Syncer, error) {
+
+	if options == nil || options.DirectoryID == "" {
+		return nil, ErrNoDirectoryID
+	}
+
+	if options.Source == nil {
+		return nil, ErrNoSource
+	}
+
+	if options.BatchSize <= 0 {
+		options.BatchSize = 20
+	}
+
+	if options.MaxRetries <= 0 {
+		options.MaxRetries = 3
+	}
+
+	if options.PageSize <= 0 {
+		options.PageSize = 100
+	}
+
+	return &Syncer{client: client, options: options, sleep: sleep}, nil
+}
+
+func sleep(ctx context.Context, duration time.Duration) error {
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Plan loads the source and returns the operations converging the directory, without applying them.
+func (s *Syncer) Plan(ctx context.Context) (*Plan, error) {
+
+	plan, _, err := s.plan(ctx)
+	return plan, err
+}
+
+func (s *Syncer) plan(ctx context.Context) (*Plan, *directory, error) {
+
+	state, err := s.options.Source.Load(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := state.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	current, err := s.directory(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan, err := newPlan(state, current, s.options.DeactivateMissing)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return plan, current, nil
+}
+
+// directory gets every user and group of the directory, the SCIM pages start at the index 1.
+func (s *Syncer) directory(ctx context.Context) (*directory, error) {
+
+	var users []*model.SCIMUserScheme
+	for startIndex := 1; ; {
+
+		page, _, err := s.client.SCIM.User.Gets(ctx, s.options.DirectoryID, nil, startIndex, s.options.PageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, page.Resources...)
+		startIndex += len(page.Resources)
+
+		if len(page.Resources) == 0 || startIndex > page.TotalResults {
+			break
+		}
+	}
+
+	var groups []*model.ScimGroupScheme
+	for startIndex := 1; ; {
+
+		page, _, err := s.client.SCIM.Group.Gets(ctx, s.options.DirectoryID, "", startIndex, s.options.PageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, page.Resources...)
+		startIndex += len(page.Resources)
+
+		if len(page.Resources) == 0 || startIndex > page.TotalResults {
+			break
+		}
+	}
+
+	return newDirectory(users, groups), nil
+}
+
+type ResultStatus string
+
+const (
+	StatusPlanned ResultStatus = "planned" // on a dry run
+	StatusApplied ResultStatus = "applied"
+	StatusFailed  ResultStatus = "failed"
+	StatusSkipped ResultStatus = "skipped" // the group of a membership operation wasn't created
+)
+
+type Result struct {
+	Operation *Operation
+	Status    ResultStatus
+	Code      int   // the status code of the last call, 0 when the operation isn't applied
+	Err       error // the error of a failed operation, or the reason of a skipped operation
+
+	// Unresolved are the members not added or removed because their user wasn't created.
+	Unresolved []string
+}
+
+// Report is the result of every operation of a plan, a failed operation doesn't stop the operations after it.
+type Report struct {
+	DryRun  bool
+	Plan    *Plan
+	Results []*Result
+}
+
+// Count returns the number of results with the status.
+func (r *Report) Count(status ResultStatus) int {
+
+	count := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Failed returns true when an operation failed or was skipped.
+func (r *Report) Failed() bool {
+	return r.Count(StatusFailed) != 0 || r.Count(StatusSkipped) != 0
+}
+
+// String returns a line per operation and a summary.
+func (r *Report) String() string {
+
+	var builder strings.Builder
+	for _, result := range r.Results {
+
+		builder.WriteString(fmt.Sprintf("%-8v %v", result.Status, result.Operation))
+		if result.Err != nil {
+			builder.WriteString(": " + result.Err.Error())
+		}
+
+		if len(result.Unresolved) != 0 {
+			builder.WriteString(" unresolved: " + strings.Join(result.Unresolved, ", "))
+		}
+
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString(fmt.Sprintf("%v operations: %v applied, %v failed, %v skipped\n", len(r.Results),
+		r.Count(StatusApplied), r.Count(StatusFailed), r.Count(StatusSkipped)))
+
+	return builder.String()
+}
+
+// Sync loads the source, plans the operations and applies them in batches, unless it's a dry run. The error is
+// returned when the plan can't be computed or the context is done, the errors of the operations are on the report.
+func (s *Syncer) Sync(ctx context.Context) (*Report, error) {
+
+	plan, current, err := s.plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{DryRun: s.options.DryRun, Plan: plan}
+
+	if s.options.DryRun {
+
+		for _, operation := range plan.Operations {
+			report.Results = append(report.Results, &Result{Operation: operation, Status: StatusPlanned})
+		}
+
+		return report, nil
+	}
+
+	// The ids of the users created by lower-cased user name, and of the groups by lower-cased name, the groups
+	// created are added.
+	userIDs := map[string]string{}
+
+	groupIDs := map[string]string{}
+	for name, group := range current.groups {
+		groupIDs[name] = group.ID
+	}
+
+	for index, operation := range plan.Operations {
+
+		if index != 0 && index%s.options.BatchSize == 0 && s.options.BatchInterval > 0 {
+			if err := s.sleep(ctx, s.options.BatchInterval); err != nil {
+				return report, err
+			}
+		}
+
+		result := s.apply(ctx, operation, userIDs, groupIDs)
+		report.Results = append(report.Results, result)
+
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+	}
+
+	return report, nil
+}
+
+func (s *Syncer) apply(ctx context.Context, operation *Operation, userIDs, groupIDs map[string]string) *Result {
+
+	result := &Result{Operation: operation}
+	directoryID := s.options.DirectoryID
+
+	var call func() (*admin.ResponseScheme, error)
+
+	switch operation.Type {
+
+	case OperationCreateGroup:
+		call = func() (*admin.ResponseScheme, error) {
+
+			group, response, err := s.client.SCIM.Group.Create(ctx, directoryID, operation.Target)
+			if err == nil {
+				groupIDs[strings.ToLower(operation.Target)] = group.ID
+			}
+
+			return response, err
+		}
+
+	case OperationCreateUser:
+		call = func() (*admin.ResponseScheme, error) {
+
+			user, response, err := s.client.SCIM.User.Create(ctx, directoryID, newUserPayload(operation.user), nil, nil)
+			if err == nil {
+				userIDs[strings.ToLower(operation.Target)] = user.ID
+			}
+
+			return response, err
+		}
+
+	case OperationUpdateUser:
+		call = func() (*admin.ResponseScheme, error) {
+
+			payload := &model.SCIMUserToPathScheme{Schemas: []string{patchOpSchema}, Operations: operation.patches}
+			_, response, err := s.client.SCIM.User.Path(ctx, directoryID, operation.ID, payload, nil, nil)
+			return response, err
+		}
+
+	case OperationDeactivateUser:
+		call = func() (*admin.ResponseScheme, error) {
+			return s.client.SCIM.User.Deactivate(ctx, directoryID, operation.ID)
+		}
+
+	case OperationAddMembers, OperationRemoveMembers:
+
+		groupID, ok := groupIDs[strings.ToLower(operation.Target)]
+		if !ok {
+			result.Status, result.Err = StatusSkipped, fmt.Errorf("group %v not created", operation.Target)
+			return result
+		}
+
+		var values []*model.SCIMGroupOperationValueScheme
+		for _, name := range operation.Changes {
+
+			// The members on the directory are identified by their id on the plan, even when they're renamed.
+			id, ok := operation.memberIDs[strings.ToLower(name)]
+			if !ok {
+				id, ok = userIDs[strings.ToLower(name)]
+			}
+
+			if !ok {
+				result.Unresolved = append(result.Unresolved, name)
+				continue
+			}
+
+			values = append(values, &model.SCIMGroupOperationValueScheme{Value: id, Display: name})
+		}
+
+		if len(values) == 0 {
+			result.Status, result.Err = StatusSkipped, fmt.Errorf("no member resolved")
+			return result
+		}
+
+		op := "add"
+		if operation.Type == OperationRemoveMembers {
+			op = "remove"
+		}
+
+		call = func() (*admin.ResponseScheme, error) {
+
+			payload := &model.SCIMGroupPathScheme{
+				Schemas:    []string{patchOpSchema},
+				Operations: []*model.SCIMGroupOperationScheme{{Op: op, Path: "members", Value: values}},
+			}
+
+			_, response, err := s.client.SCIM.Group.Path(ctx, directoryID, groupID, payload)
+			return response, err
+		}
+	}
+
+	response, err := s.retry(ctx, call)
+	if response != nil {
+		result.Code = response.Code
+	}
+
+	result.Status, result.Err = StatusApplied, err
+	if err != nil {
+		result.Status = StatusFailed
+	}
+
+	return result
+}
+
+// retry calls until the call isn't rate limited, or the retries are exhausted.
+func (s *Syncer) retry(ctx context.Context, call func() (*admin.ResponseScheme, error)) (*admin.ResponseScheme, error) {
+
+	wait := time.Second
+
+	for attempt := 0; ; attempt++ {
+
+		response, err := call()
+		if err == nil || response == nil || response.Code != http.StatusTooManyRequests || attempt == s.options.MaxRetries {
+			return response, err
+		}
+
+		delay := wait
+		if values := response.Headers["Retry-After"]; len(values) != 0 {
+			if seconds, parseErr := strconv.Atoi(values[0]); parseErr == nil {
+				delay = time.Duration(seconds) * time.Second
+			}
+		}
+
+		if err := s.sleep(ctx, delay); err != nil {
+			return response, err
+		}
+
+		wait *= 2
+	}
+}
+
+func newUserPayload(user *User) *model.SCIMUserScheme {
+
+	payload := &model.SCIMUserScheme{
+		UserName:    user.UserName,
+		ExternalID:  user.externalID(),
+		Emails:      []*model.SCIMUserEmailScheme{{Value: user.email(), Type: "work", Primary: true}},
+		DisplayName: user.DisplayName,
+		Title:       user.Title,
+		Active:      true,
+	}
+
+	if user.GivenName != "" || user.FamilyName != "" {
+		payload.Name = &model.SCIMUserNameScheme{GivenName: user.GivenName, FamilyName: user.FamilyName}
+	}
+
+	if user.Department != "" {
+		payload.EnterpriseInfo = &model.SCIMEnterpriseUserInfoScheme{Department: user.Department}
+	}
+
+	return payload
+}
@@ -0,0 +1,419 @@
+package scimsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/admin"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeUser struct {
+	ID          string `json:"id"`
+	ExternalID  string `json:"externalId"`
+	UserName    string `json:"userName"`
+	DisplayName string `json:"displayName,omitempty"`
+	Active      bool   `json:"active"`
+	Emails      []struct {
+		Value   string `json:"value"`
+		Primary bool   `json:"primary"`
+	} `json:"emails"`
+}
+
+type fakeGroup struct {
+	ID          string   `json:"id"`
+	DisplayName string   `json:"displayName"`
+	Members     []string `json:"-"`
+}
+
+// fakeDirectory serves the SCIM endpoints used by the syncer, recording the changes requested.
+type fakeDirectory struct {
+	mu          sync.Mutex
+	users       []*fakeUser
+	groups      []*fakeGroup
+	requests    []string
+	rateLimited int // the number of changes answered with a 429 before being accepted
+	nextID      int
+}
+
+func (f *fakeDirectory) user(id string) *fakeUser {
+
+	for _, user := range f.users {
+		if user.ID == id {
+			return user
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeDirectory) group(id string) *fakeGroup {
+
+	for _, group := range f.groups {
+		if group.ID == id {
+			return group
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeDirectory) groupResource(group *fakeGroup) map[string]interface{} {
+
+	members := []interface{}{}
+	for _, member := range group.Members {
+		members = append(members, map[string]interface{}{"value": member})
+	}
+
+	return map[string]interface{}{"id": group.ID, "displayName": group.DisplayName, "members": members}
+}
+
+func page(r *http.Request, resources []interface{}) map[string]interface{} {
+
+	startIndex, _ := strconv.Atoi(r.URL.Query().Get("startIndex"))
+	count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+
+	from, to := startIndex-1, startIndex-1+count
+	if to > len(resources) {
+		to = len(resources)
+	}
+
+	if from > to {
+		from = to
+	}
+
+	return map[string]interface{}{"totalResults": len(resources), "startIndex": startIndex, "Resources": resources[from:to]}
+}
+
+func (f *fakeDirectory) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/scim/directory/dir/"), "/")
+	body, _ := ioutil.ReadAll(r.Body)
+
+	if r.Method != http.MethodGet {
+
+		if f.rateLimited > 0 {
+			f.rateLimited--
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+	}
+
+	var response interface{}
+
+	switch {
+
+	case r.Method == http.MethodGet && parts[0] == "Users":
+
+		resources := []interface{}{}
+		for _, user := range f.users {
+			resources = append(resources, user)
+		}
+
+		response = page(r, resources)
+
+	case r.Method == http.MethodGet && parts[0] == "Groups":
+
+		resources := []interface{}{}
+		for _, group := range f.groups {
+			resources = append(resources, f.groupResource(group))
+		}
+
+		response = page(r, resources)
+
+	case r.Method == http.MethodPost && parts[0] == "Users":
+
+		user := &fakeUser{}
+		_ = json.Unmarshal(body, user)
+
+		f.nextID++
+		user.ID = fmt.Sprintf("u%v", f.nextID)
+		f.users = append(f.users, user)
+		response = user
+
+	case r.Method == http.MethodPost && parts[0] == "Groups":
+
+		group := &fakeGroup{}
+		_ = json.Unmarshal(body, group)
+
+		f.nextID++
+		group.ID = fmt.Sprintf("g%v", f.nextID)
+		f.groups = append(f.groups, group)
+		response = f.groupResource(group)
+
+	case r.Method == http.MethodPatch && parts[0] == "Users":
+		response = f.user(parts[1])
+
+	case r.Method == http.MethodDelete && parts[0] == "Users":
+		f.user(parts[1]).Active = false
+		w.WriteHeader(http.StatusNoContent)
+		return
+
+	case r.Method == http.MethodPatch && parts[0] == "Groups":
+
+		var payload struct {
+			Operations []struct {
+				Op    string `json:"op"`
+				Value []struct {
+					Value string `json:"value"`
+				} `json:"value"`
+			} `json:"Operations"`
+		}
+
+		_ = json.Unmarshal(body, &payload)
+
+		group := f.group(parts[1])
+		for _, operation := range payload.Operations {
+			for _, value := range operation.Value {
+
+				if operation.Op == "add" {
+					group.Members = append(group.Members, value.Value)
+					continue
+				}
+
+				for index, member := range group.Members {
+					if member == value.Value {
+						group.Members = append(group.Members[:index], group.Members[index+1:]...)
+						break
+					}
+				}
+			}
+		}
+
+		response = f.groupResource(group)
+
+	default:
+		http.Error(w, "unexpected request "+r.URL.String(), http.StatusNotImplemented)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func newFakeDirectory() *fakeDirectory {
+
+	user := func(id, userName, displayName string, active bool) *fakeUser {
+
+		user := &fakeUser{ID: id, ExternalID: userName, UserName: userName, DisplayName: displayName, Active: active}
+		user.Emails = append(user.Emails, struct {
+			Value   string `json:"value"`
+			Primary bool   `json:"primary"`
+		}{userName, true})
+
+		return user
+	}
+
+	return &fakeDirectory{
+		nextID: 100,
+		users: []*fakeUser{
+			user("1", "alice@example.com", "Alice", true),
+			user("2", "bob@example.com", "Bob", true),
+			user("3", "carol@example.com", "Carol", true),
+			user("4", "dave@example.com", "Dave", false),
+		},
+		groups: []*fakeGroup{
+			{ID: "10", DisplayName: "engineering", Members: []string{"1", "3"}},
+			{ID: "11", DisplayName: "contractors", Members: []string{"3"}},
+		},
+	}
+}
+
+const usersCSV = `userName,displayName,department,active,groups,ignored
+alice@example.com,Alice Smith,Research,,engineering;design,x
+bob@example.com,Bob,,true,engineering,
+carol@example.com,Carol,,false,,
+erin@example.com,Erin,,,design,
+`
+
+func startSyncer(t *testing.T, fake *fakeDirectory, options *Options) (*Syncer, func()) {
+
+	server := httptest.NewServer(fake)
+
+	client, err := admin.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.Site, err = url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	syncer, err := NewSyncer(client, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return syncer, server.Close
+}
+
+func TestSyncer_Sync(t *testing.T) {
+
+	fake := newFakeDirectory()
+	options := &Options{DirectoryID: "dir", Source: NewCSVSource(strings.NewReader(usersCSV)), DryRun: true, PageSize: 2,
+		BatchSize: 3, BatchInterval: time.Minute}
+
+	syncer, closeServer := startSyncer(t, fake, options)
+	defer closeServer()
+
+	var slept []time.Duration
+	syncer.sleep = func(ctx context.Context, duration time.Duration) error {
+		slept = append(slept, duration)
+		return nil
+	}
+
+	report, err := syncer.Sync(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, fake.requests)
+	assert.Equal(t, 7, report.Count(StatusPlanned))
+	assert.Equal(t, "create-group    design\n"+
+		"create-user     erin@example.com\n"+
+		"update-user     alice@example.com (1): displayName, department\n"+
+		"add-members     design: alice@example.com, erin@example.com\n"+
+		"add-members     engineering (10): bob@example.com\n"+
+		"remove-members  engineering (10): carol@example.com\n"+
+		"deactivate-user carol@example.com (3)\n", report.Plan.String())
+
+	// The source was read by the dry run, the plan is applied from a JSON source.
+	state, err := NewCSVSource(strings.NewReader(usersCSV)).Load(context.Background())
+	assert.NoError(t, err)
+
+	document, err := json.Marshal(state)
+	assert.NoError(t, err)
+
+	options.Source, options.DryRun = NewJSONSource(strings.NewReader(string(document))), false
+	fake.rateLimited = 1
+
+	report, err = syncer.Sync(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, report.Failed(), report.String())
+	assert.Equal(t, 7, report.Count(StatusApplied))
+	assert.Equal(t, []time.Duration{7 * time.Second, time.Minute, time.Minute}, slept)
+
+	assert.Equal(t, []string{
+		`POST /scim/directory/dir/Groups {"displayName":"design"}`,
+		`POST /scim/directory/dir/Users {"id":"","externalId":"erin@example.com","userName":"erin@example.com",` +
+			`"emails":[{"value":"erin@example.com","type":"work","primary":true}],"displayName":"Erin","active":true}`,
+		`PATCH /scim/directory/dir/Users/1 {"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"operations":[` +
+			`{"op":"replace","path":"displayName","value":"Alice Smith"},` +
+			`{"op":"replace","path":"urn:ietf:params:scim:schemas:extension:enterprise:2.1:User:department","value":"Research"}]}`,
+		`PATCH /scim/directory/dir/Groups/g101 {"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[` +
+			`{"op":"add","path":"members","value":[{"value":"1","display":"alice@example.com"},{"value":"u102","display":"erin@example.com"}]}]}`,
+		`PATCH /scim/directory/dir/Groups/10 {"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[` +
+			`{"op":"add","path":"members","value":[{"value":"2","display":"bob@example.com"}]}]}`,
+		`PATCH /scim/directory/dir/Groups/10 {"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[` +
+			`{"op":"remove","path":"members","value":[{"value":"3","display":"carol@example.com"}]}]}`,
+		`DELETE /scim/directory/dir/Users/3 `,
+	}, fake.requests)
+
+	assert.Equal(t, []string{"1", "2"}, fake.group("10").Members)
+	assert.False(t, fake.user("3").Active)
+}
+
+func TestSyncer_Sync_RenamedUser(t *testing.T) {
+
+	fake := newFakeDirectory()
+
+	// alice is matched by its external id, she's a member of engineering already, and is added to contractors.
+	source := &State{
+		Users: []*User{
+			{UserName: "alice.smith@example.com", ExternalID: "alice@example.com", Email: "alice@example.com", DisplayName: "Alice"},
+			{UserName: "carol@example.com", DisplayName: "Carol"},
+		},
+		Groups: []*Group{
+			{Name: "engineering", Members: []string{"alice.smith@example.com", "carol@example.com"}},
+			{Name: "contractors", Members: []string{"alice.smith@example.com", "carol@example.com"}},
+		},
+	}
+
+	syncer, closeServer := startSyncer(t, fake, &Options{DirectoryID: "dir", Source: source})
+	defer closeServer()
+
+	report, err := syncer.Sync(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, report.Failed(), report.String())
+	assert.Equal(t, "update-user     alice.smith@example.com (1): userName\n"+
+		"add-members     contractors (11): alice.smith@example.com\n", report.Plan.String())
+
+	assert.Equal(t, []string{
+		`PATCH /scim/directory/dir/Users/1 {"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"operations":[` +
+			`{"op":"replace","path":"userName","value":"alice.smith@example.com"}]}`,
+		`PATCH /scim/directory/dir/Groups/11 {"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[` +
+			`{"op":"add","path":"members","value":[{"value":"1","display":"alice.smith@example.com"}]}]}`,
+	}, fake.requests)
+
+	assert.Equal(t, []string{"1", "3"}, fake.group("10").Members)
+	assert.Equal(t, []string{"3", "1"}, fake.group("11").Members)
+}
+
+func TestSyncer_Plan(t *testing.T) {
+
+	fake := newFakeDirectory()
+	active := true
+
+	source := &State{
+		Users: []*User{
+			{UserName: "Alice@example.com", DisplayName: "Alice"},
+			{UserName: "dave@example.com", Active: &active},
+		},
+		Groups: []*Group{{Name: "Contractors", Members: []string{"dave@example.com", "carol@example.com"}}},
+	}
+
+	options := &Options{DirectoryID: "dir", Source: source, DeactivateMissing: true}
+	syncer, closeServer := startSyncer(t, fake, options)
+	defer closeServer()
+
+	plan, err := syncer.Plan(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "update-user     dave@example.com (4): active\n"+
+		"add-members     Contractors (11): dave@example.com\n"+
+		"deactivate-user bob@example.com (2)\n"+
+		"deactivate-user carol@example.com (3)\n", plan.String())
+
+	source.Groups[0].Members = append(source.Groups[0].Members, "mallory@example.com")
+	_, err = syncer.Plan(context.Background())
+	assert.EqualError(t, err, "scimsync: group member not on the source or the directory: mallory@example.com (Contractors)")
+
+	source.Users = append(source.Users, &User{UserName: "ALICE@example.com"})
+	_, err = syncer.Plan(context.Background())
+	assert.EqualError(t, err, "scimsync: duplicate user name: ALICE@example.com")
+}
+
+func TestNewSyncer(t *testing.T) {
+
+	_, err := NewSyncer(nil, &Options{Source: &State{}})
+	assert.Equal(t, ErrNoDirectoryID, err)
+
+	_, err = NewSyncer(nil, &Options{DirectoryID: "dir"})
+	assert.Equal(t, ErrNoSource, err)
+}
+
+func TestNewCSVSource(t *testing.T) {
+
+	_, err := NewCSVSource(strings.NewReader("email\nalice@example.com\n")).Load(context.Background())
+	assert.Equal(t, ErrNoUserNameColumn, err)
+
+	_, err = NewCSVSource(strings.NewReader("userName,active\nalice@example.com,maybe\n")).Load(context.Background())
+	assert.EqualError(t, err, `scimsync: line 2: invalid active value "maybe"`)
+
+	state, err := NewCSVSource(strings.NewReader(usersCSV)).Load(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, state.Users, 4)
+	assert.False(t, state.Users[2].active())
+	assert.Equal(t, &Group{Name: "design", Members: []string{"alice@example.com", "erin@example.com"}}, state.Groups[1])
+}
@@ -1,5 +1,7 @@
 package confluence
 
+import "github.com/chrisccoy/go-atlassian/service/common"
+
 type AuthenticationService struct {
 	client *Client
 
@@ -8,6 +10,8 @@ type AuthenticationService struct {
 
 	userAgentProvided bool
 	agent             string
+
+	signer common.RequestSigner
 }
 
 func (a *AuthenticationService) SetBasicAuth(mail, token string) {
@@ -23,3 +27,8 @@ func (a *AuthenticationService) SetUserAgent(agent string) {
 	a.agent = agent
 	a.userAgentProvided = true
 }
+
+// SetRequestSigner signs each request with the signer, e.g. the Atlassian Connect JWT, once its URL is final.
+func (a *AuthenticationService) SetRequestSigner(signer common.RequestSigner) {
+	a.signer = signer
+}
@@ -18,12 +18,15 @@ type Client struct {
 	HTTP *http.Client
 	Site *url.URL
 
-	Auth     *AuthenticationService
-	Content  *ContentService
-	Space    *SpaceService
-	Label    *LabelService
-	Search   *SearchService
-	LongTask *LongTaskService
+	Auth       *AuthenticationService
+	Content    *ContentService
+	Space      *SpaceService
+	Label      *LabelService
+	Search     *SearchService
+	LongTask   *LongTaskService
+	Template   *TemplateService
+	InlineTask *InlineTaskService
+	V2         *V2Service
 }
 
 func New(httpClient *http.Client, site string) (client *Client, err error) {
@@ -72,6 +75,9 @@ func New(httpClient *http.Client, site string) (client *Client, err error) {
 	client.Label = &LabelService{client: client}
 	client.Search = &SearchService{client: client}
 	client.LongTask = &LongTaskService{client: client}
+	client.Template = &TemplateService{client: client}
+	client.InlineTask = &InlineTaskService{client: client}
+	client.V2 = newV2Service(client)
 	return
 }
 
@@ -97,6 +103,12 @@ func (c *Client) newRequest(ctx context.Context, method, apiEndpoint string, pay
 		request.Header.Set("User-Agent", c.Auth.agent)
 	}
 
+	if c.Auth.signer != nil {
+		if err = c.Auth.signer.Sign(request); err != nil {
+			return nil, err
+		}
+	}
+
 	return
 }
 
@@ -129,6 +141,7 @@ func transformTheHTTPResponse(response *http.Response, structure interface{}) (r
 	responseTransformed.Code = response.StatusCode
 	responseTransformed.Endpoint = response.Request.URL.String()
 	responseTransformed.Method = response.Request.Method
+	responseTransformed.Headers = response.Header
 
 	var wasSuccess = response.StatusCode >= 200 && response.StatusCode < 300
 	if !wasSuccess {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/attachment"
 	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
 	"io"
 	"mime/multipart"
@@ -192,6 +193,121 @@ func (c *ContentAttachmentService) Create(ctx context.Context, attachmentID, sta
 	return
 }
 
+// Stream returns the contents of an attachment as a stream, the body isn't buffered on memory.
+// The Range header is sent when the options offset is set, the size and the SHA-256 checksum
+// are verified when the stream is fully read. The caller must close the stream.
+// Docs: https://docs.go-atlassian.io/confluence-cloud/content/attachments#download-attachment
+func (c *ContentAttachmentService) Stream(ctx context.Context, contentID, attachmentID string, opts *model.AttachmentDownloadOptionsScheme) (
+	stream io.ReadCloser, response *ResponseScheme, err error) {
+
+	if len(contentID) == 0 {
+		return nil, nil, model.ErrNoContentIDError
+	}
+
+	if len(attachmentID) == 0 {
+		return nil, nil, notAttachmentIDError
+	}
+
+	var endpoint = fmt.Sprintf("/rest/api/content/%v/child/attachment/%v/download", contentID, attachmentID)
+
+	request, err := c.client.newRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment.SetRange(request, opts)
+
+	httpResponse, err := c.client.HTTP.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode >= 300 {
+		defer httpResponse.Body.Close()
+		response, err = transformTheHTTPResponse(httpResponse, nil)
+		return nil, response, err
+	}
+
+	stream, err = attachment.NewStream(httpResponse, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response = &ResponseScheme{
+		Code:     httpResponse.StatusCode,
+		Endpoint: request.URL.String(),
+		Method:   request.Method,
+		Headers:  httpResponse.Header,
+	}
+
+	return
+}
+
+// DownloadTo copies the contents of an attachment into the writer, returning the bytes written.
+// Docs: https://docs.go-atlassian.io/confluence-cloud/content/attachments#download-attachment
+func (c *ContentAttachmentService) DownloadTo(ctx context.Context, contentID, attachmentID string, writer io.Writer,
+	opts *model.AttachmentDownloadOptionsScheme) (written int64, response *ResponseScheme, err error) {
+
+	if writer == nil {
+		return 0, nil, model.ErrNoWriterError
+	}
+
+	stream, response, err := c.Stream(ctx, contentID, attachmentID, opts)
+	if err != nil {
+		return 0, response, err
+	}
+
+	defer stream.Close()
+
+	written, err = io.Copy(writer, stream)
+	return
+}
+
+// Upload adds or updates one or more attachments of a piece of content on a single multipart request,
+// the files are streamed from their readers. The attachments already existing are updated with a new version.
+// Docs: https://docs.go-atlassian.io/confluence-cloud/content/attachments#create-or-update-attachment
+func (c *ContentAttachmentService) Upload(ctx context.Context, contentID, status string, files []*model.AttachmentFileScheme,
+	progress model.AttachmentProgressFunc) (result *model.ContentPageScheme, response *ResponseScheme, err error) {
+
+	if len(contentID) == 0 {
+		return nil, nil, model.ErrNoContentIDError
+	}
+
+	query := url.Values{}
+	if len(status) != 0 {
+		query.Add("status", status)
+	}
+
+	var endpoint strings.Builder
+	endpoint.WriteString(fmt.Sprintf("/rest/api/content/%v/child/attachment", contentID))
+
+	if query.Encode() != "" {
+		endpoint.WriteString(fmt.Sprintf("?%v", query.Encode()))
+	}
+
+	body, contentType, err := attachment.NewMultipart(files, map[string]string{"minorEdit": "true"}, progress)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer body.Close()
+
+	request, err := c.client.newRequest(ctx, http.MethodPut, endpoint.String(), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request.Header.Add("Content-Type", contentType)
+	request.Header.Set("X-Atlassian-Token", "no-check")
+
+	response, err = c.client.Call(request, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
 var (
 	notAttachmentIDError = fmt.Errorf("error, the attachment ID is required, please provide a valid value")
 	notFileNameError     = fmt.Errorf("error, the fileName is required, please provide a valid value")
@@ -1,16 +1,23 @@
 package confluence
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
 	"github.com/stretchr/testify/assert"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestContentAttachmentService_Gets(t *testing.T) {
@@ -662,3 +669,282 @@ func TestContentAttachmentService_Create(t *testing.T) {
 	}
 
 }
+
+func TestContentAttachmentService_DownloadTo(t *testing.T) {
+
+	content, err := ioutil.ReadFile("./mocks/mock.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checksum := sha256.Sum256(content)
+
+	testCases := []struct {
+		name                    string
+		contentID, attachmentID string
+		opts                    *model.AttachmentDownloadOptionsScheme
+		mockFile                string
+		wantHTTPMethod          string
+		endpoint                string
+		context                 context.Context
+		wantHTTPCodeReturn      int
+		wantErr                 bool
+	}{
+		{
+			name:         "DownloadAttachmentWhenTheParametersAreCorrect",
+			contentID:    "5949392",
+			attachmentID: "att5949393",
+			opts: &model.AttachmentDownloadOptionsScheme{
+				Size:   int64(len(content)),
+				SHA256: hex.EncodeToString(checksum[:]),
+			},
+			mockFile:           "./mocks/mock.png",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/rest/api/content/5949392/child/attachment/att5949393/download",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            false,
+		},
+
+		{
+			name:               "DownloadAttachmentWhenTheChecksumIsIncorrect",
+			contentID:          "5949392",
+			attachmentID:       "att5949393",
+			opts:               &model.AttachmentDownloadOptionsScheme{SHA256: "0000"},
+			mockFile:           "./mocks/mock.png",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/rest/api/content/5949392/child/attachment/att5949393/download",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "DownloadAttachmentWhenTheAttachmentIDIsNotProvided",
+			contentID:          "5949392",
+			attachmentID:       "",
+			mockFile:           "./mocks/mock.png",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/rest/api/content/5949392/child/attachment/att5949393/download",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "DownloadAttachmentWhenTheStatusCodeIsIncorrect",
+			contentID:          "5949392",
+			attachmentID:       "att5949393",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/rest/api/content/5949392/child/attachment/att5949393/download",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNotFound,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &ContentAttachmentService{client: mockClient}
+
+			var buffer bytes.Buffer
+			written, gotResponse, err := service.DownloadTo(testCase.context, testCase.contentID, testCase.attachmentID, &buffer, testCase.opts)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.Error(t, err)
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.Equal(t, int64(len(content)), written)
+				assert.Equal(t, content, buffer.Bytes())
+			}
+
+		})
+
+	}
+
+}
+
+func TestContentAttachmentService_Stream(t *testing.T) {
+
+	content := []byte("hello world")
+	checksum := sha256.Sum256(content)
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "hello.txt", time.Time{}, bytes.NewReader(content))
+	}))
+
+	defer mockServer.Close()
+
+	mockClient, err := startMockClient(mockServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := &ContentAttachmentService{client: mockClient}
+
+	var progress []int64
+	stream, gotResponse, err := service.Stream(context.Background(), "5949392", "att5949393", &model.AttachmentDownloadOptionsScheme{
+		Offset:   6,
+		Partial:  bytes.NewReader(content[:6]),
+		SHA256:   hex.EncodeToString(checksum[:]),
+		Progress: func(transferred, total int64) { progress = append(progress, transferred, total) },
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, gotResponse.Code)
+
+	defer stream.Close()
+
+	remaining, err := ioutil.ReadAll(stream)
+	assert.NoError(t, err)
+	assert.Equal(t, "world", string(remaining))
+	assert.Equal(t, []int64{11, 11}, progress)
+}
+
+func TestContentAttachmentService_Upload(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		contentID, status  string
+		files              []*model.AttachmentFileScheme
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+	}{
+		{
+			name:      "UploadAttachmentsWhenTheParametersAreCorrect",
+			contentID: "5949392",
+			status:    "current",
+			files: []*model.AttachmentFileScheme{
+				{Name: "first.txt", Reader: strings.NewReader("first")},
+				{Name: "second.txt", Reader: strings.NewReader("second")},
+			},
+			mockFile:           "./mocks/get-content-attachments.json",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/rest/api/content/5949392/child/attachment?status=current",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            false,
+		},
+
+		{
+			name:               "UploadAttachmentsWhenTheFilesAreNotProvided",
+			contentID:          "5949392",
+			mockFile:           "./mocks/get-content-attachments.json",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/rest/api/content/5949392/child/attachment",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "UploadAttachmentsWhenTheContentIDIsNotProvided",
+			contentID:          "",
+			files:              []*model.AttachmentFileScheme{{Name: "first.txt", Reader: strings.NewReader("first")}},
+			mockFile:           "./mocks/get-content-attachments.json",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/rest/api/content/5949392/child/attachment",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+		},
+
+		{
+			name:               "UploadAttachmentsWhenTheStatusCodeIsIncorrect",
+			contentID:          "5949392",
+			files:              []*model.AttachmentFileScheme{{Name: "first.txt", Reader: strings.NewReader("first")}},
+			mockFile:           "./mocks/get-content-attachments.json",
+			wantHTTPMethod:     http.MethodPut,
+			endpoint:           "/rest/api/content/5949392/child/attachment",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &ContentAttachmentService{client: mockClient}
+
+			gotResult, gotResponse, err := service.Upload(testCase.context, testCase.contentID, testCase.status, testCase.files, nil)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.Error(t, err)
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+			}
+
+		})
+
+	}
+
+}
@@ -0,0 +1,284 @@
+// Package cql builds Confluence Query Language queries, e.g. the cql of SearchService.Content and ContentService.Search.
+//
+// The values are quoted and escaped, so the titles, labels and ancestors are safe to use on the queries:
+//
+//	query := cql.Where(
+//		cql.Space.Equals("DEV"),
+//		cql.Type.Equals("page"),
+//		cql.Or(cql.Label.Equals("runbook"), cql.Label.Equals("on-call")),
+//		cql.LastModified.After(cql.Now("-4w")),
+//	).OrderBy(cql.LastModified, cql.Descending)
+//
+//	// space = "DEV" AND type = "page" AND (label = "runbook" OR label = "on-call") AND lastmodified > now("-4w") ORDER BY lastmodified DESC
+//	query.String()
+package cql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field is a CQL field, the fields not declared can be used as Field("name").
+type Field string
+
+const (
+	ID           Field = "id"
+	Space        Field = "space"
+	SpaceType    Field = "space.type"
+	Type         Field = "type"
+	Label        Field = "label"
+	Ancestor     Field = "ancestor"
+	Parent       Field = "parent"
+	Content      Field = "content"
+	Creator      Field = "creator"
+	Contributor  Field = "contributor"
+	Mention      Field = "mention"
+	Watcher      Field = "watcher"
+	Favourite    Field = "favourite"
+	Created      Field = "created"
+	LastModified Field = "lastmodified"
+	Title        Field = "title"
+	Text         Field = "text"
+)
+
+// Clause is a condition of a query, or a group of conditions.
+type Clause interface {
+	// CQL returns the clause as CQL, the groups are parenthesized.
+	CQL() string
+}
+
+// Raw is a clause written as CQL, it's added to the query as it is.
+type Raw string
+
+func (r Raw) CQL() string { return string(r) }
+
+// Function is a CQL function, e.g. currentUser() or now("-4w"), the function values aren't quoted.
+type Function struct {
+	Name      string
+	Arguments []string
+}
+
+func (f Function) String() string {
+
+	arguments := make([]string, len(f.Arguments))
+	for index, argument := range f.Arguments {
+		arguments[index] = Quote(argument)
+	}
+
+	return f.Name + "(" + strings.Join(arguments, ", ") + ")"
+}
+
+func function(name string, offset []string) Function {
+	return Function{Name: name, Arguments: offset}
+}
+
+// CurrentUser is the user running the query.
+func CurrentUser() Function { return Function{Name: "currentUser"} }
+
+// CurrentSpace is the space of the content the query runs on, e.g. on a macro.
+func CurrentSpace() Function { return Function{Name: "currentSpace"} }
+
+// CurrentContent is the content the query runs on, e.g. on a macro.
+func CurrentContent() Function { return Function{Name: "currentContent"} }
+
+// Now, and the start and end date functions, take an optional offset, e.g. "-4w", "+1d" or "-2h".
+func Now(offset ...string) Function          { return function("now", offset) }
+func StartOfDay(offset ...string) Function   { return function("startOfDay", offset) }
+func StartOfWeek(offset ...string) Function  { return function("startOfWeek", offset) }
+func StartOfMonth(offset ...string) Function { return function("startOfMonth", offset) }
+func StartOfYear(offset ...string) Function  { return function("startOfYear", offset) }
+func EndOfDay(offset ...string) Function     { return function("endOfDay", offset) }
+func EndOfWeek(offset ...string) Function    { return function("endOfWeek", offset) }
+func EndOfMonth(offset ...string) Function   { return function("endOfMonth", offset) }
+func EndOfYear(offset ...string) Function    { return function("endOfYear", offset) }
+
+// Value returns a value as CQL. The strings are quoted, the integers are kept, the times are formatted as
+// "2006-01-02", or as "2006/01/02 15:04" when they have a time of the day, and the functions aren't quoted.
+func Value(value interface{}) string {
+
+	switch typed := value.(type) {
+	case Function:
+		return typed.String()
+	case string:
+		return Quote(typed)
+	case int:
+		return strconv.Itoa(typed)
+	case int64:
+		return strconv.FormatInt(typed, 10)
+	case time.Time:
+		if typed.Hour() == 0 && typed.Minute() == 0 {
+			return Quote(typed.Format("2006-01-02"))
+		}
+		return Quote(typed.Format("2006/01/02 15:04"))
+	default:
+		return Quote(fmt.Sprint(value))
+	}
+}
+
+var quoteReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// Quote returns a string as a double-quoted CQL string, escaping the quotes and the backslashes.
+func Quote(value string) string {
+	return `"` + quoteReplacer.Replace(value) + `"`
+}
+
+type condition struct {
+	field    Field
+	operator string
+	value    string
+}
+
+func (c *condition) CQL() string {
+	return string(c.field) + " " + c.operator + " " + c.value
+}
+
+func (f Field) compare(operator string, value interface{}) Clause {
+	return &condition{field: f, operator: operator, value: Value(value)}
+}
+
+func (f Field) list(operator string, values []interface{}) Clause {
+
+	items := make([]string, len(values))
+	for index, value := range values {
+		items[index] = Value(value)
+	}
+
+	return &condition{field: f, operator: operator, value: "(" + strings.Join(items, ", ") + ")"}
+}
+
+// Equals, and the other conditions, take a string, an integer, a time.Time or a Function value, see Value.
+func (f Field) Equals(value interface{}) Clause      { return f.compare("=", value) }
+func (f Field) NotEquals(value interface{}) Clause   { return f.compare("!=", value) }
+func (f Field) Contains(value interface{}) Clause    { return f.compare("~", value) }
+func (f Field) NotContains(value interface{}) Clause { return f.compare("!~", value) }
+func (f Field) After(value interface{}) Clause       { return f.compare(">", value) }
+func (f Field) AfterOrOn(value interface{}) Clause   { return f.compare(">=", value) }
+func (f Field) Before(value interface{}) Clause      { return f.compare("<", value) }
+func (f Field) BeforeOrOn(value interface{}) Clause  { return f.compare("<=", value) }
+func (f Field) In(values ...interface{}) Clause      { return f.list("IN", values) }
+func (f Field) NotIn(values ...interface{}) Clause   { return f.list("NOT IN", values) }
+func (f Field) InStrings(values ...string) Clause    { return f.list("IN", toInterfaces(values)) }
+func (f Field) NotInStrings(values ...string) Clause { return f.list("NOT IN", toInterfaces(values)) }
+
+func toInterfaces(values []string) []interface{} {
+
+	items := make([]interface{}, len(values))
+	for index, value := range values {
+		items[index] = value
+	}
+
+	return items
+}
+
+type group struct {
+	operator string
+	clauses  []Clause
+}
+
+func (g *group) CQL() string {
+
+	var parts []string
+	for _, clause := range g.clauses {
+
+		text := clause.CQL()
+		if nested, ok := clause.(*group); ok && len(nested.clauses) > 1 {
+			text = "(" + text + ")"
+		}
+
+		parts = append(parts, text)
+	}
+
+	return strings.Join(parts, " "+g.operator+" ")
+}
+
+// nonEmpty returns the clauses not nil and not empty, e.g. an empty And.
+func (g *group) nonEmpty() []Clause {
+
+	var clauses []Clause
+	for _, clause := range g.clauses {
+		if clause != nil && clause.CQL() != "" {
+			clauses = append(clauses, clause)
+		}
+	}
+
+	return clauses
+}
+
+func newGroup(operator string, clauses []Clause) *group {
+
+	g := &group{operator: operator, clauses: clauses}
+	g.clauses = g.nonEmpty()
+	return g
+}
+
+// And matches when every clause matches, the nil clauses are ignored.
+func And(clauses ...Clause) Clause { return newGroup("AND", clauses) }
+
+// Or matches when any clause matches, the nil clauses are ignored.
+func Or(clauses ...Clause) Clause { return newGroup("OR", clauses) }
+
+type not struct{ clause Clause }
+
+func (n *not) CQL() string {
+
+	text := n.clause.CQL()
+	if text == "" {
+		return ""
+	}
+
+	return "NOT (" + text + ")"
+}
+
+// Not matches when the clause doesn't match. It's nil when the clause is nil, and empty when the clause is empty,
+// so the groups ignore it.
+func Not(clause Clause) Clause {
+
+	if clause == nil {
+		return nil
+	}
+
+	return &not{clause: clause}
+}
+
+type Direction string
+
+const (
+	Ascending  Direction = "ASC"
+	Descending Direction = "DESC"
+)
+
+// Query is a CQL query, the clauses joined by AND and its ordering.
+type Query struct {
+	clauses []Clause
+	order   []string
+}
+
+// Where returns a query matching every clause, the nil clauses are ignored.
+func Where(clauses ...Clause) *Query {
+	return &Query{clauses: clauses}
+}
+
+// And adds the clauses to the query.
+func (q *Query) And(clauses ...Clause) *Query {
+	q.clauses = append(q.clauses, clauses...)
+	return q
+}
+
+// OrderBy adds an ordering field, the fields are applied in the order they're added.
+func (q *Query) OrderBy(field Field, direction Direction) *Query {
+	q.order = append(q.order, string(field)+" "+string(direction))
+	return q
+}
+
+// String returns the query as CQL.
+func (q *Query) String() string {
+
+	query := newGroup("AND", q.clauses).CQL()
+	if len(q.order) != 0 {
+		query = strings.TrimSpace(query + " ORDER BY " + strings.Join(q.order, ", "))
+	}
+
+	return query
+}
@@ -0,0 +1,86 @@
+package cql
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestQuery_String(t *testing.T) {
+
+	testCases := []struct {
+		name  string
+		query *Query
+		want  string
+	}{
+		{
+			name: "when the query has conditions, groups and ordering",
+			query: Where(
+				Space.Equals("DEV"),
+				Type.Equals("page"),
+				Or(Label.Equals("runbook"), Label.Equals("on-call")),
+				LastModified.After(Now("-4w")),
+			).OrderBy(LastModified, Descending).OrderBy(Title, Ascending),
+			want: `space = "DEV" AND type = "page" AND (label = "runbook" OR label = "on-call") AND lastmodified > now("-4w") ` +
+				`ORDER BY lastmodified DESC, title ASC`,
+		},
+
+		{
+			name:  "when the values have quotes and backslashes",
+			query: Where(Title.Contains(`Release "2.0" notes \ draft`), Ancestor.Equals(123456)),
+			want:  `title ~ "Release \"2.0\" notes \\ draft" AND ancestor = 123456`,
+		},
+
+		{
+			name: "when the query has lists, functions and negations",
+			query: Where(
+				Label.InStrings("a", "b c"),
+				Space.NotIn("ARCHIVE", CurrentSpace()),
+				Creator.Equals(CurrentUser()),
+				Not(Or(Type.Equals("blogpost"), Type.Equals("comment"))),
+			),
+			want: `label IN ("a", "b c") AND space NOT IN ("ARCHIVE", currentSpace()) AND creator = currentUser() AND ` +
+				`NOT (type = "blogpost" OR type = "comment")`,
+		},
+
+		{
+			name: "when the query has dates",
+			query: Where(
+				Created.AfterOrOn(time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)),
+				Created.Before(time.Date(2021, 6, 1, 13, 30, 0, 0, time.UTC)),
+				LastModified.BeforeOrOn(EndOfMonth()),
+			),
+			want: `created >= "2021-05-01" AND created < "2021/06/01 13:30" AND lastmodified <= endOfMonth()`,
+		},
+
+		{
+			name:  "when the groups are nested or empty",
+			query: Where(nil, And(), Or(Space.Equals("A"), And(Type.Equals("page"), Label.NotEquals("x"))), Or(ID.Equals(1))),
+			want:  `(space = "A" OR (type = "page" AND label != "x")) AND id = 1`,
+		},
+
+		{
+			name:  "when the negated clause is nil",
+			query: Where(Space.Equals("DEV"), Not(nil)),
+			want:  `space = "DEV"`,
+		},
+
+		{
+			name:  "when the negated clause is empty",
+			query: Where(Not(And()), Space.Equals("DEV"), Not(Or(nil))),
+			want:  `space = "DEV"`,
+		},
+
+		{
+			name:  "when the query is extended with raw clauses",
+			query: Where(Space.Equals("DEV")).And(Raw(`macro = "jira"`)),
+			want:  `space = "DEV" AND macro = "jira"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, testCase.query.String())
+		})
+	}
+}
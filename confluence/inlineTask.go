@@ -0,0 +1,187 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type InlineTaskService struct{ client *Client }
+
+// Search returns the inline tasks matching the options, e.g. the incomplete tasks of a space assigned to a user.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v1/api-group-inline-tasks/#api-wiki-rest-api-inlinetasks-search-get
+func (i *InlineTaskService) Search(ctx context.Context, options *model.InlineTaskSearchOptionsScheme, startAt, maxResults int) (
+	result *model.InlineTaskPageScheme, response *ResponseScheme, err error) {
+
+	query := url.Values{}
+	query.Add("start", strconv.Itoa(startAt))
+	query.Add("limit", strconv.Itoa(maxResults))
+
+	if options != nil {
+
+		if options.Status != "" && !isValidInlineTaskStatus(options.Status) {
+			return nil, nil, model.ErrInvalidInlineTaskStatusError
+		}
+
+		values := map[string]string{
+			"spaceKey":               options.SpaceKey,
+			"pageId":                 options.PageID,
+			"assignee":               options.Assignee,
+			"creator":                options.Creator,
+			"completedUserAccountId": options.Completer,
+			"status":                 options.Status,
+		}
+
+		dates := map[string]time.Time{
+			"duedateFrom":      options.DueDateFrom,
+			"duedateTo":        options.DueDateTo,
+			"createdateFrom":   options.CreateDateFrom,
+			"createdateTo":     options.CreateDateTo,
+			"completedateFrom": options.CompleteDateFrom,
+			"completedateTo":   options.CompleteDateTo,
+		}
+
+		for key, value := range values {
+			if value != "" {
+				query.Add(key, value)
+			}
+		}
+
+		for key, value := range dates {
+			if !value.IsZero() {
+				query.Add(key, strconv.FormatInt(epochMilliseconds(value), 10))
+			}
+		}
+	}
+
+	var endpoint = fmt.Sprintf("/rest/api/inlinetasks/search?%v", query.Encode())
+
+	request, err := i.client.newRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err = i.client.Call(request, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Get returns an inline task by its global id.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v1/api-group-inline-tasks/#api-wiki-rest-api-inlinetasks-inlinetaskid-get
+func (i *InlineTaskService) Get(ctx context.Context, globalID string) (result *model.InlineTaskScheme, response *ResponseScheme,
+	err error) {
+
+	if len(globalID) == 0 {
+		return nil, nil, model.ErrNoInlineTaskIDError
+	}
+
+	var endpoint = fmt.Sprintf("/rest/api/inlinetasks/%v", globalID)
+
+	request, err := i.client.newRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err = i.client.Call(request, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Update marks an inline task complete or incomplete.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v1/api-group-inline-tasks/#api-wiki-rest-api-inlinetasks-inlinetaskid-put
+func (i *InlineTaskService) Update(ctx context.Context, globalID, status string) (result *model.InlineTaskScheme,
+	response *ResponseScheme, err error) {
+
+	if len(globalID) == 0 {
+		return nil, nil, model.ErrNoInlineTaskIDError
+	}
+
+	if !isValidInlineTaskStatus(status) {
+		return nil, nil, model.ErrInvalidInlineTaskStatusError
+	}
+
+	payloadAsReader, err := transformStructToReader(&model.InlineTaskUpdatePayloadScheme{Status: status})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var endpoint = fmt.Sprintf("/rest/api/inlinetasks/%v", globalID)
+
+	request, err := i.client.newRequest(ctx, http.MethodPut, endpoint, payloadAsReader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err = i.client.Call(request, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Complete marks an inline task complete.
+func (i *InlineTaskService) Complete(ctx context.Context, globalID string) (*model.InlineTaskScheme, *ResponseScheme, error) {
+	return i.Update(ctx, globalID, model.InlineTaskStatusComplete)
+}
+
+// Reopen marks an inline task incomplete.
+func (i *InlineTaskService) Reopen(ctx context.Context, globalID string) (*model.InlineTaskScheme, *ResponseScheme, error) {
+	return i.Update(ctx, globalID, model.InlineTaskStatusIncomplete)
+}
+
+// Report searches every incomplete task matching the options, e.g. of a space, and summarizes them by assignee.
+// The tasks overdue are the tasks due before now.
+func (i *InlineTaskService) Report(ctx context.Context, options *model.InlineTaskSearchOptionsScheme, now time.Time) (
+	*InlineTaskReport, error) {
+
+	search := model.InlineTaskSearchOptionsScheme{}
+	if options != nil {
+		search = *options
+	}
+
+	search.Status = model.InlineTaskStatusIncomplete
+
+	const maxResults = 100
+	var tasks []*model.InlineTaskScheme
+
+	for startAt := 0; ; startAt += maxResults {
+
+		page, _, err := i.Search(ctx, &search, startAt, maxResults)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, page.Results...)
+		if len(page.Results) < maxResults {
+			break
+		}
+	}
+
+	return NewInlineTaskReport(tasks, now), nil
+}
+
+func isValidInlineTaskStatus(status string) bool {
+	return status == model.InlineTaskStatusComplete || status == model.InlineTaskStatusIncomplete
+}
+
+func epochMilliseconds(value time.Time) int64 {
+	return value.UnixNano() / int64(time.Millisecond)
+}
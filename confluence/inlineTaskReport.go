@@ -0,0 +1,152 @@
+package confluence
+
+import (
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/confluence/storage"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+// InlineTaskReport summarizes the open inline tasks by assignee, e.g. to post it on Slack or on a Jira issue.
+type InlineTaskReport struct {
+	Generated  time.Time
+	Total      int
+	Overdue    int
+	Unassigned int
+
+	// Assignees are sorted by the number of tasks, the unassigned tasks are last.
+	Assignees []*InlineTaskAssigneeReport
+}
+
+type InlineTaskAssigneeReport struct {
+	AccountID string // empty on the unassigned tasks
+	Overdue   int
+
+	// Tasks are sorted by due date, the tasks without due date are last.
+	Tasks []*model.InlineTaskScheme
+}
+
+// NewInlineTaskReport summarizes the incomplete tasks, the complete tasks are ignored.
+func NewInlineTaskReport(tasks []*model.InlineTaskScheme, now time.Time) *InlineTaskReport {
+
+	report := &InlineTaskReport{Generated: now}
+	byAssignee := map[string]*InlineTaskAssigneeReport{}
+
+	for _, task := range tasks {
+
+		if task.Status == model.InlineTaskStatusComplete {
+			continue
+		}
+
+		assignee, ok := byAssignee[task.Assignee]
+		if !ok {
+			assignee = &InlineTaskAssigneeReport{AccountID: task.Assignee}
+			byAssignee[task.Assignee] = assignee
+			report.Assignees = append(report.Assignees, assignee)
+		}
+
+		assignee.Tasks = append(assignee.Tasks, task)
+		report.Total++
+
+		if isOverdue(task, now) {
+			assignee.Overdue++
+			report.Overdue++
+		}
+
+		if task.Assignee == "" {
+			report.Unassigned++
+		}
+	}
+
+	for _, assignee := range report.Assignees {
+
+		tasks := assignee.Tasks
+		sort.SliceStable(tasks, func(i, j int) bool {
+			if (tasks[i].DueDate == 0) != (tasks[j].DueDate == 0) {
+				return tasks[j].DueDate == 0
+			}
+			return tasks[i].DueDate < tasks[j].DueDate
+		})
+	}
+
+	assignees := report.Assignees
+	sort.SliceStable(assignees, func(i, j int) bool {
+		if (assignees[i].AccountID == "") != (assignees[j].AccountID == "") {
+			return assignees[j].AccountID == ""
+		}
+		if len(assignees[i].Tasks) != len(assignees[j].Tasks) {
+			return len(assignees[i].Tasks) > len(assignees[j].Tasks)
+		}
+		return assignees[i].AccountID < assignees[j].AccountID
+	})
+
+	return report
+}
+
+// String returns the report as plain text, a line per task under its assignee.
+func (r *InlineTaskReport) String() string {
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%v open tasks, %v overdue, %v unassigned\n", r.Total, r.Overdue, r.Unassigned))
+
+	for _, assignee := range r.Assignees {
+
+		name := assignee.AccountID
+		if name == "" {
+			name = "Unassigned"
+		}
+
+		builder.WriteString(fmt.Sprintf("\n%v: %v tasks, %v overdue\n", name, len(assignee.Tasks), assignee.Overdue))
+
+		for _, task := range assignee.Tasks {
+
+			builder.WriteString("- ")
+
+			if task.DueDate != 0 {
+
+				due := fromEpochMilliseconds(task.DueDate).UTC().Format("2006-01-02")
+				if isOverdue(task, r.Generated) {
+					due += ", overdue"
+				}
+
+				builder.WriteString("[due " + due + "] ")
+			}
+
+			builder.WriteString(InlineTaskText(task))
+
+			if task.Title != "" {
+				builder.WriteString(" (" + task.Title + ")")
+			}
+
+			builder.WriteString("\n")
+		}
+	}
+
+	return builder.String()
+}
+
+// InlineTaskText returns the text of a task, its description or the text of its storage format body.
+func InlineTaskText(task *model.InlineTaskScheme) string {
+
+	text := task.Description
+	if text == "" && task.Body != "" {
+
+		if body, err := storage.Parse(task.Body); err == nil {
+			text = body.TextContent()
+		} else {
+			text = task.Body
+		}
+	}
+
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func isOverdue(task *model.InlineTaskScheme, now time.Time) bool {
+	return task.DueDate != 0 && task.Status != model.InlineTaskStatusComplete && fromEpochMilliseconds(task.DueDate).Before(now)
+}
+
+func fromEpochMilliseconds(value int64) time.Time {
+	return time.Unix(0, value*int64(time.Millisecond))
+}
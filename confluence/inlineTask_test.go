@@ -0,0 +1,270 @@
+package confluence
+
+import (
+	"context"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestInlineTaskService_Search(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		options            *model.InlineTaskSearchOptionsScheme
+		mockFile           string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+		expectedError      string
+	}{
+		{
+			name: "when the parameters are correct",
+			options: &model.InlineTaskSearchOptionsScheme{
+				SpaceKey:    "DUMMY",
+				Assignee:    "5b10ac8d82e05b22cc7d4ef5",
+				Status:      model.InlineTaskStatusIncomplete,
+				DueDateFrom: time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC),
+			},
+			mockFile: "./mocks/search-inline-tasks.json",
+			endpoint: "/rest/api/inlinetasks/search?assignee=5b10ac8d82e05b22cc7d4ef5&duedateFrom=1619827200000&limit=100" +
+				"&spaceKey=DUMMY&start=0&status=incomplete",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+		},
+
+		{
+			name:               "when the options are not provided",
+			mockFile:           "./mocks/search-inline-tasks.json",
+			endpoint:           "/rest/api/inlinetasks/search?limit=100&start=0",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+		},
+
+		{
+			name:               "when the status is not valid",
+			options:            &model.InlineTaskSearchOptionsScheme{Status: "done"},
+			mockFile:           "./mocks/search-inline-tasks.json",
+			endpoint:           "/rest/api/inlinetasks/search?limit=100&start=0&status=done",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+			expectedError:      "confluence: invalid inline task status: (complete, incomplete)",
+		},
+
+		{
+			name:               "when the context is not provided",
+			mockFile:           "./mocks/search-inline-tasks.json",
+			endpoint:           "/rest/api/inlinetasks/search?limit=100&start=0",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+			expectedError:      "request creation failed: net/http: nil Context",
+		},
+
+		{
+			name:               "when the response status is not correct",
+			mockFile:           "./mocks/search-inline-tasks.json",
+			endpoint:           "/rest/api/inlinetasks/search?limit=100&start=0",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+			expectedError:      "request failed. Please analyze the request body for more details. Status Code: 400",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			mockServer, err := startMockServer(&mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     http.MethodGet,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &InlineTaskService{client: mockClient}
+
+			gotResult, gotResponse, err := service.Search(testCase.context, testCase.options, 0, 100)
+
+			if testCase.wantErr {
+				assert.EqualError(t, err, testCase.expectedError)
+			} else {
+
+				assert.NoError(t, err)
+				assert.Equal(t, 2, gotResult.Size)
+				assert.Equal(t, int64(1001), gotResult.Results[0].GlobalID)
+				assert.Equal(t, mockServer.URL+testCase.endpoint, gotResponse.Endpoint)
+			}
+		})
+	}
+}
+
+func TestInlineTaskService_Update(t *testing.T) {
+
+	testCases := []struct {
+		name          string
+		globalID      string
+		status        string
+		wantErr       bool
+		expectedError string
+	}{
+		{
+			name:     "when the parameters are correct",
+			globalID: "1001",
+			status:   model.InlineTaskStatusComplete,
+		},
+
+		{
+			name:          "when the task id is not provided",
+			status:        model.InlineTaskStatusComplete,
+			wantErr:       true,
+			expectedError: "confluence: no inline task id set",
+		},
+
+		{
+			name:          "when the status is not valid",
+			globalID:      "1001",
+			status:        "done",
+			wantErr:       true,
+			expectedError: "confluence: invalid inline task status: (complete, incomplete)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			mockServer, err := startMockServer(&mockServerOptions{
+				Endpoint:           "/rest/api/inlinetasks/1001",
+				MockFilePath:       "./mocks/get-inline-task.json",
+				MethodAccepted:     http.MethodPut,
+				ResponseCodeWanted: http.StatusOK,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &InlineTaskService{client: mockClient}
+
+			gotResult, _, err := service.Update(context.Background(), testCase.globalID, testCase.status)
+
+			if testCase.wantErr {
+				assert.EqualError(t, err, testCase.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, model.InlineTaskStatusComplete, gotResult.Status)
+			}
+		})
+	}
+}
+
+func TestInlineTaskService_Get(t *testing.T) {
+
+	mockServer, err := startMockServer(&mockServerOptions{
+		Endpoint:           "/rest/api/inlinetasks/1001",
+		MockFilePath:       "./mocks/get-inline-task.json",
+		MethodAccepted:     http.MethodGet,
+		ResponseCodeWanted: http.StatusOK,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer mockServer.Close()
+
+	mockClient, err := startMockClient(mockServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := &InlineTaskService{client: mockClient}
+
+	gotResult, _, err := service.Get(context.Background(), "1001")
+	assert.NoError(t, err)
+	assert.Equal(t, "5b10ac8d82e05b22cc7d4ef5", gotResult.CompleteUser)
+
+	_, _, err = service.Get(context.Background(), "")
+	assert.EqualError(t, err, "confluence: no inline task id set")
+}
+
+func TestInlineTaskService_Report(t *testing.T) {
+
+	mockServer, err := startMockServer(&mockServerOptions{
+		Endpoint:           "/rest/api/inlinetasks/search?limit=100&spaceKey=DUMMY&start=0&status=incomplete",
+		MockFilePath:       "./mocks/search-inline-tasks.json",
+		MethodAccepted:     http.MethodGet,
+		ResponseCodeWanted: http.StatusOK,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer mockServer.Close()
+
+	mockClient, err := startMockClient(mockServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := &InlineTaskService{client: mockClient}
+
+	report, err := service.Report(context.Background(), &model.InlineTaskSearchOptionsScheme{SpaceKey: "DUMMY"},
+		time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, 1, report.Overdue)
+	assert.Equal(t, 1, report.Unassigned)
+	assert.Equal(t, "2 open tasks, 1 overdue, 1 unassigned\n\n"+
+		"5b10ac8d82e05b22cc7d4ef5: 1 tasks, 1 overdue\n"+
+		"- [due 2021-05-08, overdue] Send the notes (Weekly meeting)\n\n"+
+		"Unassigned: 1 tasks, 0 overdue\n"+
+		"- Book the room (Weekly meeting)\n", report.String())
+}
+
+func TestNewInlineTaskReport(t *testing.T) {
+
+	now := time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC)
+	day := int64(24 * time.Hour / time.Millisecond)
+
+	tasks := []*model.InlineTaskScheme{
+		{GlobalID: 1, Assignee: "a", Description: "later", DueDate: epochMilliseconds(now) + day},
+		{GlobalID: 2, Assignee: "b", Description: "no due date"},
+		{GlobalID: 3, Assignee: "a", Description: "no due date"},
+		{GlobalID: 4, Assignee: "a", Description: "sooner", DueDate: epochMilliseconds(now) - day},
+		{GlobalID: 5, Assignee: "b", Description: "done", Status: model.InlineTaskStatusComplete},
+	}
+
+	report := NewInlineTaskReport(tasks, now)
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 1, report.Overdue)
+	assert.Equal(t, "a", report.Assignees[0].AccountID)
+
+	var order []int64
+	for _, task := range report.Assignees[0].Tasks {
+		order = append(order, task.GlobalID)
+	}
+
+	assert.Equal(t, []int64{4, 1, 3}, order)
+	assert.Len(t, report.Assignees[1].Tasks, 1)
+}
@@ -2,11 +2,14 @@ package confluence
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type LongTaskService struct{ client *Client }
@@ -60,3 +63,58 @@ func (l *LongTaskService) Get(ctx context.Context, taskID string) (result *model
 
 	return
 }
+
+// Wait polls a long-running task until it's finished, e.g. the task returned by Space.Delete or CopyHierarchy.
+// The task is returned when it's successful, a *models.TaskError wrapping models.ErrTaskFailedError is returned
+// when it's finished without success. The options result is decoded from the finished task, e.g. a struct reading
+// its additionalDetails. The Confluence long tasks can't be cancelled, so CancelOnContextDone is ignored.
+// Docs: https://docs.go-atlassian.io/confluence-cloud/long-task#get-long-running-task
+func (l *LongTaskService) Wait(ctx context.Context, taskID string, opts *models.TaskWaitOptionsScheme) (result *models.LongTaskScheme,
+	response *ResponseScheme, err error) {
+
+	if len(taskID) == 0 {
+		return nil, nil, models.ErrNoTaskIDError
+	}
+
+	for attempt := 0; ; attempt++ {
+
+		result, response, err = l.Get(ctx, taskID)
+		if err != nil {
+			return nil, response, err
+		}
+
+		if opts != nil && opts.Progress != nil {
+			opts.Progress(result.PercentageComplete, result.Status)
+		}
+
+		if result.Finished {
+
+			if !result.Successful {
+
+				var messages []string
+				for _, message := range result.Errors {
+					messages = append(messages, message.Translation)
+				}
+
+				return result, response, &models.TaskError{ID: taskID, Status: models.TaskStatusFailed, Message: strings.Join(messages, "; ")}
+			}
+
+			if opts != nil && opts.Result != nil {
+				if err = json.Unmarshal(response.Bytes.Bytes(), opts.Result); err != nil {
+					return result, response, err
+				}
+			}
+
+			return result, response, nil
+		}
+
+		timer := time.NewTimer(opts.Delay(attempt))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, response, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -3,10 +3,13 @@ package confluence
 import (
 	"context"
 	"fmt"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
 	"github.com/stretchr/testify/assert"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 )
 
 func TestLongTaskService_Gets(t *testing.T) {
@@ -240,3 +243,101 @@ func TestLongTaskService_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestLongTaskService_Wait(t *testing.T) {
+
+	testCases := []struct {
+		name          string
+		taskID        string
+		polls         []string
+		wantProgress  []int
+		wantResult    string
+		wantErr       bool
+		expectedError string
+	}{
+		{
+			name:   "when the task is successful",
+			taskID: "1100001",
+			polls: []string{
+				`{"id":"1100001","percentageComplete":10,"finished":false}`,
+				`{"id":"1100001","percentageComplete":60,"finished":false}`,
+				`{"id":"1100001","percentageComplete":100,"finished":true,"successful":true,"additionalDetails":{"destinationId":"5949392"}}`,
+			},
+			wantProgress: []int{10, 60, 100},
+			wantResult:   "5949392",
+		},
+
+		{
+			name:   "when the task is finished without success",
+			taskID: "1100001",
+			polls: []string{
+				`{"id":"1100001","percentageComplete":100,"finished":true,"successful":false,"errors":[{"translation":"Space not found"}]}`,
+			},
+			wantErr:       true,
+			expectedError: "atlassian: the task failed: 1100001: Space not found",
+		},
+
+		{
+			name:          "when the task id is not provided",
+			taskID:        "",
+			wantErr:       true,
+			expectedError: "atlassian: no task id set",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			polls := testCase.polls
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+				if r.URL.Path != "/rest/api/longtask/1100001" || len(polls) == 0 {
+					http.Error(w, "unexpected request", http.StatusBadRequest)
+					return
+				}
+
+				w.Write([]byte(polls[0]))
+				polls = polls[1:]
+			}))
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			implementation := &LongTaskService{client: mockClient}
+
+			var progress []int
+			var details struct {
+				AdditionalDetails struct {
+					DestinationID string `json:"destinationId"`
+				} `json:"additionalDetails"`
+			}
+
+			gotResult, gotResponse, err := implementation.Wait(context.Background(), testCase.taskID, &models.TaskWaitOptionsScheme{
+				Interval: time.Millisecond,
+				Progress: func(percentage int, status string) { progress = append(progress, percentage) },
+				Result:   &details,
+			})
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.expectedError)
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.True(t, gotResult.Successful)
+				assert.Equal(t, testCase.wantProgress, progress)
+				assert.Equal(t, testCase.wantResult, details.AdditionalDetails.DestinationID)
+			}
+		})
+	}
+}
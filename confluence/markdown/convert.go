@@ -0,0 +1,519 @@
+// Package markdown converts Confluence pages to Markdown files and exports a space, or a page subtree,
+// to a local directory mirroring the page tree.
+package markdown
+
+import (
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/confluence/storage"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Converter converts storage format bodies to Markdown.
+//
+// The elements without a Markdown equivalent are converted to their text, the macros are converted to their
+// body, except the code macro (a fenced code block) and the panels (a quote starting with the panel type).
+type Converter struct {
+
+	// PageLink returns the target of a link to a page, e.g. the relative path of the exported page.
+	// The link text is kept without a link when it's not set or it returns an empty string.
+	PageLink func(spaceKey, title string) string
+
+	// AttachmentLink returns the target of a link or an image of an attachment of the page.
+	AttachmentLink func(fileName string) string
+}
+
+// Convert converts a storage format body to Markdown.
+func (c *Converter) Convert(body string) (string, error) {
+
+	document, err := storage.Parse(body)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(c.blocks(document.Children)) + "\n", nil
+}
+
+var blockElements = map[string]bool{
+	"p": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "ul": true, "ol": true,
+	"blockquote": true, "pre": true, "hr": true, "table": true, "div": true, "section": true,
+	"ac:layout": true, "ac:layout-section": true, "ac:layout-cell": true, "ac:rich-text-body": true,
+	"ac:task-list": true,
+}
+
+var panelMacros = map[string]string{"info": "Info", "note": "Note", "warning": "Warning", "tip": "Tip", "panel": "Panel"}
+
+func isBlock(node *storage.Node) bool {
+
+	if node.Type != storage.ElementNode {
+		return false
+	}
+
+	if node.Name == "ac:structured-macro" {
+		name := node.Attr("ac:name")
+		return name == "code" || name == "toc" || panelMacros[name] != "" ||
+			len(node.ChildElements("ac:rich-text-body")) != 0
+	}
+
+	return blockElements[node.Name]
+}
+
+// blocks converts the nodes to blocks separated by a blank line, the inline nodes between blocks are a paragraph.
+func (c *Converter) blocks(nodes []*storage.Node) string {
+
+	var (
+		blocks []string
+		inline []*storage.Node
+	)
+
+	flush := func() {
+		if text := strings.TrimSpace(c.inline(inline)); text != "" {
+			blocks = append(blocks, text)
+		}
+		inline = nil
+	}
+
+	for _, node := range nodes {
+
+		if !isBlock(node) {
+			inline = append(inline, node)
+			continue
+		}
+
+		flush()
+		if block := strings.TrimRight(c.block(node), "\n "); strings.TrimSpace(block) != "" {
+			blocks = append(blocks, block)
+		}
+	}
+
+	flush()
+	return strings.Join(blocks, "\n\n")
+}
+
+func (c *Converter) block(node *storage.Node) string {
+
+	switch node.Name {
+
+	case "p":
+		return strings.TrimSpace(c.inline(node.Children))
+
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level, _ := strconv.Atoi(node.Name[1:])
+		return strings.Repeat("#", level) + " " + strings.TrimSpace(c.inline(node.Children))
+
+	case "ul", "ol":
+		return c.list(node)
+
+	case "ac:task-list":
+		return c.taskList(node)
+
+	case "blockquote":
+		return prefixLines(c.blocks(node.Children), "> ", "> ")
+
+	case "pre":
+		return fence("", node.TextContent())
+
+	case "hr":
+		return "---"
+
+	case "table":
+		return c.table(node)
+
+	case "ac:structured-macro":
+		return c.macro(node)
+	}
+
+	return c.blocks(node.Children)
+}
+
+func (c *Converter) list(node *storage.Node) string {
+
+	var items []string
+	for index, item := range node.ChildElements("li") {
+
+		marker := "- "
+		if node.Name == "ol" {
+			marker = strconv.Itoa(index+1) + ". "
+		}
+
+		items = append(items, prefixLines(c.blocks(item.Children), marker, strings.Repeat(" ", len(marker))))
+	}
+
+	return strings.Join(items, "\n")
+}
+
+func (c *Converter) taskList(node *storage.Node) string {
+
+	var items []string
+	for _, task := range node.ChildElements("ac:task") {
+
+		marker := "- [ ] "
+		for _, status := range task.ChildElements("ac:task-status") {
+			if strings.TrimSpace(status.TextContent()) == "complete" {
+				marker = "- [x] "
+			}
+		}
+
+		var body string
+		for _, taskBody := range task.ChildElements("ac:task-body") {
+			body = c.blocks(taskBody.Children)
+		}
+
+		items = append(items, prefixLines(body, marker, "      "))
+	}
+
+	return strings.Join(items, "\n")
+}
+
+func (c *Converter) table(node *storage.Node) string {
+
+	var rows [][]string
+	columns := 0
+
+	for _, row := range node.Rows() {
+
+		var cells []string
+		for _, cell := range row.ChildElements("") {
+			if cell.Name == "td" || cell.Name == "th" {
+				text := strings.TrimSpace(c.blocks(cell.Children))
+				text = strings.ReplaceAll(text, "|", `\|`)
+				text = strings.ReplaceAll(text, "\n\n", "<br>")
+				cells = append(cells, strings.ReplaceAll(text, "\n", "<br>"))
+			}
+		}
+
+		if len(cells) > columns {
+			columns = len(cells)
+		}
+
+		rows = append(rows, cells)
+	}
+
+	if len(rows) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	for index, row := range rows {
+
+		for len(row) < columns {
+			row = append(row, "")
+		}
+
+		builder.WriteString("| " + strings.Join(row, " | ") + " |\n")
+
+		// The first row is the header, GitHub flavored Markdown requires one.
+		if index == 0 {
+			builder.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+		}
+	}
+
+	return builder.String()
+}
+
+func (c *Converter) macro(node *storage.Node) string {
+
+	name := node.Attr("ac:name")
+
+	switch {
+
+	case name == "code":
+		var code string
+		for _, body := range node.ChildElements("ac:plain-text-body") {
+			code = body.TextContent()
+		}
+		return fence(node.MacroParameter("language"), code)
+
+	case name == "toc":
+		return ""
+
+	case panelMacros[name] != "":
+
+		heading := "**" + panelMacros[name]
+		if title := node.MacroParameter("title"); title != "" {
+			heading += ": " + escape(title)
+		}
+		heading += "**"
+
+		var body string
+		for _, richText := range node.ChildElements("ac:rich-text-body") {
+			body = c.blocks(richText.Children)
+		}
+
+		if body == "" {
+			return prefixLines(heading, "> ", "> ")
+		}
+
+		return prefixLines(heading+"\n\n"+body, "> ", "> ")
+	}
+
+	var blocks []string
+	for _, richText := range node.ChildElements("ac:rich-text-body") {
+		blocks = append(blocks, c.blocks(richText.Children))
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// inline converts the nodes to a single line, the line breaks are the only new lines.
+func (c *Converter) inline(nodes []*storage.Node) string {
+
+	var builder strings.Builder
+	for _, node := range nodes {
+
+		switch node.Type {
+
+		case storage.TextNode:
+			builder.WriteString(escape(collapseSpaces(node.Text)))
+
+		case storage.CDataNode:
+			builder.WriteString(escape(node.Text))
+
+		case storage.ElementNode:
+			builder.WriteString(c.inlineElement(node))
+		}
+	}
+
+	return builder.String()
+}
+
+func (c *Converter) inlineElement(node *storage.Node) string {
+
+	switch node.Name {
+
+	case "strong", "b":
+		return wrap("**", c.inline(node.Children))
+
+	case "em", "i":
+		return wrap("_", c.inline(node.Children))
+
+	case "s", "del":
+		return wrap("~~", c.inline(node.Children))
+
+	case "code":
+		return codeSpan(node.TextContent())
+
+	case "br":
+		return "  \n"
+
+	case "a":
+		text := c.inline(node.Children)
+		if href := node.Attr("href"); href != "" {
+			return "[" + text + "](" + href + ")"
+		}
+		return text
+
+	case "ac:link":
+		return c.link(node)
+
+	case "ac:image":
+		return c.image(node)
+
+	case "ac:emoticon":
+		return node.Attr("ac:emoji-fallback")
+
+	case "ac:structured-macro":
+
+		switch node.Attr("ac:name") {
+		case "status":
+			return codeSpan(node.MacroParameter("title"))
+		case "jira":
+			return node.MacroParameter("key")
+		}
+
+		if isBlock(node) {
+			return c.block(node)
+		}
+
+		return ""
+
+	case "ac:parameter", "ac:plain-text-link-body", "ac:link-body":
+		return ""
+	}
+
+	return c.inline(node.Children)
+}
+
+func (c *Converter) link(node *storage.Node) string {
+
+	var text string
+	for _, body := range node.ChildElements("ac:plain-text-link-body") {
+		text = escape(body.TextContent())
+	}
+
+	for _, body := range node.ChildElements("ac:link-body") {
+		text = c.inline(body.Children)
+	}
+
+	if page := firstChild(node, "ri:page"); page != nil {
+
+		if text == "" {
+			text = escape(page.Attr("ri:content-title"))
+		}
+
+		if c.PageLink != nil {
+			if target := c.PageLink(page.Attr("ri:space-key"), page.Attr("ri:content-title")); target != "" {
+				return "[" + text + "](" + linkDestination(target) + ")"
+			}
+		}
+
+		return text
+	}
+
+	if attachment := firstChild(node, "ri:attachment"); attachment != nil {
+
+		fileName := attachment.Attr("ri:filename")
+		if text == "" {
+			text = escape(fileName)
+		}
+
+		if c.AttachmentLink != nil {
+			return "[" + text + "](" + linkDestination(c.AttachmentLink(fileName)) + ")"
+		}
+
+		return text
+	}
+
+	if user := firstChild(node, "ri:user"); user != nil && text == "" {
+		return "@" + user.Attr("ri:account-id")
+	}
+
+	return text
+}
+
+func (c *Converter) image(node *storage.Node) string {
+
+	alt := escape(node.Attr("ac:alt"))
+
+	if attachment := firstChild(node, "ri:attachment"); attachment != nil {
+
+		fileName := attachment.Attr("ri:filename")
+		target := fileName
+		if c.AttachmentLink != nil {
+			target = c.AttachmentLink(fileName)
+		}
+
+		return "![" + alt + "](" + linkDestination(target) + ")"
+	}
+
+	if location := firstChild(node, "ri:url"); location != nil {
+		return "![" + alt + "](" + linkDestination(location.Attr("ri:value")) + ")"
+	}
+
+	return ""
+}
+
+func firstChild(node *storage.Node, name string) *storage.Node {
+
+	children := node.ChildElements(name)
+	if len(children) == 0 {
+		return nil
+	}
+
+	return children[0]
+}
+
+// prefixLines prefixes the first line and the next lines of the text, the empty lines aren't indented.
+func prefixLines(text, first, next string) string {
+
+	lines := strings.Split(text, "\n")
+	for index, line := range lines {
+
+		prefix := next
+		if index == 0 {
+			prefix = first
+		}
+
+		if line == "" && index != 0 {
+			lines[index] = strings.TrimRight(prefix, " ")
+			continue
+		}
+
+		lines[index] = prefix + line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// fence returns a fenced code block, the fence is longer than the backtick runs of the code.
+func fence(language, code string) string {
+
+	marker := "```"
+	for strings.Contains(code, marker) {
+		marker += "`"
+	}
+
+	return fmt.Sprintf("%v%v\n%v\n%v", marker, language, strings.TrimRight(code, "\n"), marker)
+}
+
+func codeSpan(text string) string {
+
+	if text == "" {
+		return ""
+	}
+
+	marker := "`"
+	for strings.Contains(text, marker) {
+		marker += "`"
+	}
+
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		return marker + " " + text + " " + marker
+	}
+
+	return marker + text + marker
+}
+
+// wrap wraps the text with the emphasis marker, keeping the surrounding spaces outside the marker.
+func wrap(marker, text string) string {
+
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+
+	leading := text[:strings.Index(text, trimmed)]
+	trailing := text[len(leading)+len(trimmed):]
+
+	return leading + marker + trimmed + marker + trailing
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`)
+
+func escape(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+func collapseSpaces(text string) string {
+
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		if text == "" {
+			return ""
+		}
+		return " "
+	}
+
+	collapsed := strings.Join(fields, " ")
+	// The non-breaking spaces are spaces too, e.g. the &nbsp; inserted by the editor after an inline element.
+	if strings.TrimLeftFunc(text, unicode.IsSpace) != text {
+		collapsed = " " + collapsed
+	}
+
+	if strings.TrimRightFunc(text, unicode.IsSpace) != text {
+		collapsed += " "
+	}
+
+	return collapsed
+}
+
+// linkDestination wraps the destinations with spaces or parentheses, e.g. <Release notes.md>.
+func linkDestination(target string) string {
+
+	if strings.ContainsAny(target, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(target) + ">"
+	}
+
+	return target
+}
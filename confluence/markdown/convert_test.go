@@ -0,0 +1,134 @@
+package markdown
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConverter_Convert(t *testing.T) {
+
+	converter := &Converter{
+		PageLink: func(spaceKey, title string) string {
+			if title == "Release notes" {
+				return "../Release notes.md"
+			}
+			return ""
+		},
+		AttachmentLink: func(fileName string) string { return "Home/_attachments/" + fileName },
+	}
+
+	testCases := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "when the body has headings and inline elements",
+			body: "<h1>Title</h1><p>Some <strong>bold</strong>, <em>italic </em>and <code>code</code> text_with * chars.<br/>Next line</p>",
+			want: "# Title\n\nSome **bold**, _italic_ and `code` text\\_with \\* chars.  \nNext line\n",
+		},
+
+		{
+			name: "when the text has non-breaking spaces next to inline elements",
+			body: "<p>a&nbsp;b <strong>c</strong>&nbsp;d&nbsp;<em>e</em></p>",
+			want: "a b **c** d _e_\n",
+		},
+
+		{
+			name: "when the body has nested lists",
+			body: "<ul><li>One<ul><li>Nested</li></ul></li><li><p>Two</p></li></ul><ol><li>First</li><li>Second</li></ol>",
+			want: "- One\n\n  - Nested\n- Two\n\n1. First\n2. Second\n",
+		},
+
+		{
+			name: "when the body has a table",
+			body: "<table><tbody><tr><th>Key</th><th>Value</th></tr><tr><td>a|b</td><td><p>1</p><p>2</p></td></tr></tbody></table>",
+			want: "| Key | Value |\n| --- | --- |\n| a\\|b | 1<br>2 |\n",
+		},
+
+		{
+			name: "when the body has macros",
+			body: `<ac:structured-macro ac:name="code"><ac:parameter ac:name="language">go</ac:parameter>` +
+				`<ac:plain-text-body><![CDATA[fmt.Println("*")]]></ac:plain-text-body></ac:structured-macro>` +
+				`<ac:structured-macro ac:name="info"><ac:parameter ac:name="title">Heads up</ac:parameter>` +
+				`<ac:rich-text-body><p>Read it</p></ac:rich-text-body></ac:structured-macro>` +
+				`<p>Status <ac:structured-macro ac:name="status"><ac:parameter ac:name="title">DONE</ac:parameter></ac:structured-macro></p>`,
+			want: "```go\nfmt.Println(\"*\")\n```\n\n> **Info: Heads up**\n>\n> Read it\n\nStatus `DONE`\n",
+		},
+
+		{
+			name: "when the body has links and images",
+			body: `<p><ac:link><ri:page ri:content-title="Release notes"/></ac:link> and ` +
+				`<ac:link><ri:page ri:content-title="Unknown"/><ac:plain-text-link-body><![CDATA[other]]></ac:plain-text-link-body></ac:link> ` +
+				`<a href="https://example.com">site</a></p><p><ac:image ac:alt="diagram"><ri:attachment ri:filename="a b.png"/></ac:image></p>`,
+			want: "[Release notes](<../Release notes.md>) and other [site](https://example.com)\n\n![diagram](<Home/_attachments/a b.png>)\n",
+		},
+
+		{
+			name: "when the body has a task list",
+			body: `<ac:task-list><ac:task><ac:task-status>complete</ac:task-status><ac:task-body>Done</ac:task-body></ac:task>` +
+				`<ac:task><ac:task-status>incomplete</ac:task-status><ac:task-body>Todo</ac:task-body></ac:task></ac:task-list>`,
+			want: "- [x] Done\n- [ ] Todo\n",
+		},
+
+		{
+			name:    "when the body is not valid",
+			body:    "<p>unclosed",
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			got, err := converter.Convert(testCase.body)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestFrontMatter_Marshal(t *testing.T) {
+
+	frontMatter := &FrontMatter{ID: "10", Title: `Say "hi"`, Version: 2, Space: "DOCS", Labels: []string{"a", "b"}}
+
+	assert.Equal(t, "---\nid: \"10\"\ntitle: \"Say \\\"hi\\\"\"\nversion: 2\nspace: \"DOCS\"\nlabels:\n  - \"a\"\n  - \"b\"\n---\n",
+		string(frontMatter.Marshal()))
+}
+
+func TestParseFrontMatter(t *testing.T) {
+
+	frontMatter := &FrontMatter{ID: "10", Title: `Say "hi"`, Version: 2, Space: "DOCS", Labels: []string{"a", "b"}}
+
+	got, body, err := ParseFrontMatter(append(frontMatter.Marshal(), "\n# Body\n"...))
+	assert.NoError(t, err)
+	assert.Equal(t, frontMatter, got)
+	assert.Equal(t, "\n# Body\n", string(body))
+
+	got, body, err = ParseFrontMatter([]byte("---\r\ntitle: 'It''s' # the title\r\nlabels: [docs, \"how to\"]\r\nother: value\r\n---\r\nBody"))
+	assert.NoError(t, err)
+	assert.Equal(t, &FrontMatter{Title: "It's", Labels: []string{"docs", "how to"}}, got)
+	assert.Equal(t, "Body", string(body))
+
+	got, _, err = ParseFrontMatter([]byte("---\n\"title\": >-\n  A long\n  title\nparent: 123\nother: {a: 1, b: [2]}\n---\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, &FrontMatter{Title: "A long title", Parent: "123"}, got)
+
+	got, body, err = ParseFrontMatter([]byte("# No front matter\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, &FrontMatter{}, got)
+	assert.Equal(t, "# No front matter\n", string(body))
+
+	_, _, err = ParseFrontMatter([]byte("---\ntitle: \"unclosed\n"))
+	assert.True(t, errors.Is(err, ErrInvalidFrontMatter))
+
+	_, _, err = ParseFrontMatter([]byte("---\nversion: two\n---\n"))
+	assert.True(t, errors.Is(err, ErrInvalidFrontMatter))
+}
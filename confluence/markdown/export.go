@@ -0,0 +1,488 @@
+package markdown
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/confluence"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrNoExportRoot = errors.New("markdown: no space key or root page id set")
+	ErrNoDirectory  = errors.New("markdown: no directory set")
+)
+
+// AttachmentsDirectory is the directory, next to the children of a page, where its attachments are downloaded.
+const AttachmentsDirectory = "_attachments"
+
+type ExportOptions struct {
+
+	// SpaceKey exports every page of the space, RootPageID exports the page and its descendants instead.
+	SpaceKey   string
+	RootPageID string
+
+	// Directory is the export directory, a page is exported as <title>.md and its children on the <title> directory.
+	Directory string
+
+	// Attachments downloads the attachments of the pages on the _attachments directory of each page.
+	Attachments bool
+
+	// PageSize is the number of pages, or attachments, requested per call, 50 by default.
+	PageSize int
+}
+
+type ExportResult struct {
+	Written            []string // the ids of the pages written, new or changed since the last export
+	Skipped            []string // the ids of the pages not changed
+	Removed            []string // the ids of the pages no longer on the tree, their files are removed
+	Downloaded         int      // the attachments downloaded
+	RemovedAttachments int      // the attachments removed, deleted from their page or from a page removed
+}
+
+// Exporter writes a page tree to a directory of Markdown files with a YAML front matter.
+//
+// The export is incremental, the versions exported are recorded on the manifest of the directory, so only the
+// pages whose version changed, or were moved, are fetched and written again.
+type Exporter struct {
+	client  *confluence.Client
+	options *ExportOptions
+}
+
+func NewExporter(client *confluence.Client, options *ExportOptions) (*Exporter, error) {
+
+	if options == nil || (options.SpaceKey == "" && options.RootPageID == "") {
+		return nil, ErrNoExportRoot
+	}
+
+	if options.Directory == "" {
+		return nil, ErrNoDirectory
+	}
+
+	if options.PageSize <= 0 {
+		options.PageSize = 50
+	}
+
+	return &Exporter{client: client, options: options}, nil
+}
+
+var listExpand = []string{"version", "metadata.labels", "space"}
+
+type exportPage struct {
+	content *model.ContentScheme
+	parent  string
+	path    string // the slash separated path of the Markdown file, relative to the export directory
+}
+
+// directory is the slash separated directory of the children and the attachments of the page.
+func (p *exportPage) directory() string {
+	return strings.TrimSuffix(p.path, ".md")
+}
+
+// Export writes the pages changed since the last export, and removes the pages no longer on the tree.
+func (e *Exporter) Export(ctx context.Context) (*ExportResult, error) {
+
+	manifest, err := loadManifest(e.options.Directory)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := e.tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byTitle := make(map[string]*exportPage, len(pages))
+	paths := make(map[string]bool, len(pages))
+	for _, page := range pages {
+		// The links resolve to the first page of the tree when the titles are duplicated.
+		if _, ok := byTitle[page.content.Title]; !ok {
+			byTitle[page.content.Title] = page
+		}
+
+		paths[page.path] = true
+	}
+
+	result := &ExportResult{}
+	exported := make(map[string]bool, len(pages))
+
+	for _, page := range pages {
+
+		id := page.content.ID
+		exported[id] = true
+
+		entry, ok := manifest.Pages[id]
+		if !ok {
+			entry = &ManifestPage{}
+			manifest.Pages[id] = entry
+		}
+
+		changed := !ok || entry.Version != versionOf(page.content) || entry.Path != page.path ||
+			!fileExists(filepath.Join(e.options.Directory, filepath.FromSlash(page.path)))
+
+		if changed {
+
+			if err = e.writePage(ctx, page, byTitle); err != nil {
+				return nil, err
+			}
+
+			// The previous file isn't removed when another page, e.g. a page renamed, is exported on it.
+			if ok && entry.Path != page.path && !paths[entry.Path] {
+				e.remove(entry.Path)
+			}
+
+			entry.Title, entry.Version, entry.Path = page.content.Title, versionOf(page.content), page.path
+			result.Written = append(result.Written, id)
+
+		} else {
+			result.Skipped = append(result.Skipped, id)
+		}
+
+		if e.options.Attachments {
+
+			downloaded, removed, err := e.syncAttachments(ctx, page, entry)
+			if err != nil {
+				return nil, err
+			}
+
+			result.Downloaded += downloaded
+			result.RemovedAttachments += removed
+		}
+
+		// The manifest is saved after each page, so an interrupted export resumes where it stopped.
+		if err = manifest.save(e.options.Directory); err != nil {
+			return nil, err
+		}
+	}
+
+	for id, entry := range manifest.Pages {
+
+		if exported[id] {
+			continue
+		}
+
+		for _, attachment := range entry.Attachments {
+			e.remove(attachment.Path)
+			result.RemovedAttachments++
+		}
+
+		if !paths[entry.Path] {
+			e.remove(entry.Path)
+		}
+
+		delete(manifest.Pages, id)
+		result.Removed = append(result.Removed, id)
+	}
+
+	return result, manifest.save(e.options.Directory)
+}
+
+// tree returns the pages to export, the parents before their children.
+func (e *Exporter) tree(ctx context.Context) ([]*exportPage, error) {
+
+	var roots []*model.ContentScheme
+	parent := ""
+
+	if e.options.RootPageID != "" {
+
+		root, _, err := e.client.Content.Get(ctx, e.options.RootPageID, append([]string{"ancestors"}, listExpand...), 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if count := len(root.Ancestors); count != 0 {
+			parent = root.Ancestors[count-1].ID
+		}
+
+		roots = append(roots, root)
+
+	} else {
+
+		for start := 0; ; start += e.options.PageSize {
+
+			content, _, err := e.client.Space.Content(ctx, e.options.SpaceKey, "root", listExpand, start, e.options.PageSize)
+			if err != nil {
+				return nil, err
+			}
+
+			if content.Page == nil {
+				break
+			}
+
+			roots = append(roots, content.Page.Results...)
+			if len(content.Page.Results) < e.options.PageSize {
+				break
+			}
+		}
+	}
+
+	var pages []*exportPage
+	if err := e.walk(ctx, roots, parent, "", &pages); err != nil {
+		return nil, err
+	}
+
+	return pages, nil
+}
+
+func (e *Exporter) walk(ctx context.Context, siblings []*model.ContentScheme, parent, directory string, pages *[]*exportPage) error {
+
+	names := map[string]bool{strings.ToLower(AttachmentsDirectory): true}
+
+	for _, content := range siblings {
+
+		name := fileName(content.Title, content.ID)
+		if names[strings.ToLower(name)] {
+			name = fileName(content.Title+"-"+content.ID, content.ID)
+		}
+
+		names[strings.ToLower(name)] = true
+
+		page := &exportPage{content: content, parent: parent, path: path.Join(directory, name+".md")}
+		*pages = append(*pages, page)
+
+		var children []*model.ContentScheme
+		for start := 0; ; start += e.options.PageSize {
+
+			chunk, _, err := e.client.Content.ChildrenDescendant.ChildrenByType(ctx, content.ID, "page", 0, listExpand,
+				start, e.options.PageSize)
+			if err != nil {
+				return err
+			}
+
+			children = append(children, chunk.Results...)
+			if len(chunk.Results) < e.options.PageSize {
+				break
+			}
+		}
+
+		if err := e.walk(ctx, children, content.ID, page.directory(), pages); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Exporter) writePage(ctx context.Context, page *exportPage, byTitle map[string]*exportPage) error {
+
+	content, _, err := e.client.Content.Get(ctx, page.content.ID, []string{"body.storage", "version", "metadata.labels", "space"}, 0)
+	if err != nil {
+		return err
+	}
+
+	// The page could be updated after the tree was read, the version written is the version fetched.
+	page.content = content
+
+	spaceKey := ""
+	if content.Space != nil {
+		spaceKey = content.Space.Key
+	}
+
+	base := path.Dir(page.path)
+	converter := &Converter{
+		PageLink: func(linkSpaceKey, title string) string {
+
+			target, ok := byTitle[title]
+			if !ok || (linkSpaceKey != "" && linkSpaceKey != spaceKey) {
+				return ""
+			}
+
+			return relativePath(base, target.path)
+		},
+		AttachmentLink: func(fileName string) string {
+			return relativePath(base, path.Join(page.directory(), AttachmentsDirectory, fileName))
+		},
+	}
+
+	body := ""
+	if content.Body != nil && content.Body.Storage != nil {
+		body = content.Body.Storage.Value
+	}
+
+	markdown, err := converter.Convert(body)
+	if err != nil {
+		return fmt.Errorf("page %v: %w", content.ID, err)
+	}
+
+	frontMatter := &FrontMatter{
+		ID:      content.ID,
+		Title:   content.Title,
+		Version: versionOf(content),
+		Space:   spaceKey,
+		Parent:  page.parent,
+		Labels:  labelsOf(content),
+	}
+
+	if content.Version != nil && content.Version.By != nil {
+		frontMatter.Author = content.Version.By.DisplayName
+	}
+
+	var buffer bytes.Buffer
+	buffer.Write(frontMatter.Marshal())
+	buffer.WriteString("\n")
+	buffer.WriteString(markdown)
+
+	return writeFileAtomic(filepath.Join(e.options.Directory, filepath.FromSlash(page.path)), buffer.Bytes())
+}
+
+// syncAttachments downloads the attachments added or updated, and removes the attachments deleted from the page.
+func (e *Exporter) syncAttachments(ctx context.Context, page *exportPage, entry *ManifestPage) (downloaded, removed int, err error) {
+
+	if entry.Attachments == nil {
+		entry.Attachments = map[string]*ManifestAttachment{}
+	}
+
+	current := map[string]bool{}
+	options := &model.GetContentAttachmentsOptionsScheme{Expand: []string{"version"}}
+
+	for start := 0; ; start += e.options.PageSize {
+
+		chunk, _, err := e.client.Content.Attachment.Gets(ctx, page.content.ID, start, e.options.PageSize, options)
+		if err != nil {
+			return downloaded, removed, err
+		}
+
+		for _, attachment := range chunk.Results {
+
+			current[attachment.ID] = true
+
+			target := path.Join(page.directory(), AttachmentsDirectory, fileName(attachment.Title, attachment.ID))
+			recorded, ok := entry.Attachments[attachment.ID]
+
+			if ok && recorded.Version == versionOf(attachment) && recorded.Path == target &&
+				fileExists(filepath.Join(e.options.Directory, filepath.FromSlash(target))) {
+				continue
+			}
+
+			if err = e.download(ctx, page.content.ID, attachment.ID, target); err != nil {
+				return downloaded, removed, err
+			}
+
+			if ok && recorded.Path != target {
+				e.remove(recorded.Path)
+			}
+
+			entry.Attachments[attachment.ID] = &ManifestAttachment{FileName: attachment.Title, Version: versionOf(attachment), Path: target}
+			downloaded++
+		}
+
+		if len(chunk.Results) < e.options.PageSize {
+			break
+		}
+	}
+
+	for id, attachment := range entry.Attachments {
+		if !current[id] {
+			e.remove(attachment.Path)
+			delete(entry.Attachments, id)
+			removed++
+		}
+	}
+
+	return downloaded, removed, nil
+}
+
+func (e *Exporter) download(ctx context.Context, contentID, attachmentID, target string) error {
+
+	destination := filepath.Join(e.options.Directory, filepath.FromSlash(target))
+	if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+		return err
+	}
+
+	temporary, err := ioutil.TempFile(filepath.Dir(destination), ".export-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(temporary.Name())
+
+	if _, _, err = e.client.Content.Attachment.DownloadTo(ctx, contentID, attachmentID, temporary, nil); err != nil {
+		temporary.Close()
+		return err
+	}
+
+	if err = temporary.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Chmod(temporary.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(temporary.Name(), destination)
+}
+
+// remove removes an exported file, and its parent directories when they're left empty.
+func (e *Exporter) remove(name string) {
+
+	if name == "" {
+		return
+	}
+
+	target := filepath.Join(e.options.Directory, filepath.FromSlash(name))
+	os.Remove(target)
+
+	root := filepath.Clean(e.options.Directory)
+	for directory := filepath.Dir(target); directory != root && strings.HasPrefix(directory, root); directory = filepath.Dir(directory) {
+		if os.Remove(directory) != nil {
+			break
+		}
+	}
+}
+
+func versionOf(content *model.ContentScheme) int {
+
+	if content.Version == nil {
+		return 0
+	}
+
+	return content.Version.Number
+}
+
+func labelsOf(content *model.ContentScheme) []string {
+
+	if content.Metadata == nil || content.Metadata.Labels == nil {
+		return nil
+	}
+
+	var labels []string
+	for _, label := range content.Metadata.Labels.Results {
+		labels = append(labels, label.Name)
+	}
+
+	return labels
+}
+
+var fileNameReplacer = strings.NewReplacer("/", "-", `\`, "-", ":", "-", "*", "-", "?", "-", `"`, "-", "<", "-", ">", "-", "|", "-")
+
+// fileName returns the file name of a title, without the characters invalid on the file systems.
+func fileName(title, id string) string {
+
+	name := strings.Trim(fileNameReplacer.Replace(title), " .")
+	if name == "" {
+		return id
+	}
+
+	return name
+}
+
+func relativePath(base, target string) string {
+
+	relative, err := filepath.Rel(filepath.FromSlash(base), filepath.FromSlash(target))
+	if err != nil {
+		return target
+	}
+
+	return filepath.ToSlash(relative)
+}
+
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
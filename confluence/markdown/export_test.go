@@ -0,0 +1,228 @@
+package markdown
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/confluence"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakePage struct {
+	id, title, parent, body string
+	version                 int
+	attachments             []*fakeAttachment
+}
+
+type fakeAttachment struct {
+	id, name, content string
+	version           int
+}
+
+// fakeConfluence serves the endpoints used by the exporter, counting the page bodies fetched.
+type fakeConfluence struct {
+	mu      sync.Mutex
+	pages   []*fakePage
+	fetched []string
+}
+
+func (f *fakeConfluence) content(page *fakePage, withBody bool) map[string]interface{} {
+
+	content := map[string]interface{}{
+		"id":       page.id,
+		"type":     "page",
+		"title":    page.title,
+		"space":    map[string]interface{}{"key": "DOCS"},
+		"version":  map[string]interface{}{"number": page.version, "by": map[string]interface{}{"displayName": "Jane"}},
+		"metadata": map[string]interface{}{"labels": map[string]interface{}{"results": []interface{}{map[string]interface{}{"name": "docs"}}}},
+	}
+
+	if withBody {
+		content["body"] = map[string]interface{}{"storage": map[string]interface{}{"value": page.body, "representation": "storage"}}
+	}
+
+	return content
+}
+
+func (f *fakeConfluence) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/rest/api/"), "/")
+
+	children := func(parent string) []interface{} {
+		results := []interface{}{}
+		for _, page := range f.pages {
+			if page.parent == parent {
+				results = append(results, f.content(page, false))
+			}
+		}
+		return results
+	}
+
+	find := func(id string) *fakePage {
+		for _, page := range f.pages {
+			if page.id == id {
+				return page
+			}
+		}
+		return nil
+	}
+
+	var response interface{}
+
+	switch {
+
+	case len(parts) == 3 && parts[0] == "space" && parts[2] == "content":
+		response = map[string]interface{}{"page": map[string]interface{}{"results": children("")}}
+
+	case len(parts) == 2 && parts[0] == "content":
+
+		page := find(parts[1])
+		if page == nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		withBody := strings.Contains(r.URL.Query().Get("expand"), "body.storage")
+		if withBody {
+			f.fetched = append(f.fetched, page.id)
+		}
+
+		response = f.content(page, withBody)
+
+	case len(parts) == 4 && parts[2] == "child" && parts[3] == "page":
+		response = map[string]interface{}{"results": children(parts[1])}
+
+	case len(parts) == 4 && parts[2] == "child" && parts[3] == "attachment":
+
+		results := []interface{}{}
+		for _, attachment := range find(parts[1]).attachments {
+			results = append(results, map[string]interface{}{
+				"id": attachment.id, "title": attachment.name, "version": map[string]interface{}{"number": attachment.version},
+			})
+		}
+
+		response = map[string]interface{}{"results": results}
+
+	case len(parts) == 6 && parts[5] == "download":
+
+		for _, attachment := range find(parts[1]).attachments {
+			if attachment.id == parts[4] {
+				fmt.Fprint(w, attachment.content)
+				return
+			}
+		}
+
+		http.NotFound(w, r)
+		return
+
+	default:
+		http.Error(w, "unexpected request "+r.URL.String(), http.StatusNotImplemented)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func readFile(t *testing.T, directory, name string) string {
+
+	content, err := ioutil.ReadFile(filepath.Join(directory, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
+
+func TestExporter_Export(t *testing.T) {
+
+	fake := &fakeConfluence{pages: []*fakePage{
+		{id: "1", title: "Home", version: 1, body: `<p>See <ac:link><ri:page ri:content-title="Release notes"/></ac:link></p>`,
+			attachments: []*fakeAttachment{{id: "att1", name: "logo.png", content: "png", version: 1}}},
+		{id: "2", title: "Release notes", parent: "1", version: 3, body: `<p><ac:link><ri:page ri:content-title="Home"/></ac:link></p>`},
+		{id: "3", title: "Release notes", parent: "1", version: 1, body: "<p>Duplicated title</p>"},
+		{id: "4", title: "Guides/FAQ", version: 1, body: "<h2>FAQ</h2>"},
+	}}
+
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client, err := confluence.New(nil, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	directory, err := ioutil.TempDir("", "export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	_, err = NewExporter(client, &ExportOptions{Directory: directory})
+	assert.Equal(t, ErrNoExportRoot, err)
+
+	exporter, err := NewExporter(client, &ExportOptions{SpaceKey: "DOCS", Directory: directory, Attachments: true})
+	assert.NoError(t, err)
+
+	result, err := exporter.Export(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3", "4"}, result.Written)
+	assert.Equal(t, 1, result.Downloaded)
+
+	assert.Equal(t, "---\nid: \"2\"\ntitle: \"Release notes\"\nversion: 3\nspace: \"DOCS\"\nparent: \"1\"\nauthor: \"Jane\"\n"+
+		"labels:\n  - \"docs\"\n---\n\n[Home](../Home.md)\n", readFile(t, directory, "Home/Release notes.md"))
+
+	assert.Contains(t, readFile(t, directory, "Home.md"), "See [Release notes](<Home/Release notes.md>)\n")
+	assert.Contains(t, readFile(t, directory, "Home/Release notes-3.md"), "Duplicated title")
+	assert.Contains(t, readFile(t, directory, "Guides-FAQ.md"), "## FAQ")
+	assert.Equal(t, "png", readFile(t, directory, "Home/_attachments/logo.png"))
+
+	// Only the pages changed are fetched again.
+	fake.fetched = nil
+	fake.pages[1].version = 4
+	fake.pages[1].body = "<p>Updated</p>"
+	fake.pages[0].attachments[0].version, fake.pages[0].attachments[0].content = 2, "png2"
+
+	result, err = exporter.Export(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2"}, fake.fetched)
+	assert.Equal(t, []string{"2"}, result.Written)
+	assert.Equal(t, []string{"1", "3", "4"}, result.Skipped)
+	assert.Equal(t, 1, result.Downloaded)
+	assert.Contains(t, readFile(t, directory, "Home/Release notes.md"), "version: 4\n")
+	assert.Equal(t, "png2", readFile(t, directory, "Home/_attachments/logo.png"))
+
+	// The pages removed from the tree and their attachments are removed.
+	fake.pages = fake.pages[1:]
+	fake.pages[0].parent, fake.pages[1].parent = "", ""
+
+	result, err = exporter.Export(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, result.Removed)
+	assert.Equal(t, 1, result.RemovedAttachments)
+	assert.Equal(t, []string{"2", "3"}, result.Written)
+
+	var files []string
+	err = filepath.Walk(directory, func(name string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			relative, _ := filepath.Rel(directory, name)
+			files = append(files, filepath.ToSlash(relative))
+		}
+		return err
+	})
+	assert.NoError(t, err)
+
+	sort.Strings(files)
+	assert.Equal(t, []string{".confluence-export.json", "Guides-FAQ.md", "Release notes-3.md", "Release notes.md"}, files)
+}
@@ -0,0 +1,87 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidFrontMatter = errors.New("markdown: invalid front matter")
+
+// FrontMatter is the YAML header of an exported page.
+type FrontMatter struct {
+	ID      string   `yaml:"id"`
+	Title   string   `yaml:"title"`
+	Version int      `yaml:"version"`
+	Space   string   `yaml:"space"`
+	Parent  string   `yaml:"parent"` // the parent page id, empty on the root pages of the space
+	Author  string   `yaml:"author"` // the display name of the author of the last version
+	Labels  []string `yaml:"labels"`
+}
+
+// Marshal returns the front matter delimited by ---, the strings are double quoted.
+func (f *FrontMatter) Marshal() []byte {
+
+	var buffer bytes.Buffer
+	buffer.WriteString("---\n")
+
+	writeString := func(key, value string) {
+		if value != "" {
+			buffer.WriteString(key + ": " + strconv.Quote(value) + "\n")
+		}
+	}
+
+	writeString("id", f.ID)
+	writeString("title", f.Title)
+
+	if f.Version != 0 {
+		buffer.WriteString("version: " + strconv.Itoa(f.Version) + "\n")
+	}
+
+	writeString("space", f.Space)
+	writeString("parent", f.Parent)
+	writeString("author", f.Author)
+
+	if len(f.Labels) != 0 {
+		buffer.WriteString("labels:\n")
+		for _, label := range f.Labels {
+			buffer.WriteString("  - " + strconv.Quote(label) + "\n")
+		}
+	}
+
+	buffer.WriteString("---\n")
+	return buffer.Bytes()
+}
+
+// ParseFrontMatter splits a Markdown file on its front matter and its body, the front matter is empty when the file
+// doesn't start with ---. The keys of FrontMatter are read, the other keys are ignored.
+func ParseFrontMatter(content []byte) (*FrontMatter, []byte, error) {
+
+	frontMatter := &FrontMatter{}
+
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return frontMatter, content, nil
+	}
+
+	end := strings.Index(text[4:], "\n---")
+	if end == -1 {
+		return nil, nil, fmt.Errorf("%w: no closing ---", ErrInvalidFrontMatter)
+	}
+
+	header, body := text[4:4+end], text[4+end+4:]
+	if newline := strings.IndexByte(body, '\n'); newline != -1 {
+		body = body[newline+1:]
+	} else {
+		body = ""
+	}
+
+	if err := yaml.Unmarshal([]byte(header), frontMatter); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidFrontMatter, err)
+	}
+
+	return frontMatter, []byte(body), nil
+}
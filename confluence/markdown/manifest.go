@@ -0,0 +1,95 @@
+package markdown
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// ManifestFileName is the file, on the export directory, recording the pages and the attachments exported.
+const ManifestFileName = ".confluence-export.json"
+
+// Manifest records the versions exported, so the next export only fetches the pages changed since.
+type Manifest struct {
+	Pages map[string]*ManifestPage `json:"pages"`
+}
+
+type ManifestPage struct {
+	Title       string                         `json:"title"`
+	Version     int                            `json:"version"`
+	Path        string                         `json:"path"`
+	Attachments map[string]*ManifestAttachment `json:"attachments,omitempty"`
+}
+
+type ManifestAttachment struct {
+	FileName string `json:"fileName"`
+	Version  int    `json:"version"`
+	Path     string `json:"path"`
+}
+
+// loadManifest reads the manifest of the directory, an empty manifest is returned on the first export.
+func loadManifest(directory string) (*Manifest, error) {
+
+	manifest := &Manifest{Pages: map[string]*ManifestPage{}}
+
+	content, err := ioutil.ReadFile(filepath.Join(directory, ManifestFileName))
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(content, manifest); err != nil {
+		return nil, err
+	}
+
+	if manifest.Pages == nil {
+		manifest.Pages = map[string]*ManifestPage{}
+	}
+
+	return manifest, nil
+}
+
+func (m *Manifest) save(directory string) error {
+
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filepath.Join(directory, ManifestFileName), content)
+}
+
+// writeFileAtomic writes the file on a temporary file renamed once it's complete, creating the parent directories.
+func writeFileAtomic(path string, content []byte) error {
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	temporary, err := ioutil.TempFile(filepath.Dir(path), ".export-*")
+	if err != nil {
+		return err
+	}
+
+	if _, err = temporary.Write(content); err != nil {
+		temporary.Close()
+		os.Remove(temporary.Name())
+		return err
+	}
+
+	if err = temporary.Close(); err != nil {
+		os.Remove(temporary.Name())
+		return err
+	}
+
+	if err = os.Chmod(temporary.Name(), 0644); err != nil {
+		os.Remove(temporary.Name())
+		return err
+	}
+
+	return os.Rename(temporary.Name(), path)
+}
@@ -0,0 +1,655 @@
+package markdown
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/confluence"
+	"github.com/chrisccoy/go-atlassian/confluence/storage"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrNoParentPage = errors.New("markdown: no parent page id set")
+
+// OrphanPolicy is what happens to the pages under the parent page without a Markdown file.
+type OrphanPolicy string
+
+const (
+	OrphanKeep    OrphanPolicy = "keep"    // the orphan pages are left as they are, the default
+	OrphanDelete  OrphanPolicy = "delete"  // the orphan pages are moved to the trash
+	OrphanArchive OrphanPolicy = "archive" // the orphan pages are archived
+)
+
+// publishMarker is the version message of the versions published, followed by the hash of the content published.
+const publishMarker = "markdown publisher"
+
+type PublishOptions struct {
+
+	// Directory is the Markdown directory, <title>.md is published as a page and the <title> directory as its children.
+	// A directory without a Markdown file next to it is published as an empty page, named after the directory.
+	Directory string
+
+	// ParentID is the page the directory is published under.
+	ParentID string
+
+	// SpaceKey is the space of the pages, the space of the parent page by default.
+	SpaceKey string
+
+	// Orphans is the policy of the pages under the parent page without a Markdown file, OrphanKeep by default.
+	Orphans OrphanPolicy
+
+	// DryRun returns the plan without changing any page.
+	DryRun bool
+
+	// PageSize is the number of pages requested per call, 50 by default.
+	PageSize int
+}
+
+type ActionType string
+
+const (
+	ActionCreate    ActionType = "create"
+	ActionUpdate    ActionType = "update"
+	ActionUnchanged ActionType = "unchanged"
+	ActionDelete    ActionType = "delete"
+	ActionArchive   ActionType = "archive"
+	ActionKeep      ActionType = "keep"
+)
+
+// Action is a change of the plan, on a Markdown file or on an orphan page.
+type Action struct {
+	Type   ActionType
+	Path   string   // the slash separated path of the Markdown file, empty on the orphan pages
+	Title  string   // the page title
+	PageID string   // the page id, set on the pages created once the plan is published
+	Labels []string // the labels added to the page
+	Images []string // the file names of the images uploaded to the page
+
+	// RemovedLabels are the global labels of the page not on its front matter, the labels of the other prefixes,
+	// e.g. my:, aren't removed.
+	RemovedLabels []string
+
+	page *sourcePage
+}
+
+// Plan is the list of the actions, the parents before their children and the orphan pages last.
+type Plan struct {
+	Actions []*Action
+}
+
+// String returns an action per line, e.g. update guides/install.md "Install" (12345).
+func (p *Plan) String() string {
+
+	var buffer bytes.Buffer
+	for _, action := range p.Actions {
+
+		buffer.WriteString(fmt.Sprintf("%-9v ", action.Type))
+
+		if action.Path != "" {
+			buffer.WriteString(action.Path + " ")
+		}
+
+		buffer.WriteString(strconv.Quote(action.Title))
+
+		if action.PageID != "" {
+			buffer.WriteString(" (" + action.PageID + ")")
+		}
+
+		if len(action.Labels) != 0 {
+			buffer.WriteString(" labels: " + strings.Join(action.Labels, ", "))
+		}
+
+		if len(action.Images) != 0 {
+			buffer.WriteString(" images: " + strings.Join(action.Images, ", "))
+		}
+
+		if len(action.RemovedLabels) != 0 {
+			buffer.WriteString(" removed labels: " + strings.Join(action.RemovedLabels, ", "))
+		}
+
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
+
+// Publisher publishes a directory of Markdown files as a page tree, the reverse of the Exporter.
+//
+// The pages are matched by the id of their front matter, then by their title. The links to other Markdown files
+// are converted to page links, and the local images are uploaded as attachments of their page. A page is updated
+// only when its title, parent, content or images changed, the hash of the content published is recorded on the
+// message of the versions created and updated, and the pages not published yet are compared by their storage
+// format, so publishing the same directory again doesn't create any version.
+//
+// The global labels of the pages are the labels of their front matter, the labels missing are added and the others
+// are removed, even on the pages unchanged.
+type Publisher struct {
+	client  *confluence.Client
+	options *PublishOptions
+}
+
+func NewPublisher(client *confluence.Client, options *PublishOptions) (*Publisher, error) {
+
+	if options == nil || options.Directory == "" {
+		return nil, ErrNoDirectory
+	}
+
+	if options.ParentID == "" {
+		return nil, ErrNoParentPage
+	}
+
+	if options.Orphans == "" {
+		options.Orphans = OrphanKeep
+	}
+
+	if options.PageSize <= 0 {
+		options.PageSize = 50
+	}
+
+	return &Publisher{client: client, options: options}, nil
+}
+
+type sourcePage struct {
+	path        string // the slash separated path of the Markdown file, or of the directory without a Markdown file
+	directory   string // the slash separated directory of the children
+	title       string
+	frontMatter *FrontMatter
+	markdown    string
+	parent      *sourcePage
+
+	storage string
+	images  map[string]string // the file names of the images, by their slash separated path
+	hash    string
+
+	id string // the page id, once matched or created
+}
+
+type existingPage struct {
+	content *model.ContentScheme
+	parent  string
+	matched bool
+}
+
+// Publish plans the changes, and applies them unless it's a dry run.
+// The plan is returned with the actions applied when the publishing fails.
+func (p *Publisher) Publish(ctx context.Context) (*Plan, error) {
+
+	sources, err := p.sources("", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.options.SpaceKey == "" {
+
+		parent, _, err := p.client.Content.Get(ctx, p.options.ParentID, []string{"space"}, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if parent.Space != nil {
+			p.options.SpaceKey = parent.Space.Key
+		}
+	}
+
+	var existing []*existingPage
+	if err = p.tree(ctx, p.options.ParentID, &existing); err != nil {
+		return nil, err
+	}
+
+	plan, err := p.plan(sources, existing)
+	if err != nil || p.options.DryRun {
+		return plan, err
+	}
+
+	return plan, p.apply(ctx, plan)
+}
+
+// sources reads the Markdown files of a directory and its subdirectories, the parents before their children.
+func (p *Publisher) sources(directory string, parent *sourcePage) ([]*sourcePage, error) {
+
+	entries, err := ioutil.ReadDir(filepath.Join(p.options.Directory, filepath.FromSlash(directory)))
+	if err != nil {
+		return nil, err
+	}
+
+	files, directories := map[string]bool{}, map[string]bool{}
+	var names []string
+
+	for _, entry := range entries {
+
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || name == AttachmentsDirectory {
+			continue
+		}
+
+		switch {
+		case entry.IsDir():
+			directories[name] = true
+		case strings.HasSuffix(name, ".md"):
+			name = strings.TrimSuffix(name, ".md")
+			files[name] = true
+		default:
+			continue
+		}
+
+		// The page and the directory of its children are a single name.
+		if !(files[name] && directories[name]) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	var pages []*sourcePage
+	for _, name := range names {
+
+		page := &sourcePage{directory: path.Join(directory, name), title: name, frontMatter: &FrontMatter{}, parent: parent}
+
+		if files[name] {
+
+			page.path = page.directory + ".md"
+
+			content, err := ioutil.ReadFile(filepath.Join(p.options.Directory, filepath.FromSlash(page.path)))
+			if err != nil {
+				return nil, err
+			}
+
+			frontMatter, body, err := ParseFrontMatter(content)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", page.path, err)
+			}
+
+			page.frontMatter, page.markdown = frontMatter, string(body)
+			if frontMatter.Title != "" {
+				page.title = frontMatter.Title
+			}
+
+		} else {
+			page.path = page.directory + "/"
+		}
+
+		var children []*sourcePage
+		if directories[name] {
+			if children, err = p.sources(page.directory, page); err != nil {
+				return nil, err
+			}
+		}
+
+		// The directories without any Markdown file, e.g. the directories of the images, aren't published.
+		if files[name] || len(children) != 0 {
+			pages = append(append(pages, page), children...)
+		}
+	}
+
+	return pages, nil
+}
+
+// tree returns the pages under a page, the parents before their children.
+func (p *Publisher) tree(ctx context.Context, parentID string, pages *[]*existingPage) error {
+
+	for start := 0; ; start += p.options.PageSize {
+
+		chunk, _, err := p.client.Content.ChildrenDescendant.ChildrenByType(ctx, parentID, "page", 0,
+			[]string{"body.storage", "version", "metadata.labels"}, start, p.options.PageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, content := range chunk.Results {
+
+			*pages = append(*pages, &existingPage{content: content, parent: parentID})
+			if err = p.tree(ctx, content.ID, pages); err != nil {
+				return err
+			}
+		}
+
+		if len(chunk.Results) < p.options.PageSize {
+			return nil
+		}
+	}
+}
+
+func (p *Publisher) plan(sources []*sourcePage, existing []*existingPage) (*Plan, error) {
+
+	byPath := make(map[string]*sourcePage, len(sources))
+	for _, page := range sources {
+		byPath[page.path] = page
+	}
+
+	byID := make(map[string]*existingPage, len(existing))
+	byTitle := make(map[string]*existingPage, len(existing))
+	for _, page := range existing {
+		byID[page.content.ID] = page
+		byTitle[page.content.Title] = page
+	}
+
+	plan := &Plan{}
+
+	for _, page := range sources {
+
+		if err := p.render(page, byPath); err != nil {
+			return nil, err
+		}
+
+		action := &Action{Title: page.title, Path: page.path, page: page}
+		plan.Actions = append(plan.Actions, action)
+
+		for _, name := range page.images {
+			action.Images = append(action.Images, name)
+		}
+
+		sort.Strings(action.Images)
+
+		match, ok := byID[page.frontMatter.ID]
+		if !ok || match.matched {
+			match, ok = byTitle[page.title]
+		}
+
+		if !ok || match.matched {
+			action.Type = ActionCreate
+			action.Labels = page.frontMatter.Labels
+			continue
+		}
+
+		match.matched = true
+		page.id, action.PageID = match.content.ID, match.content.ID
+
+		parentID := p.options.ParentID
+		if page.parent != nil {
+			parentID = page.parent.id
+		}
+
+		if match.parent == parentID && match.content.Title == page.title && p.unchanged(page, match.content) {
+			action.Type, action.Images = ActionUnchanged, nil
+		} else {
+			action.Type = ActionUpdate
+		}
+
+		current, wanted := map[string]bool{}, map[string]bool{}
+		for _, label := range labelsOf(match.content) {
+			current[label] = true
+		}
+
+		for _, label := range page.frontMatter.Labels {
+
+			wanted[label] = true
+			if !current[label] {
+				action.Labels = append(action.Labels, label)
+			}
+		}
+
+		if match.content.Metadata != nil && match.content.Metadata.Labels != nil {
+			for _, label := range match.content.Metadata.Labels.Results {
+				if (label.Prefix == "" || label.Prefix == "global") && !wanted[label.Name] {
+					action.RemovedLabels = append(action.RemovedLabels, label.Name)
+				}
+			}
+		}
+
+		sort.Strings(action.RemovedLabels)
+	}
+
+	orphanAction := map[OrphanPolicy]ActionType{OrphanKeep: ActionKeep, OrphanDelete: ActionDelete, OrphanArchive: ActionArchive}
+	for _, page := range existing {
+		if !page.matched {
+			plan.Actions = append(plan.Actions, &Action{Type: orphanAction[p.options.Orphans], Title: page.content.Title,
+				PageID: page.content.ID})
+		}
+	}
+
+	return plan, nil
+}
+
+// render converts the Markdown of a page, and hashes its title, its storage format and its images.
+func (p *Publisher) render(page *sourcePage, byPath map[string]*sourcePage) error {
+
+	base := path.Dir(page.path)
+	page.images = map[string]string{}
+
+	converter := &StorageConverter{
+		PageLink: func(destination string) (spaceKey, title string, ok bool) {
+
+			target, ok := byPath[localPath(base, destination)]
+			if !ok {
+				return "", "", false
+			}
+
+			return "", target.title, true
+		},
+		Image: func(source string) (fileName string, ok bool) {
+
+			name := localPath(base, source)
+			if name == "" || !fileExists(filepath.Join(p.options.Directory, filepath.FromSlash(name))) {
+				return "", false
+			}
+
+			page.images[name] = path.Base(name)
+			return path.Base(name), true
+		},
+	}
+
+	page.storage = converter.Convert(page.markdown).String()
+
+	hash := sha256.New()
+	hash.Write([]byte(page.title + "\n" + page.storage + "\n"))
+
+	var images []string
+	for name := range page.images {
+		images = append(images, name)
+	}
+
+	sort.Strings(images)
+
+	for _, name := range images {
+
+		content, err := ioutil.ReadFile(filepath.Join(p.options.Directory, filepath.FromSlash(name)))
+		if err != nil {
+			return err
+		}
+
+		hash.Write([]byte(page.images[name] + "\n"))
+		hash.Write(content)
+	}
+
+	page.hash = hex.EncodeToString(hash.Sum(nil))
+	return nil
+}
+
+var publishHashPattern = regexp.MustCompile(`sha256:([0-9a-f]{64})`)
+
+// unchanged returns true when the hash of the last version published is the hash of the page, or, when the
+// last version wasn't published, e.g. a page edited on Confluence, when its storage format is the same.
+func (p *Publisher) unchanged(page *sourcePage, content *model.ContentScheme) bool {
+
+	if content.Version != nil {
+		if match := publishHashPattern.FindStringSubmatch(content.Version.Message); match != nil {
+			return match[1] == page.hash
+		}
+	}
+
+	if content.Body == nil || content.Body.Storage == nil {
+		return false
+	}
+
+	current, err := storage.Parse(content.Body.Storage.Value)
+	if err != nil {
+		return false
+	}
+
+	return current.String() == page.storage
+}
+
+func (p *Publisher) apply(ctx context.Context, plan *Plan) error {
+
+	var orphans []*Action
+
+	for _, action := range plan.Actions {
+
+		page := action.page
+
+		switch action.Type {
+
+		case ActionCreate, ActionUpdate:
+
+			parentID := p.options.ParentID
+			if page.parent != nil {
+				parentID = page.parent.id
+			}
+
+			payload := &model.ContentScheme{
+				Type:      "page",
+				Title:     page.title,
+				Space:     &model.SpaceScheme{Key: p.options.SpaceKey},
+				Ancestors: []*model.ContentScheme{{ID: parentID}},
+				Body:      &model.BodyScheme{Storage: &model.BodyNodeScheme{Value: page.storage, Representation: "storage"}},
+			}
+
+			if action.Type == ActionCreate {
+
+				payload.Version = &model.ContentVersionScheme{Number: 1, Message: publishMarker + " sha256:" + page.hash}
+				content, _, err := p.client.Content.Create(ctx, payload)
+				if err != nil {
+					return fmt.Errorf("%v: %w", page.path, err)
+				}
+
+				page.id, action.PageID = content.ID, content.ID
+
+			} else {
+
+				// The version is read again, the page could be edited after the tree was read.
+				current, _, err := p.client.Content.Get(ctx, page.id, []string{"version"}, 0)
+				if err != nil {
+					return fmt.Errorf("%v: %w", page.path, err)
+				}
+
+				payload.Version = &model.ContentVersionScheme{Number: versionOf(current) + 1, Message: publishMarker + " sha256:" + page.hash}
+				if _, _, err = p.client.Content.Update(ctx, page.id, payload); err != nil {
+					return fmt.Errorf("%v: %w", page.path, err)
+				}
+			}
+
+			if err := p.upload(ctx, page); err != nil {
+				return err
+			}
+
+		case ActionDelete, ActionArchive:
+			orphans = append(orphans, action)
+			continue
+		}
+
+		if len(action.Labels) != 0 {
+
+			var labels []*model.ContentLabelPayloadScheme
+			for _, label := range action.Labels {
+				labels = append(labels, &model.ContentLabelPayloadScheme{Prefix: "global", Name: label})
+			}
+
+			if _, _, err := p.client.Content.Label.Add(ctx, page.id, labels, false); err != nil {
+				return fmt.Errorf("%v: %w", page.path, err)
+			}
+		}
+
+		for _, label := range action.RemovedLabels {
+			if _, err := p.client.Content.Label.Remove(ctx, page.id, label); err != nil {
+				return fmt.Errorf("%v: %w", page.path, err)
+			}
+		}
+	}
+
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	if p.options.Orphans == OrphanArchive {
+
+		payload := &model.ContentArchivePayloadScheme{}
+		for _, action := range orphans {
+
+			id, err := strconv.Atoi(action.PageID)
+			if err != nil {
+				return fmt.Errorf("page %v: %w", action.PageID, err)
+			}
+
+			payload.Pages = append(payload.Pages, &model.ContentArchiveIDPayloadScheme{ID: id})
+		}
+
+		_, _, err := p.client.Content.Archive(ctx, payload)
+		return err
+	}
+
+	// The children are deleted before their parents.
+	for index := len(orphans) - 1; index >= 0; index-- {
+		if _, err := p.client.Content.Delete(ctx, orphans[index].PageID, ""); err != nil {
+			return fmt.Errorf("page %v: %w", orphans[index].PageID, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Publisher) upload(ctx context.Context, page *sourcePage) error {
+
+	var images []string
+	for name := range page.images {
+		images = append(images, name)
+	}
+
+	sort.Strings(images)
+
+	for _, name := range images {
+
+		file, err := os.Open(filepath.Join(p.options.Directory, filepath.FromSlash(name)))
+		if err != nil {
+			return err
+		}
+
+		_, _, err = p.client.Content.Attachment.CreateOrUpdate(ctx, page.id, "current", page.images[name], file)
+		file.Close()
+
+		if err != nil {
+			return fmt.Errorf("%v: %v: %w", page.path, name, err)
+		}
+	}
+
+	return nil
+}
+
+// localPath returns the slash separated path, relative to the directory, of a relative link destination without
+// its fragment, or an empty string when the destination is a URL or an absolute path.
+func localPath(base, destination string) string {
+
+	if index := strings.IndexByte(destination, '#'); index != -1 {
+		destination = destination[:index]
+	}
+
+	if destination == "" || strings.HasPrefix(destination, "/") {
+		return ""
+	}
+
+	if parsed, err := url.Parse(destination); err != nil || parsed.Scheme != "" || parsed.Host != "" {
+		return ""
+	}
+
+	if unescaped, err := url.PathUnescape(destination); err == nil {
+		destination = unescaped
+	}
+
+	name := path.Clean(path.Join(base, destination))
+	if name == ".." || strings.HasPrefix(name, "../") {
+		return ""
+	}
+
+	return name
+}
@@ -0,0 +1,314 @@
+package markdown
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/confluence"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type publishedPage struct {
+	id, title, parent, body, message string
+	version                          int
+	labels                           []string
+}
+
+// fakePublishConfluence serves the endpoints used by the publisher, recording the changes requested.
+type fakePublishConfluence struct {
+	mu       sync.Mutex
+	pages    []*publishedPage
+	requests []string
+	nextID   int
+}
+
+func (f *fakePublishConfluence) find(id string) *publishedPage {
+
+	for _, page := range f.pages {
+		if page.id == id {
+			return page
+		}
+	}
+
+	return nil
+}
+
+func (f *fakePublishConfluence) content(page *publishedPage) map[string]interface{} {
+
+	var labels []interface{}
+	for _, label := range page.labels {
+		labels = append(labels, map[string]interface{}{"name": label})
+	}
+
+	return map[string]interface{}{
+		"id":       page.id,
+		"type":     "page",
+		"title":    page.title,
+		"space":    map[string]interface{}{"key": "DOCS"},
+		"version":  map[string]interface{}{"number": page.version, "message": page.message},
+		"body":     map[string]interface{}{"storage": map[string]interface{}{"value": page.body, "representation": "storage"}},
+		"metadata": map[string]interface{}{"labels": map[string]interface{}{"results": labels}},
+	}
+}
+
+func (f *fakePublishConfluence) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/rest/api/"), "/")
+	if r.Method != http.MethodGet {
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	}
+
+	var payload struct {
+		Title     string `json:"title"`
+		Ancestors []struct {
+			ID string `json:"id"`
+		} `json:"ancestors"`
+		Body struct {
+			Storage struct {
+				Value string `json:"value"`
+			} `json:"storage"`
+		} `json:"body"`
+		Version struct {
+			Number  int    `json:"number"`
+			Message string `json:"message"`
+		} `json:"version"`
+	}
+
+	body, _ := ioutil.ReadAll(r.Body)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		_ = json.Unmarshal(body, &payload)
+	}
+
+	var response interface{}
+
+	switch {
+
+	case r.Method == http.MethodGet && len(parts) == 2:
+		response = map[string]interface{}{"id": parts[1], "space": map[string]interface{}{"key": "DOCS"}}
+		if page := f.find(parts[1]); page != nil {
+			response = f.content(page)
+		}
+
+	case r.Method == http.MethodGet && len(parts) == 4 && parts[3] == "page":
+
+		results := []interface{}{}
+		for _, page := range f.pages {
+			if page.parent == parts[1] {
+				results = append(results, f.content(page))
+			}
+		}
+
+		response = map[string]interface{}{"results": results}
+
+	case r.Method == http.MethodPost && len(parts) == 1:
+
+		f.nextID++
+		page := &publishedPage{id: fmt.Sprint(f.nextID), title: payload.Title, parent: payload.Ancestors[0].ID,
+			body: payload.Body.Storage.Value, version: 1, message: payload.Version.Message}
+
+		f.pages = append(f.pages, page)
+		response = f.content(page)
+
+	case r.Method == http.MethodPut && len(parts) == 2:
+
+		page := f.find(parts[1])
+		page.title, page.parent, page.body = payload.Title, payload.Ancestors[0].ID, payload.Body.Storage.Value
+		page.version, page.message = payload.Version.Number, payload.Version.Message
+		response = f.content(page)
+
+	case r.Method == http.MethodPost && len(parts) == 3 && parts[2] == "label":
+
+		var labels []struct {
+			Name string `json:"name"`
+		}
+
+		_ = json.Unmarshal(body, &labels)
+
+		page := f.find(parts[1])
+		for _, label := range labels {
+			page.labels = append(page.labels, label.Name)
+		}
+
+		response = map[string]interface{}{"results": []interface{}{}}
+
+	case r.Method == http.MethodPut && len(parts) == 4 && parts[3] == "attachment":
+		response = map[string]interface{}{"results": []interface{}{}}
+
+	case r.Method == http.MethodDelete && len(parts) == 4 && parts[2] == "label":
+
+		page := f.find(parts[1])
+		for index, label := range page.labels {
+			if label == parts[3] {
+				page.labels = append(page.labels[:index], page.labels[index+1:]...)
+				break
+			}
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		return
+
+	case r.Method == http.MethodDelete && len(parts) == 2:
+		w.WriteHeader(http.StatusNoContent)
+		return
+
+	case r.Method == http.MethodPost && len(parts) == 2 && parts[1] == "archive":
+		response = map[string]interface{}{"id": "task"}
+
+	default:
+		http.Error(w, "unexpected request "+r.URL.String(), http.StatusNotImplemented)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func writeFiles(t *testing.T, directory string, files map[string]string) {
+
+	for name, content := range files {
+
+		target := filepath.Join(directory, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(target, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPublisher_Publish(t *testing.T) {
+
+	fake := &fakePublishConfluence{nextID: 100, pages: []*publishedPage{
+		{id: "10", title: "Install", parent: "1", version: 4, body: "<p>Old</p>", labels: []string{"docs", "obsolete"}},
+		{id: "11", title: "Old page", parent: "1", version: 1},
+		{id: "12", title: "Old child", parent: "11", version: 1},
+	}}
+
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client, err := confluence.New(nil, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	directory, err := ioutil.TempDir("", "publish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	writeFiles(t, directory, map[string]string{
+		"Home.md":          "---\ntitle: \"Welcome\"\nlabels: [docs, home]\n---\nSee [Install](Home/Install.md#setup)\n\n![logo](images/logo.png)\n",
+		"Home/Install.md":  "---\nlabels:\n  - docs\n---\nBack [home](../Home.md)\n",
+		"Guides/FAQ.md":    "## FAQ\n",
+		"images/logo.png":  "png",
+		".hidden/Draft.md": "Draft",
+	})
+
+	_, err = NewPublisher(client, &PublishOptions{Directory: directory})
+	assert.Equal(t, ErrNoParentPage, err)
+
+	options := &PublishOptions{Directory: directory, ParentID: "1", Orphans: OrphanDelete, DryRun: true}
+	publisher, err := NewPublisher(client, options)
+	assert.NoError(t, err)
+
+	plan, err := publisher.Publish(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, fake.requests)
+	assert.Equal(t, "create    Guides/ \"Guides\"\n"+
+		"create    Guides/FAQ.md \"FAQ\"\n"+
+		"create    Home.md \"Welcome\" labels: docs, home images: logo.png\n"+
+		"update    Home/Install.md \"Install\" (10) removed labels: obsolete\n"+
+		"delete    \"Old page\" (11)\n"+
+		"delete    \"Old child\" (12)\n", plan.String())
+
+	options.DryRun = false
+	plan, err = publisher.Publish(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"POST /rest/api/content",
+		"POST /rest/api/content",
+		"POST /rest/api/content",
+		"PUT /rest/api/content/103/child/attachment",
+		"POST /rest/api/content/103/label",
+		"PUT /rest/api/content/10",
+		"DELETE /rest/api/content/10/label/obsolete",
+		"DELETE /rest/api/content/12",
+		"DELETE /rest/api/content/11",
+	}, fake.requests)
+
+	home, install := fake.find("103"), fake.find("10")
+	assert.Equal(t, []string{"docs", "home"}, home.labels)
+	assert.Equal(t, `<p>See <ac:link><ri:page ri:content-title="Install" /></ac:link></p>`+
+		`<p><ac:image ac:alt="logo"><ri:attachment ri:filename="logo.png" /></ac:image></p>`, home.body)
+	assert.Equal(t, []string{"docs"}, install.labels)
+	assert.Equal(t, "103", install.parent)
+	assert.Equal(t, 5, install.version)
+	assert.Equal(t, `<p>Back <ac:link><ri:page ri:content-title="Welcome" /><ac:plain-text-link-body><![CDATA[home]]>`+
+		`</ac:plain-text-link-body></ac:link></p>`, install.body)
+	assert.Contains(t, install.message, "markdown publisher sha256:")
+
+	assert.Contains(t, home.message, "markdown publisher sha256:")
+
+	// Publishing again doesn't create any version, the orphans deleted are no longer on the tree. The storage of
+	// the pages created is normalized by Confluence, e.g. with local ids, they're compared by their hash.
+	fake.pages = []*publishedPage{install, fake.find("101"), fake.find("102"), home}
+	fake.requests = nil
+	home.body = strings.ReplaceAll(home.body, "<p>", `<p local-id="a1b2">`)
+
+	plan, err = publisher.Publish(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, fake.requests)
+
+	for _, action := range plan.Actions {
+		assert.Equal(t, ActionUnchanged, action.Type, action.Path)
+	}
+
+	// The pages changed are updated.
+	writeFiles(t, directory, map[string]string{"Home/Install.md": "---\nlabels: [docs]\n---\nChanged\n"})
+
+	_, err = publisher.Publish(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"PUT /rest/api/content/10"}, fake.requests)
+	assert.Equal(t, 6, install.version)
+}
+
+func TestPublisher_Publish_Archive(t *testing.T) {
+
+	fake := &fakePublishConfluence{pages: []*publishedPage{{id: "11", title: "Old page", parent: "1", version: 1}}}
+
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client, err := confluence.New(nil, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	directory, err := ioutil.TempDir("", "publish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	publisher, err := NewPublisher(client, &PublishOptions{Directory: directory, ParentID: "1", SpaceKey: "DOCS", Orphans: OrphanArchive})
+	assert.NoError(t, err)
+
+	plan, err := publisher.Publish(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "archive   \"Old page\" (11)\n", plan.String())
+	assert.Equal(t, []string{"POST /rest/api/content/archive"}, fake.requests)
+}
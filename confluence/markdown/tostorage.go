@@ -0,0 +1,734 @@
+package markdown
+
+import (
+	"github.com/chrisccoy/go-atlassian/confluence/storage"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// StorageConverter converts Markdown to the storage format, including the GitHub flavored Markdown tables, task
+// lists and strikethrough. The quotes starting with **Info**, **Note**, **Warning**, **Tip** or **Panel**,
+// optionally followed by a title, e.g. **Info: Heads up**, are converted to panels, as the Converter writes them.
+type StorageConverter struct {
+
+	// PageLink resolves the destination of a link to a page, e.g. the relative path of another Markdown file.
+	// The destination is kept as a link when it's not set or it returns false.
+	PageLink func(destination string) (spaceKey, title string, ok bool)
+
+	// Image resolves the source of an image to the file name of an attachment of the page.
+	// The image is shown from its source URL when it's not set or it returns false.
+	Image func(source string) (fileName string, ok bool)
+}
+
+// Convert converts Markdown to a storage format document.
+func (s *StorageConverter) Convert(markdown string) *storage.Node {
+
+	markdown = strings.ReplaceAll(markdown, "\r\n", "\n")
+	markdown = strings.ReplaceAll(markdown, "\t", "    ")
+
+	return storage.Document(s.blocks(strings.Split(markdown, "\n"))...)
+}
+
+var (
+	headingPattern    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ ]+(.*?))?(?:[ ]+#+)?[ ]*$`)
+	listMarkerPattern = regexp.MustCompile(`^( *)([-*+]|\d{1,9}[.)])( +|$)(.*)$`)
+	breakPattern      = regexp.MustCompile(`^ {0,3}([-*_])( *[-*_]){2,} *$`)
+	delimiterPattern  = regexp.MustCompile(`^ *\|? *:?-+:? *(\| *:?-+:? *)*\|? *$`)
+	autolinkPattern   = regexp.MustCompile(`^<(https?://[^ <>]+)>`)
+	taskPattern       = regexp.MustCompile(`^\[([ xX])\][ ]+`)
+)
+
+var panelKinds = map[string]string{"Info": "info", "Note": "note", "Warning": "warning", "Tip": "tip", "Panel": "panel"}
+
+func (s *StorageConverter) blocks(lines []string) []*storage.Node {
+
+	var nodes []*storage.Node
+
+	for index := 0; index < len(lines); {
+
+		line := lines[index]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+
+		case trimmed == "":
+			index++
+
+		case fenceMarker(trimmed) != "":
+
+			marker, indent := fenceMarker(trimmed), len(line)-len(strings.TrimLeft(line, " "))
+			language := strings.TrimSpace(strings.TrimLeft(trimmed, marker[:1]))
+
+			var code []string
+			for index++; index < len(lines); index++ {
+
+				if closing := strings.TrimSpace(lines[index]); strings.HasPrefix(closing, marker) && strings.Trim(closing, marker[:1]) == "" {
+					index++
+					break
+				}
+
+				code = append(code, trimIndent(lines[index], indent))
+			}
+
+			nodes = append(nodes, storage.CodeBlock(language, strings.Join(code, "\n")))
+
+		case headingPattern.MatchString(line):
+
+			match := headingPattern.FindStringSubmatch(line)
+			nodes = append(nodes, storage.Heading(len(match[1]), s.inline(match[2])...))
+			index++
+
+		case breakPattern.MatchString(line):
+			nodes = append(nodes, storage.Element("hr"))
+			index++
+
+		case strings.HasPrefix(trimmed, ">"):
+
+			var quoted []string
+			for ; index < len(lines); index++ {
+
+				current := strings.TrimSpace(lines[index])
+				if !strings.HasPrefix(current, ">") {
+					break
+				}
+
+				current = strings.TrimPrefix(current, ">")
+				quoted = append(quoted, strings.TrimPrefix(current, " "))
+			}
+
+			nodes = append(nodes, s.quote(s.blocks(quoted)))
+
+		case strings.Contains(line, "|") && index+1 < len(lines) && delimiterPattern.MatchString(lines[index+1]) &&
+			strings.Contains(lines[index+1], "-"):
+
+			rows := []*storage.Node{s.tableRow(line, storage.HeaderCell)}
+			for index += 2; index < len(lines) && strings.TrimSpace(lines[index]) != "" && strings.Contains(lines[index], "|"); index++ {
+				rows = append(rows, s.tableRow(lines[index], storage.Cell))
+			}
+
+			nodes = append(nodes, storage.Table(rows...))
+
+		case listMarkerPattern.MatchString(line) && !breakPattern.MatchString(line):
+
+			var list *storage.Node
+			list, index = s.list(lines, index)
+			nodes = append(nodes, list)
+
+		default:
+
+			paragraph := []string{strings.TrimLeft(line, " ")}
+			for index++; index < len(lines) && !startsBlock(lines[index]); index++ {
+				paragraph = append(paragraph, strings.TrimLeft(lines[index], " "))
+			}
+
+			nodes = append(nodes, storage.Paragraph(s.inline(strings.TrimRight(strings.Join(paragraph, "\n"), " "))...))
+		}
+	}
+
+	return nodes
+}
+
+// startsBlock reports whether the line ends a paragraph.
+func startsBlock(line string) bool {
+
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || fenceMarker(trimmed) != "" || strings.HasPrefix(trimmed, ">") ||
+		headingPattern.MatchString(line) || breakPattern.MatchString(line) {
+		return true
+	}
+
+	// An ordered list only interrupts a paragraph when it starts at 1.
+	if match := listMarkerPattern.FindStringSubmatch(line); match != nil && match[4] != "" {
+		marker := match[2]
+		return !unicode.IsDigit(rune(marker[0])) || strings.TrimLeft(marker[:len(marker)-1], "0") == "1"
+	}
+
+	return false
+}
+
+func fenceMarker(trimmed string) string {
+
+	for _, character := range []string{"`", "~"} {
+
+		count := len(trimmed) - len(strings.TrimLeft(trimmed, character))
+		if count >= 3 && (character == "~" || !strings.Contains(trimmed[count:], "`")) {
+			return strings.Repeat(character, count)
+		}
+	}
+
+	return ""
+}
+
+func trimIndent(line string, indent int) string {
+
+	for count := 0; count < indent && strings.HasPrefix(line, " "); count++ {
+		line = line[1:]
+	}
+
+	return line
+}
+
+// quote returns a panel when the quote starts with the panel type in bold, or a blockquote.
+func (s *StorageConverter) quote(children []*storage.Node) *storage.Node {
+
+	if len(children) != 0 && children[0].Name == "p" && len(children[0].Children) != 0 {
+
+		first := children[0].Children[0]
+		if first.Name == "strong" {
+
+			heading := first.TextContent()
+			kind, title := heading, ""
+			if separator := strings.Index(heading, ":"); separator != -1 {
+				kind, title = heading[:separator], strings.TrimSpace(heading[separator+1:])
+			}
+
+			if macro, ok := panelKinds[kind]; ok {
+
+				rest := children[0].Children[1:]
+				for len(rest) != 0 && (rest[0].Name == "br" || (rest[0].Type == storage.TextNode && strings.TrimSpace(rest[0].Text) == "")) {
+					rest = rest[1:]
+				}
+
+				body := children[1:]
+				if len(rest) != 0 {
+					body = append([]*storage.Node{storage.Paragraph(rest...)}, body...)
+				}
+
+				return storage.Panel(macro, title, body...)
+			}
+		}
+	}
+
+	return storage.Element("blockquote", children...)
+}
+
+func (s *StorageConverter) tableRow(line string, cell func(children ...*storage.Node) *storage.Node) *storage.Node {
+
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	row := storage.Row()
+	var current strings.Builder
+
+	for index := 0; index < len(line); index++ {
+
+		if line[index] == '\\' && index+1 < len(line) && line[index+1] == '|' {
+			current.WriteByte('|')
+			index++
+			continue
+		}
+
+		if line[index] == '|' {
+			row.Append(cell(s.inline(strings.ReplaceAll(strings.TrimSpace(current.String()), "<br>", "\\\n"))...))
+			current.Reset()
+			continue
+		}
+
+		current.WriteByte(line[index])
+	}
+
+	return row.Append(cell(s.inline(strings.ReplaceAll(strings.TrimSpace(current.String()), "<br>", "\\\n"))...))
+}
+
+type listItem struct {
+	lines []string
+	task  string // the task status, complete or incomplete, when the item starts with [ ] or [x]
+}
+
+// list parses the list starting on the line, returning the list and the index of the line after it.
+func (s *StorageConverter) list(lines []string, index int) (*storage.Node, int) {
+
+	first := listMarkerPattern.FindStringSubmatch(lines[index])
+	ordered := unicode.IsDigit(rune(first[2][0]))
+	siblingIndent := len(first[1]) + len(first[2]) + 1
+
+	var items []*listItem
+
+	for index < len(lines) {
+
+		match := listMarkerPattern.FindStringSubmatch(lines[index])
+		if match == nil || breakPattern.MatchString(lines[index]) || unicode.IsDigit(rune(match[2][0])) != ordered ||
+			len(match[1]) >= siblingIndent {
+			break
+		}
+
+		contentIndent := len(match[1]) + len(match[2]) + len(match[3])
+		if len(match[3]) > 4 || match[4] == "" {
+			contentIndent = len(match[1]) + len(match[2]) + 1
+		}
+
+		item := &listItem{lines: []string{match[4]}}
+		if task := taskPattern.FindStringSubmatch(match[4]); task != nil {
+			item.task = "incomplete"
+			if task[1] != " " {
+				item.task = "complete"
+			}
+		}
+
+		for index++; index < len(lines); index++ {
+
+			line := lines[index]
+			indent := len(line) - len(strings.TrimLeft(line, " "))
+
+			if strings.TrimSpace(line) == "" {
+
+				// A blank line continues the item when the next line is indented as its content.
+				next := index + 1
+				for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+					next++
+				}
+
+				if next < len(lines) && len(lines[next])-len(strings.TrimLeft(lines[next], " ")) >= contentIndent {
+					item.lines = append(item.lines, "")
+					continue
+				}
+
+				break
+			}
+
+			if indent >= contentIndent {
+				item.lines = append(item.lines, line[contentIndent:])
+				continue
+			}
+
+			if startsBlock(line) || listMarkerPattern.MatchString(line) || item.lines[len(item.lines)-1] == "" {
+				break
+			}
+
+			// A lazy continuation line of the item paragraph.
+			item.lines = append(item.lines, strings.TrimSpace(line))
+		}
+
+		items = append(items, item)
+
+		// The blank lines between the items of the list.
+		next := index
+		for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+			next++
+		}
+
+		if next < len(lines) && next != index {
+			if match := listMarkerPattern.FindStringSubmatch(lines[next]); match != nil && len(match[1]) < siblingIndent &&
+				unicode.IsDigit(rune(match[2][0])) == ordered {
+				index = next
+			}
+		}
+	}
+
+	isTaskList := true
+	for _, item := range items {
+		if item.task == "" {
+			isTaskList = false
+		}
+	}
+
+	if isTaskList {
+
+		list := storage.Element("ac:task-list")
+		for _, item := range items {
+
+			lines := append([]string{taskPattern.ReplaceAllString(item.lines[0], "")}, item.lines[1:]...)
+			list.Append(storage.Element("ac:task",
+				storage.Element("ac:task-status", storage.Text(item.task)),
+				storage.Element("ac:task-body", s.itemContent(lines)...)))
+		}
+
+		return list, index
+	}
+
+	list := storage.List(ordered)
+	for _, item := range items {
+		list.Append(storage.ListItem(s.itemContent(item.lines)...))
+	}
+
+	return list, index
+}
+
+// itemContent returns the blocks of a list item, a single paragraph is unwrapped.
+func (s *StorageConverter) itemContent(lines []string) []*storage.Node {
+
+	blocks := s.blocks(lines)
+	if len(blocks) != 0 && blocks[0].Name == "p" {
+
+		tight := true
+		for _, line := range lines {
+			if strings.TrimSpace(line) == "" {
+				tight = false
+			}
+		}
+
+		if tight {
+			return append(blocks[0].Children, blocks[1:]...)
+		}
+	}
+
+	return blocks
+}
+
+// inline parses the inline elements, the text can span several lines.
+func (s *StorageConverter) inline(text string) []*storage.Node {
+
+	var (
+		nodes  []*storage.Node
+		buffer strings.Builder
+	)
+
+	flush := func() {
+		if buffer.Len() != 0 {
+			nodes = append(nodes, storage.Text(buffer.String()))
+			buffer.Reset()
+		}
+	}
+
+	for index := 0; index < len(text); {
+
+		character := text[index]
+
+		switch {
+
+		case character == '\\' && index+1 < len(text) && text[index+1] == '\n':
+			flush()
+			nodes = append(nodes, storage.LineBreak())
+			index += 2
+
+		case character == '\\' && index+1 < len(text) && isPunctuation(text[index+1]):
+			buffer.WriteByte(text[index+1])
+			index += 2
+
+		case character == '\n':
+
+			content := buffer.String()
+			trimmed := strings.TrimRight(content, " ")
+			buffer.Reset()
+			buffer.WriteString(trimmed)
+
+			if len(content)-len(trimmed) >= 2 {
+				flush()
+				nodes = append(nodes, storage.LineBreak())
+			} else {
+				buffer.WriteByte(' ')
+			}
+
+			index++
+
+		case character == '`':
+
+			run := countRun(text, index, '`')
+			closing := findRun(text, index+run, '`', run)
+			if closing == -1 {
+				buffer.WriteString(text[index : index+run])
+				index += run
+				continue
+			}
+
+			code := strings.ReplaceAll(text[index+run:closing], "\n", " ")
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+				code = code[1 : len(code)-1]
+			}
+
+			flush()
+			nodes = append(nodes, storage.Code(code))
+			index = closing + run
+
+		case character == '!' && index+1 < len(text) && text[index+1] == '[':
+
+			label, destination, end, ok := parseLink(text, index+1)
+			if !ok {
+				buffer.WriteByte(character)
+				index++
+				continue
+			}
+
+			flush()
+			nodes = append(nodes, s.image(storage.Element("span", s.inline(label)...).TextContent(), destination))
+			index = end
+
+		case character == '[':
+
+			label, destination, end, ok := parseLink(text, index)
+			if !ok {
+				buffer.WriteByte(character)
+				index++
+				continue
+			}
+
+			flush()
+			nodes = append(nodes, s.link(label, destination))
+			index = end
+
+		case character == '<' && autolinkPattern.MatchString(text[index:]):
+
+			match := autolinkPattern.FindStringSubmatch(text[index:])
+			flush()
+			nodes = append(nodes, storage.Link(match[1], storage.Text(match[1])))
+			index += len(match[0])
+
+		case character == '*' || character == '_' || character == '~':
+
+			node, end := s.emphasis(text, index)
+			if node == nil {
+				run := countRun(text, index, character)
+				buffer.WriteString(text[index : index+run])
+				index += run
+				continue
+			}
+
+			flush()
+			nodes = append(nodes, node)
+			index = end
+
+		default:
+			buffer.WriteByte(character)
+			index++
+		}
+	}
+
+	flush()
+	return nodes
+}
+
+// emphasis parses the strong, emphasis or strikethrough starting on the index, returning nil when it's not closed.
+func (s *StorageConverter) emphasis(text string, index int) (*storage.Node, int) {
+
+	character := text[index]
+	run := countRun(text, index, character)
+
+	var size int
+	var element string
+
+	switch {
+	case character == '~' && run == 2:
+		size, element = 2, "s"
+	case character != '~' && run >= 2:
+		size, element = 2, "strong"
+	case character != '~' && run == 1:
+		size, element = 1, "em"
+	default:
+		return nil, 0
+	}
+
+	// The opening delimiter must be followed by a non space, the underscores can't be intraword.
+	if index+size >= len(text) || isSpace(text[index+size]) {
+		return nil, 0
+	}
+
+	if character == '_' && index > 0 && isWordCharacter(text, index-1) {
+		return nil, 0
+	}
+
+	for position := index + size; position < len(text); {
+
+		switch text[position] {
+
+		case '\\':
+			position += 2
+			continue
+
+		case '`':
+			codeRun := countRun(text, position, '`')
+			if closing := findRun(text, position+codeRun, '`', codeRun); closing != -1 {
+				position = closing + codeRun
+				continue
+			}
+			position += codeRun
+			continue
+
+		case character:
+
+			closingRun := countRun(text, position, character)
+			matches := closingRun == size || (size == 2 && closingRun > 2)
+			if matches && !isSpace(text[position-1]) &&
+				(character != '_' || position+closingRun >= len(text) || !isWordCharacter(text, position+closingRun)) {
+
+				inner := text[index+size : position]
+				return storage.Element(element, s.inline(inner)...), position + size
+			}
+
+			position += closingRun
+			continue
+		}
+
+		position++
+	}
+
+	return nil, 0
+}
+
+func (s *StorageConverter) link(label, destination string) *storage.Node {
+
+	if s.PageLink != nil {
+		if spaceKey, title, ok := s.PageLink(destination); ok {
+
+			text := storage.Element("span", s.inline(label)...).TextContent()
+			if text == title {
+				text = ""
+			}
+
+			return storage.PageLink(spaceKey, title, text)
+		}
+	}
+
+	return storage.Link(destination, s.inline(label)...)
+}
+
+func (s *StorageConverter) image(alt, source string) *storage.Node {
+
+	var image *storage.Node
+	if s.Image != nil {
+		if fileName, ok := s.Image(source); ok {
+			image = storage.Image(fileName)
+		}
+	}
+
+	if image == nil {
+		image = storage.ImageURL(source)
+	}
+
+	if alt != "" {
+		image.SetAttr("ac:alt", alt)
+	}
+
+	return image
+}
+
+// parseLink parses a [label](destination) starting on the bracket, returning the index after the link.
+func parseLink(text string, index int) (label, destination string, end int, ok bool) {
+
+	depth := 0
+	position := index
+	for ; position < len(text); position++ {
+
+		switch text[position] {
+		case '\\':
+			position++
+			continue
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+
+		if depth == 0 {
+			break
+		}
+	}
+
+	if position >= len(text)-1 || text[position+1] != '(' {
+		return "", "", 0, false
+	}
+
+	label = text[index+1 : position]
+	position += 2
+
+	if position < len(text) && text[position] == '<' {
+
+		closing := strings.IndexByte(text[position:], '>')
+		if closing == -1 {
+			return "", "", 0, false
+		}
+
+		destination = text[position+1 : position+closing]
+		position += closing + 1
+
+	} else {
+
+		start, parentheses := position, 0
+		for ; position < len(text); position++ {
+
+			if text[position] == '(' {
+				parentheses++
+			}
+
+			if text[position] == ')' {
+				if parentheses == 0 {
+					break
+				}
+				parentheses--
+			}
+
+			if isSpace(text[position]) {
+				break
+			}
+		}
+
+		destination = text[start:position]
+	}
+
+	// The optional title, e.g. [label](destination "title"), isn't kept.
+	for position < len(text) && isSpace(text[position]) {
+		position++
+	}
+
+	if position < len(text) && (text[position] == '"' || text[position] == '\'') {
+
+		closing := strings.IndexByte(text[position+1:], text[position])
+		if closing == -1 {
+			return "", "", 0, false
+		}
+
+		position += closing + 2
+		for position < len(text) && isSpace(text[position]) {
+			position++
+		}
+	}
+
+	if position >= len(text) || text[position] != ')' {
+		return "", "", 0, false
+	}
+
+	return label, destination, position + 1, true
+}
+
+func countRun(text string, index int, character byte) int {
+
+	count := 0
+	for index+count < len(text) && text[index+count] == character {
+		count++
+	}
+
+	return count
+}
+
+// findRun returns the index of the next run of exactly the size provided, or -1.
+func findRun(text string, index int, character byte, size int) int {
+
+	for position := index; position < len(text); {
+
+		if text[position] != character {
+			position++
+			continue
+		}
+
+		run := countRun(text, position, character)
+		if run == size {
+			return position
+		}
+
+		position += run
+	}
+
+	return -1
+}
+
+func isPunctuation(character byte) bool {
+	return character < utf8.RuneSelf && unicode.IsPunct(rune(character)) || strings.IndexByte("$+<=>^`|~", character) != -1
+}
+
+func isSpace(character byte) bool {
+	return character == ' ' || character == '\n'
+}
+
+func isWordCharacter(text string, index int) bool {
+
+	if text[index] < utf8.RuneSelf {
+		return unicode.IsLetter(rune(text[index])) || unicode.IsDigit(rune(text[index]))
+	}
+
+	// A byte of a multi-byte rune, e.g. an accented letter.
+	return true
+}
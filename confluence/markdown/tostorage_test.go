@@ -0,0 +1,91 @@
+package markdown
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestStorageConverter_Convert(t *testing.T) {
+
+	converter := &StorageConverter{
+		PageLink: func(destination string) (spaceKey, title string, ok bool) {
+			if destination == "../Release notes.md" {
+				return "", "Release notes", true
+			}
+			return "", "", false
+		},
+		Image: func(source string) (fileName string, ok bool) {
+			if strings.HasPrefix(source, "http") {
+				return "", false
+			}
+			return source[strings.LastIndex(source, "/")+1:], true
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{
+			name:     "when the markdown has headings and inline elements",
+			markdown: "# Title\n\nSome **bold**, _italic_, ~~old~~ and `a*b` text\\_with chars.  \nNext line\n",
+			want: "<h1>Title</h1><p>Some <strong>bold</strong>, <em>italic</em>, <s>old</s> and <code>a*b</code> " +
+				"text_with chars.<br />Next line</p>",
+		},
+
+		{
+			name:     "when the markdown has nested lists",
+			markdown: "- One\n  - Nested\n- Two\n\n1. First\n2. Second\n",
+			want:     "<ul><li>One<ul><li>Nested</li></ul></li><li>Two</li></ul><ol><li>First</li><li>Second</li></ol>",
+		},
+
+		{
+			name:     "when the markdown has a task list",
+			markdown: "- [x] Done\n- [ ] Todo\n",
+			want: "<ac:task-list><ac:task><ac:task-status>complete</ac:task-status><ac:task-body>Done</ac:task-body></ac:task>" +
+				"<ac:task><ac:task-status>incomplete</ac:task-status><ac:task-body>Todo</ac:task-body></ac:task></ac:task-list>",
+		},
+
+		{
+			name:     "when the markdown has a table",
+			markdown: "| Key | Value |\n| --- | --- |\n| a\\|b | 1<br>2 |\n",
+			want:     "<table><tbody><tr><th>Key</th><th>Value</th></tr><tr><td>a|b</td><td>1<br />2</td></tr></tbody></table>",
+		},
+
+		{
+			name:     "when the markdown has a code block and a panel",
+			markdown: "```go\nfmt.Println(\"<\")\n```\n\n> **Info: Heads up**\n>\n> Read it\n",
+			want: `<ac:structured-macro ac:name="code" ac:schema-version="1"><ac:parameter ac:name="language">go</ac:parameter>` +
+				`<ac:plain-text-body><![CDATA[fmt.Println("<")]]></ac:plain-text-body></ac:structured-macro>` +
+				`<ac:structured-macro ac:name="info" ac:schema-version="1"><ac:parameter ac:name="title">Heads up</ac:parameter>` +
+				`<ac:rich-text-body><p>Read it</p></ac:rich-text-body></ac:structured-macro>`,
+		},
+
+		{
+			name:     "when the markdown has links and images",
+			markdown: "[Release notes](<../Release notes.md>), [notes](<../Release notes.md>) and <https://example.com>\n\n![diagram](img/a.png)",
+			want: `<p><ac:link><ri:page ri:content-title="Release notes" /></ac:link>, ` +
+				`<ac:link><ri:page ri:content-title="Release notes" /><ac:plain-text-link-body><![CDATA[notes]]></ac:plain-text-link-body></ac:link> ` +
+				`and <a href="https://example.com">https://example.com</a></p>` +
+				`<p><ac:image ac:alt="diagram"><ri:attachment ri:filename="a.png" /></ac:image></p>`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, converter.Convert(testCase.markdown).String())
+		})
+	}
+}
+
+func TestStorageConverter_RoundTrip(t *testing.T) {
+
+	body := "<h2>Install</h2><p>Run <code>make</code>, then <strong>restart</strong>.</p><ul><li>One</li><li>Two</li></ul>" +
+		"<table><tbody><tr><th>A</th></tr><tr><td>1</td></tr></tbody></table>"
+
+	markdown, err := (&Converter{}).Convert(body)
+	assert.NoError(t, err)
+	assert.Equal(t, body, (&StorageConverter{}).Convert(markdown).String())
+}
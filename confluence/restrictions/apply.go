@@ -0,0 +1,135 @@
+package restrictions
+
+import (
+	"context"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/confluence"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+)
+
+type ApplyOptions struct {
+
+	// TemplatePageID is the page whose restrictions are copied.
+	TemplatePageID string
+
+	// RootPageID is the root of the subtree the restrictions are copied to, the root page included.
+	RootPageID string
+
+	// DryRun returns the pages that would be updated without changing them.
+	DryRun bool
+
+	// PageSize is the number of pages, or restrictions, requested per call, 50 by default.
+	PageSize int
+}
+
+type ApplyResult struct {
+	Updated   []string // the ids of the pages whose restrictions were replaced, or would be on a dry run
+	Unchanged []string // the ids of the pages with the restrictions of the template page already
+}
+
+// Applier replaces the read and update restrictions of every page of a subtree by the restrictions of a template page.
+type Applier struct {
+	client  *confluence.Client
+	options *ApplyOptions
+}
+
+func NewApplier(client *confluence.Client, options *ApplyOptions) (*Applier, error) {
+
+	if options == nil || options.TemplatePageID == "" {
+		return nil, ErrNoTemplatePage
+	}
+
+	if options.RootPageID == "" {
+		return nil, ErrNoApplyRoot
+	}
+
+	if options.PageSize <= 0 {
+		options.PageSize = 50
+	}
+
+	return &Applier{client: client, options: options}, nil
+}
+
+// Apply copies the restrictions of the template page, the parents are updated before their children, so a
+// failure leaves the top of the subtree restricted. The template page isn't updated when it's on the subtree.
+func (a *Applier) Apply(ctx context.Context) (*ApplyResult, error) {
+
+	read, update, err := restrictionsOf(ctx, a.client, a.options.TemplatePageID, a.options.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := tree(ctx, a.client, "", a.options.RootPageID, a.options.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := updatePayload(read, update)
+	result := &ApplyResult{}
+
+	for _, page := range pages {
+
+		id := page.content.ID
+		if id == a.options.TemplatePageID {
+			continue
+		}
+
+		currentRead, currentUpdate, err := restrictionsOf(ctx, a.client, id, a.options.PageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if currentRead.Equal(read) && currentUpdate.Equal(update) {
+			result.Unchanged = append(result.Unchanged, id)
+			continue
+		}
+
+		if !a.options.DryRun {
+
+			if len(payload.Results) == 0 {
+				_, _, err = a.client.Content.Restriction.Delete(ctx, id, nil)
+			} else {
+				_, _, err = a.client.Content.Restriction.Update(ctx, id, payload, nil)
+			}
+
+			if err != nil {
+				return result, fmt.Errorf("page %v: %w", id, err)
+			}
+		}
+
+		result.Updated = append(result.Updated, id)
+	}
+
+	return result, nil
+}
+
+func updatePayload(read, update *Restrictions) *model.ContentRestrictionUpdatePayloadScheme {
+
+	payload := &model.ContentRestrictionUpdatePayloadScheme{}
+
+	for _, operation := range []struct {
+		name         string
+		restrictions *Restrictions
+	}{{OperationRead, read}, {OperationUpdate, update}} {
+
+		if operation.restrictions.Empty() {
+			continue
+		}
+
+		restrictions := &model.ContentRestrictionRestrictionUpdateScheme{}
+		for _, user := range operation.restrictions.Users {
+			restrictions.User = append(restrictions.User, &model.ContentUserScheme{Type: "known", AccountID: user.AccountID})
+		}
+
+		for _, group := range operation.restrictions.Groups {
+			restrictions.Group = append(restrictions.Group, &model.SpaceGroupScheme{Type: "group", Name: group})
+		}
+
+		payload.Results = append(payload.Results, &model.ContentRestrictionUpdateScheme{
+			Operation:    operation.name,
+			Restrictions: restrictions,
+		})
+	}
+
+	return payload
+}
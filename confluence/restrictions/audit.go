@@ -0,0 +1,391 @@
+// Package restrictions audits the read and update restrictions of a page tree, and copies the restrictions of a
+// template page to a page tree.
+package restrictions
+
+import (
+	"context"
+	"errors"
+	"github.com/chrisccoy/go-atlassian/confluence"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrNoAuditRoot    = errors.New("restrictions: no space key or root page id set")
+	ErrNoTemplatePage = errors.New("restrictions: no template page id set")
+	ErrNoApplyRoot    = errors.New("restrictions: no root page id set")
+)
+
+const (
+	OperationRead   = "read"
+	OperationUpdate = "update"
+)
+
+// UserStatusFunc returns whether a user account is active, e.g. from the account status of the admin API users.
+type UserStatusFunc func(ctx context.Context, accountID string) (active bool, err error)
+
+type AuditOptions struct {
+
+	// SpaceKey audits every page of the space, RootPageID audits the page and its descendants instead.
+	// The space key is required to check the anonymous access.
+	SpaceKey   string
+	RootPageID string
+
+	// UserStatus checks the users of the restrictions, the deactivated users aren't flagged when it's not set.
+	UserStatus UserStatusFunc
+
+	// PageSize is the number of pages, or restrictions, requested per call, 50 by default.
+	PageSize int
+}
+
+// Auditor collects the read and update restrictions of every page of a tree, and flags the pages whose
+// restrictions differ from their ancestors, the pages open to the anonymous users, and the pages restricted
+// to deactivated users.
+type Auditor struct {
+	client  *confluence.Client
+	options *AuditOptions
+	active  map[string]bool
+}
+
+func NewAuditor(client *confluence.Client, options *AuditOptions) (*Auditor, error) {
+
+	if options == nil || (options.SpaceKey == "" && options.RootPageID == "") {
+		return nil, ErrNoAuditRoot
+	}
+
+	if options.PageSize <= 0 {
+		options.PageSize = 50
+	}
+
+	return &Auditor{client: client, options: options, active: map[string]bool{}}, nil
+}
+
+// Audit walks the tree, the parents before their children, and returns the report of every page.
+func (a *Auditor) Audit(ctx context.Context) (*Report, error) {
+
+	report := &Report{SpaceKey: a.options.SpaceKey}
+
+	if a.options.SpaceKey != "" {
+
+		space, _, err := a.client.Space.Get(ctx, a.options.SpaceKey, []string{"permissions"})
+		if err != nil {
+			return nil, err
+		}
+
+		report.AnonymousRead = anonymousRead(space)
+	}
+
+	pages, err := tree(ctx, a.client, a.options.SpaceKey, a.options.RootPageID, a.options.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*PageReport, len(pages))
+
+	for _, page := range pages {
+
+		read, update, err := restrictionsOf(ctx, a.client, page.content.ID, a.options.PageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := &PageReport{
+			ID:       page.content.ID,
+			Title:    page.content.Title,
+			ParentID: page.parent,
+			Path:     page.path,
+			Read:     read,
+			Update:   update,
+		}
+
+		byID[entry.ID] = entry
+		report.Pages = append(report.Pages, entry)
+
+		// The nearest ancestor with restrictions, and whether any ancestor restricts the read access.
+		var restricted *PageReport
+		readRestricted := !read.Empty()
+
+		for ancestor := byID[entry.ParentID]; ancestor != nil; ancestor = byID[ancestor.ParentID] {
+
+			if restricted == nil && (!ancestor.Read.Empty() || !ancestor.Update.Empty()) {
+				restricted = ancestor
+			}
+
+			readRestricted = readRestricted || !ancestor.Read.Empty()
+		}
+
+		if !read.Empty() || !update.Empty() {
+			if restricted == nil || !read.Equal(restricted.Read) || !update.Equal(restricted.Update) {
+				entry.Findings = append(entry.Findings, FindingDiffersFromAncestors)
+			}
+		}
+
+		// The read restrictions of the ancestors are inherited, the pages under a restricted page aren't open.
+		if report.AnonymousRead && !readRestricted {
+			entry.Findings = append(entry.Findings, FindingAnonymousAccess)
+		}
+
+		if a.options.UserStatus != nil {
+
+			for _, user := range append(append([]*User{}, read.users()...), update.users()...) {
+
+				active, err := a.isActive(ctx, user.AccountID)
+				if err != nil {
+					return nil, err
+				}
+
+				if !active {
+					entry.Findings = append(entry.Findings, FindingDeactivatedUser)
+					break
+				}
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func (a *Auditor) isActive(ctx context.Context, accountID string) (bool, error) {
+
+	if active, ok := a.active[accountID]; ok {
+		return active, nil
+	}
+
+	active, err := a.options.UserStatus(ctx, accountID)
+	if err != nil {
+		return false, err
+	}
+
+	a.active[accountID] = active
+	return active, nil
+}
+
+// anonymousRead returns whether the anonymous users can read the space.
+func anonymousRead(space *model.SpaceScheme) bool {
+
+	for _, permission := range space.Permissions {
+		if permission.AnonymousAccess && permission.Operation != nil && permission.Operation.Operation == OperationRead {
+			return true
+		}
+	}
+
+	return false
+}
+
+type treePage struct {
+	content *model.ContentScheme
+	parent  string
+	path    string // the titles of the ancestors on the tree and of the page, separated by " / "
+}
+
+// tree returns the pages of a space, or a page and its descendants, the parents before their children.
+func tree(ctx context.Context, client *confluence.Client, spaceKey, rootPageID string, pageSize int) ([]*treePage, error) {
+
+	var roots []*model.ContentScheme
+	parent := ""
+
+	if rootPageID != "" {
+
+		root, _, err := client.Content.Get(ctx, rootPageID, []string{"ancestors"}, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if count := len(root.Ancestors); count != 0 {
+			parent = root.Ancestors[count-1].ID
+		}
+
+		roots = append(roots, root)
+
+	} else {
+
+		for start := 0; ; start += pageSize {
+
+			content, _, err := client.Space.Content(ctx, spaceKey, "root", nil, start, pageSize)
+			if err != nil {
+				return nil, err
+			}
+
+			if content.Page == nil {
+				break
+			}
+
+			roots = append(roots, content.Page.Results...)
+			if len(content.Page.Results) < pageSize {
+				break
+			}
+		}
+	}
+
+	var pages []*treePage
+	if err := walk(ctx, client, roots, parent, "", pageSize, &pages); err != nil {
+		return nil, err
+	}
+
+	return pages, nil
+}
+
+func walk(ctx context.Context, client *confluence.Client, siblings []*model.ContentScheme, parent, path string, pageSize int,
+	pages *[]*treePage) error {
+
+	for _, content := range siblings {
+
+		page := &treePage{content: content, parent: parent, path: content.Title}
+		if path != "" {
+			page.path = path + " / " + content.Title
+		}
+
+		*pages = append(*pages, page)
+
+		var children []*model.ContentScheme
+		for start := 0; ; start += pageSize {
+
+			chunk, _, err := client.Content.ChildrenDescendant.ChildrenByType(ctx, content.ID, "page", 0, nil, start, pageSize)
+			if err != nil {
+				return err
+			}
+
+			children = append(children, chunk.Results...)
+			if len(chunk.Results) < pageSize {
+				break
+			}
+		}
+
+		if err := walk(ctx, client, children, content.ID, page.path, pageSize, pages); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var restrictionExpand = []string{"restrictions.user", "restrictions.group"}
+
+// restrictionsOf returns the read and update restrictions set on a page, not the restrictions inherited.
+func restrictionsOf(ctx context.Context, client *confluence.Client, contentID string, pageSize int) (read, update *Restrictions,
+	err error) {
+
+	read, update = &Restrictions{}, &Restrictions{}
+
+	for start := 0; ; start += pageSize {
+
+		page, _, err := client.Content.Restriction.Gets(ctx, contentID, restrictionExpand, start, pageSize)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, restriction := range page.Results {
+
+			var target *Restrictions
+			switch restriction.Operation {
+			case OperationRead:
+				target = read
+			case OperationUpdate:
+				target = update
+			default:
+				continue
+			}
+
+			if restriction.Restrictions == nil {
+				continue
+			}
+
+			if users := restriction.Restrictions.User; users != nil {
+				for _, user := range users.Results {
+					target.Users = append(target.Users, &User{AccountID: user.AccountID, DisplayName: user.DisplayName})
+				}
+			}
+
+			if groups := restriction.Restrictions.Group; groups != nil {
+				for _, group := range groups.Results {
+					target.Groups = append(target.Groups, group.Name)
+				}
+			}
+		}
+
+		if len(page.Results) < pageSize {
+			break
+		}
+	}
+
+	read.sort()
+	update.sort()
+	return read, update, nil
+}
+
+// Restrictions are the users and the groups of an operation, sorted by account id and name.
+type Restrictions struct {
+	Users  []*User  `json:"users"`
+	Groups []string `json:"groups"`
+}
+
+type User struct {
+	AccountID   string `json:"accountId"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// Empty returns true when the operation isn't restricted.
+func (r *Restrictions) Empty() bool {
+	return r == nil || (len(r.Users) == 0 && len(r.Groups) == 0)
+}
+
+// Equal returns true when the restrictions have the same users and groups.
+func (r *Restrictions) Equal(other *Restrictions) bool {
+
+	if r.Empty() || other.Empty() {
+		return r.Empty() == other.Empty()
+	}
+
+	if len(r.Users) != len(other.Users) || len(r.Groups) != len(other.Groups) {
+		return false
+	}
+
+	for index, user := range r.Users {
+		if user.AccountID != other.Users[index].AccountID {
+			return false
+		}
+	}
+
+	for index, group := range r.Groups {
+		if group != other.Groups[index] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Restrictions) users() []*User {
+
+	if r == nil {
+		return nil
+	}
+
+	return r.Users
+}
+
+func (r *Restrictions) sort() {
+
+	sort.Slice(r.Users, func(i, j int) bool { return r.Users[i].AccountID < r.Users[j].AccountID })
+	sort.Strings(r.Groups)
+}
+
+// String returns the users, by account id, and the groups, e.g. user:5b10ac8d82e05b22cc7d4ef5 group:admins.
+func (r *Restrictions) String() string {
+
+	if r == nil {
+		return ""
+	}
+
+	var parts []string
+	for _, user := range r.Users {
+		parts = append(parts, "user:"+user.AccountID)
+	}
+
+	for _, group := range r.Groups {
+		parts = append(parts, "group:"+group)
+	}
+
+	return strings.Join(parts, " ")
+}
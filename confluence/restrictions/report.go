@@ -0,0 +1,88 @@
+package restrictions
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+type Finding string
+
+const (
+	// FindingDiffersFromAncestors is a page whose restrictions differ from the nearest ancestor with restrictions,
+	// or a restricted page without any restricted ancestor.
+	FindingDiffersFromAncestors Finding = "differs-from-ancestors"
+
+	// FindingAnonymousAccess is a page of a space open to the anonymous users without read restrictions,
+	// on the page or on its ancestors.
+	FindingAnonymousAccess Finding = "anonymous-access"
+
+	// FindingDeactivatedUser is a page restricted to a deactivated user.
+	FindingDeactivatedUser Finding = "deactivated-user"
+)
+
+type Report struct {
+	SpaceKey      string        `json:"spaceKey,omitempty"`
+	AnonymousRead bool          `json:"anonymousRead"` // the space permissions grant the read access to the anonymous users
+	Pages         []*PageReport `json:"pages"`
+}
+
+// PageReport has the restrictions set on a page, the restrictions inherited from its ancestors aren't included.
+type PageReport struct {
+	ID       string        `json:"id"`
+	Title    string        `json:"title"`
+	ParentID string        `json:"parentId,omitempty"`
+	Path     string        `json:"path"`
+	Read     *Restrictions `json:"read"`
+	Update   *Restrictions `json:"update"`
+	Findings []Finding     `json:"findings"`
+}
+
+// Flagged returns the pages with findings.
+func (r *Report) Flagged() []*PageReport {
+
+	var pages []*PageReport
+	for _, page := range r.Pages {
+		if len(page.Findings) != 0 {
+			pages = append(pages, page)
+		}
+	}
+
+	return pages
+}
+
+// WriteJSON writes the report as an indented JSON document.
+func (r *Report) WriteJSON(writer io.Writer) error {
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+var csvHeader = []string{"id", "title", "path", "read", "update", "findings"}
+
+// WriteCSV writes the report as CSV, a row per page, the restrictions are formatted as Restrictions.String.
+func (r *Report) WriteCSV(writer io.Writer) error {
+
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, page := range r.Pages {
+
+		findings := make([]string, len(page.Findings))
+		for index, finding := range page.Findings {
+			findings[index] = string(finding)
+		}
+
+		row := []string{page.ID, page.Title, page.Path, page.Read.String(), page.Update.String(), strings.Join(findings, " ")}
+		if err := csvWriter.Write(row); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
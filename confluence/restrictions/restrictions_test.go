@@ -0,0 +1,339 @@
+package restrictions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/chrisccoy/go-atlassian/confluence"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakePage struct {
+	id, title, parent         string
+	readUsers, readGroups     []string
+	updateUsers, updateGroups []string
+}
+
+// fakeRestrictionsConfluence serves the endpoints used by the auditor and the applier, recording the changes requested.
+type fakeRestrictionsConfluence struct {
+	mu        sync.Mutex
+	anonymous bool
+	pages     []*fakePage
+	requests  []string
+}
+
+func (f *fakeRestrictionsConfluence) find(id string) *fakePage {
+
+	for _, page := range f.pages {
+		if page.id == id {
+			return page
+		}
+	}
+
+	return nil
+}
+
+func restriction(operation string, users, groups []string) map[string]interface{} {
+
+	userResults, groupResults := []interface{}{}, []interface{}{}
+	for _, user := range users {
+		userResults = append(userResults, map[string]interface{}{"type": "known", "accountId": user, "displayName": strings.ToUpper(user)})
+	}
+
+	for _, group := range groups {
+		groupResults = append(groupResults, map[string]interface{}{"type": "group", "name": group})
+	}
+
+	return map[string]interface{}{
+		"operation": operation,
+		"restrictions": map[string]interface{}{
+			"user":  map[string]interface{}{"results": userResults},
+			"group": map[string]interface{}{"results": groupResults},
+		},
+	}
+}
+
+func (f *fakeRestrictionsConfluence) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/rest/api/"), "/")
+	if r.Method != http.MethodGet {
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	}
+
+	page := func(page *fakePage) map[string]interface{} {
+		return map[string]interface{}{"id": page.id, "type": "page", "title": page.title}
+	}
+
+	children := func(parent string) []interface{} {
+
+		results := []interface{}{}
+		for _, candidate := range f.pages {
+			if candidate.parent == parent {
+				results = append(results, page(candidate))
+			}
+		}
+
+		return results
+	}
+
+	var response interface{}
+
+	switch {
+
+	case r.Method == http.MethodGet && len(parts) == 2 && parts[0] == "space":
+
+		permissions := []interface{}{}
+		if f.anonymous {
+			permissions = append(permissions, map[string]interface{}{
+				"anonymousAccess": true,
+				"operation":       map[string]interface{}{"operation": "read", "targetType": "space"},
+			})
+		}
+
+		response = map[string]interface{}{"key": parts[1], "permissions": permissions}
+
+	case r.Method == http.MethodGet && len(parts) == 3 && parts[0] == "space":
+		response = map[string]interface{}{"page": map[string]interface{}{"results": children("")}}
+
+	case r.Method == http.MethodGet && len(parts) == 2:
+
+		content := page(f.find(parts[1]))
+		if parent := f.find(parts[1]).parent; parent != "" {
+			content["ancestors"] = []interface{}{map[string]interface{}{"id": "0"}, map[string]interface{}{"id": parent}}
+		}
+
+		response = content
+
+	case r.Method == http.MethodGet && len(parts) == 4 && parts[3] == "page":
+		response = map[string]interface{}{"results": children(parts[1])}
+
+	case r.Method == http.MethodGet && len(parts) == 3 && parts[2] == "restriction":
+
+		current := f.find(parts[1])
+		response = map[string]interface{}{"results": []interface{}{
+			restriction(OperationRead, current.readUsers, current.readGroups),
+			restriction(OperationUpdate, current.updateUsers, current.updateGroups),
+		}}
+
+	case r.Method == http.MethodPut && len(parts) == 3 && parts[2] == "restriction":
+
+		var payload struct {
+			Results []struct {
+				Operation    string `json:"operation"`
+				Restrictions struct {
+					User []struct {
+						AccountID string `json:"accountId"`
+					} `json:"user"`
+					Group []struct {
+						Name string `json:"name"`
+					} `json:"group"`
+				} `json:"restrictions"`
+			} `json:"results"`
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &payload)
+
+		current := f.find(parts[1])
+		current.readUsers, current.readGroups, current.updateUsers, current.updateGroups = nil, nil, nil, nil
+
+		for _, result := range payload.Results {
+
+			var users, groups []string
+			for _, user := range result.Restrictions.User {
+				users = append(users, user.AccountID)
+			}
+
+			for _, group := range result.Restrictions.Group {
+				groups = append(groups, group.Name)
+			}
+
+			if result.Operation == OperationRead {
+				current.readUsers, current.readGroups = users, groups
+			} else {
+				current.updateUsers, current.updateGroups = users, groups
+			}
+		}
+
+		response = map[string]interface{}{"results": []interface{}{}}
+
+	case r.Method == http.MethodDelete && len(parts) == 3 && parts[2] == "restriction":
+
+		current := f.find(parts[1])
+		current.readUsers, current.readGroups, current.updateUsers, current.updateGroups = nil, nil, nil, nil
+		response = map[string]interface{}{"results": []interface{}{}}
+
+	default:
+		http.Error(w, "unexpected request "+r.URL.String(), http.StatusNotImplemented)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func newFake() *fakeRestrictionsConfluence {
+
+	return &fakeRestrictionsConfluence{anonymous: true, pages: []*fakePage{
+		{id: "1", title: "Home"},
+		{id: "2", title: "Team", parent: "1", readGroups: []string{"team"}, updateUsers: []string{"bob", "alice"}},
+		{id: "3", title: "Notes", parent: "2", readGroups: []string{"team"}, updateUsers: []string{"alice", "bob"}},
+		{id: "4", title: "Private", parent: "2", readUsers: []string{"carol"}},
+		{id: "5", title: "Public", parent: "1"},
+	}}
+}
+
+func startClient(t *testing.T, handler http.Handler) (*confluence.Client, func()) {
+
+	server := httptest.NewServer(handler)
+
+	client, err := confluence.New(nil, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, server.Close
+}
+
+func TestAuditor_Audit(t *testing.T) {
+
+	client, closeServer := startClient(t, newFake())
+	defer closeServer()
+
+	_, err := NewAuditor(client, &AuditOptions{})
+	assert.Equal(t, ErrNoAuditRoot, err)
+
+	var checked []string
+	auditor, err := NewAuditor(client, &AuditOptions{
+		SpaceKey: "DOCS",
+		UserStatus: func(ctx context.Context, accountID string) (bool, error) {
+			checked = append(checked, accountID)
+			return accountID != "carol", nil
+		},
+	})
+	assert.NoError(t, err)
+
+	report, err := auditor.Audit(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, report.AnonymousRead)
+
+	var paths []string
+	for _, page := range report.Pages {
+		paths = append(paths, page.Path)
+	}
+
+	assert.Equal(t, []string{"Home", "Home / Team", "Home / Team / Notes", "Home / Team / Private", "Home / Public"}, paths)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, checked)
+
+	findings := map[string][]Finding{}
+	for _, page := range report.Flagged() {
+		findings[page.ID] = page.Findings
+	}
+
+	assert.Equal(t, map[string][]Finding{
+		"1": {FindingAnonymousAccess},
+		"2": {FindingDiffersFromAncestors},
+		"4": {FindingDiffersFromAncestors, FindingDeactivatedUser},
+		"5": {FindingAnonymousAccess},
+	}, findings)
+
+	assert.Equal(t, "user:alice user:bob", report.Pages[1].Update.String())
+	assert.Equal(t, "BOB", report.Pages[1].Update.Users[1].DisplayName)
+
+	var buffer bytes.Buffer
+	assert.NoError(t, report.WriteCSV(&buffer))
+	assert.Equal(t, "id,title,path,read,update,findings\n"+
+		"1,Home,Home,,,anonymous-access\n"+
+		"2,Team,Home / Team,group:team,user:alice user:bob,differs-from-ancestors\n"+
+		"3,Notes,Home / Team / Notes,group:team,user:alice user:bob,\n"+
+		"4,Private,Home / Team / Private,user:carol,,differs-from-ancestors deactivated-user\n"+
+		"5,Public,Home / Public,,,anonymous-access\n", buffer.String())
+
+	buffer.Reset()
+	assert.NoError(t, report.WriteJSON(&buffer))
+
+	var decoded Report
+	assert.NoError(t, json.Unmarshal(buffer.Bytes(), &decoded))
+	assert.Equal(t, "carol", decoded.Pages[3].Read.Users[0].AccountID)
+}
+
+func TestAuditor_Audit_Subtree(t *testing.T) {
+
+	client, closeServer := startClient(t, newFake())
+	defer closeServer()
+
+	auditor, err := NewAuditor(client, &AuditOptions{
+		RootPageID: "2",
+		UserStatus: func(ctx context.Context, accountID string) (bool, error) {
+			return false, errors.New("status unavailable")
+		},
+	})
+	assert.NoError(t, err)
+
+	_, err = auditor.Audit(context.Background())
+	assert.EqualError(t, err, "status unavailable")
+
+	auditor.options.UserStatus = nil
+
+	report, err := auditor.Audit(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, report.AnonymousRead)
+	assert.Len(t, report.Pages, 3)
+	assert.Equal(t, "1", report.Pages[0].ParentID)
+	assert.Equal(t, "Team / Private", report.Pages[2].Path)
+}
+
+func TestApplier_Apply(t *testing.T) {
+
+	fake := newFake()
+	client, closeServer := startClient(t, fake)
+	defer closeServer()
+
+	_, err := NewApplier(client, &ApplyOptions{RootPageID: "2"})
+	assert.Equal(t, ErrNoTemplatePage, err)
+
+	_, err = NewApplier(client, &ApplyOptions{TemplatePageID: "2"})
+	assert.Equal(t, ErrNoApplyRoot, err)
+
+	options := &ApplyOptions{TemplatePageID: "2", RootPageID: "2", DryRun: true}
+	applier, err := NewApplier(client, options)
+	assert.NoError(t, err)
+
+	result, err := applier.Apply(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, fake.requests)
+	assert.Equal(t, []string{"4"}, result.Updated)
+	assert.Equal(t, []string{"3"}, result.Unchanged)
+
+	options.DryRun = false
+	_, err = applier.Apply(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"PUT /rest/api/content/4/restriction"}, fake.requests)
+
+	private := fake.find("4")
+	assert.Equal(t, []string{"team"}, private.readGroups)
+	assert.Equal(t, []string{"alice", "bob"}, private.updateUsers)
+	assert.Nil(t, private.readUsers)
+
+	// A template without restrictions removes the restrictions of the subtree.
+	fake.requests = nil
+	options.TemplatePageID, options.RootPageID = "5", "2"
+
+	result, err = applier.Apply(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2", "3", "4"}, result.Updated)
+	assert.Equal(t, []string{
+		"DELETE /rest/api/content/2/restriction",
+		"DELETE /rest/api/content/3/restriction",
+		"DELETE /rest/api/content/4/restriction",
+	}, fake.requests)
+}
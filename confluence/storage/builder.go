@@ -0,0 +1,303 @@
+package storage
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Status macro colours.
+const (
+	StatusGrey   = "Grey"
+	StatusRed    = "Red"
+	StatusYellow = "Yellow"
+	StatusGreen  = "Green"
+	StatusBlue   = "Blue"
+	StatusPurple = "Purple"
+)
+
+// Layout section types.
+const (
+	LayoutSingle           = "single"
+	LayoutTwoEqual         = "two_equal"
+	LayoutTwoLeftSidebar   = "two_left_sidebar"
+	LayoutTwoRightSidebar  = "two_right_sidebar"
+	LayoutThreeEqual       = "three_equal"
+	LayoutThreeWithSidebar = "three_with_sidebars"
+)
+
+// Document returns a document with the nodes provided.
+func Document(children ...*Node) *Node {
+	return &Node{Type: DocumentNode, Children: children}
+}
+
+// Element returns an element, the name can use the ac: and ri: prefixes.
+func Element(name string, children ...*Node) *Node {
+	return &Node{Type: ElementNode, Name: name, Children: children}
+}
+
+// Text returns a text node, it's escaped when it's rendered.
+func Text(text string) *Node {
+	return &Node{Type: TextNode, Text: text}
+}
+
+// CData returns a text node rendered as a CDATA section.
+func CData(text string) *Node {
+	return &Node{Type: CDataNode, Text: text}
+}
+
+func Paragraph(children ...*Node) *Node {
+	return Element("p", children...)
+}
+
+// Heading returns a h1 to h6 element, the level is clamped to that range.
+func Heading(level int, children ...*Node) *Node {
+
+	if level < 1 {
+		level = 1
+	}
+
+	if level > 6 {
+		level = 6
+	}
+
+	return Element("h"+strconv.Itoa(level), children...)
+}
+
+func Strong(children ...*Node) *Node {
+	return Element("strong", children...)
+}
+
+func Emphasis(children ...*Node) *Node {
+	return Element("em", children...)
+}
+
+func Code(text string) *Node {
+	return Element("code", Text(text))
+}
+
+func LineBreak() *Node {
+	return Element("br")
+}
+
+// Link returns an external link.
+func Link(href string, children ...*Node) *Node {
+	return Element("a", children...).SetAttr("href", href)
+}
+
+func List(ordered bool, items ...*Node) *Node {
+
+	if ordered {
+		return Element("ol", items...)
+	}
+
+	return Element("ul", items...)
+}
+
+func ListItem(children ...*Node) *Node {
+	return Element("li", children...)
+}
+
+// Table returns a table with the rows provided, the rows are wrapped on a tbody element.
+func Table(rows ...*Node) *Node {
+	return Element("table", Element("tbody", rows...))
+}
+
+func Row(cells ...*Node) *Node {
+	return Element("tr", cells...)
+}
+
+func Cell(children ...*Node) *Node {
+	return Element("td", children...)
+}
+
+func HeaderCell(children ...*Node) *Node {
+	return Element("th", children...)
+}
+
+// TextTable returns a table with a header row and text cells.
+func TextTable(header []string, rows ...[]string) *Node {
+
+	table := Table()
+	body := table.Children[0]
+
+	if len(header) != 0 {
+
+		row := Row()
+		for _, value := range header {
+			row.Append(HeaderCell(Text(value)))
+		}
+
+		body.Append(row)
+	}
+
+	for _, values := range rows {
+
+		row := Row()
+		for _, value := range values {
+			row.Append(Cell(Text(value)))
+		}
+
+		body.Append(row)
+	}
+
+	return table
+}
+
+// Macro returns a structured macro, the parameters are sorted by name and the body is optional,
+// e.g. RichTextBody or PlainTextBody.
+func Macro(name string, parameters map[string]string, body *Node) *Node {
+
+	macro := Element("ac:structured-macro").SetAttr("ac:name", name).SetAttr("ac:schema-version", "1")
+
+	names := make([]string, 0, len(parameters))
+	for parameter := range parameters {
+		names = append(names, parameter)
+	}
+
+	sort.Strings(names)
+
+	for _, parameter := range names {
+		macro.Append(Element("ac:parameter", Text(parameters[parameter])).SetAttr("ac:name", parameter))
+	}
+
+	if body != nil {
+		macro.Append(body)
+	}
+
+	return macro
+}
+
+// RichTextBody returns the body of the macros containing storage format, e.g. the info or expand macros.
+func RichTextBody(children ...*Node) *Node {
+	return Element("ac:rich-text-body", children...)
+}
+
+// PlainTextBody returns the body of the macros containing text, e.g. the code macro.
+func PlainTextBody(text string) *Node {
+	return Element("ac:plain-text-body", CData(text))
+}
+
+// CodeBlock returns a code macro, the language is optional.
+func CodeBlock(language, code string) *Node {
+
+	parameters := map[string]string{}
+	if language != "" {
+		parameters["language"] = language
+	}
+
+	return Macro("code", parameters, PlainTextBody(code))
+}
+
+// Panel returns an info, note, tip or warning macro.
+func Panel(kind, title string, children ...*Node) *Node {
+
+	parameters := map[string]string{}
+	if title != "" {
+		parameters["title"] = title
+	}
+
+	return Macro(kind, parameters, RichTextBody(children...))
+}
+
+// Status returns a status lozenge, e.g. Status("In progress", StatusBlue, false).
+func Status(title, colour string, subtle bool) *Node {
+
+	parameters := map[string]string{"title": title}
+	if colour != "" {
+		parameters["colour"] = colour
+	}
+
+	if subtle {
+		parameters["subtle"] = "true"
+	}
+
+	return Macro("status", parameters, nil)
+}
+
+// JiraIssue returns the Jira macro showing one issue, the server ID is the application link ID and it's optional
+// when the site has a single Jira application link.
+func JiraIssue(issueKey, serverID string) *Node {
+
+	parameters := map[string]string{"key": issueKey}
+	if serverID != "" {
+		parameters["serverId"] = serverID
+	}
+
+	return Macro("jira", parameters, nil)
+}
+
+// JiraIssues returns the Jira macro showing the issues matching a JQL query, the columns are optional.
+func JiraIssues(jql, serverID string, columns []string, maximumIssues int) *Node {
+
+	parameters := map[string]string{"jqlQuery": jql}
+	if serverID != "" {
+		parameters["serverId"] = serverID
+	}
+
+	if len(columns) != 0 {
+		parameters["columns"] = strings.Join(columns, ",")
+	}
+
+	if maximumIssues > 0 {
+		parameters["maximumIssues"] = strconv.Itoa(maximumIssues)
+	}
+
+	return Macro("jira", parameters, nil)
+}
+
+// UserLink returns a user mention.
+func UserLink(accountID string) *Node {
+	return Element("ac:link", Element("ri:user").SetAttr("ri:account-id", accountID))
+}
+
+// PageLink returns a link to a page, the space key is optional when the page is on the same space
+// and the text is optional.
+func PageLink(spaceKey, title, text string) *Node {
+
+	page := Element("ri:page").SetAttr("ri:content-title", title)
+	if spaceKey != "" {
+		page.SetAttr("ri:space-key", spaceKey)
+	}
+
+	return linkWithBody(page, text)
+}
+
+// AttachmentLink returns a link to an attachment of the page, the text is optional.
+func AttachmentLink(fileName, text string) *Node {
+	return linkWithBody(Element("ri:attachment").SetAttr("ri:filename", fileName), text)
+}
+
+// Image returns an image showing an attachment of the page.
+func Image(fileName string) *Node {
+	return Element("ac:image", Element("ri:attachment").SetAttr("ri:filename", fileName))
+}
+
+// ImageURL returns an image showing an external image.
+func ImageURL(url string) *Node {
+	return Element("ac:image", Element("ri:url").SetAttr("ri:value", url))
+}
+
+// Layout returns a page layout with the sections provided.
+func Layout(sections ...*Node) *Node {
+	return Element("ac:layout", sections...)
+}
+
+// LayoutSection returns a layout section, the number of cells must match the section type.
+func LayoutSection(sectionType string, cells ...*Node) *Node {
+	return Element("ac:layout-section", cells...).SetAttr("ac:type", sectionType)
+}
+
+func LayoutCell(children ...*Node) *Node {
+	return Element("ac:layout-cell", children...)
+}
+
+func linkWithBody(resource *Node, text string) *Node {
+
+	link := Element("ac:link", resource)
+	if text != "" {
+		link.Append(Element("ac:plain-text-link-body", CData(text)))
+	}
+
+	return link
+}
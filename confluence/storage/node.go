@@ -0,0 +1,285 @@
+// Package storage builds and parses the Confluence storage format, the XHTML based representation
+// used on the storage body of the pages and blog posts, e.g. models.BodyScheme.Storage.
+//
+// The documents are trees of nodes, so the existing bodies can be parsed, edited (e.g. a table cell or a
+// macro parameter) and rendered again without string concatenation.
+package storage
+
+import (
+	"strings"
+)
+
+type NodeType int
+
+const (
+	// DocumentNode is the root of a parsed or built document, it's rendered as its children.
+	DocumentNode NodeType = iota
+
+	// ElementNode is an XHTML element or a Confluence element, e.g. p or ac:structured-macro.
+	ElementNode
+
+	// TextNode is a text, it's escaped when it's rendered.
+	TextNode
+
+	// CDataNode is a text rendered as a CDATA section, e.g. the plain text body of the code macro.
+	CDataNode
+
+	// CommentNode is an XML comment.
+	CommentNode
+)
+
+type Attr struct {
+	Name  string
+	Value string
+}
+
+// Node is a node of a storage format document, the element and attribute names keep the ac: and ri: prefixes.
+type Node struct {
+	Type     NodeType
+	Name     string
+	Attrs    []Attr
+	Text     string
+	Children []*Node
+}
+
+// Attr returns the value of an attribute, or an empty string when it's not set.
+func (n *Node) Attr(name string) string {
+
+	for _, attr := range n.Attrs {
+		if attr.Name == name {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
+
+// SetAttr sets the value of an attribute, keeping the attributes order.
+func (n *Node) SetAttr(name, value string) *Node {
+
+	for index := range n.Attrs {
+		if n.Attrs[index].Name == name {
+			n.Attrs[index].Value = value
+			return n
+		}
+	}
+
+	n.Attrs = append(n.Attrs, Attr{Name: name, Value: value})
+	return n
+}
+
+// RemoveAttr removes an attribute.
+func (n *Node) RemoveAttr(name string) *Node {
+
+	attrs := n.Attrs[:0]
+	for _, attr := range n.Attrs {
+		if attr.Name != name {
+			attrs = append(attrs, attr)
+		}
+	}
+
+	n.Attrs = attrs
+	return n
+}
+
+// Append adds the nodes at the end of the children.
+func (n *Node) Append(children ...*Node) *Node {
+	n.Children = append(n.Children, children...)
+	return n
+}
+
+// Replace replaces the children with the nodes provided.
+func (n *Node) Replace(children ...*Node) *Node {
+	n.Children = children
+	return n
+}
+
+// TextContent returns the concatenated text of the node and its descendants.
+func (n *Node) TextContent() string {
+
+	var builder strings.Builder
+	n.Walk(func(node *Node) bool {
+		if node.Type == TextNode || node.Type == CDataNode {
+			builder.WriteString(node.Text)
+		}
+		return true
+	})
+
+	return builder.String()
+}
+
+// SetText replaces the children with a text node, or the CDATA section of the node when it has one.
+func (n *Node) SetText(text string) *Node {
+
+	if n.Type == TextNode || n.Type == CDataNode {
+		n.Text = text
+		return n
+	}
+
+	if len(n.Children) == 1 && n.Children[0].Type == CDataNode {
+		n.Children[0].Text = text
+		return n
+	}
+
+	return n.Replace(Text(text))
+}
+
+// Walk visits the node and its descendants depth-first, the children of a node aren't visited when visit returns false.
+func (n *Node) Walk(visit func(node *Node) bool) {
+
+	if !visit(n) {
+		return
+	}
+
+	for _, child := range n.Children {
+		child.Walk(visit)
+	}
+}
+
+// Find returns the descendants matching the predicate, in document order.
+func (n *Node) Find(match func(node *Node) bool) []*Node {
+
+	var nodes []*Node
+	for _, child := range n.Children {
+		child.Walk(func(node *Node) bool {
+			if match(node) {
+				nodes = append(nodes, node)
+			}
+			return true
+		})
+	}
+
+	return nodes
+}
+
+// FindFirst returns the first descendant matching the predicate, or nil.
+func (n *Node) FindFirst(match func(node *Node) bool) *Node {
+
+	nodes := n.Find(match)
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	return nodes[0]
+}
+
+// Elements returns the descendant elements with the name provided, e.g. table or ri:user.
+func (n *Node) Elements(name string) []*Node {
+	return n.Find(func(node *Node) bool { return node.Type == ElementNode && node.Name == name })
+}
+
+// ChildElements returns the child elements with the name provided, or every child element when the name is empty.
+func (n *Node) ChildElements(name string) []*Node {
+
+	var nodes []*Node
+	for _, child := range n.Children {
+		if child.Type == ElementNode && (name == "" || child.Name == name) {
+			nodes = append(nodes, child)
+		}
+	}
+
+	return nodes
+}
+
+// Macros returns the structured macros with the name provided, or every macro when the name is empty.
+func (n *Node) Macros(name string) []*Node {
+
+	return n.Find(func(node *Node) bool {
+		return node.Type == ElementNode && node.Name == "ac:structured-macro" && (name == "" || node.Attr("ac:name") == name)
+	})
+}
+
+// MacroParameter returns the value of a macro parameter, or an empty string when it's not set.
+func (n *Node) MacroParameter(name string) string {
+
+	for _, parameter := range n.ChildElements("ac:parameter") {
+		if parameter.Attr("ac:name") == name {
+			return parameter.TextContent()
+		}
+	}
+
+	return ""
+}
+
+// SetMacroParameter sets the value of a macro parameter, the parameter is added before the macro body when it's new.
+func (n *Node) SetMacroParameter(name, value string) *Node {
+
+	for _, parameter := range n.ChildElements("ac:parameter") {
+		if parameter.Attr("ac:name") == name {
+			parameter.Replace(Text(value))
+			return n
+		}
+	}
+
+	parameter := Element("ac:parameter", Text(value)).SetAttr("ac:name", name)
+
+	index := len(n.Children)
+	for position, child := range n.Children {
+		if child.Type == ElementNode && (child.Name == "ac:rich-text-body" || child.Name == "ac:plain-text-body") {
+			index = position
+			break
+		}
+	}
+
+	n.Children = append(n.Children[:index], append([]*Node{parameter}, n.Children[index:]...)...)
+	return n
+}
+
+// Rows returns the rows of a table, including the rows inside thead, tbody and tfoot.
+func (n *Node) Rows() []*Node {
+
+	var rows []*Node
+	for _, child := range n.ChildElements("") {
+
+		switch child.Name {
+		case "tr":
+			rows = append(rows, child)
+		case "thead", "tbody", "tfoot":
+			rows = append(rows, child.ChildElements("tr")...)
+		}
+	}
+
+	return rows
+}
+
+// Cell returns the th or td cell of a table on the row and column provided, starting at zero, or nil.
+func (n *Node) Cell(row, column int) *Node {
+
+	rows := n.Rows()
+	if row < 0 || row >= len(rows) {
+		return nil
+	}
+
+	var cells []*Node
+	for _, cell := range rows[row].ChildElements("") {
+		if cell.Name == "td" || cell.Name == "th" {
+			cells = append(cells, cell)
+		}
+	}
+
+	if column < 0 || column >= len(cells) {
+		return nil
+	}
+
+	return cells[column]
+}
+
+// Remove removes the descendants matching the predicate, returning the number of nodes removed.
+func (n *Node) Remove(match func(node *Node) bool) int {
+
+	removed := 0
+	children := n.Children[:0]
+	for _, child := range n.Children {
+
+		if match(child) {
+			removed++
+			continue
+		}
+
+		removed += child.Remove(match)
+		children = append(children, child)
+	}
+
+	n.Children = children
+	return removed
+}
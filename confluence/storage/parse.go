@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrInvalidStorageFormat = errors.New("storage: invalid storage format")
+
+// Parse parses a storage format body into a document node.
+//
+// The HTML entities (e.g. &nbsp;) are decoded, and the CDATA sections are kept, so the plain text
+// macro bodies are rendered as CDATA again.
+func Parse(value string) (*Node, error) {
+
+	// The body is a fragment, the raw tokens are read, so the ac: and ri: prefixes don't need to be declared.
+	wrapped := "<storage>" + value + "</storage>"
+
+	decoder := xml.NewDecoder(strings.NewReader(wrapped))
+	decoder.Strict = true
+	decoder.Entity = xml.HTMLEntity
+
+	var (
+		document = &Node{Type: DocumentNode}
+		stack    []*Node
+		position int64
+	)
+
+	for {
+
+		position = decoder.InputOffset()
+
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStorageFormat, err)
+		}
+
+		var parent *Node
+		if len(stack) > 1 {
+			parent = stack[len(stack)-1]
+		} else {
+			parent = document
+		}
+
+		switch typed := token.(type) {
+
+		case xml.StartElement:
+
+			node := &Node{Type: ElementNode, Name: qualifiedName(typed.Name)}
+			for _, attr := range typed.Attr {
+
+				if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+					continue
+				}
+
+				node.Attrs = append(node.Attrs, Attr{Name: qualifiedName(attr.Name), Value: attr.Value})
+			}
+
+			// The wrapper element isn't added to the document.
+			if len(stack) != 0 {
+				parent.Children = append(parent.Children, node)
+			}
+
+			stack = append(stack, node)
+
+		case xml.EndElement:
+
+			// RawToken doesn't check the elements are balanced.
+			if len(stack) == 0 || stack[len(stack)-1].Name != qualifiedName(typed.Name) {
+				return nil, fmt.Errorf("%w: unexpected end element %v", ErrInvalidStorageFormat, qualifiedName(typed.Name))
+			}
+
+			stack = stack[:len(stack)-1]
+
+		case xml.CharData:
+
+			nodeType := TextNode
+			if strings.HasPrefix(wrapped[position:], "<![CDATA[") {
+				nodeType = CDataNode
+			}
+
+			text := string(typed)
+
+			// The adjacent texts are merged, so the text content of an element is a single node.
+			if count := len(parent.Children); count != 0 && parent.Children[count-1].Type == nodeType && nodeType == TextNode {
+				parent.Children[count-1].Text += text
+				continue
+			}
+
+			parent.Children = append(parent.Children, &Node{Type: nodeType, Text: text})
+
+		case xml.Comment:
+			parent.Children = append(parent.Children, &Node{Type: CommentNode, Text: string(typed)})
+		}
+	}
+
+	if len(stack) != 0 {
+		return nil, fmt.Errorf("%w: unclosed element %v", ErrInvalidStorageFormat, stack[len(stack)-1].Name)
+	}
+
+	return document, nil
+}
+
+// qualifiedName returns the prefixed name, RawToken keeps the prefixes as written on the body.
+func qualifiedName(name xml.Name) string {
+
+	if name.Space == "" {
+		return name.Local
+	}
+
+	return name.Space + ":" + name.Local
+}
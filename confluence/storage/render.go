@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"bufio"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"io"
+	"strings"
+)
+
+// voidElements are the XHTML elements without content, they're rendered self-closed.
+var voidElements = map[string]bool{
+	"br": true, "hr": true, "img": true, "col": true, "area": true, "input": true, "wbr": true,
+}
+
+var (
+	textEscaper      = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	attributeEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+// Render writes the node on the storage format.
+func (n *Node) Render(w io.Writer) error {
+
+	writer := bufio.NewWriter(w)
+	n.render(writer)
+	return writer.Flush()
+}
+
+// String returns the node on the storage format.
+func (n *Node) String() string {
+
+	var builder strings.Builder
+	_ = n.Render(&builder)
+	return builder.String()
+}
+
+// Storage returns the node as a storage body, e.g. to set models.BodyScheme.Storage on ContentService.Create.
+func (n *Node) Storage() *model.BodyNodeScheme {
+	return &model.BodyNodeScheme{Value: n.String(), Representation: "storage"}
+}
+
+func (n *Node) render(writer *bufio.Writer) {
+
+	switch n.Type {
+
+	case TextNode:
+		textEscaper.WriteString(writer, n.Text)
+
+	case CDataNode:
+		// The CDATA end sequence can't be nested, it's split on two sections.
+		writer.WriteString("<![CDATA[")
+		writer.WriteString(strings.ReplaceAll(n.Text, "]]>", "]]]]><![CDATA[>"))
+		writer.WriteString("]]>")
+
+	case CommentNode:
+		writer.WriteString("<!--")
+		writer.WriteString(n.Text)
+		writer.WriteString("-->")
+
+	case DocumentNode:
+		for _, child := range n.Children {
+			child.render(writer)
+		}
+
+	case ElementNode:
+
+		writer.WriteString("<")
+		writer.WriteString(n.Name)
+
+		for _, attr := range n.Attrs {
+			writer.WriteString(" ")
+			writer.WriteString(attr.Name)
+			writer.WriteString(`="`)
+			attributeEscaper.WriteString(writer, attr.Value)
+			writer.WriteString(`"`)
+		}
+
+		// The Confluence elements (e.g. ri:page) are self-closed when they're empty.
+		if len(n.Children) == 0 && (voidElements[n.Name] || strings.Contains(n.Name, ":")) {
+			writer.WriteString(" />")
+			return
+		}
+
+		writer.WriteString(">")
+
+		for _, child := range n.Children {
+			child.render(writer)
+		}
+
+		writer.WriteString("</")
+		writer.WriteString(n.Name)
+		writer.WriteString(">")
+	}
+}
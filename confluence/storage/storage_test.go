@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+
+	document := Document(
+		Heading(2, Text("Release & rollout")),
+		Paragraph(Text("Owner: "), UserLink("5b10ac8d82e05b22cc7d4ef5"), Text(" "), Status("In progress", StatusBlue, false)),
+		Layout(LayoutSection(LayoutTwoEqual,
+			LayoutCell(JiraIssue("KP-1", "")),
+			LayoutCell(PageLink("DUMMY", "Runbook", "the runbook"), Image("diagram.png")),
+		)),
+		CodeBlock("go", `fmt.Println("a < b")`),
+		TextTable([]string{"Service", "Version"}, []string{"api", "1.2"}),
+	)
+
+	expected := `<h2>Release &amp; rollout</h2>` +
+		`<p>Owner: <ac:link><ri:user ri:account-id="5b10ac8d82e05b22cc7d4ef5" /></ac:link> ` +
+		`<ac:structured-macro ac:name="status" ac:schema-version="1"><ac:parameter ac:name="colour">Blue</ac:parameter>` +
+		`<ac:parameter ac:name="title">In progress</ac:parameter></ac:structured-macro></p>` +
+		`<ac:layout><ac:layout-section ac:type="two_equal"><ac:layout-cell>` +
+		`<ac:structured-macro ac:name="jira" ac:schema-version="1"><ac:parameter ac:name="key">KP-1</ac:parameter></ac:structured-macro>` +
+		`</ac:layout-cell><ac:layout-cell>` +
+		`<ac:link><ri:page ri:content-title="Runbook" ri:space-key="DUMMY" /><ac:plain-text-link-body><![CDATA[the runbook]]></ac:plain-text-link-body></ac:link>` +
+		`<ac:image><ri:attachment ri:filename="diagram.png" /></ac:image>` +
+		`</ac:layout-cell></ac:layout-section></ac:layout>` +
+		`<ac:structured-macro ac:name="code" ac:schema-version="1"><ac:parameter ac:name="language">go</ac:parameter>` +
+		`<ac:plain-text-body><![CDATA[fmt.Println("a < b")]]></ac:plain-text-body></ac:structured-macro>` +
+		`<table><tbody><tr><th>Service</th><th>Version</th></tr><tr><td>api</td><td>1.2</td></tr></tbody></table>`
+
+	assert.Equal(t, expected, document.String())
+	assert.Equal(t, "storage", document.Storage().Representation)
+
+	// The rendered document is parsed back to the same tree.
+	parsed, err := Parse(expected)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, parsed.String())
+	assert.Equal(t, document, parsed)
+}
+
+func TestParse(t *testing.T) {
+
+	body := `<p>Hello&nbsp;<strong>team</strong></p>` +
+		`<table><tbody><tr><th>Name</th><th>Status</th></tr>` +
+		`<tr><td>api</td><td><ac:structured-macro ac:name="status" ac:schema-version="1" ac:macro-id="1c2d">` +
+		`<ac:parameter ac:name="title">Red</ac:parameter><ac:parameter ac:name="colour">Red</ac:parameter></ac:structured-macro></td></tr>` +
+		`</tbody></table>` +
+		`<ac:structured-macro ac:name="info" ac:schema-version="1"><ac:rich-text-body><p>Note</p></ac:rich-text-body></ac:structured-macro>` +
+		`<ac:structured-macro ac:name="code"><ac:plain-text-body><![CDATA[if a < b && c {}]]></ac:plain-text-body></ac:structured-macro>` +
+		`<!-- generated -->`
+
+	document, err := Parse(body)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Hello\u00a0team", document.ChildElements("p")[0].TextContent())
+
+	// Update one table cell.
+	table := document.Elements("table")[0]
+	assert.Equal(t, "api", table.Cell(1, 0).TextContent())
+	assert.Nil(t, table.Cell(2, 0))
+
+	table.Cell(1, 0).SetText("api-gateway")
+
+	// Update a macro parameter, and add a new one before the body.
+	status := table.Cell(1, 1).Macros("status")[0]
+	assert.Equal(t, "Red", status.MacroParameter("colour"))
+	status.SetMacroParameter("colour", StatusGreen).SetMacroParameter("title", "Green")
+
+	info := document.Macros("info")[0]
+	info.SetMacroParameter("title", "Heads up")
+
+	code := document.Macros("code")[0]
+	assert.Equal(t, "if a < b && c {}", code.TextContent())
+	code.ChildElements("ac:plain-text-body")[0].SetText("x := 1")
+
+	assert.Len(t, document.Macros(""), 3)
+
+	expected := "<p>Hello\u00a0<strong>team</strong></p>" +
+		`<table><tbody><tr><th>Name</th><th>Status</th></tr>` +
+		`<tr><td>api-gateway</td><td><ac:structured-macro ac:name="status" ac:schema-version="1" ac:macro-id="1c2d">` +
+		`<ac:parameter ac:name="title">Green</ac:parameter><ac:parameter ac:name="colour">Green</ac:parameter></ac:structured-macro></td></tr>` +
+		`</tbody></table>` +
+		`<ac:structured-macro ac:name="info" ac:schema-version="1"><ac:parameter ac:name="title">Heads up</ac:parameter>` +
+		`<ac:rich-text-body><p>Note</p></ac:rich-text-body></ac:structured-macro>` +
+		`<ac:structured-macro ac:name="code"><ac:plain-text-body><![CDATA[x := 1]]></ac:plain-text-body></ac:structured-macro>` +
+		`<!-- generated -->`
+
+	assert.Equal(t, expected, document.String())
+
+	// Remove the macros.
+	assert.Equal(t, 3, document.Remove(func(node *Node) bool { return node.Name == "ac:structured-macro" }))
+	assert.Empty(t, document.Macros(""))
+}
+
+func TestParse_Errors(t *testing.T) {
+
+	for _, body := range []string{"<p>unclosed", "<p></strong>", "<p>&unknown;</p>", "</p>"} {
+
+		_, err := Parse(body)
+		assert.True(t, errors.Is(err, ErrInvalidStorageFormat), body)
+	}
+}
+
+func TestCDataEscaping(t *testing.T) {
+
+	document := Document(PlainTextBody("a ]]> b"))
+
+	parsed, err := Parse(document.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "a ]]> b", parsed.TextContent())
+}
@@ -0,0 +1,248 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/confluence/storage"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type TemplateService struct{ client *Client }
+
+// Create creates a new content template, the template is a global template when the payload has no space.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v1/api-group-template/#api-wiki-rest-api-template-post
+func (t *TemplateService) Create(ctx context.Context, payload *model.ContentTemplatePayloadScheme) (
+	result *model.ContentTemplateScheme, response *ResponseScheme, err error) {
+
+	if payload == nil || len(payload.Name) == 0 {
+		return nil, nil, model.ErrNoTemplateNameError
+	}
+
+	payloadAsReader, err := transformStructToReader(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var endpoint = "/rest/api/template"
+
+	request, err := t.client.newRequest(ctx, http.MethodPost, endpoint, payloadAsReader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err = t.client.Call(request, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Update updates the content template of the payload template id.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v1/api-group-template/#api-wiki-rest-api-template-put
+func (t *TemplateService) Update(ctx context.Context, payload *model.ContentTemplatePayloadScheme) (
+	result *model.ContentTemplateScheme, response *ResponseScheme, err error) {
+
+	if payload == nil || len(payload.TemplateID) == 0 {
+		return nil, nil, model.ErrNoTemplateIDError
+	}
+
+	if len(payload.Name) == 0 {
+		return nil, nil, model.ErrNoTemplateNameError
+	}
+
+	payloadAsReader, err := transformStructToReader(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var endpoint = "/rest/api/template"
+
+	request, err := t.client.newRequest(ctx, http.MethodPut, endpoint, payloadAsReader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err = t.client.Call(request, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Get returns a content template, a page template or a modified blueprint template.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v1/api-group-template/#api-wiki-rest-api-template-contenttemplateid-get
+func (t *TemplateService) Get(ctx context.Context, templateID string) (result *model.ContentTemplateScheme,
+	response *ResponseScheme, err error) {
+
+	if len(templateID) == 0 {
+		return nil, nil, model.ErrNoTemplateIDError
+	}
+
+	var endpoint = fmt.Sprintf("/rest/api/template/%v", templateID)
+
+	request, err := t.client.newRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err = t.client.Call(request, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Remove deletes a content template, the blueprint templates modified are reverted to their original version.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v1/api-group-template/#api-wiki-rest-api-template-contenttemplateid-delete
+func (t *TemplateService) Remove(ctx context.Context, templateID string) (response *ResponseScheme, err error) {
+
+	if len(templateID) == 0 {
+		return nil, model.ErrNoTemplateIDError
+	}
+
+	var endpoint = fmt.Sprintf("/rest/api/template/%v", templateID)
+
+	request, err := t.client.newRequest(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err = t.client.Call(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	return
+}
+
+// Pages returns the page templates of a space, or the global page templates when the space key is empty.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v1/api-group-template/#api-wiki-rest-api-template-page-get
+func (t *TemplateService) Pages(ctx context.Context, spaceKey string, expand []string, startAt, maxResults int) (
+	result *model.ContentTemplatePageScheme, response *ResponseScheme, err error) {
+	return t.list(ctx, "page", spaceKey, expand, startAt, maxResults)
+}
+
+// Blueprints returns the blueprint templates of a space, or the global blueprint templates when the space key is empty.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v1/api-group-template/#api-wiki-rest-api-template-blueprint-get
+func (t *TemplateService) Blueprints(ctx context.Context, spaceKey string, expand []string, startAt, maxResults int) (
+	result *model.ContentTemplatePageScheme, response *ResponseScheme, err error) {
+	return t.list(ctx, "blueprint", spaceKey, expand, startAt, maxResults)
+}
+
+func (t *TemplateService) list(ctx context.Context, templateType, spaceKey string, expand []string, startAt, maxResults int) (
+	result *model.ContentTemplatePageScheme, response *ResponseScheme, err error) {
+
+	query := url.Values{}
+	query.Add("start", strconv.Itoa(startAt))
+	query.Add("limit", strconv.Itoa(maxResults))
+
+	if len(spaceKey) != 0 {
+		query.Add("spaceKey", spaceKey)
+	}
+
+	if len(expand) != 0 {
+		query.Add("expand", strings.Join(expand, ","))
+	}
+
+	var endpoint = fmt.Sprintf("/rest/api/template/%v?%v", templateType, query.Encode())
+
+	request, err := t.client.newRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err = t.client.Call(request, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// CreateContent creates a piece of content from a page template, the payload sets the type, title, space and
+// ancestors of the content, and its body is the template body with its variables replaced by their values.
+// The template variables, e.g. <at:var at:name="owner"/>, without a value return ErrNoTemplateVariableError.
+func (t *TemplateService) CreateContent(ctx context.Context, templateID string, payload *model.ContentScheme,
+	variables map[string]string) (result *model.ContentScheme, response *ResponseScheme, err error) {
+
+	if payload == nil {
+		return nil, nil, model.ErrNoContentTypeError
+	}
+
+	template, response, err := t.Get(ctx, templateID)
+	if err != nil {
+		return nil, response, err
+	}
+
+	body := ""
+	if template.Body != nil && template.Body.Storage != nil {
+		body = template.Body.Storage.Value
+	}
+
+	value, err := ApplyTemplateVariables(body, variables)
+	if err != nil {
+		return nil, response, err
+	}
+
+	content := *payload
+	content.Body = &model.BodyScheme{Storage: &model.BodyNodeScheme{Value: value, Representation: "storage"}}
+
+	return t.client.Content.Create(ctx, &content)
+}
+
+// ApplyTemplateVariables replaces the variables of a template storage body by their values,
+// and removes the declarations of the variables.
+func ApplyTemplateVariables(body string, variables map[string]string) (string, error) {
+
+	document, err := storage.Parse(body)
+	if err != nil {
+		return "", err
+	}
+
+	document.Remove(func(node *storage.Node) bool { return node.Name == "at:declarations" })
+
+	missing := map[string]bool{}
+	for _, variable := range document.Elements("at:var") {
+
+		name := variable.Attr("at:name")
+
+		value, ok := variables[name]
+		if !ok {
+			missing[name] = true
+			continue
+		}
+
+		*variable = *storage.Text(value)
+	}
+
+	if len(missing) != 0 {
+
+		var names []string
+		for name := range missing {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+		return "", fmt.Errorf("%w: %v", model.ErrNoTemplateVariableError, strings.Join(names, ", "))
+	}
+
+	return document.String(), nil
+}
@@ -0,0 +1,420 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestTemplateService_Create(t *testing.T) {
+
+	payload := &model.ContentTemplatePayloadScheme{
+		Name:         "Meeting notes",
+		TemplateType: "page",
+		Body: &model.ContentTemplateBodyScheme{
+			Storage: &model.BodyNodeScheme{
+				Value:          "<p>Owner: <at:var at:name=\"owner\" /></p>",
+				Representation: "storage",
+			},
+		},
+		Labels: []*model.ContentLabelPayloadScheme{{Prefix: "global", Name: "meeting-notes"}},
+		Space:  &model.SpaceScheme{Key: "DUMMY"},
+	}
+
+	testCases := []struct {
+		name               string
+		payload            *model.ContentTemplatePayloadScheme
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+		expectedError      string
+	}{
+		{
+			name:               "when the parameters are correct",
+			payload:            payload,
+			mockFile:           "./mocks/get-template.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/rest/api/template",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+		},
+
+		{
+			name:               "when the template name is not provided",
+			payload:            &model.ContentTemplatePayloadScheme{TemplateType: "page"},
+			mockFile:           "./mocks/get-template.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/rest/api/template",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+			expectedError:      "confluence: no template name set",
+		},
+
+		{
+			name:               "when the context is not provided",
+			payload:            payload,
+			mockFile:           "./mocks/get-template.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/rest/api/template",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+			expectedError:      "request creation failed: net/http: nil Context",
+		},
+
+		{
+			name:               "when the response status is not correct",
+			payload:            payload,
+			mockFile:           "./mocks/get-template.json",
+			wantHTTPMethod:     http.MethodPost,
+			endpoint:           "/rest/api/template",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+			expectedError:      "request failed. Please analyze the request body for more details. Status Code: 400",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			//Init a new HTTP mock server
+			mockOptions := mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			}
+
+			mockServer, err := startMockServer(&mockOptions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			//Init the library instance
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &TemplateService{client: mockClient}
+
+			gotResult, gotResponse, err := service.Create(testCase.context, testCase.payload)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.expectedError)
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.Equal(t, "196609", gotResult.TemplateID)
+				assert.Equal(t, "meeting-notes", gotResult.Labels[0].Name)
+
+				apiEndpoint, err := url.Parse(gotResponse.Endpoint)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				assert.Equal(t, testCase.endpoint, apiEndpoint.Path)
+			}
+		})
+	}
+}
+
+func TestTemplateService_Update(t *testing.T) {
+
+	testCases := []struct {
+		name          string
+		payload       *model.ContentTemplatePayloadScheme
+		wantErr       bool
+		expectedError string
+	}{
+		{
+			name:    "when the parameters are correct",
+			payload: &model.ContentTemplatePayloadScheme{TemplateID: "196609", Name: "Meeting notes", TemplateType: "page"},
+		},
+
+		{
+			name:          "when the template id is not provided",
+			payload:       &model.ContentTemplatePayloadScheme{Name: "Meeting notes"},
+			wantErr:       true,
+			expectedError: "confluence: no template id set",
+		},
+
+		{
+			name:          "when the template name is not provided",
+			payload:       &model.ContentTemplatePayloadScheme{TemplateID: "196609"},
+			wantErr:       true,
+			expectedError: "confluence: no template name set",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			mockServer, err := startMockServer(&mockServerOptions{
+				Endpoint:           "/rest/api/template",
+				MockFilePath:       "./mocks/get-template.json",
+				MethodAccepted:     http.MethodPut,
+				ResponseCodeWanted: http.StatusOK,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &TemplateService{client: mockClient}
+
+			gotResult, _, err := service.Update(context.Background(), testCase.payload)
+
+			if testCase.wantErr {
+				assert.EqualError(t, err, testCase.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Meeting notes", gotResult.Name)
+			}
+		})
+	}
+}
+
+func TestTemplateService_Get(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		templateID         string
+		mockFile           string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+		expectedError      string
+	}{
+		{
+			name:               "when the parameters are correct",
+			templateID:         "196609",
+			mockFile:           "./mocks/get-template.json",
+			endpoint:           "/rest/api/template/196609",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+		},
+
+		{
+			name:               "when the template id is not provided",
+			mockFile:           "./mocks/get-template.json",
+			endpoint:           "/rest/api/template/196609",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+			expectedError:      "confluence: no template id set",
+		},
+
+		{
+			name:               "when the response body is empty",
+			templateID:         "196609",
+			mockFile:           "./mocks/empty-json.json",
+			endpoint:           "/rest/api/template/196609",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+			expectedError:      "unexpected end of JSON input",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			mockServer, err := startMockServer(&mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     http.MethodGet,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &TemplateService{client: mockClient}
+
+			gotResult, gotResponse, err := service.Get(testCase.context, testCase.templateID)
+
+			if testCase.wantErr {
+				assert.EqualError(t, err, testCase.expectedError)
+			} else {
+
+				assert.NoError(t, err)
+				assert.Equal(t, "DUMMY", gotResult.Space.Key)
+				assert.Equal(t, "storage", gotResult.Body.Storage.Representation)
+				assert.Equal(t, testCase.endpoint, gotResponse.Endpoint[len(mockServer.URL):])
+			}
+		})
+	}
+}
+
+func TestTemplateService_Pages(t *testing.T) {
+
+	testCases := []struct {
+		name       string
+		blueprints bool
+		spaceKey   string
+		expand     []string
+		endpoint   string
+	}{
+		{
+			name:     "when the page templates of a space are requested",
+			spaceKey: "DUMMY",
+			expand:   []string{"body"},
+			endpoint: "/rest/api/template/page?expand=body&limit=25&spaceKey=DUMMY&start=0",
+		},
+
+		{
+			name:     "when the global page templates are requested",
+			endpoint: "/rest/api/template/page?limit=25&start=0",
+		},
+
+		{
+			name:       "when the blueprint templates are requested",
+			blueprints: true,
+			spaceKey:   "DUMMY",
+			endpoint:   "/rest/api/template/blueprint?limit=25&spaceKey=DUMMY&start=0",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			mockServer, err := startMockServer(&mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       "./mocks/get-templates.json",
+				MethodAccepted:     http.MethodGet,
+				ResponseCodeWanted: http.StatusOK,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			service := &TemplateService{client: mockClient}
+
+			var gotResult *model.ContentTemplatePageScheme
+			if testCase.blueprints {
+				gotResult, _, err = service.Blueprints(context.Background(), testCase.spaceKey, testCase.expand, 0, 25)
+			} else {
+				gotResult, _, err = service.Pages(context.Background(), testCase.spaceKey, testCase.expand, 0, 25)
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, 2, gotResult.Size)
+			assert.Equal(t, "meeting-notes-item", gotResult.Results[1].OriginalTemplate.ModuleKey)
+		})
+	}
+}
+
+func TestTemplateService_Remove(t *testing.T) {
+
+	mockServer, err := startMockServer(&mockServerOptions{
+		Endpoint:           "/rest/api/template/196609",
+		MethodAccepted:     http.MethodDelete,
+		ResponseCodeWanted: http.StatusNoContent,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer mockServer.Close()
+
+	mockClient, err := startMockClient(mockServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := &TemplateService{client: mockClient}
+
+	gotResponse, err := service.Remove(context.Background(), "196609")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, gotResponse.Code)
+
+	_, err = service.Remove(context.Background(), "")
+	assert.EqualError(t, err, "confluence: no template id set")
+}
+
+func TestTemplateService_CreateContent(t *testing.T) {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/template/196609", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "./mocks/get-template.json")
+	})
+
+	var created string
+	mux.HandleFunc("/rest/api/content", func(w http.ResponseWriter, r *http.Request) {
+
+		var payload model.ContentScheme
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		created = payload.Body.Storage.Value
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"id": "10", "type": "page", "title": "Weekly"}`)
+	})
+
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	mockClient, err := startMockClient(mockServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mockClient.Template = &TemplateService{client: mockClient}
+	mockClient.Content = &ContentService{client: mockClient}
+
+	payload := &model.ContentScheme{Type: "page", Title: "Weekly", Space: &model.SpaceScheme{Key: "DUMMY"}}
+
+	gotResult, _, err := mockClient.Template.CreateContent(context.Background(), "196609", payload, map[string]string{"owner": "Jane & John"})
+	assert.NoError(t, err)
+	assert.Equal(t, "10", gotResult.ID)
+	assert.Equal(t, "<p>Owner: Jane &amp; John</p>", created)
+	assert.Nil(t, payload.Body)
+
+	_, _, err = mockClient.Template.CreateContent(context.Background(), "196609", payload, nil)
+	assert.True(t, errors.Is(err, model.ErrNoTemplateVariableError))
+	assert.EqualError(t, err, "confluence: no template variable value set: owner")
+}
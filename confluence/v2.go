@@ -0,0 +1,242 @@
+package confluence
+
+import (
+	"context"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// V2Service groups the Confluence REST API v2 resources, the requests share the authentication and the HTTP client
+// of the v1 services. The collections are paginated with a cursor instead of an offset.
+type V2Service struct {
+	Page     *V2PageService
+	BlogPost *V2BlogPostService
+	Space    *V2SpaceService
+	Comment  *V2CommentService
+	Label    *V2LabelService
+	Version  *V2VersionService
+	Property *V2PropertyService
+}
+
+func newV2Service(client *Client) *V2Service {
+
+	return &V2Service{
+		Page:     &V2PageService{client: client},
+		BlogPost: &V2BlogPostService{client: client},
+		Space:    &V2SpaceService{client: client},
+		Comment:  &V2CommentService{client: client},
+		Label:    &V2LabelService{client: client},
+		Version:  &V2VersionService{client: client},
+		Property: &V2PropertyService{client: client},
+	}
+}
+
+// callV2 sends a request to the v2 API, encoding the payload and decoding the result when they're set.
+func (c *Client) callV2(ctx context.Context, method, endpoint string, payload, result interface{}) (*ResponseScheme, error) {
+
+	var payloadAsReader io.Reader
+	if payload != nil {
+
+		reader, err := transformStructToReader(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		payloadAsReader = reader
+	}
+
+	request, err := c.newRequest(ctx, method, endpoint, payloadAsReader)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	return c.Call(request, result)
+}
+
+// cursorQuery returns the query of a paginated request, the cursor is omitted on the first page.
+func cursorQuery(cursor string, limit int) url.Values {
+
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	return query
+}
+
+// NextCursor returns the cursor of the next page, or an empty string on the last page.
+// The cursor is read from the Link header, e.g. </wiki/api/v2/pages?cursor=abc>; rel="next", and from the
+// _links.next of the body when the header isn't returned.
+func NextCursor(response *ResponseScheme, links *models.ChunkLinksSchemeV2) string {
+
+	if response != nil {
+		for _, header := range response.Headers["Link"] {
+			for _, link := range strings.Split(header, ",") {
+
+				parts := strings.Split(link, ";")
+				if len(parts) < 2 {
+					continue
+				}
+
+				isNext := false
+				for _, parameter := range parts[1:] {
+					if strings.ReplaceAll(strings.TrimSpace(parameter), " ", "") == `rel="next"` {
+						isNext = true
+					}
+				}
+
+				if !isNext {
+					continue
+				}
+
+				target := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+				if cursor := cursorFromLink(target); cursor != "" {
+					return cursor
+				}
+			}
+		}
+	}
+
+	if links != nil {
+		return cursorFromLink(links.Next)
+	}
+
+	return ""
+}
+
+func cursorFromLink(link string) string {
+
+	if link == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+
+	return parsed.Query().Get("cursor")
+}
+
+func isValidContentTypeV2(contentType string) bool {
+	return contentType == models.ContentTypePagesV2 || contentType == models.ContentTypeBlogPostsV2
+}
+
+// cursorIterator fetches the pages of a v2 collection when the buffered results are consumed.
+type cursorIterator struct {
+	ctx      context.Context
+	fetch    func(ctx context.Context, cursor string) (results []interface{}, next string, response *ResponseScheme, err error)
+	buffer   []interface{}
+	current  interface{}
+	cursor   string
+	done     bool
+	response *ResponseScheme
+	err      error
+}
+
+func (c *cursorIterator) next() bool {
+
+	for len(c.buffer) == 0 {
+
+		if c.done || c.err != nil {
+			c.current = nil
+			return false
+		}
+
+		results, next, response, err := c.fetch(c.ctx, c.cursor)
+		c.response = response
+		if err != nil {
+			c.err = err
+			c.current = nil
+			return false
+		}
+
+		c.buffer, c.cursor, c.done = results, next, next == ""
+	}
+
+	c.current, c.buffer = c.buffer[0], c.buffer[1:]
+	return true
+}
+
+// PageIteratorV2 iterates over the pages of a collection, Next must be called before each Page.
+type PageIteratorV2 struct{ iterator *cursorIterator }
+
+func (p *PageIteratorV2) Next() bool { return p.iterator.next() }
+func (p *PageIteratorV2) Page() *models.PageSchemeV2 {
+	return p.iterator.current.(*models.PageSchemeV2)
+}
+func (p *PageIteratorV2) Response() *ResponseScheme { return p.iterator.response }
+func (p *PageIteratorV2) Err() error                { return p.iterator.err }
+
+// BlogPostIteratorV2 iterates over the blog posts of a collection, Next must be called before each BlogPost.
+type BlogPostIteratorV2 struct{ iterator *cursorIterator }
+
+func (b *BlogPostIteratorV2) Next() bool { return b.iterator.next() }
+func (b *BlogPostIteratorV2) BlogPost() *models.BlogPostSchemeV2 {
+	return b.iterator.current.(*models.BlogPostSchemeV2)
+}
+func (b *BlogPostIteratorV2) Response() *ResponseScheme { return b.iterator.response }
+func (b *BlogPostIteratorV2) Err() error                { return b.iterator.err }
+
+// SpaceIteratorV2 iterates over the spaces of a collection, Next must be called before each Space.
+type SpaceIteratorV2 struct{ iterator *cursorIterator }
+
+func (s *SpaceIteratorV2) Next() bool { return s.iterator.next() }
+func (s *SpaceIteratorV2) Space() *models.SpaceSchemeV2 {
+	return s.iterator.current.(*models.SpaceSchemeV2)
+}
+func (s *SpaceIteratorV2) Response() *ResponseScheme { return s.iterator.response }
+func (s *SpaceIteratorV2) Err() error                { return s.iterator.err }
+
+// CommentIteratorV2 iterates over the comments of a collection, Next must be called before each Comment.
+type CommentIteratorV2 struct{ iterator *cursorIterator }
+
+func (c *CommentIteratorV2) Next() bool { return c.iterator.next() }
+func (c *CommentIteratorV2) Comment() *models.CommentSchemeV2 {
+	return c.iterator.current.(*models.CommentSchemeV2)
+}
+func (c *CommentIteratorV2) Response() *ResponseScheme { return c.iterator.response }
+func (c *CommentIteratorV2) Err() error                { return c.iterator.err }
+
+// LabelIteratorV2 iterates over the labels of a collection, Next must be called before each Label.
+type LabelIteratorV2 struct{ iterator *cursorIterator }
+
+func (l *LabelIteratorV2) Next() bool { return l.iterator.next() }
+func (l *LabelIteratorV2) Label() *models.LabelSchemeV2 {
+	return l.iterator.current.(*models.LabelSchemeV2)
+}
+func (l *LabelIteratorV2) Response() *ResponseScheme { return l.iterator.response }
+func (l *LabelIteratorV2) Err() error                { return l.iterator.err }
+
+// VersionIteratorV2 iterates over the versions of a collection, Next must be called before each Version.
+type VersionIteratorV2 struct{ iterator *cursorIterator }
+
+func (v *VersionIteratorV2) Next() bool { return v.iterator.next() }
+func (v *VersionIteratorV2) Version() *models.VersionSchemeV2 {
+	return v.iterator.current.(*models.VersionSchemeV2)
+}
+func (v *VersionIteratorV2) Response() *ResponseScheme { return v.iterator.response }
+func (v *VersionIteratorV2) Err() error                { return v.iterator.err }
+
+// PropertyIteratorV2 iterates over the content properties of a collection, Next must be called before each Property.
+type PropertyIteratorV2 struct{ iterator *cursorIterator }
+
+func (p *PropertyIteratorV2) Next() bool { return p.iterator.next() }
+func (p *PropertyIteratorV2) Property() *models.ContentPropertySchemeV2 {
+	return p.iterator.current.(*models.ContentPropertySchemeV2)
+}
+func (p *PropertyIteratorV2) Response() *ResponseScheme { return p.iterator.response }
+func (p *PropertyIteratorV2) Err() error                { return p.iterator.err }
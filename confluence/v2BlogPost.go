@@ -0,0 +1,126 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"net/http"
+)
+
+type V2BlogPostService struct{ client *Client }
+
+// Gets returns the blog posts matching the options, the cursor of the next page is returned by NextCursor.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-blog-post/#api-blogposts-get
+func (b *V2BlogPostService) Gets(ctx context.Context, options *models.ContentGetsOptionsSchemeV2, cursor string, limit int) (
+	result *models.BlogPostChunkSchemeV2, response *ResponseScheme, err error) {
+
+	query := contentGetsQueryV2(options, cursor, limit)
+	endpoint := fmt.Sprintf("/wiki/api/v2/blogposts?%v", query.Encode())
+
+	response, err = b.client.callV2(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Iterate returns an iterator over the blog posts matching the options.
+func (b *V2BlogPostService) Iterate(ctx context.Context, options *models.ContentGetsOptionsSchemeV2, limit int) *BlogPostIteratorV2 {
+
+	return &BlogPostIteratorV2{iterator: &cursorIterator{
+		ctx: ctx,
+		fetch: func(ctx context.Context, cursor string) ([]interface{}, string, *ResponseScheme, error) {
+
+			chunk, response, err := b.Gets(ctx, options, cursor, limit)
+			if err != nil {
+				return nil, "", response, err
+			}
+
+			results := make([]interface{}, len(chunk.Results))
+			for index, post := range chunk.Results {
+				results[index] = post
+			}
+
+			return results, NextCursor(response, chunk.Links), response, nil
+		},
+	}}
+}
+
+// Get returns a specific blog post.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-blog-post/#api-blogposts-id-get
+func (b *V2BlogPostService) Get(ctx context.Context, blogPostID string, options *models.ContentGetOptionsSchemeV2) (
+	result *models.BlogPostSchemeV2, response *ResponseScheme, err error) {
+
+	if len(blogPostID) == 0 {
+		return nil, nil, models.ErrNoContentIDError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/blogposts/%v", blogPostID)
+	if query := contentGetQueryV2(options); query.Encode() != "" {
+		endpoint = fmt.Sprintf("%v?%v", endpoint, query.Encode())
+	}
+
+	response, err = b.client.callV2(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Create creates a blog post in the space.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-blog-post/#api-blogposts-post
+func (b *V2BlogPostService) Create(ctx context.Context, payload *models.ContentCreatePayloadSchemeV2) (
+	result *models.BlogPostSchemeV2, response *ResponseScheme, err error) {
+
+	if payload == nil {
+		return nil, nil, structureNotParsedError
+	}
+
+	if len(payload.SpaceID) == 0 {
+		return nil, nil, models.ErrNoSpaceIDError
+	}
+
+	response, err = b.client.callV2(ctx, http.MethodPost, "/wiki/api/v2/blogposts", payload, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Update updates a blog post, the version number of the payload must be the current version number plus one.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-blog-post/#api-blogposts-id-put
+func (b *V2BlogPostService) Update(ctx context.Context, blogPostID string, payload *models.ContentUpdatePayloadSchemeV2) (
+	result *models.BlogPostSchemeV2, response *ResponseScheme, err error) {
+
+	if len(blogPostID) == 0 {
+		return nil, nil, models.ErrNoContentIDError
+	}
+
+	if payload == nil {
+		return nil, nil, structureNotParsedError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/blogposts/%v", blogPostID)
+
+	response, err = b.client.callV2(ctx, http.MethodPut, endpoint, payload, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Delete deletes a blog post, the blog post is moved to the trash.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-blog-post/#api-blogposts-id-delete
+func (b *V2BlogPostService) Delete(ctx context.Context, blogPostID string) (response *ResponseScheme, err error) {
+
+	if len(blogPostID) == 0 {
+		return nil, models.ErrNoContentIDError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/blogposts/%v", blogPostID)
+	return b.client.callV2(ctx, http.MethodDelete, endpoint, nil, nil)
+}
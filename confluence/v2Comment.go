@@ -0,0 +1,145 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"net/http"
+	"net/url"
+)
+
+type V2CommentService struct{ client *Client }
+
+// Gets returns the footer comments of a page or a blog post, the content type is models.ContentTypePagesV2 or
+// models.ContentTypeBlogPostsV2, the cursor of the next page is returned by NextCursor.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-pages-id-footer-comments-get
+func (c *V2CommentService) Gets(ctx context.Context, contentType, contentID, bodyFormat, cursor string, limit int) (
+	result *models.CommentChunkSchemeV2, response *ResponseScheme, err error) {
+
+	if !isValidContentTypeV2(contentType) {
+		return nil, nil, models.ErrInvalidContentTypeV2Error
+	}
+
+	if len(contentID) == 0 {
+		return nil, nil, models.ErrNoContentIDError
+	}
+
+	query := cursorQuery(cursor, limit)
+	if bodyFormat != "" {
+		query.Set("body-format", bodyFormat)
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/%v/%v/footer-comments", contentType, contentID)
+	if query.Encode() != "" {
+		endpoint = fmt.Sprintf("%v?%v", endpoint, query.Encode())
+	}
+
+	response, err = c.client.callV2(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Iterate returns an iterator over the footer comments of a page or a blog post.
+func (c *V2CommentService) Iterate(ctx context.Context, contentType, contentID, bodyFormat string, limit int) *CommentIteratorV2 {
+
+	return &CommentIteratorV2{iterator: &cursorIterator{
+		ctx: ctx,
+		fetch: func(ctx context.Context, cursor string) ([]interface{}, string, *ResponseScheme, error) {
+
+			chunk, response, err := c.Gets(ctx, contentType, contentID, bodyFormat, cursor, limit)
+			if err != nil {
+				return nil, "", response, err
+			}
+
+			results := make([]interface{}, len(chunk.Results))
+			for index, comment := range chunk.Results {
+				results[index] = comment
+			}
+
+			return results, NextCursor(response, chunk.Links), response, nil
+		},
+	}}
+}
+
+// Get returns a specific footer comment.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-footer-comments-comment-id-get
+func (c *V2CommentService) Get(ctx context.Context, commentID, bodyFormat string) (result *models.CommentSchemeV2,
+	response *ResponseScheme, err error) {
+
+	if len(commentID) == 0 {
+		return nil, nil, models.ErrNoContentCommentIDError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/footer-comments/%v", commentID)
+	if bodyFormat != "" {
+		query := url.Values{}
+		query.Set("body-format", bodyFormat)
+		endpoint = fmt.Sprintf("%v?%v", endpoint, query.Encode())
+	}
+
+	response, err = c.client.callV2(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Create creates a footer comment on a page or a blog post, or a reply when the parent comment is set.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-footer-comments-post
+func (c *V2CommentService) Create(ctx context.Context, payload *models.CommentCreatePayloadSchemeV2) (
+	result *models.CommentSchemeV2, response *ResponseScheme, err error) {
+
+	if payload == nil {
+		return nil, nil, structureNotParsedError
+	}
+
+	if payload.PageID == "" && payload.BlogPostID == "" && payload.ParentCommentID == "" {
+		return nil, nil, models.ErrNoContentIDError
+	}
+
+	response, err = c.client.callV2(ctx, http.MethodPost, "/wiki/api/v2/footer-comments", payload, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Update updates a footer comment, the version number of the payload must be the current version number plus one.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-footer-comments-comment-id-put
+func (c *V2CommentService) Update(ctx context.Context, commentID string, payload *models.CommentUpdatePayloadSchemeV2) (
+	result *models.CommentSchemeV2, response *ResponseScheme, err error) {
+
+	if len(commentID) == 0 {
+		return nil, nil, models.ErrNoContentCommentIDError
+	}
+
+	if payload == nil {
+		return nil, nil, structureNotParsedError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/footer-comments/%v", commentID)
+
+	response, err = c.client.callV2(ctx, http.MethodPut, endpoint, payload, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Delete deletes a footer comment.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-comment/#api-footer-comments-comment-id-delete
+func (c *V2CommentService) Delete(ctx context.Context, commentID string) (response *ResponseScheme, err error) {
+
+	if len(commentID) == 0 {
+		return nil, models.ErrNoContentCommentIDError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/footer-comments/%v", commentID)
+	return c.client.callV2(ctx, http.MethodDelete, endpoint, nil, nil)
+}
@@ -0,0 +1,64 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"net/http"
+)
+
+type V2LabelService struct{ client *Client }
+
+// Gets returns the labels of a page, a blog post or a space, the content type is models.ContentTypePagesV2,
+// models.ContentTypeBlogPostsV2 or models.ContentTypeSpacesV2, the labels are filtered by prefix when it's set.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-label/#api-pages-id-labels-get
+func (l *V2LabelService) Gets(ctx context.Context, contentType, contentID, prefix, cursor string, limit int) (
+	result *models.LabelChunkSchemeV2, response *ResponseScheme, err error) {
+
+	if !isValidContentTypeV2(contentType) && contentType != models.ContentTypeSpacesV2 {
+		return nil, nil, models.ErrInvalidContentTypeV2Error
+	}
+
+	if len(contentID) == 0 {
+		return nil, nil, models.ErrNoContentIDError
+	}
+
+	query := cursorQuery(cursor, limit)
+	if prefix != "" {
+		query.Set("prefix", prefix)
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/%v/%v/labels", contentType, contentID)
+	if query.Encode() != "" {
+		endpoint = fmt.Sprintf("%v?%v", endpoint, query.Encode())
+	}
+
+	response, err = l.client.callV2(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Iterate returns an iterator over the labels of a page, a blog post or a space.
+func (l *V2LabelService) Iterate(ctx context.Context, contentType, contentID, prefix string, limit int) *LabelIteratorV2 {
+
+	return &LabelIteratorV2{iterator: &cursorIterator{
+		ctx: ctx,
+		fetch: func(ctx context.Context, cursor string) ([]interface{}, string, *ResponseScheme, error) {
+
+			chunk, response, err := l.Gets(ctx, contentType, contentID, prefix, cursor, limit)
+			if err != nil {
+				return nil, "", response, err
+			}
+
+			results := make([]interface{}, len(chunk.Results))
+			for index, label := range chunk.Results {
+				results[index] = label
+			}
+
+			return results, NextCursor(response, chunk.Links), response, nil
+		},
+	}}
+}
@@ -0,0 +1,203 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type V2PageService struct{ client *Client }
+
+// Gets returns the pages matching the options, the cursor of the next page is returned by NextCursor.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-page/#api-pages-get
+func (p *V2PageService) Gets(ctx context.Context, options *models.ContentGetsOptionsSchemeV2, cursor string, limit int) (
+	result *models.PageChunkSchemeV2, response *ResponseScheme, err error) {
+
+	query := contentGetsQueryV2(options, cursor, limit)
+	endpoint := fmt.Sprintf("/wiki/api/v2/pages?%v", query.Encode())
+
+	response, err = p.client.callV2(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Iterate returns an iterator over the pages matching the options, the pages are requested while the iterator advances.
+func (p *V2PageService) Iterate(ctx context.Context, options *models.ContentGetsOptionsSchemeV2, limit int) *PageIteratorV2 {
+
+	return &PageIteratorV2{iterator: &cursorIterator{
+		ctx: ctx,
+		fetch: func(ctx context.Context, cursor string) ([]interface{}, string, *ResponseScheme, error) {
+
+			chunk, response, err := p.Gets(ctx, options, cursor, limit)
+			if err != nil {
+				return nil, "", response, err
+			}
+
+			results := make([]interface{}, len(chunk.Results))
+			for index, page := range chunk.Results {
+				results[index] = page
+			}
+
+			return results, NextCursor(response, chunk.Links), response, nil
+		},
+	}}
+}
+
+// Get returns a specific page.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-page/#api-pages-id-get
+func (p *V2PageService) Get(ctx context.Context, pageID string, options *models.ContentGetOptionsSchemeV2) (
+	result *models.PageSchemeV2, response *ResponseScheme, err error) {
+
+	if len(pageID) == 0 {
+		return nil, nil, models.ErrNoContentIDError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/pages/%v", pageID)
+	if query := contentGetQueryV2(options); query.Encode() != "" {
+		endpoint = fmt.Sprintf("%v?%v", endpoint, query.Encode())
+	}
+
+	response, err = p.client.callV2(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Create creates a page in the space, the page is created on the root of the space when the parent isn't set.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-page/#api-pages-post
+func (p *V2PageService) Create(ctx context.Context, payload *models.ContentCreatePayloadSchemeV2) (result *models.PageSchemeV2,
+	response *ResponseScheme, err error) {
+
+	if payload == nil {
+		return nil, nil, structureNotParsedError
+	}
+
+	if len(payload.SpaceID) == 0 {
+		return nil, nil, models.ErrNoSpaceIDError
+	}
+
+	response, err = p.client.callV2(ctx, http.MethodPost, "/wiki/api/v2/pages", payload, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Update updates a page, the version number of the payload must be the current version number plus one.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-page/#api-pages-id-put
+func (p *V2PageService) Update(ctx context.Context, pageID string, payload *models.ContentUpdatePayloadSchemeV2) (
+	result *models.PageSchemeV2, response *ResponseScheme, err error) {
+
+	if len(pageID) == 0 {
+		return nil, nil, models.ErrNoContentIDError
+	}
+
+	if payload == nil {
+		return nil, nil, structureNotParsedError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/pages/%v", pageID)
+
+	response, err = p.client.callV2(ctx, http.MethodPut, endpoint, payload, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Delete deletes a page, the page is moved to the trash.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-page/#api-pages-id-delete
+func (p *V2PageService) Delete(ctx context.Context, pageID string) (response *ResponseScheme, err error) {
+
+	if len(pageID) == 0 {
+		return nil, models.ErrNoContentIDError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/pages/%v", pageID)
+	return p.client.callV2(ctx, http.MethodDelete, endpoint, nil, nil)
+}
+
+// Children returns the child pages of a page, the cursor of the next page is returned by NextCursor.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-children/#api-pages-id-children-get
+func (p *V2PageService) Children(ctx context.Context, pageID, cursor string, limit int) (result *models.PageChunkSchemeV2,
+	response *ResponseScheme, err error) {
+
+	if len(pageID) == 0 {
+		return nil, nil, models.ErrNoContentIDError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/pages/%v/children", pageID)
+	if query := cursorQuery(cursor, limit); query.Encode() != "" {
+		endpoint = fmt.Sprintf("%v?%v", endpoint, query.Encode())
+	}
+
+	response, err = p.client.callV2(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+func contentGetsQueryV2(options *models.ContentGetsOptionsSchemeV2, cursor string, limit int) url.Values {
+
+	query := cursorQuery(cursor, limit)
+	if options == nil {
+		return query
+	}
+
+	if len(options.SpaceIDs) != 0 {
+		query.Set("space-id", strings.Join(options.SpaceIDs, ","))
+	}
+
+	if options.Title != "" {
+		query.Set("title", options.Title)
+	}
+
+	if len(options.Status) != 0 {
+		query.Set("status", strings.Join(options.Status, ","))
+	}
+
+	if options.BodyFormat != "" {
+		query.Set("body-format", options.BodyFormat)
+	}
+
+	if options.Sort != "" {
+		query.Set("sort", options.Sort)
+	}
+
+	return query
+}
+
+func contentGetQueryV2(options *models.ContentGetOptionsSchemeV2) url.Values {
+
+	query := url.Values{}
+	if options == nil {
+		return query
+	}
+
+	if options.BodyFormat != "" {
+		query.Set("body-format", options.BodyFormat)
+	}
+
+	if options.Version != 0 {
+		query.Set("version", strconv.Itoa(options.Version))
+	}
+
+	if options.GetDraft {
+		query.Set("get-draft", "true")
+	}
+
+	return query
+}
@@ -0,0 +1,169 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"net/http"
+)
+
+type V2PropertyService struct{ client *Client }
+
+// Gets returns the content properties of a page or a blog post, the content type is models.ContentTypePagesV2 or
+// models.ContentTypeBlogPostsV2, the properties are filtered by key when it's set.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-content-properties/#api-pages-page-id-properties-get
+func (p *V2PropertyService) Gets(ctx context.Context, contentType, contentID, key, cursor string, limit int) (
+	result *models.ContentPropertyChunkSchemeV2, response *ResponseScheme, err error) {
+
+	if !isValidContentTypeV2(contentType) {
+		return nil, nil, models.ErrInvalidContentTypeV2Error
+	}
+
+	if len(contentID) == 0 {
+		return nil, nil, models.ErrNoContentIDError
+	}
+
+	query := cursorQuery(cursor, limit)
+	if key != "" {
+		query.Set("key", key)
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/%v/%v/properties", contentType, contentID)
+	if query.Encode() != "" {
+		endpoint = fmt.Sprintf("%v?%v", endpoint, query.Encode())
+	}
+
+	response, err = p.client.callV2(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Iterate returns an iterator over the content properties of a page or a blog post.
+func (p *V2PropertyService) Iterate(ctx context.Context, contentType, contentID, key string, limit int) *PropertyIteratorV2 {
+
+	return &PropertyIteratorV2{iterator: &cursorIterator{
+		ctx: ctx,
+		fetch: func(ctx context.Context, cursor string) ([]interface{}, string, *ResponseScheme, error) {
+
+			chunk, response, err := p.Gets(ctx, contentType, contentID, key, cursor, limit)
+			if err != nil {
+				return nil, "", response, err
+			}
+
+			results := make([]interface{}, len(chunk.Results))
+			for index, property := range chunk.Results {
+				results[index] = property
+			}
+
+			return results, NextCursor(response, chunk.Links), response, nil
+		},
+	}}
+}
+
+// Get returns a specific content property of a page or a blog post.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-content-properties/#api-pages-page-id-properties-property-id-get
+func (p *V2PropertyService) Get(ctx context.Context, contentType, contentID, propertyID string) (
+	result *models.ContentPropertySchemeV2, response *ResponseScheme, err error) {
+
+	if !isValidContentTypeV2(contentType) {
+		return nil, nil, models.ErrInvalidContentTypeV2Error
+	}
+
+	if len(contentID) == 0 {
+		return nil, nil, models.ErrNoContentIDError
+	}
+
+	if len(propertyID) == 0 {
+		return nil, nil, models.ErrNoContentPropertyIDError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/%v/%v/properties/%v", contentType, contentID, propertyID)
+
+	response, err = p.client.callV2(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Create creates a content property on a page or a blog post.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-content-properties/#api-pages-page-id-properties-post
+func (p *V2PropertyService) Create(ctx context.Context, contentType, contentID string, payload *models.ContentPropertyPayloadSchemeV2) (
+	result *models.ContentPropertySchemeV2, response *ResponseScheme, err error) {
+
+	if !isValidContentTypeV2(contentType) {
+		return nil, nil, models.ErrInvalidContentTypeV2Error
+	}
+
+	if len(contentID) == 0 {
+		return nil, nil, models.ErrNoContentIDError
+	}
+
+	if payload == nil || payload.Key == "" {
+		return nil, nil, models.ErrNoContentPropertyError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/%v/%v/properties", contentType, contentID)
+
+	response, err = p.client.callV2(ctx, http.MethodPost, endpoint, payload, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Update updates a content property, the version number of the payload must be the current version number plus one.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-content-properties/#api-pages-page-id-properties-property-id-put
+func (p *V2PropertyService) Update(ctx context.Context, contentType, contentID, propertyID string,
+	payload *models.ContentPropertyPayloadSchemeV2) (result *models.ContentPropertySchemeV2, response *ResponseScheme, err error) {
+
+	if !isValidContentTypeV2(contentType) {
+		return nil, nil, models.ErrInvalidContentTypeV2Error
+	}
+
+	if len(contentID) == 0 {
+		return nil, nil, models.ErrNoContentIDError
+	}
+
+	if len(propertyID) == 0 {
+		return nil, nil, models.ErrNoContentPropertyIDError
+	}
+
+	if payload == nil || payload.Key == "" {
+		return nil, nil, models.ErrNoContentPropertyError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/%v/%v/properties/%v", contentType, contentID, propertyID)
+
+	response, err = p.client.callV2(ctx, http.MethodPut, endpoint, payload, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Delete deletes a content property of a page or a blog post.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-content-properties/#api-pages-page-id-properties-property-id-delete
+func (p *V2PropertyService) Delete(ctx context.Context, contentType, contentID, propertyID string) (response *ResponseScheme, err error) {
+
+	if !isValidContentTypeV2(contentType) {
+		return nil, models.ErrInvalidContentTypeV2Error
+	}
+
+	if len(contentID) == 0 {
+		return nil, models.ErrNoContentIDError
+	}
+
+	if len(propertyID) == 0 {
+		return nil, models.ErrNoContentPropertyIDError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/%v/%v/properties/%v", contentType, contentID, propertyID)
+	return p.client.callV2(ctx, http.MethodDelete, endpoint, nil, nil)
+}
@@ -0,0 +1,105 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type V2SpaceService struct{ client *Client }
+
+// Gets returns the spaces matching the options, the cursor of the next page is returned by NextCursor.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-space/#api-spaces-get
+func (s *V2SpaceService) Gets(ctx context.Context, options *models.GetSpacesOptionSchemeV2, cursor string, limit int) (
+	result *models.SpaceChunkSchemeV2, response *ResponseScheme, err error) {
+
+	query := cursorQuery(cursor, limit)
+	if options != nil {
+
+		if len(options.IDs) != 0 {
+			query.Set("ids", strings.Join(options.IDs, ","))
+		}
+
+		if len(options.Keys) != 0 {
+			query.Set("keys", strings.Join(options.Keys, ","))
+		}
+
+		if options.Type != "" {
+			query.Set("type", options.Type)
+		}
+
+		if options.Status != "" {
+			query.Set("status", options.Status)
+		}
+
+		if len(options.Labels) != 0 {
+			query.Set("labels", strings.Join(options.Labels, ","))
+		}
+
+		if options.Sort != "" {
+			query.Set("sort", options.Sort)
+		}
+
+		if options.DescriptionFormat != "" {
+			query.Set("description-format", options.DescriptionFormat)
+		}
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/spaces?%v", query.Encode())
+
+	response, err = s.client.callV2(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Iterate returns an iterator over the spaces matching the options.
+func (s *V2SpaceService) Iterate(ctx context.Context, options *models.GetSpacesOptionSchemeV2, limit int) *SpaceIteratorV2 {
+
+	return &SpaceIteratorV2{iterator: &cursorIterator{
+		ctx: ctx,
+		fetch: func(ctx context.Context, cursor string) ([]interface{}, string, *ResponseScheme, error) {
+
+			chunk, response, err := s.Gets(ctx, options, cursor, limit)
+			if err != nil {
+				return nil, "", response, err
+			}
+
+			results := make([]interface{}, len(chunk.Results))
+			for index, space := range chunk.Results {
+				results[index] = space
+			}
+
+			return results, NextCursor(response, chunk.Links), response, nil
+		},
+	}}
+}
+
+// Get returns a specific space, the description is returned when the format is set, e.g. plain or view.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-space/#api-spaces-id-get
+func (s *V2SpaceService) Get(ctx context.Context, spaceID, descriptionFormat string) (result *models.SpaceSchemeV2,
+	response *ResponseScheme, err error) {
+
+	if len(spaceID) == 0 {
+		return nil, nil, models.ErrNoSpaceIDError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/spaces/%v", spaceID)
+	if descriptionFormat != "" {
+		query := url.Values{}
+		query.Set("description-format", descriptionFormat)
+		endpoint = fmt.Sprintf("%v?%v", endpoint, query.Encode())
+	}
+
+	response, err = s.client.callV2(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
@@ -0,0 +1,82 @@
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"net/http"
+)
+
+type V2VersionService struct{ client *Client }
+
+// Gets returns the versions of a page or a blog post, the content type is models.ContentTypePagesV2 or
+// models.ContentTypeBlogPostsV2, the cursor of the next page is returned by NextCursor.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-version/#api-pages-id-versions-get
+func (v *V2VersionService) Gets(ctx context.Context, contentType, contentID, cursor string, limit int) (
+	result *models.VersionChunkSchemeV2, response *ResponseScheme, err error) {
+
+	if !isValidContentTypeV2(contentType) {
+		return nil, nil, models.ErrInvalidContentTypeV2Error
+	}
+
+	if len(contentID) == 0 {
+		return nil, nil, models.ErrNoContentIDError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/%v/%v/versions", contentType, contentID)
+	if query := cursorQuery(cursor, limit); query.Encode() != "" {
+		endpoint = fmt.Sprintf("%v?%v", endpoint, query.Encode())
+	}
+
+	response, err = v.client.callV2(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
+
+// Iterate returns an iterator over the versions of a page or a blog post.
+func (v *V2VersionService) Iterate(ctx context.Context, contentType, contentID string, limit int) *VersionIteratorV2 {
+
+	return &VersionIteratorV2{iterator: &cursorIterator{
+		ctx: ctx,
+		fetch: func(ctx context.Context, cursor string) ([]interface{}, string, *ResponseScheme, error) {
+
+			chunk, response, err := v.Gets(ctx, contentType, contentID, cursor, limit)
+			if err != nil {
+				return nil, "", response, err
+			}
+
+			results := make([]interface{}, len(chunk.Results))
+			for index, version := range chunk.Results {
+				results[index] = version
+			}
+
+			return results, NextCursor(response, chunk.Links), response, nil
+		},
+	}}
+}
+
+// Get returns a specific version of a page or a blog post.
+// Docs: https://developer.atlassian.com/cloud/confluence/rest/v2/api-group-version/#api-pages-page-id-versions-version-number-get
+func (v *V2VersionService) Get(ctx context.Context, contentType, contentID string, versionNumber int) (
+	result *models.VersionSchemeV2, response *ResponseScheme, err error) {
+
+	if !isValidContentTypeV2(contentType) {
+		return nil, nil, models.ErrInvalidContentTypeV2Error
+	}
+
+	if len(contentID) == 0 {
+		return nil, nil, models.ErrNoContentIDError
+	}
+
+	endpoint := fmt.Sprintf("/wiki/api/v2/%v/%v/versions/%v", contentType, contentID, versionNumber)
+
+	response, err = v.client.callV2(ctx, http.MethodGet, endpoint, nil, &result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return
+}
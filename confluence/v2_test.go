@@ -0,0 +1,462 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNextCursor(t *testing.T) {
+
+	testCases := []struct {
+		name     string
+		response *ResponseScheme
+		links    *models.ChunkLinksSchemeV2
+		want     string
+	}{
+		{
+			name: "when the Link header is returned",
+			response: &ResponseScheme{Headers: map[string][]string{
+				"Link": {`</wiki/api/v2/pages?cursor=abc%3D&limit=25>; rel="next", <https://site.atlassian.net/wiki>; rel="base"`},
+			}},
+			links: &models.ChunkLinksSchemeV2{Next: "/wiki/api/v2/pages?cursor=ignored"},
+			want:  "abc=",
+		},
+
+		{
+			name:     "when the Link header is not returned",
+			response: &ResponseScheme{},
+			links:    &models.ChunkLinksSchemeV2{Next: "/wiki/api/v2/pages?cursor=def&limit=25"},
+			want:     "def",
+		},
+
+		{
+			name: "when the Link header has no next page",
+			response: &ResponseScheme{Headers: map[string][]string{
+				"Link": {`<https://site.atlassian.net/wiki>; rel="base"`},
+			}},
+			links: &models.ChunkLinksSchemeV2{},
+		},
+
+		{
+			name: "when the response and the links are not provided",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, NextCursor(testCase.response, testCase.links))
+		})
+	}
+}
+
+func Test_V2_Page_Service_Gets(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		options            *models.ContentGetsOptionsSchemeV2
+		cursor             string
+		limit              int
+		mockFile           string
+		headers            map[string]string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantCursor         string
+		wantErr            bool
+		expectedError      string
+	}{
+		{
+			name: "when the parameters are correct",
+			options: &models.ContentGetsOptionsSchemeV2{
+				SpaceIDs:   []string{"196612", "196613"},
+				Status:     []string{"current"},
+				BodyFormat: "storage",
+				Sort:       "-modified-date",
+			},
+			cursor:             "eyJpZCI6IjY1NjAwIn0",
+			limit:              1,
+			mockFile:           "./mocks/get-pages-v2.json",
+			headers:            map[string]string{"Link": `</wiki/api/v2/pages?cursor=bmV4dA&limit=1>; rel="next"`},
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/wiki/api/v2/pages?body-format=storage&cursor=eyJpZCI6IjY1NjAwIn0&limit=1&sort=-modified-date&space-id=196612%2C196613&status=current",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantCursor:         "bmV4dA",
+		},
+
+		{
+			name:               "when the Link header is not returned",
+			limit:              1,
+			mockFile:           "./mocks/get-pages-v2.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/wiki/api/v2/pages?limit=1",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantCursor:         "eyJpZCI6IjY1NjAxIn0",
+		},
+
+		{
+			name:               "when the context is not provided",
+			limit:              1,
+			mockFile:           "./mocks/get-pages-v2.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/wiki/api/v2/pages?limit=1",
+			context:            nil,
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+			expectedError:      "request creation failed: net/http: nil Context",
+		},
+
+		{
+			name:               "when the response status is not correct",
+			limit:              1,
+			mockFile:           "./mocks/get-pages-v2.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/wiki/api/v2/pages?limit=1",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusBadRequest,
+			wantErr:            true,
+			expectedError:      "request failed. Please analyze the request body for more details. Status Code: 400",
+		},
+
+		{
+			name:               "when the response body is empty",
+			limit:              1,
+			mockFile:           "./mocks/empty-json.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/wiki/api/v2/pages?limit=1",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+			expectedError:      "unexpected end of JSON input",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			mockServer, err := startMockServer(&mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				Headers:            testCase.headers,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotResult, gotResponse, err := mockClient.V2.Page.Gets(testCase.context, testCase.options, testCase.cursor, testCase.limit)
+
+			if testCase.wantErr {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.wantHTTPCodeReturn, gotResponse.Code)
+			assert.Len(t, gotResult.Results, 1)
+			assert.Equal(t, "<p>Release notes</p>", gotResult.Results[0].Body.Storage.Value)
+			assert.Equal(t, testCase.wantCursor, NextCursor(gotResponse, gotResult.Links))
+		})
+	}
+}
+
+func Test_V2_Page_Service_Get(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		pageID             string
+		options            *models.ContentGetOptionsSchemeV2
+		mockFile           string
+		wantHTTPMethod     string
+		endpoint           string
+		context            context.Context
+		wantHTTPCodeReturn int
+		wantErr            bool
+		expectedError      string
+	}{
+		{
+			name:               "when the parameters are correct",
+			pageID:             "65601",
+			options:            &models.ContentGetOptionsSchemeV2{BodyFormat: "storage", Version: 3, GetDraft: true},
+			mockFile:           "./mocks/get-page-v2.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/wiki/api/v2/pages/65601?body-format=storage&get-draft=true&version=3",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+		},
+
+		{
+			name:               "when the options are not provided",
+			pageID:             "65601",
+			mockFile:           "./mocks/get-page-v2.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/wiki/api/v2/pages/65601",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+		},
+
+		{
+			name:               "when the page id is not provided",
+			mockFile:           "./mocks/get-page-v2.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/wiki/api/v2/pages/65601",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusOK,
+			wantErr:            true,
+			expectedError:      "confluence: no content id set",
+		},
+
+		{
+			name:               "when the response status is not correct",
+			pageID:             "65601",
+			mockFile:           "./mocks/get-page-v2.json",
+			wantHTTPMethod:     http.MethodGet,
+			endpoint:           "/wiki/api/v2/pages/65601",
+			context:            context.Background(),
+			wantHTTPCodeReturn: http.StatusNotFound,
+			wantErr:            true,
+			expectedError:      "request failed. Please analyze the request body for more details. Status Code: 404",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			mockServer, err := startMockServer(&mockServerOptions{
+				Endpoint:           testCase.endpoint,
+				MockFilePath:       testCase.mockFile,
+				MethodAccepted:     testCase.wantHTTPMethod,
+				ResponseCodeWanted: testCase.wantHTTPCodeReturn,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer mockServer.Close()
+
+			mockClient, err := startMockClient(mockServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotResult, gotResponse, err := mockClient.V2.Page.Get(testCase.context, testCase.pageID, testCase.options)
+
+			if testCase.wantErr {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.wantHTTPCodeReturn, gotResponse.Code)
+			assert.Equal(t, "65601", gotResult.ID)
+			assert.Equal(t, 3, gotResult.Version.Number)
+		})
+	}
+}
+
+func Test_V2_Page_Service_Create(t *testing.T) {
+
+	var received *models.ContentCreatePayloadSchemeV2
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.Method != http.MethodPost || r.URL.Path != "/wiki/api/v2/pages" {
+			http.Error(w, fmt.Sprintf("unexpected request %v %v", r.Method, r.URL.Path), http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"65602","title":"Release notes","spaceId":"196612","version":{"number":1}}`)
+	}))
+	defer mockServer.Close()
+
+	mockClient, err := startMockClient(mockServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := &models.ContentCreatePayloadSchemeV2{
+		SpaceID:  "196612",
+		Status:   "current",
+		Title:    "Release notes",
+		ParentID: "65540",
+		Body:     &models.BodyNodeScheme{Value: "<p>Release notes</p>", Representation: "storage"},
+	}
+
+	page, response, err := mockClient.V2.Page.Create(context.Background(), payload)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "65602", page.ID)
+	assert.Equal(t, payload, received)
+
+	_, _, err = mockClient.V2.Page.Create(context.Background(), &models.ContentCreatePayloadSchemeV2{Title: "Release notes"})
+	assert.EqualError(t, err, "confluence: no space id set")
+
+	_, _, err = mockClient.V2.Page.Create(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func Test_V2_Page_Service_Delete(t *testing.T) {
+
+	mockServer, err := startMockServer(&mockServerOptions{
+		Endpoint:           "/wiki/api/v2/pages/65601",
+		MethodAccepted:     http.MethodDelete,
+		ResponseCodeWanted: http.StatusNoContent,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer mockServer.Close()
+
+	mockClient, err := startMockClient(mockServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := mockClient.V2.Page.Delete(context.Background(), "65601")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, response.Code)
+
+	_, err = mockClient.V2.Page.Delete(context.Background(), "")
+	assert.EqualError(t, err, "confluence: no content id set")
+}
+
+func Test_V2_Page_Service_Iterate(t *testing.T) {
+
+	// The first page returns the cursor on the Link header, the second one on the body.
+	chunks := map[string]string{
+		"":   `{"results":[{"id":"1"},{"id":"2"}]}`,
+		"c2": `{"results":[{"id":"3"}],"_links":{"next":"/wiki/api/v2/pages?cursor=c3&limit=2"}}`,
+		"c3": `{"results":[]}`,
+	}
+
+	var cursors []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+
+		body, ok := chunks[cursor]
+		if !ok || r.URL.Query().Get("space-id") != "196612" {
+			http.Error(w, "unexpected cursor", http.StatusNotFound)
+			return
+		}
+
+		if cursor == "" {
+			w.Header().Set("Link", `</wiki/api/v2/pages?cursor=c2&limit=2>; rel="next"`)
+		}
+
+		fmt.Fprint(w, body)
+	}))
+	defer mockServer.Close()
+
+	mockClient, err := startMockClient(mockServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	iterator := mockClient.V2.Page.Iterate(context.Background(), &models.ContentGetsOptionsSchemeV2{SpaceIDs: []string{"196612"}}, 2)
+
+	var ids []string
+	for iterator.Next() {
+		ids = append(ids, iterator.Page().ID)
+	}
+
+	assert.NoError(t, iterator.Err())
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+	assert.Equal(t, []string{"", "c2", "c3"}, cursors)
+	assert.Equal(t, http.StatusOK, iterator.Response().Code)
+
+	// The errors stop the iteration.
+	delete(chunks, "c2")
+	cursors = nil
+
+	iterator = mockClient.V2.Page.Iterate(context.Background(), &models.ContentGetsOptionsSchemeV2{SpaceIDs: []string{"196612"}}, 2)
+
+	ids = nil
+	for iterator.Next() {
+		ids = append(ids, iterator.Page().ID)
+	}
+
+	assert.Equal(t, []string{"1", "2"}, ids)
+	assert.EqualError(t, iterator.Err(), "request failed. Please analyze the request body for more details. Status Code: 404")
+	assert.False(t, iterator.Next())
+}
+
+func Test_V2_Content_Type_Validation(t *testing.T) {
+
+	mockClient, err := startMockClient("https://ctreminiom.atlassian.net")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	_, _, err = mockClient.V2.Comment.Gets(ctx, "attachments", "65601", "", "", 25)
+	assert.EqualError(t, err, "confluence: invalid content type: (pages, blogposts)")
+
+	_, _, err = mockClient.V2.Label.Gets(ctx, models.ContentTypeSpacesV2, "", "", "", 25)
+	assert.EqualError(t, err, "confluence: no content id set")
+
+	_, _, err = mockClient.V2.Version.Gets(ctx, models.ContentTypeSpacesV2, "196612", "", 25)
+	assert.EqualError(t, err, "confluence: invalid content type: (pages, blogposts)")
+
+	_, _, err = mockClient.V2.Property.Get(ctx, models.ContentTypeBlogPostsV2, "65601", "")
+	assert.EqualError(t, err, "confluence: no content property id set")
+
+	_, _, err = mockClient.V2.Property.Create(ctx, models.ContentTypePagesV2, "65601", &models.ContentPropertyPayloadSchemeV2{})
+	assert.EqualError(t, err, "confluence: no content property set")
+
+	_, _, err = mockClient.V2.Space.Get(ctx, "", "")
+	assert.EqualError(t, err, "confluence: no space id set")
+
+	_, err = mockClient.V2.Comment.Delete(ctx, "")
+	assert.EqualError(t, err, "confluence: no comment id set")
+}
+
+func Test_V2_Property_Service_Gets(t *testing.T) {
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.URL.Path != "/wiki/api/v2/blogposts/65601/properties" || r.URL.RawQuery != "key=editor&limit=10" {
+			http.Error(w, fmt.Sprintf("unexpected request %v", r.URL), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprint(w, `{"results":[{"id":"7","key":"editor","value":{"version":"v2"},"version":{"number":2}}]}`)
+	}))
+	defer mockServer.Close()
+
+	mockClient, err := startMockClient(mockServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, response, err := mockClient.V2.Property.Gets(context.Background(), models.ContentTypeBlogPostsV2, "65601", "editor", "", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, map[string]interface{}{"version": "v2"}, result.Results[0].Value)
+	assert.Empty(t, NextCursor(response, result.Links))
+}
@@ -0,0 +1,142 @@
+// Package connect signs the requests sent by an Atlassian Connect app to the sites it's installed on.
+//
+// The Authenticator signs each request with a JWT carrying the query string hash of the request, the
+// UserAuthenticator exchanges it for an access token acting as a user. Both are common.RequestSigner, set them on
+// the Jira, Agile, Service Management or Confluence clients, the authentication of the Jira clients implements
+// common.RequestSignerSetter:
+//
+//	authenticator, err := connect.New(nil, installation)
+//	client.Auth.(common.RequestSignerSetter).SetRequestSigner(authenticator)
+package connect
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/chrisccoy/go-atlassian/service/common"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoInstallation  = errors.New("connect: no installation set")
+	ErrNoAppKey        = errors.New("connect: no app key set")
+	ErrNoSharedSecret  = errors.New("connect: no shared secret set")
+	ErrNoOAuthClientID = errors.New("connect: no oauth client id set")
+	ErrNoAccountID     = errors.New("connect: no account id set")
+	ErrTokenExchange   = errors.New("connect: access token exchange failed")
+)
+
+// DefaultExpiration is the lifetime of the tokens signing the requests.
+const DefaultExpiration = 3 * time.Minute
+
+// Authenticator signs the requests with a JWT issued by the app, signed with HS256 by the shared secret of the
+// installation.
+type Authenticator struct {
+	// Expiration is the lifetime of the tokens, DefaultExpiration by default.
+	Expiration time.Duration
+
+	http         common.HttpClient
+	installation *models.ConnectInstallationScheme
+	basePath     string
+	now          func() time.Time
+}
+
+// New returns the authenticator of an installation, the httpClient is used by the act-as-user token exchange.
+func New(httpClient common.HttpClient, installation *models.ConnectInstallationScheme) (*Authenticator, error) {
+
+	if installation == nil {
+		return nil, ErrNoInstallation
+	}
+
+	if installation.Key == "" {
+		return nil, ErrNoAppKey
+	}
+
+	if installation.SharedSecret == "" {
+		return nil, ErrNoSharedSecret
+	}
+
+	baseURL, err := url.Parse(installation.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Authenticator{
+		Expiration:   DefaultExpiration,
+		http:         httpClient,
+		installation: installation,
+		basePath:     strings.TrimSuffix(baseURL.Path, "/"),
+		now:          time.Now,
+	}, nil
+}
+
+// Sign sets the Authorization header of the request, the query string hash is computed from the request path
+// relative to the installation base URL, e.g. without the /wiki context path of Confluence when it's set.
+func (a *Authenticator) Sign(request *http.Request) error {
+
+	path := request.URL.Path
+	if a.basePath != "" && (path == a.basePath || strings.HasPrefix(path, a.basePath+"/")) {
+		path = strings.TrimPrefix(path, a.basePath)
+	}
+
+	token, err := a.Token(request.Method, path, request.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Authorization", "JWT "+token)
+	return nil
+}
+
+// Token returns the JWT of a request, the path is relative to the installation base URL.
+func (a *Authenticator) Token(method, path string, query url.Values) (string, error) {
+
+	issuedAt := a.now()
+
+	return signToken(a.installation.SharedSecret, map[string]interface{}{
+		"iss": a.installation.Key,
+		"iat": issuedAt.Unix(),
+		"exp": issuedAt.Add(a.expiration()).Unix(),
+		"qsh": QueryStringHash(method, path, query),
+	})
+}
+
+func (a *Authenticator) expiration() time.Duration {
+
+	if a.Expiration <= 0 {
+		return DefaultExpiration
+	}
+
+	return a.Expiration
+}
+
+// signToken returns the HS256 JWT of the claims signed by the secret.
+func signToken(secret string, claims map[string]interface{}) (string, error) {
+
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
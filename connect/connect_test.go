@@ -0,0 +1,249 @@
+package connect
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+var installation = &models.ConnectInstallationScheme{
+	Key:           "com.example.app",
+	ClientKey:     "client-key",
+	OAuthClientID: "oauth-client-id",
+	SharedSecret:  "shared-secret",
+	BaseURL:       "https://ctreminiom.atlassian.net/wiki",
+}
+
+// claimsOf verifies the signature of the token returning its claims.
+func claimsOf(t *testing.T, secret, token string) map[string]interface{} {
+
+	parts := strings.Split(token, ".")
+	if !assert.Len(t, parts, 3) {
+		return nil
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), parts[2])
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	assert.NoError(t, err)
+
+	var claims map[string]interface{}
+	assert.NoError(t, json.Unmarshal(payload, &claims))
+	return claims
+}
+
+func TestNew(t *testing.T) {
+
+	testCases := []struct {
+		name         string
+		installation *models.ConnectInstallationScheme
+		wantErr      error
+	}{
+		{
+			name:         "when the installation is valid",
+			installation: installation,
+		},
+
+		{
+			name:    "when the installation is not provided",
+			wantErr: ErrNoInstallation,
+		},
+
+		{
+			name:         "when the app key is not provided",
+			installation: &models.ConnectInstallationScheme{SharedSecret: "shared-secret"},
+			wantErr:      ErrNoAppKey,
+		},
+
+		{
+			name:         "when the shared secret is not provided",
+			installation: &models.ConnectInstallationScheme{Key: "com.example.app"},
+			wantErr:      ErrNoSharedSecret,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			got, err := New(nil, testCase.installation)
+			if testCase.wantErr != nil {
+				assert.True(t, errors.Is(err, testCase.wantErr), err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+			}
+		})
+	}
+}
+
+func TestAuthenticator_Sign(t *testing.T) {
+
+	authenticator, err := New(nil, installation)
+	assert.NoError(t, err)
+
+	issuedAt := time.Unix(1617282000, 0)
+	authenticator.now = func() time.Time { return issuedAt }
+
+	testCases := []struct {
+		name   string
+		target string
+		path   string
+		query  url.Values
+	}{
+		{
+			name:   "when the request is under the base url context path",
+			target: "https://ctreminiom.atlassian.net/wiki/rest/api/content?spaceKey=DUMMY&limit=25",
+			path:   "/rest/api/content",
+			query:  url.Values{"spaceKey": {"DUMMY"}, "limit": {"25"}},
+		},
+
+		{
+			name:   "when the request is outside the base url context path",
+			target: "https://ctreminiom.atlassian.net/rest/api/space",
+			path:   "/rest/api/space",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			request := httptest.NewRequest(http.MethodGet, testCase.target, nil)
+			assert.NoError(t, authenticator.Sign(request))
+
+			header := request.Header.Get("Authorization")
+			assert.True(t, strings.HasPrefix(header, "JWT "), header)
+
+			claims := claimsOf(t, "shared-secret", strings.TrimPrefix(header, "JWT "))
+			assert.Equal(t, "com.example.app", claims["iss"])
+			assert.Equal(t, float64(issuedAt.Unix()), claims["iat"])
+			assert.Equal(t, float64(issuedAt.Add(DefaultExpiration).Unix()), claims["exp"])
+			assert.Equal(t, QueryStringHash(http.MethodGet, testCase.path, testCase.query), claims["qsh"])
+		})
+	}
+}
+
+func TestUserAuthenticator_Sign(t *testing.T) {
+
+	var exchanges int
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+
+		exchanges++
+
+		assert.NoError(t, request.ParseForm())
+		assert.Equal(t, jwtBearerGrantType, request.PostForm.Get("grant_type"))
+		assert.Equal(t, "READ WRITE", request.PostForm.Get("scope"))
+
+		claims := claimsOf(t, "shared-secret", request.PostForm.Get("assertion"))
+		assert.Equal(t, "urn:atlassian:connect:clientid:oauth-client-id", claims["iss"])
+		assert.Equal(t, "urn:atlassian:connect:useraccountid:5b10ac8d82e05b22cc7d4ef5", claims["sub"])
+		assert.Equal(t, "https://ctreminiom.atlassian.net/wiki", claims["tnt"])
+		assert.Equal(t, AuthorizationServer, claims["aud"])
+
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write([]byte(`{"access_token":"access-token","expires_in":900,"token_type":"Bearer"}`))
+	}))
+	defer server.Close()
+
+	authenticator, err := New(server.Client(), installation)
+	assert.NoError(t, err)
+
+	now := time.Unix(1617282000, 0)
+	authenticator.now = func() time.Time { return now }
+
+	user := authenticator.ActAsUser("5b10ac8d82e05b22cc7d4ef5", "read", "write")
+	user.TokenURL = server.URL + "/oauth2/token"
+
+	for range []int{1, 2} {
+
+		request := httptest.NewRequest(http.MethodGet, "https://ctreminiom.atlassian.net/rest/api/3/myself", nil)
+		assert.NoError(t, user.Sign(request))
+		assert.Equal(t, "Bearer access-token", request.Header.Get("Authorization"))
+	}
+
+	assert.Equal(t, 1, exchanges, "the access token must be cached")
+
+	now = now.Add(15 * time.Minute)
+
+	_, err = user.Token(httptest.NewRequest(http.MethodGet, "/", nil).Context())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, exchanges, "the expired access token must be exchanged again")
+}
+
+func TestUserAuthenticator_Token(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusUnauthorized)
+		_, _ = writer.Write([]byte(`{"error":"invalid_grant"}`))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name         string
+		installation *models.ConnectInstallationScheme
+		accountID    string
+		wantErr      error
+	}{
+		{
+			name:         "when the exchange is rejected",
+			installation: installation,
+			accountID:    "5b10ac8d82e05b22cc7d4ef5",
+			wantErr:      ErrTokenExchange,
+		},
+
+		{
+			name:         "when the oauth client id is not provided",
+			installation: &models.ConnectInstallationScheme{Key: "com.example.app", SharedSecret: "shared-secret"},
+			accountID:    "5b10ac8d82e05b22cc7d4ef5",
+			wantErr:      ErrNoOAuthClientID,
+		},
+
+		{
+			name:         "when the account id is not provided",
+			installation: installation,
+			wantErr:      ErrNoAccountID,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			authenticator, err := New(server.Client(), testCase.installation)
+			assert.NoError(t, err)
+
+			user := authenticator.ActAsUser(testCase.accountID)
+			user.TokenURL = server.URL
+
+			_, err = user.Token(httptest.NewRequest(http.MethodGet, "/", nil).Context())
+			assert.True(t, errors.Is(err, testCase.wantErr), err)
+		})
+	}
+}
+
+func TestQueryStringHash(t *testing.T) {
+
+	canonical := "GET&/rest/api/3/search&fields=key%2Csummary,status&jql=project%20%3D%20KP"
+	sum := sha256.Sum256([]byte(canonical))
+
+	got := QueryStringHash("get", "/rest/api/3/search/", url.Values{
+		"jql":    {"project = KP"},
+		"fields": {"status", "key,summary"},
+		"jwt":    {"ignored"},
+	})
+
+	assert.Equal(t, hex.EncodeToString(sum[:]), got)
+}
@@ -0,0 +1,52 @@
+package connect
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// QueryStringHash returns the Connect query string hash of a request, the SHA-256 of its canonical request:
+// the method, the path and the sorted query parameters, without the jwt parameter.
+func QueryStringHash(method, path string, query url.Values) string {
+
+	if path == "" {
+		path = "/"
+	}
+
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	var keys []string
+	for key := range query {
+		if key != "jwt" {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	parameters := make([]string, len(keys))
+	for index, key := range keys {
+
+		values := make([]string, len(query[key]))
+		for position, value := range query[key] {
+			values[position] = encode(value)
+		}
+
+		sort.Strings(values)
+		parameters[index] = encode(key) + "=" + strings.Join(values, ",")
+	}
+
+	canonical := strings.ToUpper(method) + "&" + strings.ReplaceAll(path, "&", "%26") + "&" + strings.Join(parameters, "&")
+	sum := sha256.Sum256([]byte(canonical))
+	return hex.EncodeToString(sum[:])
+}
+
+// encode percent-encodes the value as RFC 3986, the spaces are %20 and the unreserved characters aren't encoded.
+func encode(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
@@ -0,0 +1,155 @@
+package connect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuthorizationServer is the Atlassian authorization server exchanging the act-as-user assertions for access tokens.
+const AuthorizationServer = "https://oauth-2-authorization-server.services.atlassian.com"
+
+const (
+	jwtBearerGrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+
+	// tokenRenewalMargin renews the access tokens before they expire, so they don't expire in flight.
+	tokenRenewalMargin = 30 * time.Second
+)
+
+// UserAuthenticator signs the requests with an access token acting as a user, exchanged with the JWT bearer grant and
+// cached until it expires.
+type UserAuthenticator struct {
+	// TokenURL is the endpoint of the token exchange, AuthorizationServer + "/oauth2/token" by default.
+	TokenURL string
+
+	authenticator *Authenticator
+	accountID     string
+	scopes        []string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// ActAsUser returns the authenticator acting as the user of the account id, the scopes are the Connect scopes of
+// the token, e.g. READ and WRITE, READ by default. The app descriptor must have the ACT_AS_USER scope.
+func (a *Authenticator) ActAsUser(accountID string, scopes ...string) *UserAuthenticator {
+
+	if len(scopes) == 0 {
+		scopes = []string{"READ"}
+	}
+
+	return &UserAuthenticator{
+		TokenURL:      AuthorizationServer + "/oauth2/token",
+		authenticator: a,
+		accountID:     accountID,
+		scopes:        scopes,
+	}
+}
+
+// Sign sets the bearer access token on the Authorization header, the token is exchanged with the request context.
+func (u *UserAuthenticator) Sign(request *http.Request) error {
+
+	token, err := u.Token(request.Context())
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Token returns the access token of the user, it's exchanged again when the cached token is about to expire.
+func (u *UserAuthenticator) Token(ctx context.Context) (string, error) {
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	now := u.authenticator.now()
+	if u.token != "" && now.Add(tokenRenewalMargin).Before(u.expiresAt) {
+		return u.token, nil
+	}
+
+	token, expiresIn, err := u.exchange(ctx, now)
+	if err != nil {
+		return "", err
+	}
+
+	u.token = token
+	u.expiresAt = now.Add(time.Duration(expiresIn) * time.Second)
+	return u.token, nil
+}
+
+func (u *UserAuthenticator) exchange(ctx context.Context, now time.Time) (string, int, error) {
+
+	installation := u.authenticator.installation
+
+	if installation.OAuthClientID == "" {
+		return "", 0, ErrNoOAuthClientID
+	}
+
+	if u.accountID == "" {
+		return "", 0, ErrNoAccountID
+	}
+
+	assertion, err := signToken(installation.SharedSecret, map[string]interface{}{
+		"iss": "urn:atlassian:connect:clientid:" + installation.OAuthClientID,
+		"sub": "urn:atlassian:connect:useraccountid:" + u.accountID,
+		"tnt": installation.BaseURL,
+		"aud": AuthorizationServer,
+		"iat": now.Unix(),
+		"exp": now.Add(u.authenticator.expiration()).Unix(),
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", jwtBearerGrantType)
+	form.Set("assertion", assertion)
+	form.Set("scope", strings.ToUpper(strings.Join(u.scopes, " ")))
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, u.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := u.authenticator.http.Do(request)
+	if err != nil {
+		return "", 0, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return "", 0, fmt.Errorf("%w: %v %s", ErrTokenExchange, response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var grant struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+
+	if err = json.Unmarshal(body, &grant); err != nil {
+		return "", 0, err
+	}
+
+	if grant.AccessToken == "" {
+		return "", 0, fmt.Errorf("%w: no access token returned", ErrTokenExchange)
+	}
+
+	return grant.AccessToken, grant.ExpiresIn, nil
+}
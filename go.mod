@@ -7,4 +7,5 @@ require (
 	github.com/imdario/mergo v0.3.13
 	github.com/stretchr/testify v1.8.0
 	github.com/tidwall/gjson v1.14.3
+	gopkg.in/yaml.v3 v3.0.1
 )
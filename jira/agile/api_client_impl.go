@@ -99,6 +99,12 @@ func (c *Client) NewRequest(ctx context.Context, method, apiEndpoint string, pay
 		request.Header.Set("User-Agent", c.Auth.GetUserAgent())
 	}
 
+	if signer, ok := c.Auth.(common.RequestSignerSetter); ok && signer.HasRequestSigner() {
+		if err := signer.GetRequestSigner().Sign(request); err != nil {
+			return nil, err
+		}
+	}
+
 	return request, nil
 }
 
@@ -112,6 +118,12 @@ func (c *Client) Call(request *http.Request, structure interface{}) (*models.Res
 	return c.TransformTheHTTPResponse(response, structure)
 }
 
+// Do sends the request returning the raw HTTP response, the caller must close the response body.
+// It implements service.Doer, used by the endpoints streaming the response, e.g. the attachment downloads.
+func (c *Client) Do(request *http.Request) (*http.Response, error) {
+	return c.HTTP.Do(request)
+}
+
 func (c *Client) TransformTheHTTPResponse(response *http.Response, structure interface{}) (*models.ResponseScheme, error) {
 
 	responseTransformed := &models.ResponseScheme{
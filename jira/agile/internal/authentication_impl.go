@@ -17,6 +17,8 @@ type AuthenticationService struct {
 
 	userAgentProvided bool
 	agent             string
+
+	signer common.RequestSigner
 }
 
 func (a *AuthenticationService) SetExperimentalFlag() {}
@@ -52,3 +54,15 @@ func (a *AuthenticationService) GetUserAgent() string {
 func (a *AuthenticationService) HasUserAgent() bool {
 	return a.userAgentProvided
 }
+
+func (a *AuthenticationService) SetRequestSigner(signer common.RequestSigner) {
+	a.signer = signer
+}
+
+func (a *AuthenticationService) GetRequestSigner() common.RequestSigner {
+	return a.signer
+}
+
+func (a *AuthenticationService) HasRequestSigner() bool {
+	return a.signer != nil
+}
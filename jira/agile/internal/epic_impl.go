@@ -18,11 +18,20 @@ func NewEpicService(client service.Client, version string) (*EpicService, error)
 		return nil, model.ErrNoVersionProvided
 	}
 
+	// The team-managed epics are edited with the platform API of the same generation as the agile API.
+	platformVersion, ok := platformVersions[version]
+	if !ok {
+		platformVersion = "latest"
+	}
+
 	return &EpicService{
-		internalClient: &internalEpicImpl{c: client, version: version},
+		internalClient: &internalEpicImpl{c: client, version: version, platformVersion: platformVersion},
 	}, nil
 }
 
+// platformVersions are the platform API versions, by agile API version.
+var platformVersions = map[string]string{"1.0": "2"}
+
 type EpicService struct {
 	internalClient agile.EpicConnector
 }
@@ -71,9 +80,91 @@ func (e *EpicService) Move(ctx context.Context, epicIdOrKey string, issues []str
 	return e.internalClient.Move(ctx, epicIdOrKey, issues)
 }
 
+// Path performs a partial update of the epic.
+//
+// A partial update means that fields not present in the request payload will not be changed.
+//
+// The epic name, summary, color and done flag can be updated.
+//
+// Note: This operation does not work for epics in next-gen projects.
+//
+// POST /rest/agile/1.0/epic/{epicIdOrKey}
+//
+// https://docs.go-atlassian.io/jira-agile/epics#partially-update-epic
+func (e *EpicService) Path(ctx context.Context, epicIdOrKey string, payload *model.EpicPayloadScheme) (*model.EpicScheme, *model.ResponseScheme, error) {
+	return e.internalClient.Path(ctx, epicIdOrKey, payload)
+}
+
+// Rank moves (ranks) an epic before or after a given epic.
+//
+// If rankCustomFieldId is not defined, the default rank field will be used.
+//
+// PUT /rest/agile/1.0/epic/{epicIdOrKey}/rank
+//
+// https://docs.go-atlassian.io/jira-agile/epics#rank-epics
+func (e *EpicService) Rank(ctx context.Context, epicIdOrKey string, payload *model.EpicRankPayloadScheme) (*model.ResponseScheme, error) {
+	return e.internalClient.Rank(ctx, epicIdOrKey, payload)
+}
+
+// IssuesWithoutEpic returns all issues that do not belong to any epic.
+//
+// This only includes issues that the user has permission to view.
+//
+// By default, the returned issues are ordered by rank.
+//
+// GET /rest/agile/1.0/epic/none/issue
+//
+// https://docs.go-atlassian.io/jira-agile/epics#get-issues-without-epic
+func (e *EpicService) IssuesWithoutEpic(ctx context.Context, opts *model.IssueOptionScheme, startAt, maxResults int) (*model.BoardIssuePageScheme, *model.ResponseScheme, error) {
+	return e.internalClient.IssuesWithoutEpic(ctx, opts, startAt, maxResults)
+}
+
+// Remove removes issues from epics.
+//
+// The user needs to have the edit issue permission for all issue they want to remove from epics.
+//
+// The maximum number of issues that can be moved in one operation is 50.
+//
+// POST /rest/agile/1.0/epic/none/issue
+//
+// https://docs.go-atlassian.io/jira-agile/epics#remove-issues-from-epic
+func (e *EpicService) Remove(ctx context.Context, issues []string) (*model.ResponseScheme, error) {
+	return e.internalClient.Remove(ctx, issues)
+}
+
+// Children returns the issues that belong to an epic of a team-managed project.
+//
+// Team-managed projects model the epic relation with the parent field instead of the Epic Link custom field.
+//
+// The issues are returned using the JQL query parent = "{epicIdOrKey}".
+//
+// GET /rest/api/2/search
+func (e *EpicService) Children(ctx context.Context, epicIdOrKey string, opts *model.IssueOptionScheme, startAt, maxResults int) (*model.BoardIssuePageScheme, *model.ResponseScheme, error) {
+	return e.internalClient.Children(ctx, epicIdOrKey, opts, startAt, maxResults)
+}
+
+// Adopt moves issues to an epic of a team-managed project, setting the parent field of each issue.
+//
+// The issues are edited one by one, the process stops on the first issue that cannot be updated.
+//
+// PUT /rest/api/2/issue/{issueIdOrKey}
+func (e *EpicService) Adopt(ctx context.Context, epicIdOrKey string, issues []string) (*model.ResponseScheme, error) {
+	return e.internalClient.Adopt(ctx, epicIdOrKey, issues)
+}
+
+// Orphan removes issues from their team-managed epic, clearing the parent field of each issue.
+//
+// The issues are edited one by one, the process stops on the first issue that cannot be updated.
+//
+// PUT /rest/api/2/issue/{issueIdOrKey}
+func (e *EpicService) Orphan(ctx context.Context, issues []string) (*model.ResponseScheme, error) {
+	return e.internalClient.Orphan(ctx, issues)
+}
+
 type internalEpicImpl struct {
-	c       service.Client
-	version string
+	c               service.Client
+	version         string
+	platformVersion string
 }
 
 func (i *internalEpicImpl) Get(ctx context.Context, epicIdOrKey string) (*model.EpicScheme, *model.ResponseScheme, error) {
@@ -161,3 +252,219 @@ func (i *internalEpicImpl) Move(ctx context.Context, epicIdOrKey string, issues
 
 	return i.c.Call(request, nil)
 }
+
+func (i *internalEpicImpl) Path(ctx context.Context, epicIdOrKey string, payload *model.EpicPayloadScheme) (*model.EpicScheme, *model.ResponseScheme, error) {
+
+	if epicIdOrKey == "" {
+		return nil, nil, model.ErrNoEpicIDError
+	}
+
+	if payload == nil {
+		return nil, nil, model.ErrNilPayloadError
+	}
+
+	reader, err := i.c.TransformStructToReader(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endpoint := fmt.Sprintf("rest/agile/%v/epic/%v", i.version, epicIdOrKey)
+
+	request, err := i.c.NewRequest(ctx, http.MethodPost, endpoint, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	epic := new(model.EpicScheme)
+	response, err := i.c.Call(request, epic)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return epic, response, nil
+}
+
+func (i *internalEpicImpl) Rank(ctx context.Context, epicIdOrKey string, payload *model.EpicRankPayloadScheme) (*model.ResponseScheme, error) {
+
+	if epicIdOrKey == "" {
+		return nil, model.ErrNoEpicIDError
+	}
+
+	if payload == nil {
+		return nil, model.ErrNilPayloadError
+	}
+
+	if payload.RankBeforeEpic == "" && payload.RankAfterEpic == "" {
+		return nil, model.ErrNoEpicRankError
+	}
+
+	reader, err := i.c.TransformStructToReader(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("rest/agile/%v/epic/%v/rank", i.version, epicIdOrKey)
+
+	request, err := i.c.NewRequest(ctx, http.MethodPut, endpoint, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.c.Call(request, nil)
+}
+
+func (i *internalEpicImpl) IssuesWithoutEpic(ctx context.Context, opts *model.IssueOptionScheme, startAt, maxResults int) (*model.BoardIssuePageScheme, *model.ResponseScheme, error) {
+
+	params := url.Values{}
+	params.Add("startAt", strconv.Itoa(startAt))
+	params.Add("maxResults", strconv.Itoa(maxResults))
+
+	if opts != nil {
+
+		params.Add("validateQuery", fmt.Sprintf("%t", opts.ValidateQuery))
+
+		if len(opts.JQL) != 0 {
+			params.Add("jql", opts.JQL)
+		}
+
+		if len(opts.Expand) != 0 {
+			params.Add("expand", strings.Join(opts.Expand, ","))
+		}
+
+		if len(opts.Fields) != 0 {
+			params.Add("fields", strings.Join(opts.Fields, ","))
+		}
+	}
+
+	endpoint := fmt.Sprintf("rest/agile/%v/epic/none/issue?%v", i.version, params.Encode())
+
+	request, err := i.c.NewRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := new(model.BoardIssuePageScheme)
+	response, err := i.c.Call(request, page)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return page, response, nil
+}
+
+func (i *internalEpicImpl) Remove(ctx context.Context, issues []string) (*model.ResponseScheme, error) {
+
+	if len(issues) == 0 {
+		return nil, model.ErrNoIssuesError
+	}
+
+	reader, err := i.c.TransformStructToReader(map[string]interface{}{"issues": issues})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("rest/agile/%v/epic/none/issue", i.version)
+
+	request, err := i.c.NewRequest(ctx, http.MethodPost, endpoint, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.c.Call(request, nil)
+}
+
+func (i *internalEpicImpl) Children(ctx context.Context, epicIdOrKey string, opts *model.IssueOptionScheme, startAt, maxResults int) (*model.BoardIssuePageScheme, *model.ResponseScheme, error) {
+
+	if epicIdOrKey == "" {
+		return nil, nil, model.ErrNoEpicIDError
+	}
+
+	jql := fmt.Sprintf("parent = %q", epicIdOrKey)
+
+	params := url.Values{}
+	params.Add("startAt", strconv.Itoa(startAt))
+	params.Add("maxResults", strconv.Itoa(maxResults))
+
+	if opts != nil {
+
+		if opts.ValidateQuery {
+			params.Add("validateQuery", "strict")
+		}
+
+		if len(opts.JQL) != 0 {
+			jql = fmt.Sprintf("%v AND (%v)", jql, opts.JQL)
+		}
+
+		if len(opts.Expand) != 0 {
+			params.Add("expand", strings.Join(opts.Expand, ","))
+		}
+
+		if len(opts.Fields) != 0 {
+			params.Add("fields", strings.Join(opts.Fields, ","))
+		}
+	}
+
+	params.Add("jql", jql)
+
+	endpoint := fmt.Sprintf("rest/api/%v/search?%v", i.platformVersion, params.Encode())
+
+	request, err := i.c.NewRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := new(model.BoardIssuePageScheme)
+	response, err := i.c.Call(request, page)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return page, response, nil
+}
+
+func (i *internalEpicImpl) Adopt(ctx context.Context, epicIdOrKey string, issues []string) (*model.ResponseScheme, error) {
+
+	if epicIdOrKey == "" {
+		return nil, model.ErrNoEpicIDError
+	}
+
+	parent := map[string]interface{}{"key": epicIdOrKey}
+	return i.parent(ctx, parent, issues)
+}
+
+func (i *internalEpicImpl) Orphan(ctx context.Context, issues []string) (*model.ResponseScheme, error) {
+	return i.parent(ctx, map[string]interface{}{"none": true}, issues)
+}
+
+// parent edits the parent field of the issues provided, the edition stops on the first failure.
+func (i *internalEpicImpl) parent(ctx context.Context, parent map[string]interface{}, issues []string) (*model.ResponseScheme, error) {
+
+	if len(issues) == 0 {
+		return nil, model.ErrNoIssuesError
+	}
+
+	var response *model.ResponseScheme
+	for _, issueKeyOrID := range issues {
+
+		payload := map[string]interface{}{"fields": map[string]interface{}{"parent": parent}}
+
+		reader, err := i.c.TransformStructToReader(payload)
+		if err != nil {
+			return response, err
+		}
+
+		endpoint := fmt.Sprintf("rest/api/%v/issue/%v", i.platformVersion, issueKeyOrID)
+
+		request, err := i.c.NewRequest(ctx, http.MethodPut, endpoint, reader)
+		if err != nil {
+			return response, err
+		}
+
+		response, err = i.c.Call(request, nil)
+		if err != nil {
+			return response, err
+		}
+	}
+
+	return response, nil
+}
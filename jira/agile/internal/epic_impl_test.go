@@ -477,3 +477,909 @@ func Test_EpicService_Move(t *testing.T) {
 		})
 	}
 }
+
+func Test_EpicService_Path(t *testing.T) {
+
+	done := true
+	payloadMocked := &model.EpicPayloadScheme{
+		Name:    "Epic Name",
+		Summary: "Epic Summary",
+		Color:   &model.EpicColorScheme{Key: "color_4"},
+		Done:    &done,
+	}
+
+	type fields struct {
+		c service.Client
+	}
+
+	type args struct {
+		ctx         context.Context
+		epicIdOrKey string
+		payload     *model.EpicPayloadScheme
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name: "when the parameters are correct",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "EPIC-1",
+				payload:     payloadMocked,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("TransformStructToReader",
+					payloadMocked).
+					Return(bytes.NewReader([]byte{}), nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/agile/1.0/epic/EPIC-1",
+					bytes.NewReader([]byte{})).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.EpicScheme{}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name: "when the api cannot be executed",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "EPIC-1",
+				payload:     payloadMocked,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("TransformStructToReader",
+					payloadMocked).
+					Return(bytes.NewReader([]byte{}), nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/agile/1.0/epic/EPIC-1",
+					bytes.NewReader([]byte{})).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.EpicScheme{}).
+					Return(&model.ResponseScheme{}, errors.New("error, unable to execute the http call"))
+
+				fields.c = client
+			},
+			Err:     errors.New("error, unable to execute the http call"),
+			wantErr: true,
+		},
+
+		{
+			name: "when the payload cannot be transformed",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "EPIC-1",
+				payload:     payloadMocked,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("TransformStructToReader",
+					payloadMocked).
+					Return(nil, model.ErrNilPayloadError)
+
+				fields.c = client
+			},
+			Err:     model.ErrNilPayloadError,
+			wantErr: true,
+		},
+
+		{
+			name: "when the payload is not provided",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "EPIC-1",
+			},
+			on: func(fields *fields) {
+				fields.c = mocks.NewClient(t)
+			},
+			Err:     model.ErrNilPayloadError,
+			wantErr: true,
+		},
+
+		{
+			name: "when the epic id is not provided",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "",
+			},
+			on: func(fields *fields) {
+				fields.c = mocks.NewClient(t)
+			},
+			Err:     model.ErrNoEpicIDError,
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			service, err := NewEpicService(testCase.fields.c, "1.0")
+			assert.NoError(t, err)
+
+			gotResult, gotResponse, err := service.Path(testCase.args.ctx, testCase.args.epicIdOrKey, testCase.args.payload)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+			}
+		})
+	}
+}
+
+func Test_EpicService_Rank(t *testing.T) {
+
+	payloadMocked := &model.EpicRankPayloadScheme{
+		RankBeforeEpic:    "EPIC-2",
+		RankCustomFieldID: 10521,
+	}
+
+	type fields struct {
+		c service.Client
+	}
+
+	type args struct {
+		ctx         context.Context
+		epicIdOrKey string
+		payload     *model.EpicRankPayloadScheme
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name: "when the parameters are correct",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "EPIC-1",
+				payload:     payloadMocked,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("TransformStructToReader",
+					payloadMocked).
+					Return(bytes.NewReader([]byte{}), nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPut,
+					"rest/agile/1.0/epic/EPIC-1/rank",
+					bytes.NewReader([]byte{})).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					nil).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name: "when the request cannot be created",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "EPIC-1",
+				payload:     payloadMocked,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("TransformStructToReader",
+					payloadMocked).
+					Return(bytes.NewReader([]byte{}), nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPut,
+					"rest/agile/1.0/epic/EPIC-1/rank",
+					bytes.NewReader([]byte{})).
+					Return(&http.Request{}, errors.New("unable to create the http request"))
+
+				fields.c = client
+			},
+			Err:     errors.New("unable to create the http request"),
+			wantErr: true,
+		},
+
+		{
+			name: "when the rank epics are not provided",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "EPIC-1",
+				payload:     &model.EpicRankPayloadScheme{RankCustomFieldID: 10521},
+			},
+			on: func(fields *fields) {
+				fields.c = mocks.NewClient(t)
+			},
+			Err:     model.ErrNoEpicRankError,
+			wantErr: true,
+		},
+
+		{
+			name: "when the payload is not provided",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "EPIC-1",
+			},
+			on: func(fields *fields) {
+				fields.c = mocks.NewClient(t)
+			},
+			Err:     model.ErrNilPayloadError,
+			wantErr: true,
+		},
+
+		{
+			name: "when the epic id is not provided",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "",
+			},
+			on: func(fields *fields) {
+				fields.c = mocks.NewClient(t)
+			},
+			Err:     model.ErrNoEpicIDError,
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			service, err := NewEpicService(testCase.fields.c, "1.0")
+			assert.NoError(t, err)
+
+			gotResponse, err := service.Rank(testCase.args.ctx, testCase.args.epicIdOrKey, testCase.args.payload)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+			}
+		})
+	}
+}
+
+func Test_EpicService_IssuesWithoutEpic(t *testing.T) {
+
+	type fields struct {
+		c service.Client
+	}
+
+	type args struct {
+		ctx        context.Context
+		startAt    int
+		maxResults int
+		opts       *model.IssueOptionScheme
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name: "when the parameters are correct",
+			args: args{
+				ctx:        context.Background(),
+				startAt:    0,
+				maxResults: 50,
+				opts: &model.IssueOptionScheme{
+					JQL:    "project = KP",
+					Fields: []string{"status"},
+				},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/agile/1.0/epic/none/issue?fields=status&jql=project+%3D+KP&maxResults=50&startAt=0&validateQuery=false",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.BoardIssuePageScheme{}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name: "when the api cannot be executed",
+			args: args{
+				ctx:        context.Background(),
+				startAt:    0,
+				maxResults: 50,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/agile/1.0/epic/none/issue?maxResults=50&startAt=0",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.BoardIssuePageScheme{}).
+					Return(&model.ResponseScheme{}, errors.New("error, unable to execute the http call"))
+
+				fields.c = client
+			},
+			Err:     errors.New("error, unable to execute the http call"),
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			service, err := NewEpicService(testCase.fields.c, "1.0")
+			assert.NoError(t, err)
+
+			gotResult, gotResponse, err := service.IssuesWithoutEpic(testCase.args.ctx, testCase.args.opts, testCase.args.startAt,
+				testCase.args.maxResults)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+			}
+		})
+	}
+}
+
+func Test_EpicService_Remove(t *testing.T) {
+
+	type fields struct {
+		c service.Client
+	}
+
+	type args struct {
+		ctx    context.Context
+		issues []string
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name: "when the parameters are correct",
+			args: args{
+				ctx:    context.Background(),
+				issues: []string{"KP-10"},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("TransformStructToReader",
+					map[string]interface{}{"issues": []string{"KP-10"}}).
+					Return(bytes.NewReader([]byte{}), nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/agile/1.0/epic/none/issue",
+					bytes.NewReader([]byte{})).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					nil).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name: "when the issues are not provided",
+			args: args{
+				ctx: context.Background(),
+			},
+			on: func(fields *fields) {
+				fields.c = mocks.NewClient(t)
+			},
+			Err:     model.ErrNoIssuesError,
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			service, err := NewEpicService(testCase.fields.c, "1.0")
+			assert.NoError(t, err)
+
+			gotResponse, err := service.Remove(testCase.args.ctx, testCase.args.issues)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+			}
+		})
+	}
+}
+
+func Test_EpicService_Children(t *testing.T) {
+
+	type fields struct {
+		c service.Client
+	}
+
+	type args struct {
+		ctx         context.Context
+		epicIdOrKey string
+		startAt     int
+		maxResults  int
+		opts        *model.IssueOptionScheme
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name: "when the parameters are correct",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "TM-1",
+				startAt:     0,
+				maxResults:  50,
+				opts: &model.IssueOptionScheme{
+					JQL:           "status = Done",
+					ValidateQuery: true,
+					Fields:        []string{"status"},
+				},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/2/search?fields=status&jql=parent+%3D+%22TM-1%22+AND+%28status+%3D+Done%29&maxResults=50&startAt=0&validateQuery=strict",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.BoardIssuePageScheme{}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name: "when the request cannot be created",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "TM-1",
+				startAt:     0,
+				maxResults:  50,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/2/search?jql=parent+%3D+%22TM-1%22&maxResults=50&startAt=0",
+					nil).
+					Return(&http.Request{}, errors.New("unable to create the http request"))
+
+				fields.c = client
+			},
+			Err:     errors.New("unable to create the http request"),
+			wantErr: true,
+		},
+
+		{
+			name: "when the epic id is not provided",
+			args: args{
+				ctx: context.Background(),
+			},
+			on: func(fields *fields) {
+				fields.c = mocks.NewClient(t)
+			},
+			Err:     model.ErrNoEpicIDError,
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			service, err := NewEpicService(testCase.fields.c, "1.0")
+			assert.NoError(t, err)
+
+			gotResult, gotResponse, err := service.Children(testCase.args.ctx, testCase.args.epicIdOrKey, testCase.args.opts,
+				testCase.args.startAt, testCase.args.maxResults)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+			}
+		})
+	}
+}
+
+func Test_EpicService_Adopt(t *testing.T) {
+
+	type fields struct {
+		c service.Client
+	}
+
+	type args struct {
+		ctx         context.Context
+		epicIdOrKey string
+		issues      []string
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name: "when the parameters are correct",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "TM-1",
+				issues:      []string{"TM-2", "TM-3"},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				payload := map[string]interface{}{"fields": map[string]interface{}{
+					"parent": map[string]interface{}{"key": "TM-1"}}}
+
+				client.On("TransformStructToReader",
+					payload).
+					Return(bytes.NewReader([]byte{}), nil).Twice()
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPut,
+					"rest/api/2/issue/TM-2",
+					bytes.NewReader([]byte{})).
+					Return(&http.Request{}, nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPut,
+					"rest/api/2/issue/TM-3",
+					bytes.NewReader([]byte{})).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					nil).
+					Return(&model.ResponseScheme{}, nil).Twice()
+
+				fields.c = client
+			},
+		},
+
+		{
+			name: "when the api cannot be executed",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "TM-1",
+				issues:      []string{"TM-2", "TM-3"},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				payload := map[string]interface{}{"fields": map[string]interface{}{
+					"parent": map[string]interface{}{"key": "TM-1"}}}
+
+				client.On("TransformStructToReader",
+					payload).
+					Return(bytes.NewReader([]byte{}), nil).Once()
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPut,
+					"rest/api/2/issue/TM-2",
+					bytes.NewReader([]byte{})).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					nil).
+					Return(&model.ResponseScheme{}, errors.New("error, unable to execute the http call"))
+
+				fields.c = client
+			},
+			Err:     errors.New("error, unable to execute the http call"),
+			wantErr: true,
+		},
+
+		{
+			name: "when the epic id is not provided",
+			args: args{
+				ctx:    context.Background(),
+				issues: []string{"TM-2"},
+			},
+			on: func(fields *fields) {
+				fields.c = mocks.NewClient(t)
+			},
+			Err:     model.ErrNoEpicIDError,
+			wantErr: true,
+		},
+
+		{
+			name: "when the issues are not provided",
+			args: args{
+				ctx:         context.Background(),
+				epicIdOrKey: "TM-1",
+			},
+			on: func(fields *fields) {
+				fields.c = mocks.NewClient(t)
+			},
+			Err:     model.ErrNoIssuesError,
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			service, err := NewEpicService(testCase.fields.c, "1.0")
+			assert.NoError(t, err)
+
+			gotResponse, err := service.Adopt(testCase.args.ctx, testCase.args.epicIdOrKey, testCase.args.issues)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+			}
+		})
+	}
+}
+
+func Test_EpicService_Orphan(t *testing.T) {
+
+	type fields struct {
+		c service.Client
+	}
+
+	type args struct {
+		ctx    context.Context
+		issues []string
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name: "when the parameters are correct",
+			args: args{
+				ctx:    context.Background(),
+				issues: []string{"TM-2"},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				payload := map[string]interface{}{"fields": map[string]interface{}{
+					"parent": map[string]interface{}{"none": true}}}
+
+				client.On("TransformStructToReader",
+					payload).
+					Return(bytes.NewReader([]byte{}), nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPut,
+					"rest/api/2/issue/TM-2",
+					bytes.NewReader([]byte{})).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					nil).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name: "when the issues are not provided",
+			args: args{
+				ctx: context.Background(),
+			},
+			on: func(fields *fields) {
+				fields.c = mocks.NewClient(t)
+			},
+			Err:     model.ErrNoIssuesError,
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			service, err := NewEpicService(testCase.fields.c, "1.0")
+			assert.NoError(t, err)
+
+			gotResponse, err := service.Orphan(testCase.args.ctx, testCase.args.issues)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,395 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/chrisccoy/go-atlassian/service"
+	"github.com/chrisccoy/go-atlassian/service/jira"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBatchSize     = 50
+	defaultPageSize      = 100
+	defaultEstimateField = "timeoriginalestimate"
+	dueDateLayout        = "2006-01-02"
+)
+
+var (
+	ErrNoCrawlSeeds    = errors.New("graph: no seed issues or JQL query set")
+	ErrUnknownLinkType = errors.New("graph: unknown issue link type")
+)
+
+type CrawlOptions struct {
+
+	// Seeds are the issue keys where the crawl starts.
+	Seeds []string
+
+	// JQL is a query whose issues are added to the seeds.
+	JQL string
+
+	// MaxDepth is the number of link hops followed from the seeds, zero means no limit.
+	MaxDepth int
+
+	// LinkTypes are the names or IDs of the link types followed, all the link types are followed when it's empty.
+	LinkTypes []string
+
+	// EstimateField is the field used as duration on the critical path, by default timeoriginalestimate.
+	EstimateField string
+
+	// EstimateUnit is the duration of one unit of the estimate field, by default one second.
+	// e.g. use 8 * time.Hour when the estimate field stores story points worth a day.
+	EstimateUnit time.Duration
+
+	// BatchSize is the number of issue keys fetched on each issue in (...) JQL query, by default 50.
+	BatchSize int
+}
+
+// Crawler follows the issue links from a set of seed issues, fetching the issues in batches through the search API.
+type Crawler struct {
+	c         service.Client
+	version   string
+	linkTypes jira.LinkTypeConnector
+}
+
+// NewCrawler returns a crawler using the Jira client and API version provided,
+// the link type connector is used to resolve the link types filter, e.g. client.Issue.Link.Type.
+func NewCrawler(client service.Client, version string, linkTypes jira.LinkTypeConnector) (*Crawler, error) {
+
+	if version == "" {
+		return nil, model.ErrNoVersionProvided
+	}
+
+	return &Crawler{c: client, version: version, linkTypes: linkTypes}, nil
+}
+
+type crawlSearchPageScheme struct {
+	StartAt    int                 `json:"startAt"`
+	MaxResults int                 `json:"maxResults"`
+	Total      int                 `json:"total"`
+	Issues     []*crawlIssueScheme `json:"issues"`
+}
+
+type crawlIssueScheme struct {
+	ID     string                     `json:"id"`
+	Key    string                     `json:"key"`
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
+// Crawl builds the dependency graph, the issues found beyond the max depth are added without being fetched.
+func (c *Crawler) Crawl(ctx context.Context, opts *CrawlOptions) (*Graph, error) {
+
+	if opts == nil || (len(opts.Seeds) == 0 && opts.JQL == "") {
+		return nil, ErrNoCrawlSeeds
+	}
+
+	allowed, err := c.allowedLinkTypes(ctx, opts.LinkTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		graph    = New()
+		visited  = make(map[string]bool)
+		frontier []string
+		fetched  []*crawlIssueScheme
+	)
+
+	if opts.JQL != "" {
+
+		fetched, err = c.search(ctx, opts.JQL, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range opts.Seeds {
+		frontier = appendUnique(frontier, key)
+	}
+
+	for _, issue := range fetched {
+		visited[issue.Key] = true
+	}
+
+	for depth := 0; ; depth++ {
+
+		var pending []string
+		for _, key := range frontier {
+			if !visited[key] {
+				pending = append(pending, key)
+				visited[key] = true
+			}
+		}
+
+		for _, batch := range batches(pending, opts.BatchSize) {
+
+			issues, err := c.search(ctx, fmt.Sprintf("issue in (%v)", strings.Join(batch, ", ")), opts)
+			if err != nil {
+				return nil, err
+			}
+
+			fetched = append(fetched, issues...)
+		}
+
+		var next []string
+		for _, issue := range fetched {
+
+			neighbours, err := c.add(graph, issue, opts, allowed)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, neighbour := range neighbours {
+				if !visited[neighbour] {
+					next = appendUnique(next, neighbour)
+				}
+			}
+		}
+
+		fetched = nil
+		frontier = next
+
+		if len(frontier) == 0 || (opts.MaxDepth != 0 && depth+1 >= opts.MaxDepth) {
+			break
+		}
+	}
+
+	return graph, nil
+}
+
+// add adds the issue and its allowed links to the graph, returning the linked issue keys.
+func (c *Crawler) add(graph *Graph, issue *crawlIssueScheme, opts *CrawlOptions, allowed map[string]bool) ([]string, error) {
+
+	node := &Node{ID: issue.ID, Key: issue.Key, Fetched: true}
+
+	var (
+		summary string
+		status  *model.StatusScheme
+		dueDate string
+		links   []*model.IssueLinkScheme
+	)
+
+	if err := decodeField(issue.Fields, "summary", &summary); err != nil {
+		return nil, err
+	}
+
+	if err := decodeField(issue.Fields, "status", &status); err != nil {
+		return nil, err
+	}
+
+	if err := decodeField(issue.Fields, "duedate", &dueDate); err != nil {
+		return nil, err
+	}
+
+	if err := decodeField(issue.Fields, "issuelinks", &links); err != nil {
+		return nil, err
+	}
+
+	node.Summary = summary
+	setNodeStatus(node, status)
+
+	if dueDate != "" {
+
+		parsed, err := time.Parse(dueDateLayout, dueDate)
+		if err != nil {
+			return nil, err
+		}
+
+		node.DueDate = &parsed
+	}
+
+	estimateField, estimateUnit := opts.EstimateField, opts.EstimateUnit
+	if estimateField == "" {
+		estimateField = defaultEstimateField
+	}
+
+	if estimateUnit == 0 {
+		estimateUnit = time.Second
+	}
+
+	var estimate *float64
+	if decodeField(issue.Fields, estimateField, &estimate) == nil && estimate != nil {
+		node.Estimate = time.Duration(*estimate * float64(estimateUnit))
+	}
+
+	if _, err := graph.AddNode(node); err != nil {
+		return nil, err
+	}
+
+	var neighbours []string
+	for _, link := range links {
+
+		if link.Type == nil || (allowed != nil && !allowed[link.Type.ID]) {
+			continue
+		}
+
+		edge := &Edge{ID: link.ID, Type: link.Type.Name, Label: link.Type.Outward}
+
+		var linked *model.LinkedIssueScheme
+		switch {
+		case link.OutwardIssue != nil:
+			linked, edge.From, edge.To = link.OutwardIssue, issue.Key, link.OutwardIssue.Key
+		case link.InwardIssue != nil:
+			linked, edge.From, edge.To = link.InwardIssue, link.InwardIssue.Key, issue.Key
+		default:
+			continue
+		}
+
+		linkedNode := &Node{ID: linked.ID, Key: linked.Key}
+		if linked.Fields != nil {
+			linkedNode.Summary = linked.Fields.Summary
+			setNodeStatus(linkedNode, linked.Fields.Status)
+		}
+
+		if _, err := graph.AddNode(linkedNode); err != nil {
+			return nil, err
+		}
+
+		if _, err := graph.AddEdge(edge); err != nil {
+			return nil, err
+		}
+
+		neighbours = append(neighbours, linked.Key)
+	}
+
+	return neighbours, nil
+}
+
+// allowedLinkTypes resolves the link type names or IDs to a set of IDs, nil means every link type is allowed.
+func (c *Crawler) allowedLinkTypes(ctx context.Context, linkTypes []string) (map[string]bool, error) {
+
+	if len(linkTypes) == 0 {
+		return nil, nil
+	}
+
+	page, _, err := c.linkTypes.Gets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make(map[string]bool, len(linkTypes))
+	for _, wanted := range linkTypes {
+
+		found := false
+		for _, linkType := range page.IssueLinkTypes {
+			if linkType.ID == wanted || strings.EqualFold(linkType.Name, wanted) {
+				allowed[linkType.ID] = true
+				found = true
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("%w: %v", ErrUnknownLinkType, wanted)
+		}
+	}
+
+	return allowed, nil
+}
+
+// search returns every issue matching the JQL query, following the pagination.
+func (c *Crawler) search(ctx context.Context, jql string, opts *CrawlOptions) ([]*crawlIssueScheme, error) {
+
+	estimateField := opts.EstimateField
+	if estimateField == "" {
+		estimateField = defaultEstimateField
+	}
+
+	var issues []*crawlIssueScheme
+	for startAt := 0; ; {
+
+		payload := struct {
+			Jql        string   `json:"jql,omitempty"`
+			MaxResults int      `json:"maxResults,omitempty"`
+			Fields     []string `json:"fields,omitempty"`
+			StartAt    int      `json:"startAt,omitempty"`
+		}{
+			Jql:        jql,
+			MaxResults: defaultPageSize,
+			Fields:     []string{"summary", "status", "duedate", "issuelinks", estimateField},
+			StartAt:    startAt,
+		}
+
+		reader, err := c.c.TransformStructToReader(&payload)
+		if err != nil {
+			return nil, err
+		}
+
+		endpoint := fmt.Sprintf("rest/api/%v/search", c.version)
+
+		request, err := c.c.NewRequest(ctx, http.MethodPost, endpoint, reader)
+		if err != nil {
+			return nil, err
+		}
+
+		page := new(crawlSearchPageScheme)
+		if _, err = c.c.Call(request, page); err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, page.Issues...)
+
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	return issues, nil
+}
+
+func decodeField(fields map[string]json.RawMessage, name string, value interface{}) error {
+
+	raw, ok := fields[name]
+	if !ok {
+		return nil
+	}
+
+	return json.Unmarshal(raw, value)
+}
+
+func setNodeStatus(node *Node, status *model.StatusScheme) {
+
+	if status == nil {
+		return
+	}
+
+	node.Status = status.Name
+	if status.StatusCategory != nil {
+		node.StatusCategory = status.StatusCategory.Key
+	}
+}
+
+func batches(keys []string, size int) [][]string {
+
+	if size <= 0 {
+		size = defaultBatchSize
+	}
+
+	var result [][]string
+	for len(keys) > size {
+		result = append(result, keys[:size])
+		keys = keys[size:]
+	}
+
+	if len(keys) != 0 {
+		result = append(result, keys)
+	}
+
+	return result
+}
+
+func appendUnique(keys []string, key string) []string {
+
+	for _, existing := range keys {
+		if existing == key {
+			return keys
+		}
+	}
+
+	return append(keys, key)
+}
@@ -0,0 +1,153 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/chrisccoy/go-atlassian/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type linkTypeConnectorMocked struct {
+	page *model.IssueLinkTypeSearchScheme
+	err  error
+}
+
+func (l *linkTypeConnectorMocked) Gets(ctx context.Context) (*model.IssueLinkTypeSearchScheme, *model.ResponseScheme, error) {
+	return l.page, &model.ResponseScheme{}, l.err
+}
+
+func (l *linkTypeConnectorMocked) Get(ctx context.Context, issueLinkTypeId string) (*model.LinkTypeScheme, *model.ResponseScheme, error) {
+	return nil, nil, nil
+}
+
+func (l *linkTypeConnectorMocked) Create(ctx context.Context, payload *model.LinkTypeScheme) (*model.LinkTypeScheme, *model.ResponseScheme, error) {
+	return nil, nil, nil
+}
+
+func (l *linkTypeConnectorMocked) Update(ctx context.Context, issueLinkTypeId string, payload *model.LinkTypeScheme) (*model.LinkTypeScheme, *model.ResponseScheme, error) {
+	return nil, nil, nil
+}
+
+func (l *linkTypeConnectorMocked) Delete(ctx context.Context, issueLinkTypeId string) (*model.ResponseScheme, error) {
+	return nil, nil
+}
+
+const (
+	crawlSeedMocked = `{"total": 1, "issues": [{"id": "1", "key": "KP-1", "fields": {
+		"summary": "Seed", "duedate": "2022-01-10", "timeoriginalestimate": 28800,
+		"status": {"name": "To Do", "statusCategory": {"key": "new"}},
+		"issuelinks": [
+			{"id": "100", "type": {"id": "10000", "name": "Blocks", "outward": "blocks"}, "outwardIssue": {"key": "KP-2"}},
+			{"id": "101", "type": {"id": "10001", "name": "Relates", "outward": "relates to"}, "outwardIssue": {"key": "KP-9"}},
+			{"id": "102", "type": {"id": "10000", "name": "Blocks", "outward": "blocks"}, "inwardIssue": {"key": "KP-0",
+				"fields": {"summary": "Embedded", "status": {"name": "Done", "statusCategory": {"key": "done"}}}}}
+		]}}]}`
+
+	crawlNeighboursMocked = `{"total": 2, "issues": [
+		{"id": "0", "key": "KP-0", "fields": {"summary": "Blocker", "issuelinks": [
+			{"id": "102", "type": {"id": "10000", "name": "Blocks", "outward": "blocks"}, "outwardIssue": {"key": "KP-1"}}]}},
+		{"id": "2", "key": "KP-2", "fields": {"summary": "Blocked", "issuelinks": [
+			{"id": "100", "type": {"id": "10000", "name": "Blocks", "outward": "blocks"}, "inwardIssue": {"key": "KP-1"}},
+			{"id": "103", "type": {"id": "10000", "name": "Blocks", "outward": "blocks"}, "outwardIssue": {"key": "KP-3"}}]}}
+	]}`
+)
+
+func mockCrawlSearch(client *mocks.Client, jql, body string) {
+
+	reader := bytes.NewReader([]byte(jql))
+
+	client.On("TransformStructToReader",
+		mock.MatchedBy(func(payload interface{}) bool {
+			encoded, _ := json.Marshal(payload)
+			return bytes.Contains(encoded, []byte(`"jql":"`+jql+`"`))
+		})).
+		Return(reader, nil).Once()
+
+	request := &http.Request{Method: jql}
+
+	client.On("NewRequest",
+		context.Background(),
+		http.MethodPost,
+		"rest/api/3/search",
+		reader).
+		Return(request, nil).Once()
+
+	client.On("Call",
+		request,
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			_ = json.Unmarshal([]byte(body), args.Get(1))
+		}).
+		Return(&model.ResponseScheme{}, nil).Once()
+}
+
+func TestCrawler_Crawl(t *testing.T) {
+
+	linkTypes := &linkTypeConnectorMocked{page: &model.IssueLinkTypeSearchScheme{
+		IssueLinkTypes: []*model.LinkTypeScheme{{ID: "10000", Name: "Blocks"}, {ID: "10001", Name: "Relates"}},
+	}}
+
+	t.Run("when the links are crawled up to the max depth", func(t *testing.T) {
+
+		client := mocks.NewClient(t)
+		mockCrawlSearch(client, "issue in (KP-1)", crawlSeedMocked)
+		mockCrawlSearch(client, "issue in (KP-2, KP-0)", crawlNeighboursMocked)
+
+		crawler, err := NewCrawler(client, "3", linkTypes)
+		assert.NoError(t, err)
+
+		graph, err := crawler.Crawl(context.Background(), &CrawlOptions{
+			Seeds:     []string{"KP-1"},
+			MaxDepth:  2,
+			LinkTypes: []string{"blocks"},
+		})
+		assert.NoError(t, err)
+
+		assert.Len(t, graph.Nodes(), 4)
+		assert.Len(t, graph.Edges(), 3)
+		assert.Nil(t, graph.Node("KP-9"))
+
+		seed := graph.Node("KP-1")
+		assert.Equal(t, 8*time.Hour, seed.Estimate)
+		assert.Equal(t, "new", seed.StatusCategory)
+		assert.Equal(t, time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC), *seed.DueDate)
+
+		assert.True(t, graph.Node("KP-0").Fetched)
+		assert.Equal(t, "Blocker", graph.Node("KP-0").Summary)
+		assert.False(t, graph.Node("KP-3").Fetched)
+
+		order, err := graph.TopologicalOrder()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"KP-0", "KP-1", "KP-2", "KP-3"}, order)
+	})
+
+	t.Run("when the link type does not exist", func(t *testing.T) {
+
+		crawler, err := NewCrawler(mocks.NewClient(t), "3", linkTypes)
+		assert.NoError(t, err)
+
+		_, err = crawler.Crawl(context.Background(), &CrawlOptions{Seeds: []string{"KP-1"}, LinkTypes: []string{"Clones"}})
+		assert.True(t, errors.Is(err, ErrUnknownLinkType))
+	})
+
+	t.Run("when the seeds are not provided", func(t *testing.T) {
+
+		crawler, err := NewCrawler(mocks.NewClient(t), "3", linkTypes)
+		assert.NoError(t, err)
+
+		_, err = crawler.Crawl(context.Background(), &CrawlOptions{})
+		assert.Equal(t, ErrNoCrawlSeeds, err)
+	})
+
+	t.Run("when the version is not provided", func(t *testing.T) {
+		_, err := NewCrawler(mocks.NewClient(t), "", linkTypes)
+		assert.Equal(t, model.ErrNoVersionProvided, err)
+	})
+}
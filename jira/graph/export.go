@@ -0,0 +1,137 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDOT writes the graph on the Graphviz DOT language, the highlighted issues (e.g. the critical path)
+// and the edges between them are drawn in bold red.
+func (g *Graph) WriteDOT(w io.Writer, highlight ...string) error {
+
+	highlighted := keySet(highlight)
+	writer := bufio.NewWriter(w)
+
+	fmt.Fprintln(writer, "digraph issues {")
+	fmt.Fprintln(writer, "  rankdir=LR;")
+	fmt.Fprintln(writer, "  node [shape=box];")
+
+	for _, node := range g.Nodes() {
+
+		attributes := fmt.Sprintf("label=%v", dotQuote(nodeLabel(node, `\n`)))
+		if highlighted[node.Key] {
+			attributes += ", color=red, penwidth=2"
+		}
+
+		fmt.Fprintf(writer, "  %v [%v];\n", dotQuote(node.Key), attributes)
+	}
+
+	for _, edge := range g.edges {
+
+		attributes := fmt.Sprintf("label=%v", dotQuote(edge.Label))
+		if highlighted[edge.From] && highlighted[edge.To] {
+			attributes += ", color=red, penwidth=2"
+		}
+
+		fmt.Fprintf(writer, "  %v -> %v [%v];\n", dotQuote(edge.From), dotQuote(edge.To), attributes)
+	}
+
+	fmt.Fprintln(writer, "}")
+
+	return writer.Flush()
+}
+
+// WriteMermaid writes the graph as a Mermaid flowchart, the highlighted issues use the critical class.
+func (g *Graph) WriteMermaid(w io.Writer, highlight ...string) error {
+
+	highlighted := keySet(highlight)
+	writer := bufio.NewWriter(w)
+
+	fmt.Fprintln(writer, "graph LR")
+
+	for _, node := range g.Nodes() {
+		fmt.Fprintf(writer, "  %v[\"%v\"]\n", mermaidID(node.Key), mermaidEscape(nodeLabel(node, ": ")))
+	}
+
+	for _, edge := range g.edges {
+
+		if edge.Label == "" {
+			fmt.Fprintf(writer, "  %v --> %v\n", mermaidID(edge.From), mermaidID(edge.To))
+			continue
+		}
+
+		fmt.Fprintf(writer, "  %v -->|%v| %v\n", mermaidID(edge.From), mermaidEscape(edge.Label), mermaidID(edge.To))
+	}
+
+	if len(highlighted) != 0 {
+
+		fmt.Fprintln(writer, "  classDef critical stroke:#d00,stroke-width:3px")
+
+		for _, node := range g.Nodes() {
+			if highlighted[node.Key] {
+				fmt.Fprintf(writer, "  class %v critical\n", mermaidID(node.Key))
+			}
+		}
+	}
+
+	return writer.Flush()
+}
+
+// MarshalJSON encodes the nodes sorted by key, the edges and the cycles of the graph.
+func (g *Graph) MarshalJSON() ([]byte, error) {
+
+	return json.Marshal(struct {
+		Nodes  []*Node    `json:"nodes"`
+		Edges  []*Edge    `json:"edges"`
+		Cycles [][]string `json:"cycles,omitempty"`
+	}{
+		Nodes:  g.Nodes(),
+		Edges:  g.Edges(),
+		Cycles: g.Cycles(),
+	})
+}
+
+func nodeLabel(node *Node, separator string) string {
+
+	if node.Summary == "" {
+		return node.Key
+	}
+
+	return node.Key + separator + node.Summary
+}
+
+func dotQuote(value string) string {
+
+	// The \n sequences are kept, so they're rendered as line breaks by Graphviz.
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
+
+func mermaidID(key string) string {
+
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+func mermaidEscape(value string) string {
+
+	replacer := strings.NewReplacer(`"`, "#quot;", "|", "#124;", "\n", " ")
+	return replacer.Replace(value)
+}
+
+func keySet(keys []string) map[string]bool {
+
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+
+	return set
+}
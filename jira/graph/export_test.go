@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGraph_WriteDOT(t *testing.T) {
+
+	graph := newGraphMocked(t, [2]string{"KP-1", "KP-2"})
+	_, _ = graph.AddNode(&Node{Key: "KP-1", Summary: `Migrate the "legacy" API`, Fetched: true})
+
+	var buffer bytes.Buffer
+	assert.NoError(t, graph.WriteDOT(&buffer, "KP-1", "KP-2"))
+
+	expected := `digraph issues {
+  rankdir=LR;
+  node [shape=box];
+  "KP-1" [label="KP-1\nMigrate the \"legacy\" API", color=red, penwidth=2];
+  "KP-2" [label="KP-2", color=red, penwidth=2];
+  "KP-1" -> "KP-2" [label="blocks", color=red, penwidth=2];
+}
+`
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestGraph_WriteMermaid(t *testing.T) {
+
+	graph := newGraphMocked(t, [2]string{"KP-1", "KP-2"})
+	_, _ = graph.AddNode(&Node{Key: "KP-2", Summary: `Release "v2"`, Fetched: true})
+
+	var buffer bytes.Buffer
+	assert.NoError(t, graph.WriteMermaid(&buffer, "KP-2"))
+
+	expected := `graph LR
+  KP_1["KP-1"]
+  KP_2["KP-2: Release #quot;v2#quot;"]
+  KP_1 -->|blocks| KP_2
+  classDef critical stroke:#d00,stroke-width:3px
+  class KP_2 critical
+`
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestGraph_MarshalJSON(t *testing.T) {
+
+	graph := newGraphMocked(t, [2]string{"KP-1", "KP-2"}, [2]string{"KP-2", "KP-1"})
+
+	encoded, err := json.Marshal(graph)
+	assert.NoError(t, err)
+
+	decoded := struct {
+		Nodes  []*Node    `json:"nodes"`
+		Edges  []*Edge    `json:"edges"`
+		Cycles [][]string `json:"cycles"`
+	}{}
+
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Len(t, decoded.Nodes, 2)
+	assert.Len(t, decoded.Edges, 2)
+	assert.Equal(t, [][]string{{"KP-1", "KP-2"}}, decoded.Cycles)
+}
@@ -0,0 +1,303 @@
+// Package graph builds directed dependency graphs from the Jira issue links.
+//
+// The graph can detect cycles, sort the issues topologically, calculate the critical path
+// using the issue estimates and due dates, and be exported to DOT, Mermaid or JSON.
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCycleDetected = errors.New("graph: the dependency graph contains cycles")
+	ErrNoNodeKey     = errors.New("graph: no node key set")
+)
+
+// Node is an issue of the dependency graph.
+type Node struct {
+	ID             string        `json:"id,omitempty"`
+	Key            string        `json:"key"`
+	Summary        string        `json:"summary,omitempty"`
+	Status         string        `json:"status,omitempty"`
+	StatusCategory string        `json:"statusCategory,omitempty"`
+	DueDate        *time.Time    `json:"dueDate,omitempty"`
+	Estimate       time.Duration `json:"estimate,omitempty"`
+
+	// Fetched is false when the issue was found through a link beyond the crawl depth,
+	// only the fields embedded on the link are available in that case.
+	Fetched bool `json:"fetched"`
+}
+
+// Edge is a directed link between two issues, From is the outward issue of the link type.
+// e.g. for the Blocks link type, From blocks To.
+type Edge struct {
+	ID    string `json:"id,omitempty"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Type  string `json:"type,omitempty"`
+	Label string `json:"label,omitempty"`
+}
+
+type Graph struct {
+	nodes    map[string]*Node
+	edges    []*Edge
+	edgeKeys map[string]bool
+	outgoing map[string][]*Edge
+	incoming map[string][]*Edge
+}
+
+func New() *Graph {
+	return &Graph{
+		nodes:    make(map[string]*Node),
+		edgeKeys: make(map[string]bool),
+		outgoing: make(map[string][]*Edge),
+		incoming: make(map[string][]*Edge),
+	}
+}
+
+// AddNode adds the node to the graph, if the key already exists the fetched information wins
+// over the information embedded on the links.
+func (g *Graph) AddNode(node *Node) (*Node, error) {
+
+	if node == nil || node.Key == "" {
+		return nil, ErrNoNodeKey
+	}
+
+	existing, ok := g.nodes[node.Key]
+	if !ok || (node.Fetched && !existing.Fetched) {
+		g.nodes[node.Key] = node
+		return node, nil
+	}
+
+	return existing, nil
+}
+
+// AddEdge adds a directed edge, the edges are de-duplicated by ID, or by endpoints and type when the ID is empty.
+// The endpoints are added as nodes when they don't exist yet.
+func (g *Graph) AddEdge(edge *Edge) (bool, error) {
+
+	if edge == nil || edge.From == "" || edge.To == "" {
+		return false, ErrNoNodeKey
+	}
+
+	key := edge.ID
+	if key == "" {
+		key = fmt.Sprintf("%v|%v|%v", edge.From, edge.To, edge.Type)
+	}
+
+	if g.edgeKeys[key] {
+		return false, nil
+	}
+
+	for _, nodeKey := range []string{edge.From, edge.To} {
+		if _, ok := g.nodes[nodeKey]; !ok {
+			g.nodes[nodeKey] = &Node{Key: nodeKey}
+		}
+	}
+
+	g.edgeKeys[key] = true
+	g.edges = append(g.edges, edge)
+	g.outgoing[edge.From] = append(g.outgoing[edge.From], edge)
+	g.incoming[edge.To] = append(g.incoming[edge.To], edge)
+
+	return true, nil
+}
+
+// Node returns the node with the key provided, or nil.
+func (g *Graph) Node(key string) *Node {
+	return g.nodes[key]
+}
+
+// Nodes returns the nodes sorted by key.
+func (g *Graph) Nodes() []*Node {
+
+	nodes := make([]*Node, 0, len(g.nodes))
+	for _, key := range g.keys() {
+		nodes = append(nodes, g.nodes[key])
+	}
+
+	return nodes
+}
+
+// Edges returns the edges in insertion order.
+func (g *Graph) Edges() []*Edge {
+	return append([]*Edge(nil), g.edges...)
+}
+
+// Successors returns the keys of the issues that depend on the issue provided.
+func (g *Graph) Successors(key string) []string {
+
+	var keys []string
+	for _, edge := range g.outgoing[key] {
+		keys = append(keys, edge.To)
+	}
+
+	return keys
+}
+
+// Predecessors returns the keys of the issues the issue provided depends on.
+func (g *Graph) Predecessors(key string) []string {
+
+	var keys []string
+	for _, edge := range g.incoming[key] {
+		keys = append(keys, edge.From)
+	}
+
+	return keys
+}
+
+// Cycles returns the strongly connected components with more than one issue, and the self-linked issues.
+// Each cycle is sorted by key, and the cycles are sorted by their first key.
+func (g *Graph) Cycles() [][]string {
+
+	var (
+		index    = 0
+		indexes  = make(map[string]int)
+		lowLinks = make(map[string]int)
+		onStack  = make(map[string]bool)
+		stack    []string
+		cycles   [][]string
+		connect  func(key string)
+	)
+
+	// Tarjan's strongly connected components algorithm.
+	connect = func(key string) {
+
+		indexes[key] = index
+		lowLinks[key] = index
+		index++
+
+		stack = append(stack, key)
+		onStack[key] = true
+
+		for _, successor := range g.Successors(key) {
+
+			if _, visited := indexes[successor]; !visited {
+				connect(successor)
+				lowLinks[key] = minInt(lowLinks[key], lowLinks[successor])
+			} else if onStack[successor] {
+				lowLinks[key] = minInt(lowLinks[key], indexes[successor])
+			}
+		}
+
+		if lowLinks[key] != indexes[key] {
+			return
+		}
+
+		var component []string
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+
+			if last == key {
+				break
+			}
+		}
+
+		if len(component) > 1 || g.selfLinked(key) {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, key := range g.keys() {
+		if _, visited := indexes[key]; !visited {
+			connect(key)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+
+	return cycles
+}
+
+// TopologicalOrder returns the issue keys sorted, so every issue is placed after the issues it depends on.
+// The ties are sorted by key, it returns ErrCycleDetected when the graph is not acyclic.
+func (g *Graph) TopologicalOrder() ([]string, error) {
+
+	inDegree := make(map[string]int, len(g.nodes))
+	for key := range g.nodes {
+		inDegree[key] = len(g.incoming[key])
+	}
+
+	var ready []string
+	for _, key := range g.keys() {
+		if inDegree[key] == 0 {
+			ready = append(ready, key)
+		}
+	}
+
+	order := make([]string, 0, len(g.nodes))
+	for len(ready) != 0 {
+
+		key := ready[0]
+		ready = ready[1:]
+		order = append(order, key)
+
+		var released []string
+		for _, successor := range g.Successors(key) {
+			inDegree[successor]--
+			if inDegree[successor] == 0 {
+				released = append(released, successor)
+			}
+		}
+
+		sort.Strings(released)
+		ready = mergeSorted(ready, released)
+	}
+
+	if len(order) != len(g.nodes) {
+		return nil, ErrCycleDetected
+	}
+
+	return order, nil
+}
+
+func (g *Graph) selfLinked(key string) bool {
+
+	for _, successor := range g.Successors(key) {
+		if successor == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g *Graph) keys() []string {
+
+	keys := make([]string, 0, len(g.nodes))
+	for key := range g.nodes {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func mergeSorted(a, b []string) []string {
+
+	merged := make([]string, 0, len(a)+len(b))
+	for len(a) != 0 && len(b) != 0 {
+		if strings.Compare(a[0], b[0]) <= 0 {
+			merged, a = append(merged, a[0]), a[1:]
+		} else {
+			merged, b = append(merged, b[0]), b[1:]
+		}
+	}
+
+	return append(append(merged, a...), b...)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
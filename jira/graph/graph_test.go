@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newGraphMocked(t *testing.T, edges ...[2]string) *Graph {
+
+	graph := New()
+	for _, edge := range edges {
+		_, err := graph.AddEdge(&Edge{From: edge[0], To: edge[1], Type: "Blocks", Label: "blocks"})
+		assert.NoError(t, err)
+	}
+
+	return graph
+}
+
+func TestGraph_AddEdge(t *testing.T) {
+
+	graph := New()
+
+	added, err := graph.AddEdge(&Edge{ID: "10001", From: "KP-1", To: "KP-2"})
+	assert.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = graph.AddEdge(&Edge{ID: "10001", From: "KP-1", To: "KP-2"})
+	assert.NoError(t, err)
+	assert.False(t, added)
+
+	_, err = graph.AddEdge(&Edge{From: "KP-1"})
+	assert.Equal(t, ErrNoNodeKey, err)
+
+	assert.Len(t, graph.Nodes(), 2)
+	assert.Equal(t, []string{"KP-2"}, graph.Successors("KP-1"))
+	assert.Equal(t, []string{"KP-1"}, graph.Predecessors("KP-2"))
+
+	node, err := graph.AddNode(&Node{Key: "KP-1", Summary: "Fetched", Fetched: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "Fetched", node.Summary)
+
+	node, err = graph.AddNode(&Node{Key: "KP-1", Summary: "Embedded on a link"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Fetched", node.Summary)
+}
+
+func TestGraph_Cycles(t *testing.T) {
+
+	testCases := []struct {
+		name  string
+		edges [][2]string
+		want  [][]string
+	}{
+		{
+			name:  "when the graph is acyclic",
+			edges: [][2]string{{"KP-1", "KP-2"}, {"KP-2", "KP-3"}, {"KP-1", "KP-3"}},
+			want:  nil,
+		},
+		{
+			name:  "when the graph contains cycles",
+			edges: [][2]string{{"KP-1", "KP-2"}, {"KP-2", "KP-3"}, {"KP-3", "KP-1"}, {"KP-4", "KP-4"}, {"KP-3", "KP-5"}},
+			want:  [][]string{{"KP-1", "KP-2", "KP-3"}, {"KP-4"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, newGraphMocked(t, testCase.edges...).Cycles())
+		})
+	}
+}
+
+func TestGraph_TopologicalOrder(t *testing.T) {
+
+	graph := newGraphMocked(t, [2]string{"KP-3", "KP-4"}, [2]string{"KP-1", "KP-4"}, [2]string{"KP-2", "KP-3"})
+
+	order, err := graph.TopologicalOrder()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"KP-1", "KP-2", "KP-3", "KP-4"}, order)
+
+	_, err = newGraphMocked(t, [2]string{"KP-1", "KP-2"}, [2]string{"KP-2", "KP-1"}).TopologicalOrder()
+	assert.Equal(t, ErrCycleDetected, err)
+}
+
+func TestGraph_CriticalPath(t *testing.T) {
+
+	start := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	dueDate := start.Add(40 * time.Hour)
+
+	graph := New()
+	for _, node := range []*Node{
+		{Key: "KP-1", Estimate: 8 * time.Hour, Fetched: true},
+		{Key: "KP-2", Estimate: 40 * time.Hour, Fetched: true, DueDate: &dueDate},
+		{Key: "KP-3", Estimate: 16 * time.Hour, Fetched: true},
+		{Key: "KP-4", Estimate: 8 * time.Hour, Fetched: true},
+		{Key: "KP-5", Estimate: 80 * time.Hour, StatusCategory: "done", Fetched: true},
+	} {
+		_, err := graph.AddNode(node)
+		assert.NoError(t, err)
+	}
+
+	for _, edge := range [][2]string{{"KP-1", "KP-2"}, {"KP-1", "KP-3"}, {"KP-2", "KP-4"}, {"KP-3", "KP-4"}, {"KP-5", "KP-3"}} {
+		_, err := graph.AddEdge(&Edge{From: edge[0], To: edge[1]})
+		assert.NoError(t, err)
+	}
+
+	schedule, err := graph.CriticalPath(start)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"KP-1", "KP-2", "KP-4"}, schedule.Path)
+	assert.Equal(t, 56*time.Hour, schedule.Duration)
+	assert.Equal(t, start.Add(56*time.Hour), schedule.Finish)
+	assert.Equal(t, 24*time.Hour, schedule.Items["KP-3"].Slack)
+	assert.False(t, schedule.Items["KP-3"].Critical)
+	assert.Equal(t, start.Add(32*time.Hour), schedule.Items["KP-3"].LatestStart)
+	assert.Equal(t, time.Duration(0), schedule.Items["KP-5"].Duration)
+	assert.Equal(t, []string{"KP-2"}, schedule.Late)
+	assert.Equal(t, -8*time.Hour, *schedule.Items["KP-2"].DueDateSlack)
+
+	_, err = newGraphMocked(t, [2]string{"KP-1", "KP-1"}).CriticalPath(start)
+	assert.Equal(t, ErrCycleDetected, err)
+}
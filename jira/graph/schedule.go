@@ -0,0 +1,164 @@
+package graph
+
+import (
+	"sort"
+	"time"
+)
+
+const doneStatusCategoryKey = "done"
+
+// Schedule is the result of the critical path method applied to the graph.
+type Schedule struct {
+	Start    time.Time                `json:"start"`
+	Finish   time.Time                `json:"finish"`
+	Duration time.Duration            `json:"duration"`
+	Path     []string                 `json:"path"`
+	Items    map[string]*ScheduleItem `json:"items"`
+
+	// Late are the issues whose earliest finish is after their due date, sorted by key.
+	Late []string `json:"late,omitempty"`
+}
+
+type ScheduleItem struct {
+	Key            string        `json:"key"`
+	Duration       time.Duration `json:"duration"`
+	EarliestStart  time.Time     `json:"earliestStart"`
+	EarliestFinish time.Time     `json:"earliestFinish"`
+	LatestStart    time.Time     `json:"latestStart"`
+	LatestFinish   time.Time     `json:"latestFinish"`
+	Slack          time.Duration `json:"slack"`
+	Critical       bool          `json:"critical"`
+
+	// DueDateSlack is the time between the earliest finish and the due date, negative when the issue is late.
+	DueDateSlack *time.Duration `json:"dueDateSlack,omitempty"`
+}
+
+// CriticalPath schedules the issues from the start time provided, using the estimates as durations.
+//
+// The issues on the done status category don't consume time, the estimate is the remaining work.
+// The critical path is the longest chain of dependent issues, the issues on it have no slack.
+// It returns ErrCycleDetected when the graph is not acyclic.
+func (g *Graph) CriticalPath(start time.Time) (*Schedule, error) {
+
+	order, err := g.TopologicalOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		earliestStart  = make(map[string]time.Duration, len(order))
+		earliestFinish = make(map[string]time.Duration, len(order))
+		latestFinish   = make(map[string]time.Duration, len(order))
+		durations      = make(map[string]time.Duration, len(order))
+		end            time.Duration
+	)
+
+	// Forward pass, the earliest start is the latest finish of the predecessors.
+	for _, key := range order {
+
+		durations[key] = g.duration(key)
+
+		for _, predecessor := range g.Predecessors(key) {
+			if earliestFinish[predecessor] > earliestStart[key] {
+				earliestStart[key] = earliestFinish[predecessor]
+			}
+		}
+
+		earliestFinish[key] = earliestStart[key] + durations[key]
+		if earliestFinish[key] > end {
+			end = earliestFinish[key]
+		}
+	}
+
+	// Backward pass, the latest finish is the earliest latest start of the successors.
+	for i := len(order) - 1; i >= 0; i-- {
+
+		key := order[i]
+		latestFinish[key] = end
+
+		for _, successor := range g.Successors(key) {
+			if latestStart := latestFinish[successor] - durations[successor]; latestStart < latestFinish[key] {
+				latestFinish[key] = latestStart
+			}
+		}
+	}
+
+	schedule := &Schedule{
+		Start:    start,
+		Finish:   start.Add(end),
+		Duration: end,
+		Items:    make(map[string]*ScheduleItem, len(order)),
+	}
+
+	for _, key := range order {
+
+		item := &ScheduleItem{
+			Key:            key,
+			Duration:       durations[key],
+			EarliestStart:  start.Add(earliestStart[key]),
+			EarliestFinish: start.Add(earliestFinish[key]),
+			LatestStart:    start.Add(latestFinish[key] - durations[key]),
+			LatestFinish:   start.Add(latestFinish[key]),
+			Slack:          latestFinish[key] - earliestFinish[key],
+		}
+
+		item.Critical = item.Slack == 0
+
+		if node := g.nodes[key]; node.DueDate != nil {
+
+			dueDateSlack := node.DueDate.Sub(item.EarliestFinish)
+			item.DueDateSlack = &dueDateSlack
+
+			if dueDateSlack < 0 {
+				schedule.Late = append(schedule.Late, key)
+			}
+		}
+
+		schedule.Items[key] = item
+	}
+
+	sort.Strings(schedule.Late)
+	schedule.Path = g.criticalChain(order, schedule.Items, earliestStart, earliestFinish, end)
+
+	return schedule, nil
+}
+
+// criticalChain walks back from the last critical issue, through the critical predecessors that finish
+// when the issue starts. The ties are broken by key, so the path is deterministic.
+func (g *Graph) criticalChain(order []string, items map[string]*ScheduleItem, earliestStart, earliestFinish map[string]time.Duration,
+	end time.Duration) []string {
+
+	var current string
+	for _, key := range order {
+		if items[key].Critical && earliestFinish[key] == end && (current == "" || key < current) {
+			current = key
+		}
+	}
+
+	var path []string
+	for current != "" {
+
+		path = append([]string{current}, path...)
+
+		var next string
+		for _, predecessor := range g.Predecessors(current) {
+			if items[predecessor].Critical && earliestFinish[predecessor] == earliestStart[current] && (next == "" || predecessor < next) {
+				next = predecessor
+			}
+		}
+
+		current = next
+	}
+
+	return path
+}
+
+func (g *Graph) duration(key string) time.Duration {
+
+	node := g.nodes[key]
+	if node.StatusCategory == doneStatusCategoryKey {
+		return 0
+	}
+
+	return node.Estimate
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/attachment"
 	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
 	"github.com/chrisccoy/go-atlassian/service"
 	"github.com/chrisccoy/go-atlassian/service/jira"
@@ -89,6 +90,36 @@ func (i *IssueAttachmentService) Download(ctx context.Context, attachmentID stri
 	return i.internalClient.Download(ctx, attachmentID, redirect)
 }
 
+// Stream returns the contents of an attachment as a stream, the body isn't buffered on memory.
+//
+// The Range header is sent when the options offset is set, the size and the SHA-256 checksum
+// are verified when the stream is fully read. The caller must close the stream.
+//
+// GET /rest/api/{2-3}/attachment/content/{id}
+//
+// https://docs.go-atlassian.io/jira-software-cloud/issues/attachments#download-attachment
+func (i *IssueAttachmentService) Stream(ctx context.Context, attachmentID string, opts *model.AttachmentDownloadOptionsScheme) (io.ReadCloser, *model.ResponseScheme, error) {
+	return i.internalClient.Stream(ctx, attachmentID, opts)
+}
+
+// DownloadTo copies the contents of an attachment into the writer, returning the bytes written.
+//
+// GET /rest/api/{2-3}/attachment/content/{id}
+//
+// https://docs.go-atlassian.io/jira-software-cloud/issues/attachments#download-attachment
+func (i *IssueAttachmentService) DownloadTo(ctx context.Context, attachmentID string, writer io.Writer, opts *model.AttachmentDownloadOptionsScheme) (int64, *model.ResponseScheme, error) {
+	return i.internalClient.DownloadTo(ctx, attachmentID, writer, opts)
+}
+
+// Upload adds one or more attachments to an issue on a single multipart request, the files are streamed from their readers.
+//
+// POST /rest/api/{2-3}/issue/{issueIdOrKey}/attachments
+//
+// https://docs.go-atlassian.io/jira-software-cloud/issues/attachments#add-attachment
+func (i *IssueAttachmentService) Upload(ctx context.Context, issueKeyOrId string, files []*model.AttachmentFileScheme, progress model.AttachmentProgressFunc) ([]*model.AttachmentScheme, *model.ResponseScheme, error) {
+	return i.internalClient.Upload(ctx, issueKeyOrId, files, progress)
+}
+
 type internalIssueAttachmentServiceImpl struct {
 	c       service.Client
 	version string
@@ -241,3 +272,98 @@ func (i *internalIssueAttachmentServiceImpl) Add(ctx context.Context, issueKeyOr
 
 	return attachments, response, nil
 }
+
+func (i *internalIssueAttachmentServiceImpl) Stream(ctx context.Context, attachmentID string, opts *model.AttachmentDownloadOptionsScheme) (io.ReadCloser, *model.ResponseScheme, error) {
+
+	if attachmentID == "" {
+		return nil, nil, model.ErrNoAttachmentIDError
+	}
+
+	endpoint := fmt.Sprintf("rest/api/%v/attachment/content/%v", i.version, attachmentID)
+
+	request, err := i.c.NewRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment.SetRange(request, opts)
+
+	doer, ok := i.c.(service.Doer)
+	if !ok {
+		return nil, nil, model.ErrNoDoerError
+	}
+
+	response, err := doer.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		defer response.Body.Close()
+
+		transformed, err := i.c.TransformTheHTTPResponse(response, nil)
+		return nil, transformed, err
+	}
+
+	stream, err := attachment.NewStream(response, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stream, &model.ResponseScheme{
+		Response: response,
+		Code:     response.StatusCode,
+		Endpoint: request.URL.String(),
+		Method:   request.Method,
+	}, nil
+}
+
+func (i *internalIssueAttachmentServiceImpl) DownloadTo(ctx context.Context, attachmentID string, writer io.Writer, opts *model.AttachmentDownloadOptionsScheme) (int64, *model.ResponseScheme, error) {
+
+	if writer == nil {
+		return 0, nil, model.ErrNoWriterError
+	}
+
+	stream, response, err := i.Stream(ctx, attachmentID, opts)
+	if err != nil {
+		return 0, response, err
+	}
+
+	defer stream.Close()
+
+	written, err := io.Copy(writer, stream)
+	if err != nil {
+		return written, response, err
+	}
+
+	return written, response, nil
+}
+
+func (i *internalIssueAttachmentServiceImpl) Upload(ctx context.Context, issueKeyOrId string, files []*model.AttachmentFileScheme, progress model.AttachmentProgressFunc) ([]*model.AttachmentScheme, *model.ResponseScheme, error) {
+
+	if issueKeyOrId == "" {
+		return nil, nil, model.ErrNoIssueKeyOrIDError
+	}
+
+	body, contentType, err := attachment.NewMultipart(files, nil, progress)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer body.Close()
+
+	endpoint := fmt.Sprintf("rest/api/%v/issue/%v/attachments", i.version, issueKeyOrId)
+
+	request, err := i.c.NewFormRequest(ctx, http.MethodPost, endpoint, contentType, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var attachments []*model.AttachmentScheme
+	response, err := i.c.Call(request, &attachments)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return attachments, response, nil
+}
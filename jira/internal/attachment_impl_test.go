@@ -1,17 +1,25 @@
 package internal
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
 	"github.com/chrisccoy/go-atlassian/service"
 	"github.com/chrisccoy/go-atlassian/service/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -919,3 +927,548 @@ func Test_internalIssueAttachmentServiceImpl_Download(t *testing.T) {
 		})
 	}
 }
+
+func Test_internalIssueAttachmentServiceImpl_DownloadTo(t *testing.T) {
+
+	content := "hello world"
+	checksum := sha256.Sum256([]byte(content))
+
+	type fields struct {
+		c       service.Client
+		version string
+	}
+
+	type args struct {
+		ctx          context.Context
+		attachmentID string
+		writer       *bytes.Buffer
+		opts         *model.AttachmentDownloadOptionsScheme
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		want    string
+		wantErr bool
+		Err     error
+	}{
+		{
+			name:   "when the checksum and the size are verified",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:          context.Background(),
+				attachmentID: "1110",
+				writer:       &bytes.Buffer{},
+				opts: &model.AttachmentDownloadOptionsScheme{
+					Size:   int64(len(content)),
+					SHA256: hex.EncodeToString(checksum[:]),
+				},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				request, _ := http.NewRequest(http.MethodGet, "https://ctreminiom.atlassian.net/rest/api/3/attachment/content/1110", nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/3/attachment/content/1110",
+					nil).
+					Return(request, nil)
+
+				client.On("Do",
+					mock.MatchedBy(func(request *http.Request) bool { return request.Header.Get("Range") == "" })).
+					Return(&http.Response{
+						StatusCode:    http.StatusOK,
+						ContentLength: int64(len(content)),
+						Body:          ioutil.NopCloser(strings.NewReader(content)),
+					}, nil)
+
+				fields.c = client
+			},
+			want: content,
+		},
+
+		{
+			name:   "when the download is resumed",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:          context.Background(),
+				attachmentID: "1110",
+				writer:       &bytes.Buffer{},
+				opts: &model.AttachmentDownloadOptionsScheme{
+					Offset:  6,
+					Partial: strings.NewReader("hello "),
+					SHA256:  hex.EncodeToString(checksum[:]),
+				},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				request, _ := http.NewRequest(http.MethodGet, "https://ctreminiom.atlassian.net/rest/api/3/attachment/content/1110", nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/3/attachment/content/1110",
+					nil).
+					Return(request, nil)
+
+				client.On("Do",
+					mock.MatchedBy(func(request *http.Request) bool { return request.Header.Get("Range") == "bytes=6-" })).
+					Return(&http.Response{
+						StatusCode: http.StatusPartialContent,
+						Header:     http.Header{"Content-Range": []string{"bytes 6-10/11"}},
+						Body:       ioutil.NopCloser(strings.NewReader("world")),
+					}, nil)
+
+				fields.c = client
+			},
+			want: "world",
+		},
+
+		{
+			name:   "when the range header is ignored by the server",
+			fields: fields{version: "2"},
+			args: args{
+				ctx:          context.Background(),
+				attachmentID: "1110",
+				writer:       &bytes.Buffer{},
+				opts:         &model.AttachmentDownloadOptionsScheme{Offset: 6, Size: int64(len(content))},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				request, _ := http.NewRequest(http.MethodGet, "https://ctreminiom.atlassian.net/rest/api/2/attachment/content/1110", nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/2/attachment/content/1110",
+					nil).
+					Return(request, nil)
+
+				client.On("Do", mock.Anything).
+					Return(&http.Response{
+						StatusCode:    http.StatusOK,
+						ContentLength: int64(len(content)),
+						Body:          ioutil.NopCloser(strings.NewReader(content)),
+					}, nil)
+
+				fields.c = client
+			},
+			want: "world",
+		},
+
+		{
+			name:   "when the checksum doesn't match",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:          context.Background(),
+				attachmentID: "1110",
+				writer:       &bytes.Buffer{},
+				opts:         &model.AttachmentDownloadOptionsScheme{SHA256: "0000"},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				request, _ := http.NewRequest(http.MethodGet, "https://ctreminiom.atlassian.net/rest/api/3/attachment/content/1110", nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/3/attachment/content/1110",
+					nil).
+					Return(request, nil)
+
+				client.On("Do", mock.Anything).
+					Return(&http.Response{
+						StatusCode:    http.StatusOK,
+						ContentLength: -1,
+						Body:          ioutil.NopCloser(strings.NewReader(content)),
+					}, nil)
+
+				fields.c = client
+			},
+			wantErr: true,
+			Err:     fmt.Errorf("%w: %v, 0000 expected", model.ErrAttachmentChecksumMismatchError, hex.EncodeToString(checksum[:])),
+		},
+
+		{
+			name:   "when the body is truncated",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:          context.Background(),
+				attachmentID: "1110",
+				writer:       &bytes.Buffer{},
+				opts:         &model.AttachmentDownloadOptionsScheme{Size: 20},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				request, _ := http.NewRequest(http.MethodGet, "https://ctreminiom.atlassian.net/rest/api/3/attachment/content/1110", nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/3/attachment/content/1110",
+					nil).
+					Return(request, nil)
+
+				client.On("Do", mock.Anything).
+					Return(&http.Response{
+						StatusCode:    http.StatusOK,
+						ContentLength: -1,
+						Body:          ioutil.NopCloser(strings.NewReader(content)),
+					}, nil)
+
+				fields.c = client
+			},
+			wantErr: true,
+			Err:     fmt.Errorf("%w: 11 bytes read, 20 bytes expected", model.ErrAttachmentSizeMismatchError),
+		},
+
+		{
+			name:   "when the resumed download checksum can't be verified",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:          context.Background(),
+				attachmentID: "1110",
+				writer:       &bytes.Buffer{},
+				opts:         &model.AttachmentDownloadOptionsScheme{Offset: 6, SHA256: hex.EncodeToString(checksum[:])},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				request, _ := http.NewRequest(http.MethodGet, "https://ctreminiom.atlassian.net/rest/api/3/attachment/content/1110", nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/3/attachment/content/1110",
+					nil).
+					Return(request, nil)
+
+				client.On("Do", mock.Anything).
+					Return(&http.Response{
+						StatusCode: http.StatusPartialContent,
+						Body:       ioutil.NopCloser(strings.NewReader("world")),
+					}, nil)
+
+				fields.c = client
+			},
+			wantErr: true,
+			Err:     model.ErrNoAttachmentPartialError,
+		},
+
+		{
+			name:   "when the response status is not valid",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:          context.Background(),
+				attachmentID: "1110",
+				writer:       &bytes.Buffer{},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				request, _ := http.NewRequest(http.MethodGet, "https://ctreminiom.atlassian.net/rest/api/3/attachment/content/1110", nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/3/attachment/content/1110",
+					nil).
+					Return(request, nil)
+
+				response := &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       ioutil.NopCloser(strings.NewReader("{}")),
+				}
+
+				client.On("Do", mock.Anything).
+					Return(response, nil)
+
+				client.On("TransformTheHTTPResponse", response, nil).
+					Return(&model.ResponseScheme{Code: http.StatusNotFound}, model.ErrInvalidStatusCodeError)
+
+				fields.c = client
+			},
+			wantErr: true,
+			Err:     model.ErrInvalidStatusCodeError,
+		},
+
+		{
+			name:   "when the client doesn't implement the doer interface",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:          context.Background(),
+				attachmentID: "1110",
+				writer:       &bytes.Buffer{},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				request, _ := http.NewRequest(http.MethodGet, "https://ctreminiom.atlassian.net/rest/api/3/attachment/content/1110", nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/3/attachment/content/1110",
+					nil).
+					Return(request, nil)
+
+				// the embedded interface hides the Do method of the mock
+				fields.c = struct{ service.Client }{client}
+			},
+			wantErr: true,
+			Err:     model.ErrNoDoerError,
+		},
+
+		{
+			name:   "when the attachment id is not provided",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:    context.Background(),
+				writer: &bytes.Buffer{},
+			},
+			wantErr: true,
+			Err:     model.ErrNoAttachmentIDError,
+		},
+
+		{
+			name:   "when the writer is not provided",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:          context.Background(),
+				attachmentID: "1110",
+			},
+			wantErr: true,
+			Err:     model.ErrNoWriterError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			attachmentService, err := NewIssueAttachmentService(testCase.fields.c, testCase.fields.version)
+			assert.NoError(t, err)
+
+			var writer io.Writer
+			if testCase.args.writer != nil {
+				writer = testCase.args.writer
+			}
+
+			written, _, err := attachmentService.DownloadTo(testCase.args.ctx, testCase.args.attachmentID, writer, testCase.args.opts)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, testCase.args.writer.String())
+				assert.Equal(t, int64(len(testCase.want)), written)
+			}
+		})
+	}
+}
+
+func Test_internalIssueAttachmentServiceImpl_Upload(t *testing.T) {
+
+	type fields struct {
+		c       service.Client
+		version string
+	}
+
+	type args struct {
+		ctx          context.Context
+		issueKeyOrId string
+		files        []*model.AttachmentFileScheme
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name:   "when the files are streamed on a single request",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:          context.Background(),
+				issueKeyOrId: "DUMMY-1",
+				files: []*model.AttachmentFileScheme{
+					{Name: "first.txt", Reader: strings.NewReader("first"), Size: 5},
+					{Name: "second.txt", Reader: strings.NewReader("second"), Size: 6},
+				},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				var (
+					payload     io.Reader
+					contentType string
+				)
+
+				client.On("NewFormRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/api/3/issue/DUMMY-1/attachments",
+					mock.Anything,
+					mock.Anything).
+					Run(func(args mock.Arguments) {
+						contentType, payload = args.String(3), args.Get(4).(io.Reader)
+					}).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					mock.Anything).
+					Run(func(args mock.Arguments) {
+
+						_, params, err := mime.ParseMediaType(contentType)
+						assert.NoError(t, err)
+
+						var names []string
+						reader := multipart.NewReader(payload, params["boundary"])
+						for {
+							part, err := reader.NextPart()
+							if err == io.EOF {
+								break
+							}
+
+							assert.NoError(t, err)
+							names = append(names, part.FileName())
+						}
+
+						assert.Equal(t, []string{"first.txt", "second.txt"}, names)
+
+						attachments := args.Get(1).(*[]*model.AttachmentScheme)
+						*attachments = []*model.AttachmentScheme{{ID: "1"}, {ID: "2"}}
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name:   "when the issue key or id is not provided",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:   context.Background(),
+				files: []*model.AttachmentFileScheme{{Name: "first.txt", Reader: strings.NewReader("first")}},
+			},
+			wantErr: true,
+			Err:     model.ErrNoIssueKeyOrIDError,
+		},
+
+		{
+			name:   "when the files are not provided",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:          context.Background(),
+				issueKeyOrId: "DUMMY-1",
+			},
+			wantErr: true,
+			Err:     model.ErrNoAttachmentFilesError,
+		},
+
+		{
+			name:   "when a file has no reader",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:          context.Background(),
+				issueKeyOrId: "DUMMY-1",
+				files:        []*model.AttachmentFileScheme{{Name: "first.txt"}},
+			},
+			wantErr: true,
+			Err:     fmt.Errorf("%w: file 0", model.ErrInvalidAttachmentFileError),
+		},
+
+		{
+			name:   "when the http request cannot be created",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:          context.Background(),
+				issueKeyOrId: "DUMMY-1",
+				files:        []*model.AttachmentFileScheme{{Name: "first.txt", Reader: strings.NewReader("first")}},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("NewFormRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/api/3/issue/DUMMY-1/attachments",
+					mock.Anything,
+					mock.Anything).
+					Return(&http.Request{}, errors.New("error, unable to create the http request"))
+
+				fields.c = client
+			},
+			wantErr: true,
+			Err:     errors.New("error, unable to create the http request"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			attachmentService, err := NewIssueAttachmentService(testCase.fields.c, testCase.fields.version)
+			assert.NoError(t, err)
+
+			var progress []int64
+			gotResult, gotResponse, err := attachmentService.Upload(testCase.args.ctx, testCase.args.issueKeyOrId, testCase.args.files,
+				func(transferred, total int64) {
+					assert.Equal(t, int64(11), total)
+					progress = append(progress, transferred)
+				})
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.Len(t, gotResult, 2)
+				assert.Equal(t, []int64{5, 11}, progress)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/chrisccoy/go-atlassian/service"
 	"github.com/chrisccoy/go-atlassian/service/common"
 	"github.com/chrisccoy/go-atlassian/service/mocks"
+	"net/http"
 	"reflect"
 	"testing"
 )
@@ -181,6 +182,30 @@ func TestAuthenticationService_HasUserAgent(t *testing.T) {
 	}
 }
 
+func TestAuthenticationService_SetRequestSigner(t *testing.T) {
+
+	signer := &connectSignerMock{}
+
+	var a common.RequestSignerSetter = &AuthenticationService{}
+	if a.HasRequestSigner() {
+		t.Errorf("HasRequestSigner() = true, want false")
+	}
+
+	a.SetRequestSigner(signer)
+
+	if !a.HasRequestSigner() {
+		t.Errorf("HasRequestSigner() = false, want true")
+	}
+
+	if got := a.GetRequestSigner(); got != signer {
+		t.Errorf("GetRequestSigner() = %v, want %v", got, signer)
+	}
+}
+
+type connectSignerMock struct{}
+
+func (c *connectSignerMock) Sign(request *http.Request) error { return nil }
+
 func TestNewAuthenticationService(t *testing.T) {
 
 	clientMocked := mocks.NewClient(t)
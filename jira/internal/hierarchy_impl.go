@@ -0,0 +1,493 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/chrisccoy/go-atlassian/service"
+	"github.com/chrisccoy/go-atlassian/service/jira"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultHierarchyBatchSize     = 50
+	defaultHierarchyPageSize      = 100
+	defaultHierarchyEstimateField = "timeoriginalestimate"
+	subTaskHierarchyLevel         = -1
+	doneStatusCategoryKey         = "done"
+)
+
+func NewHierarchyService(client service.Client, version string) (*HierarchyService, error) {
+
+	if version == "" {
+		return nil, model.ErrNoVersionProvided
+	}
+
+	return &HierarchyService{
+		internalClient: &internalHierarchyImpl{c: client, version: version},
+	}, nil
+}
+
+type HierarchyService struct {
+	internalClient jira.HierarchyConnector
+}
+
+// Tree builds the hierarchy below an issue, e.g. Initiative → Epic → Story → Sub-task.
+//
+// The children are fetched level by level using batched parent in (...) JQL queries, the site issue type
+// hierarchy levels decide which issues can have children.
+//
+// GET /rest/api/{2-3}/issuetype
+//
+// POST /rest/api/{2-3}/search
+//
+// https://docs.go-atlassian.io/jira-software-cloud/issues/hierarchy#get-issue-tree
+func (h *HierarchyService) Tree(ctx context.Context, issueKeyOrID string, opts *model.IssueHierarchyOptionsScheme) (*model.IssueHierarchyScheme, *model.ResponseScheme, error) {
+	return h.internalClient.Tree(ctx, issueKeyOrID, opts)
+}
+
+// Search builds the hierarchies below the issues returned by a JQL query.
+//
+// Issues returned by the query that belong to another returned issue are nested instead of being roots.
+//
+// GET /rest/api/{2-3}/issuetype
+//
+// POST /rest/api/{2-3}/search
+//
+// https://docs.go-atlassian.io/jira-software-cloud/issues/hierarchy#search-issue-trees
+func (h *HierarchyService) Search(ctx context.Context, jql string, opts *model.IssueHierarchyOptionsScheme) (*model.IssueHierarchyScheme, *model.ResponseScheme, error) {
+	return h.internalClient.Search(ctx, jql, opts)
+}
+
+type internalHierarchyImpl struct {
+	c       service.Client
+	version string
+}
+
+// hierarchySearchPageScheme keeps the issue fields as raw messages, so the estimate and the
+// Epic Link custom fields can be decoded regardless of their ID.
+type hierarchySearchPageScheme struct {
+	StartAt    int `json:"startAt"`
+	MaxResults int `json:"maxResults"`
+	Total      int `json:"total"`
+	Issues     []*struct {
+		ID     string                     `json:"id"`
+		Key    string                     `json:"key"`
+		Fields map[string]json.RawMessage `json:"fields"`
+	} `json:"issues"`
+}
+
+func (i *internalHierarchyImpl) Tree(ctx context.Context, issueKeyOrID string, opts *model.IssueHierarchyOptionsScheme) (*model.IssueHierarchyScheme, *model.ResponseScheme, error) {
+
+	if issueKeyOrID == "" {
+		return nil, nil, model.ErrNoIssueKeyOrIDError
+	}
+
+	tree, response, err := i.Search(ctx, fmt.Sprintf("issue = %v", issueKeyOrID), opts)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if len(tree.Roots) == 0 {
+		return nil, response, model.ErrNoHierarchyRootError
+	}
+
+	return tree, response, nil
+}
+
+func (i *internalHierarchyImpl) Search(ctx context.Context, jql string, opts *model.IssueHierarchyOptionsScheme) (*model.IssueHierarchyScheme, *model.ResponseScheme, error) {
+
+	if jql == "" {
+		return nil, nil, model.ErrNoJQLError
+	}
+
+	if opts == nil {
+		opts = &model.IssueHierarchyOptionsScheme{}
+	}
+
+	levels, response, err := i.levels(ctx)
+	if err != nil {
+		return nil, response, err
+	}
+
+	seeds, response, err := i.search(ctx, jql, opts, levels)
+	if err != nil {
+		return nil, response, err
+	}
+
+	var (
+		nodes    = make(map[string]*model.IssueHierarchyNodeScheme)
+		attached = make(map[string]bool)
+		frontier []*model.IssueHierarchyNodeScheme
+	)
+
+	for _, seed := range seeds {
+		if _, ok := nodes[seed.Key]; !ok {
+			nodes[seed.Key] = seed
+			frontier = append(frontier, seed)
+		}
+	}
+
+	for depth := 0; len(frontier) != 0 && (opts.MaxDepth == 0 || depth < opts.MaxDepth); depth++ {
+
+		var parents []string
+		for _, node := range frontier {
+			if node.Level > subTaskHierarchyLevel {
+				parents = append(parents, node.Key)
+			}
+		}
+
+		var next []*model.IssueHierarchyNodeScheme
+		for _, batch := range hierarchyBatches(parents, opts.BatchSize) {
+
+			children, response, err := i.search(ctx, hierarchyChildrenJQL(batch, opts.EpicLinkField), opts, levels)
+			if err != nil {
+				return nil, response, err
+			}
+
+			for _, child := range children {
+
+				parent, ok := nodes[child.ParentKey]
+				if !ok || attached[child.Key] || child.Key == parent.Key || child.Level >= parent.Level {
+					continue
+				}
+
+				if existing, ok := nodes[child.Key]; ok {
+					// The child was returned by the seed query, it's moved below its parent.
+					child = existing
+				} else {
+					nodes[child.Key] = child
+					next = append(next, child)
+				}
+
+				parent.Children = append(parent.Children, child)
+				attached[child.Key] = true
+			}
+		}
+
+		frontier = next
+	}
+
+	tree := &model.IssueHierarchyScheme{}
+	for _, seed := range seeds {
+		if !attached[seed.Key] && nodes[seed.Key] == seed {
+			tree.Roots = append(tree.Roots, seed)
+		}
+	}
+
+	for _, root := range tree.Roots {
+		rollUpHierarchyNode(root)
+	}
+
+	tree.Levels = summarizeHierarchyLevels(tree.Roots)
+
+	return tree, response, nil
+}
+
+// levels returns the hierarchy level of each issue type available on the site.
+func (i *internalHierarchyImpl) levels(ctx context.Context) (map[string]int, *model.ResponseScheme, error) {
+
+	endpoint := fmt.Sprintf("rest/api/%v/issuetype", i.version)
+
+	request, err := i.c.NewRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var types []*model.IssueTypeScheme
+	response, err := i.c.Call(request, &types)
+	if err != nil {
+		return nil, response, err
+	}
+
+	levels := make(map[string]int, len(types))
+	for _, issueType := range types {
+
+		level := issueType.HierarchyLevel
+		if issueType.Subtask {
+			level = subTaskHierarchyLevel
+		}
+
+		levels[issueType.ID] = level
+	}
+
+	return levels, response, nil
+}
+
+// search returns every issue matching the JQL query, following the pagination.
+func (i *internalHierarchyImpl) search(ctx context.Context, jql string, opts *model.IssueHierarchyOptionsScheme, levels map[string]int) (
+	[]*model.IssueHierarchyNodeScheme, *model.ResponseScheme, error) {
+
+	estimateField := opts.EstimateField
+	if estimateField == "" {
+		estimateField = defaultHierarchyEstimateField
+	}
+
+	fields := []string{"summary", "status", "issuetype", "parent", estimateField}
+	if opts.EpicLinkField != "" {
+		fields = append(fields, opts.EpicLinkField)
+	}
+	fields = append(fields, opts.Fields...)
+
+	var (
+		nodes    []*model.IssueHierarchyNodeScheme
+		response *model.ResponseScheme
+	)
+
+	for startAt := 0; ; {
+
+		payload := struct {
+			Jql        string   `json:"jql,omitempty"`
+			MaxResults int      `json:"maxResults,omitempty"`
+			Fields     []string `json:"fields,omitempty"`
+			StartAt    int      `json:"startAt,omitempty"`
+		}{
+			Jql:        jql,
+			MaxResults: defaultHierarchyPageSize,
+			Fields:     fields,
+			StartAt:    startAt,
+		}
+
+		reader, err := i.c.TransformStructToReader(&payload)
+		if err != nil {
+			return nil, response, err
+		}
+
+		endpoint := fmt.Sprintf("rest/api/%v/search", i.version)
+
+		request, err := i.c.NewRequest(ctx, http.MethodPost, endpoint, reader)
+		if err != nil {
+			return nil, response, err
+		}
+
+		page := new(hierarchySearchPageScheme)
+		response, err = i.c.Call(request, page)
+		if err != nil {
+			return nil, response, err
+		}
+
+		for _, issue := range page.Issues {
+
+			node, err := newHierarchyNode(issue.ID, issue.Key, issue.Fields, estimateField, opts.EpicLinkField, levels)
+			if err != nil {
+				return nil, response, err
+			}
+
+			for _, field := range opts.Fields {
+				if value, ok := issue.Fields[field]; ok {
+					if node.Fields == nil {
+						node.Fields = make(map[string]json.RawMessage)
+					}
+					node.Fields[field] = value
+				}
+			}
+
+			nodes = append(nodes, node)
+		}
+
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	return nodes, response, nil
+}
+
+func newHierarchyNode(id, key string, fields map[string]json.RawMessage, estimateField, epicLinkField string, levels map[string]int) (
+	*model.IssueHierarchyNodeScheme, error) {
+
+	node := &model.IssueHierarchyNodeScheme{ID: id, Key: key}
+
+	if value, ok := fields["summary"]; ok {
+		if err := json.Unmarshal(value, &node.Summary); err != nil {
+			return nil, err
+		}
+	}
+
+	if value, ok := fields["status"]; ok {
+		if err := json.Unmarshal(value, &node.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	if value, ok := fields["issuetype"]; ok {
+		if err := json.Unmarshal(value, &node.IssueType); err != nil {
+			return nil, err
+		}
+	}
+
+	if node.IssueType != nil {
+
+		level, ok := levels[node.IssueType.ID]
+		if !ok {
+			level = node.IssueType.HierarchyLevel
+			if node.IssueType.Subtask {
+				level = subTaskHierarchyLevel
+			}
+		}
+
+		node.Level = level
+	}
+
+	if value, ok := fields["parent"]; ok {
+
+		parent := new(model.ParentScheme)
+		if err := json.Unmarshal(value, &parent); err != nil {
+			return nil, err
+		}
+
+		if parent != nil {
+			node.ParentKey = parent.Key
+		}
+	}
+
+	if value, ok := fields[epicLinkField]; ok && node.ParentKey == "" {
+
+		// The Epic Link field is null on issues without epic, the key is only decoded when it's a string.
+		var epicKey string
+		if json.Unmarshal(value, &epicKey) == nil {
+			node.ParentKey = epicKey
+		}
+	}
+
+	if value, ok := fields[estimateField]; ok {
+
+		var estimate *float64
+		if json.Unmarshal(value, &estimate) == nil && estimate != nil {
+			node.Estimate = *estimate
+		}
+	}
+
+	return node, nil
+}
+
+// hierarchyChildrenJQL returns the JQL query used to fetch the children of the parents provided.
+func hierarchyChildrenJQL(parents []string, epicLinkField string) string {
+
+	keys := strings.Join(parents, ", ")
+	jql := fmt.Sprintf("parent in (%v)", keys)
+
+	if epicLinkField != "" {
+		fieldID := strings.TrimPrefix(epicLinkField, "customfield_")
+		jql = fmt.Sprintf("%v OR cf[%v] in (%v)", jql, fieldID, keys)
+	}
+
+	return jql
+}
+
+func hierarchyBatches(keys []string, size int) [][]string {
+
+	if size <= 0 {
+		size = defaultHierarchyBatchSize
+	}
+
+	var batches [][]string
+	for len(keys) > size {
+		batches = append(batches, keys[:size])
+		keys = keys[size:]
+	}
+
+	if len(keys) != 0 {
+		batches = append(batches, keys)
+	}
+
+	return batches
+}
+
+// rollUpHierarchyNode calculates the roll-up of the node and its descendants, children first.
+func rollUpHierarchyNode(node *model.IssueHierarchyNodeScheme) *model.IssueHierarchyRollUpScheme {
+
+	rollUp := &model.IssueHierarchyRollUpScheme{
+		Issues:           1,
+		Estimate:         node.Estimate,
+		StatusCategories: make(map[string]int),
+	}
+
+	category := hierarchyStatusCategory(node)
+	if category != "" {
+		rollUp.StatusCategories[category]++
+	}
+
+	if category == doneStatusCategoryKey {
+		rollUp.DoneEstimate = node.Estimate
+	}
+
+	for _, child := range node.Children {
+
+		childRollUp := rollUpHierarchyNode(child)
+
+		rollUp.Issues += childRollUp.Issues
+		rollUp.Estimate += childRollUp.Estimate
+		rollUp.DoneEstimate += childRollUp.DoneEstimate
+
+		for key, count := range childRollUp.StatusCategories {
+			rollUp.StatusCategories[key] += count
+		}
+	}
+
+	if rollUp.Estimate > 0 {
+		rollUp.Progress = rollUp.DoneEstimate / rollUp.Estimate
+	} else {
+		rollUp.Progress = float64(rollUp.StatusCategories[doneStatusCategoryKey]) / float64(rollUp.Issues)
+	}
+
+	node.RollUp = rollUp
+	return rollUp
+}
+
+// summarizeHierarchyLevels aggregates the nodes of the trees by hierarchy level, from the top level down.
+func summarizeHierarchyLevels(roots []*model.IssueHierarchyNodeScheme) []*model.IssueHierarchyLevelScheme {
+
+	var (
+		summaries = make(map[int]*model.IssueHierarchyLevelScheme)
+		types     = make(map[int]map[string]bool)
+	)
+
+	for _, root := range roots {
+
+		root.Walk(func(node *model.IssueHierarchyNodeScheme) {
+
+			summary, ok := summaries[node.Level]
+			if !ok {
+				summary = &model.IssueHierarchyLevelScheme{Level: node.Level, StatusCategories: make(map[string]int)}
+				summaries[node.Level] = summary
+				types[node.Level] = make(map[string]bool)
+			}
+
+			summary.Issues++
+			summary.Estimate += node.Estimate
+
+			if category := hierarchyStatusCategory(node); category != "" {
+				summary.StatusCategories[category]++
+			}
+
+			if node.IssueType != nil && !types[node.Level][node.IssueType.Name] {
+				types[node.Level][node.IssueType.Name] = true
+				summary.IssueTypes = append(summary.IssueTypes, node.IssueType.Name)
+			}
+		})
+	}
+
+	result := make([]*model.IssueHierarchyLevelScheme, 0, len(summaries))
+	for _, summary := range summaries {
+		sort.Strings(summary.IssueTypes)
+		result = append(result, summary)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Level > result[j].Level })
+
+	return result
+}
+
+func hierarchyStatusCategory(node *model.IssueHierarchyNodeScheme) string {
+
+	if node.Status == nil || node.Status.StatusCategory == nil {
+		return ""
+	}
+
+	return node.Status.StatusCategory.Key
+}
@@ -0,0 +1,286 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/chrisccoy/go-atlassian/service"
+	"github.com/chrisccoy/go-atlassian/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"testing"
+)
+
+const (
+	hierarchyIssueTypesMocked = `[
+		{"id": "10000", "name": "Initiative", "hierarchyLevel": 2},
+		{"id": "10001", "name": "Epic", "hierarchyLevel": 1},
+		{"id": "10002", "name": "Story"},
+		{"id": "10003", "name": "Sub-task", "subtask": true, "hierarchyLevel": -1}
+	]`
+
+	hierarchyRootMocked = `{"startAt": 0, "maxResults": 100, "total": 1, "issues": [
+		{"id": "1", "key": "KP-1", "fields": {"summary": "Initiative", "issuetype": {"id": "10000", "name": "Initiative"},
+			"status": {"statusCategory": {"key": "indeterminate"}}}}
+	]}`
+
+	hierarchyEpicsMocked = `{"startAt": 0, "maxResults": 100, "total": 1, "issues": [
+		{"id": "2", "key": "KP-2", "fields": {"summary": "Epic", "issuetype": {"id": "10001", "name": "Epic"},
+			"parent": {"key": "KP-1"}, "status": {"statusCategory": {"key": "indeterminate"}}}}
+	]}`
+
+	hierarchyStoriesMocked = `{"startAt": 0, "maxResults": 100, "total": 2, "issues": [
+		{"id": "3", "key": "KP-3", "fields": {"summary": "Story done", "issuetype": {"id": "10002", "name": "Story"},
+			"customfield_10014": "KP-2", "customfield_10016": 3, "status": {"statusCategory": {"key": "done"}}}},
+		{"id": "4", "key": "KP-4", "fields": {"summary": "Story to do", "issuetype": {"id": "10002", "name": "Story"},
+			"parent": {"key": "KP-2"}, "customfield_10016": 5, "status": {"statusCategory": {"key": "new"}}}}
+	]}`
+
+	hierarchySubTasksMocked = `{"startAt": 0, "maxResults": 100, "total": 1, "issues": [
+		{"id": "5", "key": "KP-5", "fields": {"summary": "Sub-task", "issuetype": {"id": "10003", "name": "Sub-task"},
+			"parent": {"key": "KP-4"}, "customfield_10016": null, "status": {"statusCategory": {"key": "done"}}}}
+	]}`
+)
+
+func Test_internalHierarchyImpl_Tree(t *testing.T) {
+
+	// mockSearch returns the search page mocked for a JQL query
+	mockSearch := func(client *mocks.Client, jql, body string) {
+
+		reader := bytes.NewReader([]byte(jql))
+
+		client.On("TransformStructToReader",
+			mock.MatchedBy(func(payload interface{}) bool {
+				encoded, _ := json.Marshal(payload)
+				return bytes.Contains(encoded, []byte(`"jql":"`+jql+`"`))
+			})).
+			Return(reader, nil).Once()
+
+		request := &http.Request{Method: jql}
+
+		client.On("NewRequest",
+			context.Background(),
+			http.MethodPost,
+			"rest/api/3/search",
+			reader).
+			Return(request, nil).Once()
+
+		client.On("Call",
+			request,
+			mock.Anything).
+			Run(func(args mock.Arguments) {
+				_ = json.Unmarshal([]byte(body), args.Get(1))
+			}).
+			Return(&model.ResponseScheme{}, nil).Once()
+	}
+
+	mockIssueTypes := func(client *mocks.Client) {
+
+		request := &http.Request{Method: http.MethodGet}
+
+		client.On("NewRequest",
+			context.Background(),
+			http.MethodGet,
+			"rest/api/3/issuetype",
+			nil).
+			Return(request, nil)
+
+		client.On("Call",
+			request,
+			mock.Anything).
+			Run(func(args mock.Arguments) {
+				_ = json.Unmarshal([]byte(hierarchyIssueTypesMocked), args.Get(1))
+			}).
+			Return(&model.ResponseScheme{}, nil)
+	}
+
+	type fields struct {
+		c service.Client
+	}
+
+	type args struct {
+		ctx          context.Context
+		issueKeyOrID string
+		opts         *model.IssueHierarchyOptionsScheme
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		check   func(t *testing.T, tree *model.IssueHierarchyScheme)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name: "when the hierarchy is built",
+			args: args{
+				ctx:          context.Background(),
+				issueKeyOrID: "KP-1",
+				opts: &model.IssueHierarchyOptionsScheme{
+					EpicLinkField: "customfield_10014",
+					EstimateField: "customfield_10016",
+				},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				mockIssueTypes(client)
+				mockSearch(client, "issue = KP-1", hierarchyRootMocked)
+				mockSearch(client, "parent in (KP-1) OR cf[10014] in (KP-1)", hierarchyEpicsMocked)
+				mockSearch(client, "parent in (KP-2) OR cf[10014] in (KP-2)", hierarchyStoriesMocked)
+				mockSearch(client, "parent in (KP-3, KP-4) OR cf[10014] in (KP-3, KP-4)", hierarchySubTasksMocked)
+
+				fields.c = client
+			},
+			check: func(t *testing.T, tree *model.IssueHierarchyScheme) {
+
+				assert.Len(t, tree.Roots, 1)
+
+				initiative := tree.Roots[0]
+				assert.Equal(t, "KP-1", initiative.Key)
+				assert.Equal(t, 2, initiative.Level)
+				assert.Len(t, initiative.Children, 1)
+
+				epic := initiative.Children[0]
+				assert.Equal(t, "KP-2", epic.Key)
+				assert.Len(t, epic.Children, 2)
+				assert.Equal(t, "KP-2", epic.Children[0].ParentKey)
+				assert.Len(t, epic.Children[1].Children, 1)
+
+				assert.Equal(t, 5, initiative.RollUp.Issues)
+				assert.Equal(t, 8.0, initiative.RollUp.Estimate)
+				assert.Equal(t, 3.0, initiative.RollUp.DoneEstimate)
+				assert.Equal(t, 0.375, initiative.RollUp.Progress)
+				assert.Equal(t, map[string]int{"indeterminate": 2, "done": 2, "new": 1}, initiative.RollUp.StatusCategories)
+
+				assert.Len(t, tree.Levels, 4)
+				assert.Equal(t, 2, tree.Levels[0].Level)
+				assert.Equal(t, -1, tree.Levels[3].Level)
+				assert.Equal(t, []string{"Story"}, tree.Levels[2].IssueTypes)
+				assert.Equal(t, 2, tree.Levels[2].Issues)
+			},
+		},
+
+		{
+			name: "when the max depth is reached",
+			args: args{
+				ctx:          context.Background(),
+				issueKeyOrID: "KP-1",
+				opts:         &model.IssueHierarchyOptionsScheme{MaxDepth: 1},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				mockIssueTypes(client)
+				mockSearch(client, "issue = KP-1", hierarchyRootMocked)
+				mockSearch(client, "parent in (KP-1)", hierarchyEpicsMocked)
+
+				fields.c = client
+			},
+			check: func(t *testing.T, tree *model.IssueHierarchyScheme) {
+				assert.Len(t, tree.Roots[0].Children, 1)
+				assert.Empty(t, tree.Roots[0].Children[0].Children)
+				assert.Equal(t, 0.0, tree.Roots[0].RollUp.Progress)
+			},
+		},
+
+		{
+			name: "when the root issue is not found",
+			args: args{
+				ctx:          context.Background(),
+				issueKeyOrID: "KP-1",
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				mockIssueTypes(client)
+				mockSearch(client, "issue = KP-1", `{"total": 0, "issues": []}`)
+
+				fields.c = client
+			},
+			Err:     model.ErrNoHierarchyRootError,
+			wantErr: true,
+		},
+
+		{
+			name: "when the issue types cannot be fetched",
+			args: args{
+				ctx:          context.Background(),
+				issueKeyOrID: "KP-1",
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/3/issuetype",
+					nil).
+					Return(&http.Request{}, errors.New("unable to create the http request"))
+
+				fields.c = client
+			},
+			Err:     errors.New("unable to create the http request"),
+			wantErr: true,
+		},
+
+		{
+			name: "when the issue key is not provided",
+			args: args{
+				ctx: context.Background(),
+			},
+			on: func(fields *fields) {
+				fields.c = mocks.NewClient(t)
+			},
+			Err:     model.ErrNoIssueKeyOrIDError,
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			hierarchyService, err := NewHierarchyService(testCase.fields.c, "3")
+			assert.NoError(t, err)
+
+			gotResult, _, err := hierarchyService.Tree(testCase.args.ctx, testCase.args.issueKeyOrID, testCase.args.opts)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				testCase.check(t, gotResult)
+			}
+		})
+	}
+}
+
+func Test_hierarchyBatches(t *testing.T) {
+
+	batches := hierarchyBatches([]string{"KP-1", "KP-2", "KP-3"}, 2)
+	assert.Equal(t, [][]string{{"KP-1", "KP-2"}, {"KP-3"}}, batches)
+
+	assert.Len(t, hierarchyBatches(make([]string, 120), 0), 3)
+	assert.Empty(t, hierarchyBatches(nil, 0))
+}
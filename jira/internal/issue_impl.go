@@ -14,6 +14,7 @@ type IssueServices struct {
 	CommentRT       *CommentRichTextService
 	CommentADF      *CommentADFService
 	Field           *IssueFieldService
+	Hierarchy       *HierarchyService
 	Label           *LabelService
 	LinkRT          *LinkRichTextService
 	LinkADF         *LinkADFService
@@ -54,6 +55,7 @@ func NewIssueService(client service.Client, version string, services *IssueServi
 		adfService.Attachment = services.Attachment
 		adfService.Comment = services.CommentADF
 		adfService.Field = services.Field
+		adfService.Hierarchy = services.Hierarchy
 		adfService.Label = services.Label
 		adfService.Link = services.LinkADF
 		adfService.Metadata = services.Metadata
@@ -68,6 +70,7 @@ func NewIssueService(client service.Client, version string, services *IssueServi
 		richTextService.Comment = services.CommentRT
 		richTextService.Attachment = services.Attachment
 		richTextService.Field = services.Field
+		richTextService.Hierarchy = services.Hierarchy
 		richTextService.Label = services.Label
 		richTextService.Link = services.LinkRT
 		richTextService.Metadata = services.Metadata
@@ -19,6 +19,7 @@ type IssueADFService struct {
 	Attachment     *IssueAttachmentService
 	Comment        *CommentADFService
 	Field          *IssueFieldService
+	Hierarchy      *HierarchyService
 	Label          *LabelService
 	Link           *LinkADFService
 	Metadata       *MetadataService
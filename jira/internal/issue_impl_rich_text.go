@@ -19,6 +19,7 @@ type IssueRichTextService struct {
 	Attachment     *IssueAttachmentService
 	Comment        *CommentRichTextService
 	Field          *IssueFieldService
+	Hierarchy      *HierarchyService
 	Label          *LabelService
 	Link           *LinkRichTextService
 	Metadata       *MetadataService
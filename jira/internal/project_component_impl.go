@@ -122,7 +122,7 @@ func (i *internalProjectComponentImpl) Gets(ctx context.Context, projectIdOrKey
 	}
 
 	var components []*model.ComponentScheme
-	response, err := i.c.Call(request, components)
+	response, err := i.c.Call(request, &components)
 	if err != nil {
 		return nil, response, err
 	}
@@ -481,6 +481,36 @@ func Test_internalProjectComponentImpl_Gets(t *testing.T) {
 	}
 }
 
+func Test_internalProjectComponentImpl_Gets_Decoded(t *testing.T) {
+
+	client := mocks.NewClient(t)
+
+	client.On("NewRequest",
+		context.Background(),
+		http.MethodGet,
+		"rest/api/3/project/DUMMY/components",
+		nil).
+		Return(&http.Request{}, nil)
+
+	// The components are decoded on the pointer to the slice.
+	client.On("Call",
+		&http.Request{},
+		mock.Anything).
+		Run(func(arguments mock.Arguments) {
+			components := arguments.Get(1).(*[]*model.ComponentScheme)
+			*components = []*model.ComponentScheme{{ID: "10000", Name: "Backend"}}
+		}).
+		Return(&model.ResponseScheme{}, nil)
+
+	componentService, err := NewProjectComponentService(client, "3")
+	assert.NoError(t, err)
+
+	gotResult, gotResponse, err := componentService.Gets(context.Background(), "DUMMY")
+	assert.NoError(t, err)
+	assert.NotNil(t, gotResponse)
+	assert.Equal(t, []*model.ComponentScheme{{ID: "10000", Name: "Backend"}}, gotResult)
+}
+
 func Test_internalProjectComponentImpl_Count(t *testing.T) {
 
 	type fields struct {
@@ -2,11 +2,13 @@ package internal
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
 	"github.com/chrisccoy/go-atlassian/service"
 	"github.com/chrisccoy/go-atlassian/service/jira"
 	"net/http"
+	"time"
 )
 
 func NewTaskService(client service.Client, version string) (*TaskService, error) {
@@ -48,6 +50,17 @@ func (t *TaskService) Cancel(ctx context.Context, taskId string) (*model.Respons
 	return t.internalClient.Cancel(ctx, taskId)
 }
 
+// Wait polls a task until it reaches a terminal status, e.g. the task returned by Project.DeleteAsynchronously.
+//
+// The task is returned when it's completed, a *model.TaskError is returned when it's failed, cancelled or dead.
+//
+// GET /rest/api/{2-3}/task/{taskId}
+//
+// https://docs.go-atlassian.io/jira-software-cloud/tasks#get-task
+func (t *TaskService) Wait(ctx context.Context, taskId string, opts *model.TaskWaitOptionsScheme) (*model.TaskScheme, *model.ResponseScheme, error) {
+	return t.internalClient.Wait(ctx, taskId, opts)
+}
+
 type internalTaskServiceImpl struct {
 	c       service.Client
 	version string
@@ -89,3 +102,69 @@ func (i *internalTaskServiceImpl) Cancel(ctx context.Context, taskId string) (*m
 
 	return i.c.Call(request, nil)
 }
+
+func (i *internalTaskServiceImpl) Wait(ctx context.Context, taskId string, opts *model.TaskWaitOptionsScheme) (*model.TaskScheme, *model.ResponseScheme, error) {
+
+	if taskId == "" {
+		return nil, nil, model.ErrNoTaskIDError
+	}
+
+	if opts == nil {
+		opts = &model.TaskWaitOptionsScheme{}
+	}
+
+	for attempt := 0; ; attempt++ {
+
+		task, response, err := i.Get(ctx, taskId)
+		if err != nil {
+
+			if ctx.Err() != nil {
+				return nil, response, i.cancelled(ctx, taskId, opts)
+			}
+
+			return nil, response, err
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(task.Progress, task.Status)
+		}
+
+		if task.IsDone() {
+
+			if task.Status != model.TaskStatusComplete {
+				return task, response, &model.TaskError{ID: task.ID, Status: task.Status, Message: task.Result}
+			}
+
+			if opts.Result != nil && task.Result != "" {
+				if err = json.Unmarshal([]byte(task.Result), opts.Result); err != nil {
+					return task, response, err
+				}
+			}
+
+			return task, response, nil
+		}
+
+		timer := time.NewTimer(opts.Delay(attempt))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return task, response, i.cancelled(ctx, taskId, opts)
+		case <-timer.C:
+		}
+	}
+}
+
+// cancelled returns the context error, the task is cancelled when it's requested by the wait options.
+func (i *internalTaskServiceImpl) cancelled(ctx context.Context, taskId string, opts *model.TaskWaitOptionsScheme) error {
+
+	if opts.CancelOnContextDone {
+
+		// The context is done, so the cancellation is requested on a new context.
+		if _, err := i.Cancel(context.Background(), taskId); err != nil {
+			return fmt.Errorf("%w, the task cancellation failed: %v", ctx.Err(), err)
+		}
+	}
+
+	return ctx.Err()
+}
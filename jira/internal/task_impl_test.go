@@ -2,13 +2,16 @@ package internal
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
 	"github.com/chrisccoy/go-atlassian/service"
 	"github.com/chrisccoy/go-atlassian/service/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func Test_internalTaskServiceImpl_Get(t *testing.T) {
@@ -297,3 +300,204 @@ func Test_internalTaskServiceImpl_Cancel(t *testing.T) {
 		})
 	}
 }
+
+func Test_internalTaskServiceImpl_Wait(t *testing.T) {
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+
+	type fields struct {
+		c       service.Client
+		version string
+	}
+
+	type args struct {
+		ctx    context.Context
+		taskId string
+		opts   *model.TaskWaitOptionsScheme
+	}
+
+	type taskResult struct {
+		ProjectID string `json:"projectId"`
+	}
+
+	testCases := []struct {
+		name       string
+		fields     fields
+		args       args
+		on         func(*fields)
+		want       *taskResult
+		wantStatus []string
+		wantErr    bool
+		Err        error
+	}{
+		{
+			name:   "when the task is completed",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:    context.Background(),
+				taskId: "uuid-sample",
+				opts:   &model.TaskWaitOptionsScheme{Interval: time.Millisecond, Result: &taskResult{}},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/3/task/uuid-sample",
+					nil).
+					Return(&http.Request{}, nil)
+
+				polls := []string{
+					`{"id":"uuid-sample","status":"ENQUEUED"}`,
+					`{"id":"uuid-sample","status":"RUNNING","progress":50}`,
+					`{"id":"uuid-sample","status":"COMPLETE","progress":100,"result":{"projectId":"10000"}}`,
+				}
+
+				client.On("Call",
+					&http.Request{},
+					mock.Anything).
+					Run(func(args mock.Arguments) {
+						assert.NoError(t, json.Unmarshal([]byte(polls[0]), args.Get(1)))
+						polls = polls[1:]
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+			want:       &taskResult{ProjectID: "10000"},
+			wantStatus: []string{"ENQUEUED", "RUNNING", "COMPLETE"},
+		},
+
+		{
+			name:   "when the task is failed",
+			fields: fields{version: "2"},
+			args: args{
+				ctx:    context.Background(),
+				taskId: "uuid-sample",
+				opts:   &model.TaskWaitOptionsScheme{Interval: time.Millisecond},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/2/task/uuid-sample",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					mock.Anything).
+					Run(func(args mock.Arguments) {
+						assert.NoError(t, json.Unmarshal([]byte(`{"id":"uuid-sample","status":"FAILED","result":"The project has issues"}`), args.Get(1)))
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+			wantErr: true,
+			Err:     &model.TaskError{ID: "uuid-sample", Status: model.TaskStatusFailed, Message: "The project has issues"},
+		},
+
+		{
+			name:   "when the context is cancelled while waiting",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:    cancelledCtx,
+				taskId: "uuid-sample",
+				opts:   &model.TaskWaitOptionsScheme{Interval: time.Hour, CancelOnContextDone: true},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("NewRequest",
+					cancelledCtx,
+					http.MethodGet,
+					"rest/api/3/task/uuid-sample",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					mock.Anything).
+					Run(func(args mock.Arguments) {
+						assert.NoError(t, json.Unmarshal([]byte(`{"id":"uuid-sample","status":"RUNNING"}`), args.Get(1)))
+						cancel()
+					}).
+					Return(&model.ResponseScheme{}, nil).
+					Once()
+
+				cancelRequest := &http.Request{Method: http.MethodPost}
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/api/3/task/uuid-sample/cancel",
+					nil).
+					Return(cancelRequest, nil)
+
+				client.On("Call",
+					cancelRequest,
+					nil).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+			wantErr: true,
+			Err:     context.Canceled,
+		},
+
+		{
+			name:   "when the task id is not provided",
+			fields: fields{version: "3"},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+			Err:     model.ErrNoTaskIDError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			var statuses []string
+			if testCase.args.opts != nil {
+				testCase.args.opts.Progress = func(percentage int, status string) {
+					statuses = append(statuses, status)
+				}
+			}
+
+			taskService, err := NewTaskService(testCase.fields.c, testCase.fields.version)
+			assert.NoError(t, err)
+
+			gotResult, gotResponse, err := taskService.Wait(testCase.args.ctx, testCase.args.taskId, testCase.args.opts)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.Equal(t, model.TaskStatusComplete, gotResult.Status)
+				assert.Equal(t, testCase.want, testCase.args.opts.Result)
+				assert.Equal(t, testCase.wantStatus, statuses)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/chrisccoy/go-atlassian/service"
+	"github.com/chrisccoy/go-atlassian/service/jira"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+func NewWebhookService(client service.Client, version string) (*WebhookService, error) {
+
+	if version == "" {
+		return nil, model.ErrNoVersionProvided
+	}
+
+	return &WebhookService{
+		internalClient: &internalWebhookImpl{c: client, version: version, now: time.Now},
+	}, nil
+}
+
+type WebhookService struct {
+	internalClient jira.WebhookConnector
+}
+
+// Register registers webhooks, the events of each webhook are filtered by its JQL filter.
+//
+// The webhooks expire after 30 days, use Refresh to extend their expiration.
+//
+// Only the Connect and OAuth 2.0 apps can use this operation.
+//
+// POST /rest/api/{2-3}/webhook
+//
+// https://docs.go-atlassian.io/jira-software-cloud/webhooks#register-dynamic-webhooks
+func (w *WebhookService) Register(ctx context.Context, payload *model.WebhookSubscriptionPayloadScheme) (*model.WebhookRegistrationScheme, *model.ResponseScheme, error) {
+	return w.internalClient.Register(ctx, payload)
+}
+
+// Gets returns a paginated list of the webhooks registered by the calling app.
+//
+// GET /rest/api/{2-3}/webhook
+//
+// https://docs.go-atlassian.io/jira-software-cloud/webhooks#get-dynamic-webhooks-for-app
+func (w *WebhookService) Gets(ctx context.Context, startAt, maxResults int) (*model.WebhookPageScheme, *model.ResponseScheme, error) {
+	return w.internalClient.Gets(ctx, startAt, maxResults)
+}
+
+// Delete removes webhooks by ID, only the webhooks registered by the calling app are removed.
+//
+// DELETE /rest/api/{2-3}/webhook
+//
+// https://docs.go-atlassian.io/jira-software-cloud/webhooks#delete-webhooks-by-id
+func (w *WebhookService) Delete(ctx context.Context, ids []int) (*model.ResponseScheme, error) {
+	return w.internalClient.Delete(ctx, ids)
+}
+
+// Refresh extends the life of the webhooks, their expiration is set to 30 days from now.
+//
+// PUT /rest/api/{2-3}/webhook/refresh
+//
+// https://docs.go-atlassian.io/jira-software-cloud/webhooks#extend-webhook-life
+func (w *WebhookService) Refresh(ctx context.Context, ids []int) (*model.WebhookRefreshScheme, *model.ResponseScheme, error) {
+	return w.internalClient.Refresh(ctx, ids)
+}
+
+// Failed returns the webhooks that failed to be delivered in the last 72 hours, after is the failure time,
+// in milliseconds since the epoch, the page starts after, use the failure time of the last webhook of a page.
+//
+// GET /rest/api/{2-3}/webhook/failed
+//
+// https://docs.go-atlassian.io/jira-software-cloud/webhooks#get-failed-webhooks
+func (w *WebhookService) Failed(ctx context.Context, maxResults int, after int64) (*model.FailedWebhookPageScheme, *model.ResponseScheme, error) {
+	return w.internalClient.Failed(ctx, maxResults, after)
+}
+
+// KeepAlive refreshes the webhooks registered by the calling app before they expire, it checks their expiration
+// on each interval, and refreshes the webhooks expiring within the threshold. It returns when the context is done.
+//
+// It's meant to run on the background, e.g. go client.Webhook.KeepAlive(ctx, opts)
+func (w *WebhookService) KeepAlive(ctx context.Context, opts *model.WebhookKeepAliveOptionsScheme) error {
+	return w.internalClient.KeepAlive(ctx, opts)
+}
+
+type internalWebhookImpl struct {
+	c       service.Client
+	version string
+	now     func() time.Time
+}
+
+func (i *internalWebhookImpl) Register(ctx context.Context, payload *model.WebhookSubscriptionPayloadScheme) (*model.WebhookRegistrationScheme, *model.ResponseScheme, error) {
+
+	if payload == nil || payload.URL == "" {
+		return nil, nil, model.ErrNoWebhookURLError
+	}
+
+	if len(payload.Webhooks) == 0 {
+		return nil, nil, model.ErrNoWebhooksError
+	}
+
+	reader, err := i.c.TransformStructToReader(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endpoint := fmt.Sprintf("rest/api/%v/webhook", i.version)
+
+	request, err := i.c.NewRequest(ctx, http.MethodPost, endpoint, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(model.WebhookRegistrationScheme)
+	response, err := i.c.Call(request, result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return result, response, nil
+}
+
+func (i *internalWebhookImpl) Gets(ctx context.Context, startAt, maxResults int) (*model.WebhookPageScheme, *model.ResponseScheme, error) {
+
+	params := url.Values{}
+	params.Add("startAt", strconv.Itoa(startAt))
+	params.Add("maxResults", strconv.Itoa(maxResults))
+
+	endpoint := fmt.Sprintf("rest/api/%v/webhook?%v", i.version, params.Encode())
+
+	request, err := i.c.NewRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := new(model.WebhookPageScheme)
+	response, err := i.c.Call(request, page)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return page, response, nil
+}
+
+func (i *internalWebhookImpl) Delete(ctx context.Context, ids []int) (*model.ResponseScheme, error) {
+
+	if len(ids) == 0 {
+		return nil, model.ErrNoWebhookIDsError
+	}
+
+	payload := struct {
+		WebhookIds []int `json:"webhookIds"`
+	}{
+		WebhookIds: ids,
+	}
+
+	reader, err := i.c.TransformStructToReader(&payload)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("rest/api/%v/webhook", i.version)
+
+	request, err := i.c.NewRequest(ctx, http.MethodDelete, endpoint, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.c.Call(request, nil)
+}
+
+func (i *internalWebhookImpl) Refresh(ctx context.Context, ids []int) (*model.WebhookRefreshScheme, *model.ResponseScheme, error) {
+
+	if len(ids) == 0 {
+		return nil, nil, model.ErrNoWebhookIDsError
+	}
+
+	payload := struct {
+		WebhookIds []int `json:"webhookIds"`
+	}{
+		WebhookIds: ids,
+	}
+
+	reader, err := i.c.TransformStructToReader(&payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endpoint := fmt.Sprintf("rest/api/%v/webhook/refresh", i.version)
+
+	request, err := i.c.NewRequest(ctx, http.MethodPut, endpoint, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(model.WebhookRefreshScheme)
+	response, err := i.c.Call(request, result)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return result, response, nil
+}
+
+func (i *internalWebhookImpl) Failed(ctx context.Context, maxResults int, after int64) (*model.FailedWebhookPageScheme, *model.ResponseScheme, error) {
+
+	params := url.Values{}
+	if maxResults > 0 {
+		params.Add("maxResults", strconv.Itoa(maxResults))
+	}
+
+	if after > 0 {
+		params.Add("after", strconv.FormatInt(after, 10))
+	}
+
+	endpoint := fmt.Sprintf("rest/api/%v/webhook/failed", i.version)
+	if params.Encode() != "" {
+		endpoint = fmt.Sprintf("%v?%v", endpoint, params.Encode())
+	}
+
+	request, err := i.c.NewRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := new(model.FailedWebhookPageScheme)
+	response, err := i.c.Call(request, page)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return page, response, nil
+}
+
+func (i *internalWebhookImpl) KeepAlive(ctx context.Context, opts *model.WebhookKeepAliveOptionsScheme) error {
+
+	if opts == nil {
+		opts = &model.WebhookKeepAliveOptionsScheme{}
+	}
+
+	interval, threshold := 12*time.Hour, 7*24*time.Hour
+	if opts.Interval > 0 {
+		interval = opts.Interval
+	}
+
+	if opts.Threshold > 0 {
+		threshold = opts.Threshold
+	}
+
+	for {
+
+		if err := i.refreshExpiring(ctx, threshold, opts); err != nil && ctx.Err() == nil && opts.OnError != nil {
+			opts.OnError(err)
+		}
+
+		timer := time.NewTimer(interval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// refreshExpiring refreshes the webhooks expiring within the threshold.
+func (i *internalWebhookImpl) refreshExpiring(ctx context.Context, threshold time.Duration, opts *model.WebhookKeepAliveOptionsScheme) error {
+
+	deadline := i.now().Add(threshold)
+
+	var ids []int
+	for startAt := 0; ; {
+
+		page, _, err := i.Gets(ctx, startAt, 100)
+		if err != nil {
+			return err
+		}
+
+		for _, webhook := range page.Values {
+			if webhook.Expiration().Before(deadline) {
+				ids = append(ids, webhook.ID)
+			}
+		}
+
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+
+		startAt += len(page.Values)
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	result, _, err := i.Refresh(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	if opts.OnRefresh != nil {
+		opts.OnRefresh(ids, result.Expiration())
+	}
+
+	return nil
+}
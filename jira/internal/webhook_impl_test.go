@@ -0,0 +1,660 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/chrisccoy/go-atlassian/service"
+	"github.com/chrisccoy/go-atlassian/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_internalWebhookImpl_Register(t *testing.T) {
+
+	payloadMocked := &model.WebhookSubscriptionPayloadScheme{
+		URL: "https://app.example.com/webhook",
+		Webhooks: []*model.WebhookSubscriptionScheme{
+			{
+				JqlFilter: "project = KP",
+				Events:    []string{"jira:issue_created", "jira:issue_updated"},
+			},
+		},
+	}
+
+	type fields struct {
+		c       service.Client
+		version string
+	}
+
+	type args struct {
+		ctx     context.Context
+		payload *model.WebhookSubscriptionPayloadScheme
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name:   "when the api version is v3",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:     context.Background(),
+				payload: payloadMocked,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("TransformStructToReader",
+					payloadMocked).
+					Return(bytes.NewReader([]byte{}), nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/api/3/webhook",
+					bytes.NewReader([]byte{})).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.WebhookRegistrationScheme{}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name:   "when the api version is v2",
+			fields: fields{version: "2"},
+			args: args{
+				ctx:     context.Background(),
+				payload: payloadMocked,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("TransformStructToReader",
+					payloadMocked).
+					Return(bytes.NewReader([]byte{}), nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/api/2/webhook",
+					bytes.NewReader([]byte{})).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.WebhookRegistrationScheme{}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name:   "when the url is not provided",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:     context.Background(),
+				payload: &model.WebhookSubscriptionPayloadScheme{Webhooks: payloadMocked.Webhooks},
+			},
+			wantErr: true,
+			Err:     model.ErrNoWebhookURLError,
+		},
+
+		{
+			name:   "when the webhooks are not provided",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:     context.Background(),
+				payload: &model.WebhookSubscriptionPayloadScheme{URL: payloadMocked.URL},
+			},
+			wantErr: true,
+			Err:     model.ErrNoWebhooksError,
+		},
+
+		{
+			name:   "when the http request cannot be created",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:     context.Background(),
+				payload: payloadMocked,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("TransformStructToReader",
+					payloadMocked).
+					Return(bytes.NewReader([]byte{}), nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/api/3/webhook",
+					bytes.NewReader([]byte{})).
+					Return(&http.Request{}, errors.New("error, unable to create the http request"))
+
+				fields.c = client
+			},
+			wantErr: true,
+			Err:     errors.New("error, unable to create the http request"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			webhookService, err := NewWebhookService(testCase.fields.c, testCase.fields.version)
+			assert.NoError(t, err)
+
+			gotResult, gotResponse, err := webhookService.Register(testCase.args.ctx, testCase.args.payload)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+			}
+
+		})
+	}
+}
+
+func Test_internalWebhookImpl_Gets(t *testing.T) {
+
+	type fields struct {
+		c       service.Client
+		version string
+	}
+
+	type args struct {
+		ctx                 context.Context
+		startAt, maxResults int
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name:   "when the api version is v3",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:        context.Background(),
+				startAt:    50,
+				maxResults: 100,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/3/webhook?maxResults=100&startAt=50",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.WebhookPageScheme{}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name:   "when the api version is v2",
+			fields: fields{version: "2"},
+			args: args{
+				ctx:        context.Background(),
+				startAt:    50,
+				maxResults: 100,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/2/webhook?maxResults=100&startAt=50",
+					nil).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.WebhookPageScheme{}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name:   "when the http request cannot be created",
+			fields: fields{version: "3"},
+			args: args{
+				ctx:        context.Background(),
+				startAt:    50,
+				maxResults: 100,
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodGet,
+					"rest/api/3/webhook?maxResults=100&startAt=50",
+					nil).
+					Return(&http.Request{}, errors.New("error, unable to create the http request"))
+
+				fields.c = client
+			},
+			wantErr: true,
+			Err:     errors.New("error, unable to create the http request"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			webhookService, err := NewWebhookService(testCase.fields.c, testCase.fields.version)
+			assert.NoError(t, err)
+
+			gotResult, gotResponse, err := webhookService.Gets(testCase.args.ctx, testCase.args.startAt,
+				testCase.args.maxResults)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+			}
+
+		})
+	}
+}
+
+func Test_internalWebhookImpl_Delete(t *testing.T) {
+
+	type fields struct {
+		c       service.Client
+		version string
+	}
+
+	type args struct {
+		ctx context.Context
+		ids []int
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name:   "when the api version is v3",
+			fields: fields{version: "3"},
+			args: args{
+				ctx: context.Background(),
+				ids: []int{10000, 10001},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("TransformStructToReader",
+					&struct {
+						WebhookIds []int `json:"webhookIds"`
+					}{WebhookIds: []int{10000, 10001}}).
+					Return(bytes.NewReader([]byte{}), nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodDelete,
+					"rest/api/3/webhook",
+					bytes.NewReader([]byte{})).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					nil).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name:   "when the webhook ids are not provided",
+			fields: fields{version: "3"},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+			Err:     model.ErrNoWebhookIDsError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			webhookService, err := NewWebhookService(testCase.fields.c, testCase.fields.version)
+			assert.NoError(t, err)
+
+			gotResponse, err := webhookService.Delete(testCase.args.ctx, testCase.args.ids)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+			}
+
+		})
+	}
+}
+
+func Test_internalWebhookImpl_Refresh(t *testing.T) {
+
+	type fields struct {
+		c       service.Client
+		version string
+	}
+
+	type args struct {
+		ctx context.Context
+		ids []int
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name:   "when the api version is v2",
+			fields: fields{version: "2"},
+			args: args{
+				ctx: context.Background(),
+				ids: []int{10000},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("TransformStructToReader",
+					&struct {
+						WebhookIds []int `json:"webhookIds"`
+					}{WebhookIds: []int{10000}}).
+					Return(bytes.NewReader([]byte{}), nil)
+
+				client.On("NewRequest",
+					context.Background(),
+					http.MethodPut,
+					"rest/api/2/webhook/refresh",
+					bytes.NewReader([]byte{})).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.WebhookRefreshScheme{}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name:   "when the webhook ids are not provided",
+			fields: fields{version: "2"},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+			Err:     model.ErrNoWebhookIDsError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			webhookService, err := NewWebhookService(testCase.fields.c, testCase.fields.version)
+			assert.NoError(t, err)
+
+			gotResult, gotResponse, err := webhookService.Refresh(testCase.args.ctx, testCase.args.ids)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+			}
+
+		})
+	}
+}
+
+func Test_internalWebhookImpl_Failed(t *testing.T) {
+
+	type fields struct {
+		c       service.Client
+		version string
+	}
+
+	type args struct {
+		ctx        context.Context
+		maxResults int
+		after      int64
+	}
+
+	testCases := []struct {
+		name     string
+		fields   fields
+		args     args
+		endpoint string
+	}{
+		{
+			name:     "when the page is the first",
+			fields:   fields{version: "3"},
+			args:     args{ctx: context.Background()},
+			endpoint: "rest/api/3/webhook/failed",
+		},
+
+		{
+			name:     "when the page starts after a failure time",
+			fields:   fields{version: "2"},
+			args:     args{ctx: context.Background(), maxResults: 50, after: 1617282000000},
+			endpoint: "rest/api/2/webhook/failed?after=1617282000000&maxResults=50",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			client := mocks.NewClient(t)
+
+			client.On("NewRequest",
+				context.Background(),
+				http.MethodGet,
+				testCase.endpoint,
+				nil).
+				Return(&http.Request{}, nil)
+
+			client.On("Call",
+				&http.Request{},
+				&model.FailedWebhookPageScheme{}).
+				Return(&model.ResponseScheme{}, nil)
+
+			testCase.fields.c = client
+
+			webhookService, err := NewWebhookService(testCase.fields.c, testCase.fields.version)
+			assert.NoError(t, err)
+
+			gotResult, gotResponse, err := webhookService.Failed(testCase.args.ctx, testCase.args.maxResults,
+				testCase.args.after)
+
+			assert.NoError(t, err)
+			assert.NotEqual(t, gotResponse, nil)
+			assert.NotEqual(t, gotResult, nil)
+		})
+	}
+}
+
+func Test_internalWebhookImpl_KeepAlive(t *testing.T) {
+
+	now := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	milliseconds := func(date time.Time) int64 { return date.UnixNano() / int64(time.Millisecond) }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := mocks.NewClient(t)
+
+	client.On("NewRequest",
+		ctx,
+		http.MethodGet,
+		mock.AnythingOfType("string"),
+		nil).
+		Return(&http.Request{}, nil)
+
+	// The webhooks are on two pages, 10000 and 10002 expire within the threshold.
+	pages := []*model.WebhookPageScheme{
+		{StartAt: 0, Values: []*model.WebhookScheme{
+			{ID: 10000, ExpirationDate: milliseconds(now.Add(24 * time.Hour))},
+			{ID: 10001, ExpirationDate: milliseconds(now.Add(20 * 24 * time.Hour))},
+		}},
+		{StartAt: 2, IsLast: true, Values: []*model.WebhookScheme{
+			{ID: 10002, ExpirationDate: milliseconds(now.Add(-time.Hour))},
+		}},
+	}
+
+	client.On("Call",
+		&http.Request{},
+		mock.AnythingOfType("*models.WebhookPageScheme")).
+		Run(func(args mock.Arguments) {
+			encoded, _ := json.Marshal(pages[0])
+			assert.NoError(t, json.Unmarshal(encoded, args.Get(1)))
+			pages = pages[1:]
+		}).
+		Return(&model.ResponseScheme{}, nil).
+		Times(2)
+
+	client.On("TransformStructToReader",
+		&struct {
+			WebhookIds []int `json:"webhookIds"`
+		}{WebhookIds: []int{10000, 10002}}).
+		Return(bytes.NewReader([]byte{}), nil)
+
+	client.On("NewRequest",
+		ctx,
+		http.MethodPut,
+		"rest/api/3/webhook/refresh",
+		bytes.NewReader([]byte{})).
+		Return(&http.Request{}, nil)
+
+	client.On("Call",
+		&http.Request{},
+		&model.WebhookRefreshScheme{}).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*model.WebhookRefreshScheme).ExpirationDate = milliseconds(now.Add(30 * 24 * time.Hour))
+		}).
+		Return(&model.ResponseScheme{}, nil)
+
+	service := &internalWebhookImpl{c: client, version: "3", now: func() time.Time { return now }}
+
+	var refreshed []int
+	var expiration time.Time
+
+	err := service.KeepAlive(ctx, &model.WebhookKeepAliveOptionsScheme{
+		Interval: time.Hour,
+		OnRefresh: func(ids []int, date time.Time) {
+			refreshed, expiration = ids, date
+			cancel()
+		},
+		OnError: func(err error) {
+			t.Errorf("unexpected error: %v", err)
+		},
+	})
+
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []int{10000, 10002}, refreshed)
+	assert.True(t, expiration.Equal(now.Add(30*24*time.Hour)))
+}
@@ -0,0 +1,138 @@
+// Package schemesync converges the schemes of a Jira site to a configuration declared in YAML: the screens, field
+// configurations, permission schemes, issue type schemes and workflow schemes.
+//
+// The items are matched by name, and they reference the issue types, fields, roles and workflows by name too, with
+// the snapshot document types, so the sections of an exported snapshot can be copied into a configuration. The
+// attributes not set on the configuration aren't changed on the site, e.g. an empty description, or a screen
+// without tabs.
+package schemesync
+
+import (
+	"errors"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/jira/snapshot"
+	"gopkg.in/yaml.v3"
+	"io"
+)
+
+// Version is the version of the configuration document format.
+const Version = 1
+
+var (
+	ErrNoConfig           = errors.New("schemesync: no configuration set")
+	ErrMissingService     = errors.New("schemesync: missing service")
+	ErrUnsupportedVersion = errors.New("schemesync: unsupported configuration version")
+	ErrNoName             = errors.New("schemesync: item without name")
+	ErrDuplicateName      = errors.New("schemesync: duplicate name")
+	ErrUnknownName        = errors.New("schemesync: unknown name")
+	ErrAmbiguousName      = errors.New("schemesync: ambiguous name")
+)
+
+// Config is the desired state of the site, the items of each kind are keyed by name.
+type Config struct {
+	Version int `json:"version" yaml:"version"`
+
+	Screens             []*snapshot.Screen             `json:"screens,omitempty" yaml:"screens,omitempty"`
+	FieldConfigurations []*snapshot.FieldConfiguration `json:"fieldConfigurations,omitempty" yaml:"fieldConfigurations,omitempty"`
+	PermissionSchemes   []*snapshot.PermissionScheme   `json:"permissionSchemes,omitempty" yaml:"permissionSchemes,omitempty"`
+	IssueTypeSchemes    []*snapshot.IssueTypeScheme    `json:"issueTypeSchemes,omitempty" yaml:"issueTypeSchemes,omitempty"`
+	WorkflowSchemes     []*snapshot.WorkflowScheme     `json:"workflowSchemes,omitempty" yaml:"workflowSchemes,omitempty"`
+}
+
+// Read reads a YAML configuration, the unknown keys are rejected so a typo doesn't silently drop a setting.
+func Read(r io.Reader) (*Config, error) {
+
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	config := &Config{}
+	if err := decoder.Decode(config); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// validate checks the version of the document, and the names are set and unique per kind.
+func (c *Config) validate() error {
+
+	if c == nil {
+		return ErrNoConfig
+	}
+
+	if c.Version > Version {
+		return fmt.Errorf("%w: %v", ErrUnsupportedVersion, c.Version)
+	}
+
+	kinds := []struct {
+		kind  Kind
+		names []string
+	}{
+		{KindScreen, nil},
+		{KindFieldConfiguration, nil},
+		{KindPermissionScheme, nil},
+		{KindIssueTypeScheme, nil},
+		{KindWorkflowScheme, nil},
+	}
+
+	for _, screen := range c.Screens {
+
+		kinds[0].names = append(kinds[0].names, screen.Name)
+
+		var tabs []string
+		for _, tab := range screen.Tabs {
+			tabs = append(tabs, tab.Name)
+		}
+
+		if err := uniqueNames("screen "+screen.Name+" tab", tabs); err != nil {
+			return err
+		}
+	}
+
+	for _, configuration := range c.FieldConfigurations {
+		kinds[1].names = append(kinds[1].names, configuration.Name)
+	}
+
+	for _, scheme := range c.PermissionSchemes {
+		kinds[2].names = append(kinds[2].names, scheme.Name)
+	}
+
+	for _, scheme := range c.IssueTypeSchemes {
+		kinds[3].names = append(kinds[3].names, scheme.Name)
+	}
+
+	for _, scheme := range c.WorkflowSchemes {
+		kinds[4].names = append(kinds[4].names, scheme.Name)
+	}
+
+	for _, kind := range kinds {
+		if err := uniqueNames(string(kind.kind), kind.names); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func uniqueNames(kind string, names []string) error {
+
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+
+		if name == "" {
+			return fmt.Errorf("%w: %v", ErrNoName, kind)
+		}
+
+		if seen[name] {
+			return fmt.Errorf("%w: %v %q", ErrDuplicateName, kind, name)
+		}
+
+		seen[name] = true
+	}
+
+	return nil
+}
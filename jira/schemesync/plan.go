@@ -0,0 +1,213 @@
+package schemesync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+type Kind string
+
+// The kinds are created and updated in the order below, and deleted in the reverse order, so the screens and the
+// field configurations are there before the schemes, and the schemes are removed before them.
+const (
+	KindScreen             Kind = "screen"
+	KindFieldConfiguration Kind = "field-configuration"
+	KindPermissionScheme   Kind = "permission-scheme"
+	KindIssueTypeScheme    Kind = "issue-type-scheme"
+	KindWorkflowScheme     Kind = "workflow-scheme"
+)
+
+var kindOrder = map[Kind]int{
+	KindScreen:             0,
+	KindFieldConfiguration: 1,
+	KindPermissionScheme:   2,
+	KindIssueTypeScheme:    3,
+	KindWorkflowScheme:     4,
+}
+
+// Change creates, updates or deletes an item of the site, it's applied with one or more calls.
+type Change struct {
+	Action Action
+	Kind   Kind
+	Name   string
+
+	// ID is the id of the item on the site, it's 0 when it's created by the plan.
+	ID int
+
+	// Details are the attributes changed, e.g. "description", "+tab Triage" or "-grant BROWSE_PROJECTS group:users".
+	Details []string
+
+	steps []*step
+}
+
+// step is a call of a change, the steps of a created item set its id for the steps after them.
+type step struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+func (c *Change) String() string {
+
+	text := fmt.Sprintf("%-6v %-19v %v", c.Action, c.Kind, c.Name)
+	if c.ID != 0 {
+		text += fmt.Sprintf(" (%v)", c.ID)
+	}
+
+	if len(c.Details) != 0 {
+		text += ": " + strings.Join(c.Details, ", ")
+	}
+
+	return text
+}
+
+func (c *Change) add(name string, run func(ctx context.Context) error) {
+	c.steps = append(c.steps, &step{name: name, run: run})
+}
+
+type Plan struct {
+	Changes []*Change
+}
+
+// Empty returns true when the site is on the desired state already.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Count returns the number of changes with the action.
+func (p *Plan) Count(action Action) int {
+
+	count := 0
+	for _, change := range p.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+
+	return count
+}
+
+// String returns the changes, one per line, in the order they're applied, and a summary.
+func (p *Plan) String() string {
+
+	var builder strings.Builder
+	for _, change := range p.Changes {
+		builder.WriteString(change.String())
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString(fmt.Sprintf("%v to create, %v to update, %v to delete\n", p.Count(ActionCreate),
+		p.Count(ActionUpdate), p.Count(ActionDelete)))
+
+	return builder.String()
+}
+
+// sort orders the creates and updates by kind, then the deletes by reverse kind, the changes of a kind by name.
+func (p *Plan) sort() {
+
+	rank := func(change *Change) int {
+
+		if change.Action == ActionDelete {
+			return len(kindOrder) + len(kindOrder) - kindOrder[change.Kind]
+		}
+
+		return kindOrder[change.Kind]
+	}
+
+	sort.SliceStable(p.Changes, func(i, j int) bool {
+
+		left, right := p.Changes[i], p.Changes[j]
+		if rank(left) != rank(right) {
+			return rank(left) < rank(right)
+		}
+
+		return strings.ToLower(left.Name) < strings.ToLower(right.Name)
+	})
+}
+
+// names resolves the names of the issue types, fields or roles to their ids, the ids are accepted too.
+type names struct {
+	kind  string
+	ids   map[string][]string // by name
+	names map[string]string   // by id
+}
+
+func newNames(kind string) *names {
+	return &names{kind: kind, ids: map[string][]string{}, names: map[string]string{}}
+}
+
+func (n *names) add(id, name string) {
+	n.ids[name] = append(n.ids[name], id)
+	n.names[id] = name
+}
+
+func (n *names) resolve(reference string) (string, error) {
+
+	if _, ok := n.names[reference]; ok {
+		return reference, nil
+	}
+
+	ids := n.ids[reference]
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("%w: %v %q", ErrUnknownName, n.kind, reference)
+	case 1:
+		return ids[0], nil
+	}
+
+	return "", fmt.Errorf("%w: %v %q (%v)", ErrAmbiguousName, n.kind, reference, strings.Join(ids, ", "))
+}
+
+// name returns the name of the id, or the id itself when it's unknown.
+func (n *names) name(id string) string {
+
+	if name, ok := n.names[id]; ok {
+		return name
+	}
+
+	return id
+}
+
+// planner compares the configuration with the site, the changes are added to the plan.
+type planner struct {
+	*Services
+	options *Options
+	plan    *Plan
+
+	issueTypes *names
+	fields     *names
+	roles      *names
+}
+
+// prune returns true when the item of the site, not on the configuration, is deleted. The kinds without items on
+// the configuration aren't pruned.
+func (p *planner) prune(declared map[string]bool, name string) bool {
+
+	if !p.options.Prune || len(declared) == 0 || declared[name] {
+		return false
+	}
+
+	return strings.HasPrefix(name, p.options.PrunePrefix)
+}
+
+// match returns the id of the site item named as the configuration item, 0 when there's none.
+func match(kind Kind, ids map[string][]int, name string) (int, error) {
+
+	switch matches := ids[name]; len(matches) {
+	case 0:
+		return 0, nil
+	case 1:
+		return matches[0], nil
+	default:
+		return 0, fmt.Errorf("%w: %v %q (%v)", ErrAmbiguousName, kind, name, matches)
+	}
+}
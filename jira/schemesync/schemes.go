@@ -0,0 +1,588 @@
+package schemesync
+
+import (
+	"context"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/jira/snapshot"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"sort"
+	"strconv"
+)
+
+func (p *planner) permissionSchemes(ctx context.Context, desired []*snapshot.PermissionScheme) error {
+
+	page, _, err := p.PermissionScheme.Gets(ctx)
+	if err != nil {
+		return fmt.Errorf("schemesync: permission schemes: %w", err)
+	}
+
+	// The permission schemes of the team-managed projects aren't shared, they're left out.
+	ids := map[string][]int{}
+	var current []*model.PermissionSchemeScheme
+	for _, scheme := range page.PermissionSchemes {
+		if scheme.Scope == nil {
+			ids[scheme.Name] = append(ids[scheme.Name], scheme.ID)
+			current = append(current, scheme)
+		}
+	}
+
+	declared := map[string]bool{}
+	for _, scheme := range desired {
+
+		declared[scheme.Name] = true
+
+		grants := map[string]*model.PermissionGrantPayloadScheme{}
+		var keys []string
+		for _, grant := range scheme.Grants {
+
+			parameter, err := p.holderParameter(grant.HolderType, grant.Holder)
+			if err != nil {
+				return fmt.Errorf("schemesync: permission scheme %q: %w", scheme.Name, err)
+			}
+
+			payload := &model.PermissionGrantPayloadScheme{
+				Holder:     &model.PermissionGrantHolderScheme{Type: grant.HolderType, Parameter: parameter},
+				Permission: grant.Permission,
+			}
+
+			key := grantKey(payload.Permission, payload.Holder)
+			if _, ok := grants[key]; !ok {
+				grants[key] = payload
+				keys = append(keys, key)
+			}
+		}
+
+		sort.Strings(keys)
+
+		id, err := match(KindPermissionScheme, ids, scheme.Name)
+		if err != nil {
+			return err
+		}
+
+		name, description := scheme.Name, scheme.Description
+
+		if id == 0 {
+
+			payload := &model.PermissionSchemeScheme{Name: name, Description: description}
+			for _, key := range keys {
+				payload.Permissions = append(payload.Permissions, &model.PermissionGrantScheme{
+					Holder:     grants[key].Holder,
+					Permission: grants[key].Permission,
+				})
+			}
+
+			change := &Change{Action: ActionCreate, Kind: KindPermissionScheme, Name: name}
+			if len(keys) != 0 {
+				change.Details = append(change.Details, fmt.Sprintf("%v grants", len(keys)))
+			}
+
+			change.add("create", func(ctx context.Context) error {
+				_, _, err := p.PermissionScheme.Create(ctx, payload)
+				return err
+			})
+
+			p.plan.Changes = append(p.plan.Changes, change)
+			continue
+		}
+
+		change := &Change{Action: ActionUpdate, Kind: KindPermissionScheme, Name: name, ID: id}
+
+		for _, existing := range current {
+			if existing.ID == id && description != "" && description != existing.Description {
+
+				change.Details = append(change.Details, "description")
+				change.add("update", func(ctx context.Context) error {
+					payload := &model.PermissionSchemeScheme{Name: name, Description: description}
+					_, _, err := p.PermissionScheme.Update(ctx, id, payload)
+					return err
+				})
+			}
+		}
+
+		currentGrants, _, err := p.PermissionSchemeGrant.Gets(ctx, id, nil)
+		if err != nil {
+			return fmt.Errorf("schemesync: permission scheme %q: %w", name, err)
+		}
+
+		present := map[string]bool{}
+		var removed []*model.PermissionGrantScheme
+		for _, grant := range currentGrants.Permissions {
+
+			key := grantKey(grant.Permission, grant.Holder)
+			present[key] = true
+
+			if _, ok := grants[key]; !ok {
+				removed = append(removed, grant)
+			}
+		}
+
+		// The grants are created before the others are deleted, so the holders don't lose a permission in between.
+		for _, key := range keys {
+			if !present[key] {
+
+				payload := grants[key]
+				change.Details = append(change.Details, "+grant "+p.grantName(payload.Permission, payload.Holder))
+				change.add("create grant", func(ctx context.Context) error {
+					_, _, err := p.PermissionSchemeGrant.Create(ctx, id, payload)
+					return err
+				})
+			}
+		}
+
+		if len(scheme.Grants) != 0 {
+
+			sort.Slice(removed, func(i, j int) bool {
+				return grantKey(removed[i].Permission, removed[i].Holder) < grantKey(removed[j].Permission, removed[j].Holder)
+			})
+
+			for _, grant := range removed {
+
+				grantID := grant.ID
+				change.Details = append(change.Details, "-grant "+p.grantName(grant.Permission, grant.Holder))
+				change.add("delete grant", func(ctx context.Context) error {
+					_, err := p.PermissionSchemeGrant.Delete(ctx, id, grantID)
+					return err
+				})
+			}
+		}
+
+		if len(change.steps) != 0 {
+			p.plan.Changes = append(p.plan.Changes, change)
+		}
+	}
+
+	for _, scheme := range current {
+		if p.prune(declared, scheme.Name) {
+
+			id := scheme.ID
+			change := &Change{Action: ActionDelete, Kind: KindPermissionScheme, Name: scheme.Name, ID: id}
+			change.add("delete", func(ctx context.Context) error {
+				_, err := p.PermissionScheme.Delete(ctx, id)
+				return err
+			})
+
+			p.plan.Changes = append(p.plan.Changes, change)
+		}
+	}
+
+	return nil
+}
+
+// holderParameter resolves the holder of the types referencing a role or a field by id, the same way the snapshots
+// name them.
+func (p *planner) holderParameter(holderType, holder string) (string, error) {
+
+	switch holderType {
+	case "projectRole":
+		return p.roles.resolve(holder)
+	case "userCustomField", "groupCustomField":
+		return p.fields.resolve(holder)
+	}
+
+	return holder, nil
+}
+
+func (p *planner) grantName(permission string, holder *model.PermissionGrantHolderScheme) string {
+
+	if holder == nil {
+		return permission
+	}
+
+	name := holder.Parameter
+	switch holder.Type {
+	case "projectRole":
+		name = p.roles.name(name)
+	case "userCustomField", "groupCustomField":
+		name = p.fields.name(name)
+	}
+
+	if name == "" {
+		return permission + " " + holder.Type
+	}
+
+	return permission + " " + holder.Type + ":" + name
+}
+
+func grantKey(permission string, holder *model.PermissionGrantHolderScheme) string {
+
+	if holder == nil {
+		return permission
+	}
+
+	return permission + "|" + holder.Type + "|" + holder.Parameter
+}
+
+func (p *planner) issueTypeSchemes(ctx context.Context, desired []*snapshot.IssueTypeScheme) error {
+
+	var current []*model.IssueTypeSchemeScheme
+	for startAt := 0; ; {
+
+		page, _, err := p.TypeScheme.Gets(ctx, nil, startAt, p.options.PageSize)
+		if err != nil {
+			return fmt.Errorf("schemesync: issue type schemes: %w", err)
+		}
+
+		current = append(current, page.Values...)
+
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	ids := map[string][]int{}
+	for _, scheme := range current {
+
+		id, err := strconv.Atoi(scheme.ID)
+		if err != nil {
+			return fmt.Errorf("schemesync: issue type scheme %q: invalid id %q", scheme.Name, scheme.ID)
+		}
+
+		ids[scheme.Name] = append(ids[scheme.Name], id)
+	}
+
+	declared := map[string]bool{}
+	for _, scheme := range desired {
+
+		declared[scheme.Name] = true
+
+		var defaultID string
+		if scheme.DefaultIssueType != "" {
+
+			var err error
+			if defaultID, err = p.issueTypes.resolve(scheme.DefaultIssueType); err != nil {
+				return fmt.Errorf("schemesync: issue type scheme %q: %w", scheme.Name, err)
+			}
+		}
+
+		var issueTypeIDs []string
+		for _, name := range scheme.IssueTypes {
+
+			issueTypeID, err := p.issueTypes.resolve(name)
+			if err != nil {
+				return fmt.Errorf("schemesync: issue type scheme %q: %w", scheme.Name, err)
+			}
+
+			issueTypeIDs = append(issueTypeIDs, issueTypeID)
+		}
+
+		id, err := match(KindIssueTypeScheme, ids, scheme.Name)
+		if err != nil {
+			return err
+		}
+
+		if id == 0 {
+
+			payload := &model.IssueTypeSchemePayloadScheme{
+				DefaultIssueTypeID: defaultID,
+				IssueTypeIds:       issueTypeIDs,
+				Name:               scheme.Name,
+				Description:        scheme.Description,
+			}
+
+			change := &Change{Action: ActionCreate, Kind: KindIssueTypeScheme, Name: scheme.Name}
+			if len(issueTypeIDs) != 0 {
+				change.Details = append(change.Details, fmt.Sprintf("%v issue types", len(issueTypeIDs)))
+			}
+
+			change.add("create", func(ctx context.Context) error {
+				_, _, err := p.TypeScheme.Create(ctx, payload)
+				return err
+			})
+
+			p.plan.Changes = append(p.plan.Changes, change)
+			continue
+		}
+
+		change := &Change{Action: ActionUpdate, Kind: KindIssueTypeScheme, Name: scheme.Name, ID: id}
+
+		var existing *model.IssueTypeSchemeScheme
+		for _, item := range current {
+			if item.ID == strconv.Itoa(id) {
+				existing = item
+			}
+		}
+
+		present := map[string]bool{}
+		for startAt := 0; ; {
+
+			page, _, err := p.TypeScheme.Items(ctx, []int{id}, startAt, p.options.PageSize)
+			if err != nil {
+				return fmt.Errorf("schemesync: issue type scheme %q: %w", scheme.Name, err)
+			}
+
+			for _, item := range page.Values {
+				present[item.IssueTypeID] = true
+			}
+
+			startAt += len(page.Values)
+			if page.IsLast || len(page.Values) == 0 {
+				break
+			}
+		}
+
+		wanted := map[string]bool{}
+		var added []int
+		for _, issueTypeID := range issueTypeIDs {
+
+			wanted[issueTypeID] = true
+			if present[issueTypeID] {
+				continue
+			}
+
+			value, err := strconv.Atoi(issueTypeID)
+			if err != nil {
+				return fmt.Errorf("schemesync: issue type scheme %q: invalid issue type id %q", scheme.Name, issueTypeID)
+			}
+
+			change.Details = append(change.Details, "+"+p.issueTypes.name(issueTypeID))
+			added = append(added, value)
+		}
+
+		// The issue types are added before the default issue type is changed, and removed after it.
+		if len(added) != 0 {
+			change.add("add issue types", func(ctx context.Context) error {
+				_, err := p.TypeScheme.Append(ctx, id, added)
+				return err
+			})
+		}
+
+		payload := &model.IssueTypeSchemePayloadScheme{Name: scheme.Name}
+		if scheme.Description != "" && scheme.Description != existing.Description {
+			change.Details = append(change.Details, "description")
+			payload.Description = scheme.Description
+		}
+
+		if defaultID != "" && defaultID != existing.DefaultIssueTypeID {
+			change.Details = append(change.Details, "default issue type")
+			payload.DefaultIssueTypeID = defaultID
+		}
+
+		if payload.Description != "" || payload.DefaultIssueTypeID != "" {
+			change.add("update", func(ctx context.Context) error {
+				_, err := p.TypeScheme.Update(ctx, id, payload)
+				return err
+			})
+		}
+
+		if len(scheme.IssueTypes) != 0 {
+
+			var removed []string
+			for issueTypeID := range present {
+				if !wanted[issueTypeID] {
+					removed = append(removed, issueTypeID)
+				}
+			}
+
+			sort.Strings(removed)
+
+			for _, issueTypeID := range removed {
+
+				value, err := strconv.Atoi(issueTypeID)
+				if err != nil {
+					return fmt.Errorf("schemesync: issue type scheme %q: invalid issue type id %q", scheme.Name, issueTypeID)
+				}
+
+				change.Details = append(change.Details, "-"+p.issueTypes.name(issueTypeID))
+				change.add("remove issue type", func(ctx context.Context) error {
+					_, err := p.TypeScheme.Remove(ctx, id, value)
+					return err
+				})
+			}
+		}
+
+		if len(change.steps) != 0 {
+			p.plan.Changes = append(p.plan.Changes, change)
+		}
+	}
+
+	for _, scheme := range current {
+		if !scheme.IsDefault && p.prune(declared, scheme.Name) {
+
+			id, _ := strconv.Atoi(scheme.ID)
+			change := &Change{Action: ActionDelete, Kind: KindIssueTypeScheme, Name: scheme.Name, ID: id}
+			change.add("delete", func(ctx context.Context) error {
+				_, err := p.TypeScheme.Delete(ctx, id)
+				return err
+			})
+
+			p.plan.Changes = append(p.plan.Changes, change)
+		}
+	}
+
+	return nil
+}
+
+func (p *planner) workflowSchemes(ctx context.Context, desired []*snapshot.WorkflowScheme) error {
+
+	var current []*model.WorkflowSchemeScheme
+	for startAt := 0; ; {
+
+		page, _, err := p.WorkflowScheme.Gets(ctx, startAt, p.options.PageSize)
+		if err != nil {
+			return fmt.Errorf("schemesync: workflow schemes: %w", err)
+		}
+
+		current = append(current, page.Values...)
+
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	ids := map[string][]int{}
+	for _, scheme := range current {
+		ids[scheme.Name] = append(ids[scheme.Name], scheme.ID)
+	}
+
+	declared := map[string]bool{}
+	for _, scheme := range desired {
+
+		declared[scheme.Name] = true
+
+		// The default mapping sets the default workflow, when it's not set on its own.
+		defaultWorkflow := scheme.DefaultWorkflow
+		mappings := map[string]string{}
+		for _, mapping := range scheme.Mappings {
+
+			if mapping.IssueType == snapshot.DefaultMapping {
+				if defaultWorkflow == "" {
+					defaultWorkflow = mapping.Target
+				}
+
+				continue
+			}
+
+			issueTypeID, err := p.issueTypes.resolve(mapping.IssueType)
+			if err != nil {
+				return fmt.Errorf("schemesync: workflow scheme %q: %w", scheme.Name, err)
+			}
+
+			mappings[issueTypeID] = mapping.Target
+		}
+
+		id, err := match(KindWorkflowScheme, ids, scheme.Name)
+		if err != nil {
+			return err
+		}
+
+		payload := &model.WorkflowSchemePayloadScheme{
+			DefaultWorkflow: defaultWorkflow,
+			Name:            scheme.Name,
+			Description:     scheme.Description,
+		}
+
+		if len(scheme.Mappings) != 0 {
+			payload.IssueTypeMappings = mappings
+		}
+
+		if id == 0 {
+
+			change := &Change{Action: ActionCreate, Kind: KindWorkflowScheme, Name: scheme.Name}
+			if len(mappings) != 0 {
+				change.Details = append(change.Details, fmt.Sprintf("%v mappings", len(mappings)))
+			}
+
+			change.add("create", func(ctx context.Context) error {
+				_, _, err := p.WorkflowScheme.Create(ctx, payload)
+				return err
+			})
+
+			p.plan.Changes = append(p.plan.Changes, change)
+			continue
+		}
+
+		var existing *model.WorkflowSchemeScheme
+		for _, item := range current {
+			if item.ID == id {
+				existing = item
+			}
+		}
+
+		change := &Change{Action: ActionUpdate, Kind: KindWorkflowScheme, Name: scheme.Name, ID: id}
+
+		if payload.Description != "" && payload.Description != existing.Description {
+			change.Details = append(change.Details, "description")
+		} else {
+			payload.Description = existing.Description
+		}
+
+		if payload.DefaultWorkflow != "" && payload.DefaultWorkflow != existing.DefaultWorkflow {
+			change.Details = append(change.Details, "default workflow")
+		} else {
+			payload.DefaultWorkflow = existing.DefaultWorkflow
+		}
+
+		if len(scheme.Mappings) != 0 {
+			change.Details = append(change.Details, p.mappingChanges(existing.IssueTypeMappings, mappings)...)
+		} else if len(existing.IssueTypeMappings) != 0 {
+			payload.IssueTypeMappings = existing.IssueTypeMappings
+		}
+
+		if len(change.Details) == 0 {
+			continue
+		}
+
+		change.add("update", func(ctx context.Context) error {
+			_, _, err := p.WorkflowScheme.Update(ctx, id, payload)
+			return err
+		})
+
+		p.plan.Changes = append(p.plan.Changes, change)
+	}
+
+	for _, scheme := range current {
+		if p.prune(declared, scheme.Name) {
+
+			id := scheme.ID
+			change := &Change{Action: ActionDelete, Kind: KindWorkflowScheme, Name: scheme.Name, ID: id}
+			change.add("delete", func(ctx context.Context) error {
+				_, err := p.WorkflowScheme.Delete(ctx, id)
+				return err
+			})
+
+			p.plan.Changes = append(p.plan.Changes, change)
+		}
+	}
+
+	return nil
+}
+
+// mappingChanges returns the issue type mappings added, changed and removed, by issue type name.
+func (p *planner) mappingChanges(current, desired map[string]string) []string {
+
+	var issueTypeIDs []string
+	for issueTypeID := range current {
+		issueTypeIDs = append(issueTypeIDs, issueTypeID)
+	}
+
+	for issueTypeID := range desired {
+		if _, ok := current[issueTypeID]; !ok {
+			issueTypeIDs = append(issueTypeIDs, issueTypeID)
+		}
+	}
+
+	sort.Slice(issueTypeIDs, func(i, j int) bool {
+		return p.issueTypes.name(issueTypeIDs[i]) < p.issueTypes.name(issueTypeIDs[j])
+	})
+
+	var changes []string
+	for _, issueTypeID := range issueTypeIDs {
+
+		name := p.issueTypes.name(issueTypeID)
+		before, hadBefore := current[issueTypeID]
+		after, hasAfter := desired[issueTypeID]
+
+		switch {
+		case !hadBefore:
+			changes = append(changes, fmt.Sprintf("+%v: %v", name, after))
+		case !hasAfter:
+			changes = append(changes, "-"+name)
+		case before != after:
+			changes = append(changes, fmt.Sprintf("%v: %v -> %v", name, before, after))
+		}
+	}
+
+	return changes
+}
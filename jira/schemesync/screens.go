@@ -0,0 +1,569 @@
+package schemesync
+
+import (
+	"context"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/jira/snapshot"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+)
+
+// tab is a screen tab, with its fields in the order shown on the screen. The tabs of the configuration have no id.
+type tab struct {
+	id     int
+	name   string
+	fields []*tabField
+}
+
+type tabField struct {
+	id, name string
+}
+
+func (t *tab) has(fieldID string) bool {
+
+	for _, field := range t.fields {
+		if field.id == fieldID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *planner) screens(ctx context.Context, desired []*snapshot.Screen) error {
+
+	var current []*model.ScreenScheme
+	for startAt := 0; ; {
+
+		page, _, err := p.Screen.Gets(ctx, nil, startAt, p.options.PageSize)
+		if err != nil {
+			return fmt.Errorf("schemesync: screens: %w", err)
+		}
+
+		current = append(current, page.Values...)
+
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	// The screens of the team-managed projects aren't shared, they're left out.
+	ids := map[string][]int{}
+	for _, screen := range current {
+		if screen.Scope == nil {
+			ids[screen.Name] = append(ids[screen.Name], screen.ID)
+		}
+	}
+
+	declared := map[string]bool{}
+	for _, screen := range desired {
+
+		declared[screen.Name] = true
+
+		tabs, err := p.desiredTabs(screen)
+		if err != nil {
+			return err
+		}
+
+		id, err := match(KindScreen, ids, screen.Name)
+		if err != nil {
+			return err
+		}
+
+		if id == 0 {
+			p.plan.Changes = append(p.plan.Changes, p.createScreen(screen, tabs))
+			continue
+		}
+
+		change := &Change{Action: ActionUpdate, Kind: KindScreen, Name: screen.Name, ID: id}
+
+		for _, existing := range current {
+			if existing.ID == id && screen.Description != "" && screen.Description != existing.Description {
+
+				name, description := screen.Name, screen.Description
+				change.Details = append(change.Details, "description")
+				change.add("update", func(ctx context.Context) error {
+					_, _, err := p.Screen.Update(ctx, id, name, description)
+					return err
+				})
+			}
+		}
+
+		if tabs != nil {
+
+			currentTabs, err := p.currentTabs(ctx, id)
+			if err != nil {
+				return fmt.Errorf("schemesync: screen %q: %w", screen.Name, err)
+			}
+
+			p.tabChanges(change, id, tabs, currentTabs)
+		}
+
+		if len(change.steps) != 0 {
+			p.plan.Changes = append(p.plan.Changes, change)
+		}
+	}
+
+	for _, screen := range current {
+		if screen.Scope == nil && p.prune(declared, screen.Name) {
+
+			id := screen.ID
+			change := &Change{Action: ActionDelete, Kind: KindScreen, Name: screen.Name, ID: id}
+			change.add("delete", func(ctx context.Context) error {
+				_, err := p.Screen.Delete(ctx, id)
+				return err
+			})
+
+			p.plan.Changes = append(p.plan.Changes, change)
+		}
+	}
+
+	return nil
+}
+
+// desiredTabs resolves the fields of the screen tabs, it returns nil when the screen has no tabs set.
+func (p *planner) desiredTabs(screen *snapshot.Screen) ([]*tab, error) {
+
+	var tabs []*tab
+	for _, item := range screen.Tabs {
+
+		current := &tab{name: item.Name}
+		for _, name := range item.Fields {
+
+			id, err := p.fields.resolve(name)
+			if err != nil {
+				return nil, fmt.Errorf("schemesync: screen %q, tab %q: %w", screen.Name, item.Name, err)
+			}
+
+			current.fields = append(current.fields, &tabField{id: id, name: name})
+		}
+
+		tabs = append(tabs, current)
+	}
+
+	return tabs, nil
+}
+
+func (p *planner) currentTabs(ctx context.Context, screenID int) ([]*tab, error) {
+
+	items, _, err := p.ScreenTab.Gets(ctx, screenID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var tabs []*tab
+	for _, item := range items {
+
+		fields, _, err := p.ScreenTabField.Gets(ctx, screenID, item.ID)
+		if err != nil {
+			return nil, fmt.Errorf("tab %q: %w", item.Name, err)
+		}
+
+		current := &tab{id: item.ID, name: item.Name}
+		for _, field := range fields {
+
+			name := field.Name
+			if name == "" {
+				name = p.fields.name(field.ID)
+			}
+
+			current.fields = append(current.fields, &tabField{id: field.ID, name: name})
+		}
+
+		tabs = append(tabs, current)
+	}
+
+	return tabs, nil
+}
+
+// createScreen creates the screen, then converges the tabs Jira adds to a new screen to the tabs of the configuration.
+func (p *planner) createScreen(screen *snapshot.Screen, tabs []*tab) *Change {
+
+	change := &Change{Action: ActionCreate, Kind: KindScreen, Name: screen.Name}
+	for _, item := range tabs {
+		change.Details = append(change.Details, fmt.Sprintf("+tab %v (%v fields)", item.name, len(item.fields)))
+	}
+
+	var id int
+
+	change.add("create", func(ctx context.Context) error {
+
+		created, _, err := p.Screen.Create(ctx, screen.Name, screen.Description)
+		if err != nil {
+			return err
+		}
+
+		id = created.ID
+		return nil
+	})
+
+	if tabs == nil {
+		return change
+	}
+
+	change.add("tabs", func(ctx context.Context) error {
+
+		currentTabs, err := p.currentTabs(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		tabChange := &Change{}
+		p.tabChanges(tabChange, id, tabs, currentTabs)
+
+		for _, step := range tabChange.steps {
+			if err := step.run(ctx); err != nil {
+				return fmt.Errorf("%v: %w", step.name, err)
+			}
+		}
+
+		return nil
+	})
+
+	return change
+}
+
+// tabChanges adds the steps converging the tabs of the screen. The tabs are created first, then the fields are
+// removed from the tabs, the tabs are deleted and the fields are added, so a field can move to another tab, and the
+// screen always has a tab. The fields and the tabs are moved last, when their order is different.
+func (p *planner) tabChanges(change *Change, screenID int, desired, current []*tab) {
+
+	tabIDs := map[string]int{}
+	currentByName := map[string]*tab{}
+	for _, item := range current {
+		tabIDs[item.name] = item.id
+		currentByName[item.name] = item
+	}
+
+	desiredByName := map[string]*tab{}
+	for _, item := range desired {
+		desiredByName[item.name] = item
+	}
+
+	for _, item := range desired {
+		if _, ok := currentByName[item.name]; !ok {
+
+			name := item.name
+			change.Details = append(change.Details, "+tab "+name)
+			change.add("create tab "+name, func(ctx context.Context) error {
+
+				created, _, err := p.ScreenTab.Create(ctx, screenID, name)
+				if err != nil {
+					return err
+				}
+
+				tabIDs[name] = created.ID
+				return nil
+			})
+		}
+	}
+
+	for _, item := range current {
+
+		wanted, ok := desiredByName[item.name]
+		if !ok {
+			continue
+		}
+
+		for _, field := range item.fields {
+			if !wanted.has(field.id) {
+
+				tabID, fieldID := item.id, field.id
+				change.Details = append(change.Details, fmt.Sprintf("-field %v (tab %v)", field.name, item.name))
+				change.add("remove field "+field.name, func(ctx context.Context) error {
+					_, err := p.ScreenTabField.Remove(ctx, screenID, tabID, fieldID)
+					return err
+				})
+			}
+		}
+	}
+
+	for _, item := range current {
+		if _, ok := desiredByName[item.name]; !ok {
+
+			tabID := item.id
+			change.Details = append(change.Details, "-tab "+item.name)
+			change.add("delete tab "+item.name, func(ctx context.Context) error {
+				_, err := p.ScreenTab.Delete(ctx, screenID, tabID)
+				return err
+			})
+		}
+	}
+
+	var tabOrder []string
+	for _, item := range current {
+		if _, ok := desiredByName[item.name]; ok {
+			tabOrder = append(tabOrder, item.name)
+		}
+	}
+
+	for _, item := range desired {
+
+		existing, ok := currentByName[item.name]
+		if !ok {
+			existing = &tab{}
+			tabOrder = append(tabOrder, item.name)
+		}
+
+		// The fields kept stay in their order, the fields added go last.
+		var fieldOrder []string
+		for _, field := range existing.fields {
+			if item.has(field.id) {
+				fieldOrder = append(fieldOrder, field.id)
+			}
+		}
+
+		name := item.name
+		for _, field := range item.fields {
+			if !existing.has(field.id) {
+
+				fieldID := field.id
+				fieldOrder = append(fieldOrder, fieldID)
+
+				change.Details = append(change.Details, fmt.Sprintf("+field %v (tab %v)", field.name, name))
+				change.add("add field "+field.name, func(ctx context.Context) error {
+					_, _, err := p.ScreenTabField.Add(ctx, screenID, tabIDs[name], fieldID)
+					return err
+				})
+			}
+		}
+
+		var wanted []string
+		for _, field := range item.fields {
+			wanted = append(wanted, field.id)
+		}
+
+		if !equalStrings(fieldOrder, wanted) {
+
+			change.Details = append(change.Details, "reorder fields (tab "+name+")")
+			change.add("reorder fields of tab "+name, func(ctx context.Context) error {
+
+				for index, fieldID := range wanted {
+
+					after, position := "", "First"
+					if index != 0 {
+						after, position = wanted[index-1], ""
+					}
+
+					if _, err := p.ScreenTabField.Move(ctx, screenID, tabIDs[name], fieldID, after, position); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})
+		}
+	}
+
+	var wantedTabs []string
+	for _, item := range desired {
+		wantedTabs = append(wantedTabs, item.name)
+	}
+
+	if !equalStrings(tabOrder, wantedTabs) {
+
+		change.Details = append(change.Details, "reorder tabs")
+		change.add("reorder tabs", func(ctx context.Context) error {
+
+			for position, name := range wantedTabs {
+				if _, err := p.ScreenTab.Move(ctx, screenID, tabIDs[name], position); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+}
+
+func (p *planner) fieldConfigurations(ctx context.Context, desired []*snapshot.FieldConfiguration) error {
+
+	var current []*model.FieldConfigurationScheme
+	for startAt := 0; ; {
+
+		page, _, err := p.FieldConfiguration.Gets(ctx, nil, false, startAt, p.options.PageSize)
+		if err != nil {
+			return fmt.Errorf("schemesync: field configurations: %w", err)
+		}
+
+		current = append(current, page.Values...)
+
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	ids := map[string][]int{}
+	for _, configuration := range current {
+		ids[configuration.Name] = append(ids[configuration.Name], configuration.ID)
+	}
+
+	declared := map[string]bool{}
+	for _, configuration := range desired {
+
+		declared[configuration.Name] = true
+
+		var items []*model.FieldConfigurationItemScheme
+		for _, item := range configuration.Items {
+
+			fieldID, err := p.fields.resolve(item.Field)
+			if err != nil {
+				return fmt.Errorf("schemesync: field configuration %q: %w", configuration.Name, err)
+			}
+
+			items = append(items, &model.FieldConfigurationItemScheme{
+				ID:          fieldID,
+				IsHidden:    item.Hidden,
+				IsRequired:  item.Required,
+				Description: item.Description,
+				Renderer:    item.Renderer,
+			})
+		}
+
+		id, err := match(KindFieldConfiguration, ids, configuration.Name)
+		if err != nil {
+			return err
+		}
+
+		name, description := configuration.Name, configuration.Description
+
+		if id == 0 {
+
+			change := &Change{Action: ActionCreate, Kind: KindFieldConfiguration, Name: name}
+			if len(items) != 0 {
+				change.Details = append(change.Details, fmt.Sprintf("%v items", len(items)))
+			}
+
+			change.add("create", func(ctx context.Context) error {
+
+				created, _, err := p.FieldConfiguration.Create(ctx, name, description)
+				if err != nil {
+					return err
+				}
+
+				id = created.ID
+				return nil
+			})
+
+			if len(items) != 0 {
+				change.add("update items", func(ctx context.Context) error {
+					payload := &model.UpdateFieldConfigurationItemPayloadScheme{FieldConfigurationItems: items}
+					_, err := p.FieldConfigurationItem.Update(ctx, id, payload)
+					return err
+				})
+			}
+
+			p.plan.Changes = append(p.plan.Changes, change)
+			continue
+		}
+
+		change := &Change{Action: ActionUpdate, Kind: KindFieldConfiguration, Name: name, ID: id}
+
+		for _, existing := range current {
+			if existing.ID == id && description != "" && description != existing.Description {
+
+				change.Details = append(change.Details, "description")
+				change.add("update", func(ctx context.Context) error {
+					_, err := p.FieldConfiguration.Update(ctx, id, name, description)
+					return err
+				})
+			}
+		}
+
+		changed, err := p.changedItems(ctx, id, items)
+		if err != nil {
+			return fmt.Errorf("schemesync: field configuration %q: %w", name, err)
+		}
+
+		if len(changed) != 0 {
+
+			for _, item := range changed {
+				change.Details = append(change.Details, "field "+p.fields.name(item.ID))
+			}
+
+			change.add("update items", func(ctx context.Context) error {
+				payload := &model.UpdateFieldConfigurationItemPayloadScheme{FieldConfigurationItems: changed}
+				_, err := p.FieldConfigurationItem.Update(ctx, id, payload)
+				return err
+			})
+		}
+
+		if len(change.steps) != 0 {
+			p.plan.Changes = append(p.plan.Changes, change)
+		}
+	}
+
+	for _, configuration := range current {
+		if !configuration.IsDefault && p.prune(declared, configuration.Name) {
+
+			id := configuration.ID
+			change := &Change{Action: ActionDelete, Kind: KindFieldConfiguration, Name: configuration.Name, ID: id}
+			change.add("delete", func(ctx context.Context) error {
+				_, err := p.FieldConfiguration.Delete(ctx, id)
+				return err
+			})
+
+			p.plan.Changes = append(p.plan.Changes, change)
+		}
+	}
+
+	return nil
+}
+
+// changedItems returns the items different on the field configuration, the description and the renderer are only
+// compared when they're set.
+func (p *planner) changedItems(ctx context.Context, id int, items []*model.FieldConfigurationItemScheme) ([]*model.FieldConfigurationItemScheme, error) {
+
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	current := map[string]*model.FieldConfigurationItemScheme{}
+	for startAt := 0; ; {
+
+		page, _, err := p.FieldConfigurationItem.Gets(ctx, id, startAt, p.options.PageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Values {
+			current[item.ID] = item
+		}
+
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	var changed []*model.FieldConfigurationItemScheme
+	for _, item := range items {
+
+		existing, ok := current[item.ID]
+		if !ok ||
+			existing.IsHidden != item.IsHidden ||
+			existing.IsRequired != item.IsRequired ||
+			(item.Description != "" && item.Description != existing.Description) ||
+			(item.Renderer != "" && item.Renderer != existing.Renderer) {
+			changed = append(changed, item)
+		}
+	}
+
+	return changed, nil
+}
+
+func equalStrings(a, b []string) bool {
+
+	if len(a) != len(b) {
+		return false
+	}
+
+	for index := range a {
+		if a[index] != b[index] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,292 @@
+package schemesync
+
+import (
+	"context"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/service/jira"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Services are the Jira services read and changed by the syncer, e.g. with a v3 client:
+//
+//	&schemesync.Services{
+//		IssueType:              client.Issue.Type,
+//		Field:                  client.Issue.Field,
+//		ProjectRole:            client.Project.Role,
+//		Screen:                 client.Screen,
+//		ScreenTab:              client.Screen.Tab,
+//		ScreenTabField:         client.Screen.Tab.Field,
+//		FieldConfiguration:     client.Issue.Field.Configuration,
+//		FieldConfigurationItem: client.Issue.Field.Configuration.Item,
+//		PermissionScheme:       client.Permission.Scheme,
+//		PermissionSchemeGrant:  client.Permission.Scheme.Grant,
+//		TypeScheme:             client.Issue.Type.Scheme,
+//		WorkflowScheme:         client.Workflow.Scheme,
+//	}
+type Services struct {
+	IssueType              jira.TypeConnector
+	Field                  jira.FieldConnector
+	ProjectRole            jira.ProjectRoleConnector
+	Screen                 jira.ScreenConnector
+	ScreenTab              jira.ScreenTabConnector
+	ScreenTabField         jira.ScreenTabFieldConnector
+	FieldConfiguration     jira.FieldConfigConnector
+	FieldConfigurationItem jira.FieldConfigItemConnector
+	PermissionScheme       jira.PermissionSchemeConnector
+	PermissionSchemeGrant  jira.PermissionSchemeGrantConnector
+	TypeScheme             jira.TypeSchemeConnector
+	WorkflowScheme         jira.WorkflowSchemeConnector
+}
+
+func (s *Services) validate() error {
+
+	if s == nil {
+		return fmt.Errorf("%w: no services set", ErrMissingService)
+	}
+
+	required := map[string]bool{
+		"IssueType":              s.IssueType == nil,
+		"Field":                  s.Field == nil,
+		"ProjectRole":            s.ProjectRole == nil,
+		"Screen":                 s.Screen == nil,
+		"ScreenTab":              s.ScreenTab == nil,
+		"ScreenTabField":         s.ScreenTabField == nil,
+		"FieldConfiguration":     s.FieldConfiguration == nil,
+		"FieldConfigurationItem": s.FieldConfigurationItem == nil,
+		"PermissionScheme":       s.PermissionScheme == nil,
+		"PermissionSchemeGrant":  s.PermissionSchemeGrant == nil,
+		"TypeScheme":             s.TypeScheme == nil,
+		"WorkflowScheme":         s.WorkflowScheme == nil,
+	}
+
+	var missing []string
+	for name, isMissing := range required {
+		if isMissing {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("%w: %v", ErrMissingService, missing)
+	}
+
+	return nil
+}
+
+type Options struct {
+	// Prune deletes the items of the site not on the configuration, only the items named with the PrunePrefix when
+	// it's set. The kinds without items on the configuration, and the default items, aren't pruned.
+	Prune       bool
+	PrunePrefix string
+
+	// PageSize is the number of items requested per call, 50 by default.
+	PageSize int
+}
+
+// Syncer plans the changes converging a site to a configuration, and applies them.
+type Syncer struct {
+	services *Services
+	options  *Options
+}
+
+func NewSyncer(services *Services, options *Options) (*Syncer, error) {
+
+	if err := services.validate(); err != nil {
+		return nil, err
+	}
+
+	if options == nil {
+		options = &Options{}
+	}
+
+	if options.PageSize <= 0 {
+		options.PageSize = 50
+	}
+
+	return &Syncer{services: services, options: options}, nil
+}
+
+// Plan compares the configuration with the site and returns the changes, in the order they're applied. Nothing is
+// changed on the site, the plan fails when a name referenced by the configuration isn't on the site.
+func (s *Syncer) Plan(ctx context.Context, config *Config) (*Plan, error) {
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	p := &planner{
+		Services:   s.services,
+		options:    s.options,
+		plan:       &Plan{},
+		issueTypes: newNames("issue type"),
+		fields:     newNames("field"),
+		roles:      newNames("role"),
+	}
+
+	if err := p.loadNames(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := p.screens(ctx, config.Screens); err != nil {
+		return nil, err
+	}
+
+	if err := p.fieldConfigurations(ctx, config.FieldConfigurations); err != nil {
+		return nil, err
+	}
+
+	if err := p.permissionSchemes(ctx, config.PermissionSchemes); err != nil {
+		return nil, err
+	}
+
+	if err := p.issueTypeSchemes(ctx, config.IssueTypeSchemes); err != nil {
+		return nil, err
+	}
+
+	if err := p.workflowSchemes(ctx, config.WorkflowSchemes); err != nil {
+		return nil, err
+	}
+
+	p.plan.sort()
+	return p.plan, nil
+}
+
+// loadNames fetches the issue types, fields and roles of the site, the team-managed ones are left out.
+func (p *planner) loadNames(ctx context.Context) error {
+
+	issueTypes, _, err := p.IssueType.Gets(ctx)
+	if err != nil {
+		return fmt.Errorf("schemesync: issue types: %w", err)
+	}
+
+	for _, issueType := range issueTypes {
+		if issueType.Scope == nil {
+			p.issueTypes.add(issueType.ID, issueType.Name)
+		}
+	}
+
+	fields, _, err := p.Field.Gets(ctx)
+	if err != nil {
+		return fmt.Errorf("schemesync: fields: %w", err)
+	}
+
+	for _, field := range fields {
+		if field.Scope == nil {
+			p.fields.add(field.ID, field.Name)
+		}
+	}
+
+	roles, _, err := p.ProjectRole.Global(ctx)
+	if err != nil {
+		return fmt.Errorf("schemesync: roles: %w", err)
+	}
+
+	for _, role := range roles {
+		p.roles.add(strconv.Itoa(role.ID), role.Name)
+	}
+
+	return nil
+}
+
+type ResultStatus string
+
+const (
+	StatusApplied ResultStatus = "applied"
+	StatusFailed  ResultStatus = "failed"
+	StatusSkipped ResultStatus = "skipped" // a change before it failed
+)
+
+type Result struct {
+	Change *Change
+	Status ResultStatus
+	Err    error // the error of the failed change
+
+	// Steps is the number of calls applied, a failed change with calls applied is partly applied.
+	Steps int
+}
+
+// Report is the result of every change of a plan, the changes after a failed change are skipped.
+type Report struct {
+	Plan    *Plan
+	Results []*Result
+}
+
+// Count returns the number of results with the status.
+func (r *Report) Count(status ResultStatus) int {
+
+	count := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Failed returns true when a change failed.
+func (r *Report) Failed() bool {
+	return r.Count(StatusFailed) != 0
+}
+
+// String returns a line per change and a summary.
+func (r *Report) String() string {
+
+	var builder strings.Builder
+	for _, result := range r.Results {
+
+		builder.WriteString(fmt.Sprintf("%-8v %v", result.Status, result.Change))
+		if result.Err != nil {
+			builder.WriteString(fmt.Sprintf(": %v (%v/%v calls applied)", result.Err, result.Steps,
+				len(result.Change.steps)))
+		}
+
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString(fmt.Sprintf("%v changes: %v applied, %v failed, %v skipped\n", len(r.Results),
+		r.Count(StatusApplied), r.Count(StatusFailed), r.Count(StatusSkipped)))
+
+	return builder.String()
+}
+
+// Apply applies the changes of the plan in order, it stops on the first failed change so the changes depending on
+// it aren't applied, the changes after it are skipped. The site isn't rolled back: a new plan shows what's left to
+// converge. The error is returned when the context is done, the error of the failed change is on the report.
+func (s *Syncer) Apply(ctx context.Context, plan *Plan) (*Report, error) {
+
+	report := &Report{Plan: plan}
+	stopped := false
+
+	for _, change := range plan.Changes {
+
+		result := &Result{Change: change, Status: StatusSkipped}
+		report.Results = append(report.Results, result)
+
+		if stopped {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			stopped = true
+			continue
+		}
+
+		result.Status = StatusApplied
+		for _, step := range change.steps {
+
+			if err := step.run(ctx); err != nil {
+				result.Status, result.Err = StatusFailed, fmt.Errorf("%v: %w", step.name, err)
+				stopped = true
+				break
+			}
+
+			result.Steps++
+		}
+	}
+
+	return report, ctx.Err()
+}
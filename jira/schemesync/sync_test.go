@@ -0,0 +1,378 @@
+package schemesync
+
+import (
+	"context"
+	"errors"
+	"github.com/chrisccoy/go-atlassian/jira/snapshot"
+	v3 "github.com/chrisccoy/go-atlassian/jira/v3"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const configMocked = `
+version: 1
+screens:
+  - name: "KP: Default Screen"
+    tabs:
+      - name: Field Tab
+        fields: [Summary, Description]
+      - name: Triage
+        fields: [Sprint]
+  - name: "KP: Bug Screen"
+    tabs:
+      - name: Triage
+        fields: [Description]
+fieldConfigurations:
+  - name: "KP: Field Configuration"
+    items:
+      - field: Summary
+        required: true
+      - field: Description
+        renderer: wiki-renderer
+permissionSchemes:
+  - name: "KP: Permission Scheme"
+    grants:
+      - permission: BROWSE_PROJECTS
+        holderType: projectRole
+        holder: Developers
+      - permission: ADMINISTER_PROJECTS
+        holderType: projectRole
+        holder: Administrators
+issueTypeSchemes:
+  - name: "KP: Issue Type Scheme"
+    defaultIssueType: Story
+    issueTypes: [Story, Bug]
+workflowSchemes:
+  - name: "KP: Workflow Scheme"
+    mappings:
+      - issueType: default
+        target: "KP: Workflow"
+      - issueType: Bug
+        target: "KP: Bug Workflow"
+`
+
+// siteMocked serves the JSON responses by request method and path, the changes not listed return an
+// empty object.
+var siteMocked = map[string]string{
+	"GET /rest/api/3/issuetype": `[{"id": "10000", "name": "Epic"}, {"id": "10001", "name": "Story"}, {"id": "10002", "name": "Bug"},
+		{"id": "10010", "name": "Story", "scope": {"type": "PROJECT"}}]`,
+	"GET /rest/api/3/field": `[{"id": "summary", "name": "Summary"}, {"id": "description", "name": "Description"},
+		{"id": "customfield_10020", "name": "Sprint"}]`,
+	"GET /rest/api/3/role": `[{"id": 10002, "name": "Administrators"}, {"id": 10003, "name": "Developers"}]`,
+
+	"GET /rest/api/3/screens": `{"isLast": true, "values": [{"id": 10400, "name": "KP: Default Screen"},
+		{"id": 10401, "name": "Legacy Screen"}, {"id": 10402, "name": "KP: Old Screen"}]}`,
+	"GET /rest/api/3/screens/10400/tabs":          `[{"id": 1, "name": "Field Tab"}]`,
+	"GET /rest/api/3/screens/10400/tabs/1/fields": `[{"id": "summary", "name": "Summary"}, {"id": "customfield_10020"}]`,
+	"POST /rest/api/3/screens/10400/tabs":         `{"id": 2, "name": "Triage"}`,
+	"POST /rest/api/3/screens":                    `{"id": 10403, "name": "KP: Bug Screen"}`,
+	"GET /rest/api/3/screens/10403/tabs":          `[{"id": 3, "name": "Field Tab"}]`,
+	"GET /rest/api/3/screens/10403/tabs/3/fields": `[]`,
+	"POST /rest/api/3/screens/10403/tabs":         `{"id": 4, "name": "Triage"}`,
+
+	"GET /rest/api/3/fieldconfiguration": `{"isLast": true, "values": [{"id": 10600, "name": "KP: Field Configuration"},
+		{"id": 10000, "name": "KP: Default Field Configuration", "isDefault": true}]}`,
+	"GET /rest/api/3/fieldconfiguration/10600/fields": `{"isLast": true, "values": [
+		{"id": "summary", "isRequired": true}, {"id": "description", "renderer": "text-renderer"}]}`,
+
+	"GET /rest/api/3/permissionscheme": `{"permissionSchemes": [{"id": 10800, "name": "KP: Permission Scheme"},
+		{"id": 10801, "name": "KP: Team Scheme", "scope": {"type": "PROJECT"}}]}`,
+	"GET /rest/api/3/permissionscheme/10800/permission": `{"permissions": [
+		{"id": 1, "holder": {"type": "projectRole", "parameter": "10003"}, "permission": "BROWSE_PROJECTS"},
+		{"id": 2, "holder": {"type": "group", "parameter": "jira-administrators"}, "permission": "ADMINISTER_PROJECTS"}]}`,
+
+	"GET /rest/api/3/issuetypescheme": `{"isLast": true, "values": [
+		{"id": "10000", "name": "KP: Default Issue Type Scheme", "isDefault": true},
+		{"id": "10101", "name": "KP: Old Issue Type Scheme"}]}`,
+	"POST /rest/api/3/issuetypescheme": `{"issueTypeSchemeId": "10102"}`,
+
+	"GET /rest/api/3/workflowscheme": `{"isLast": true, "values": [{"id": 10700, "name": "KP: Workflow Scheme",
+		"defaultWorkflow": "jira", "issueTypeMappings": {"10002": "KP: Workflow"}}]}`,
+	"PUT /rest/api/3/workflowscheme/10700": `{"id": 10700}`,
+}
+
+type siteServer struct {
+	mu       sync.Mutex
+	changes  []string
+	failures map[string]int
+}
+
+// newSyncerMocked returns a syncer of the mocked site, the failures are the status codes of the failed changes.
+func newSyncerMocked(t *testing.T, failures map[string]int) (*Syncer, *siteServer) {
+
+	site := &siteServer{failures: failures}
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+
+		key := request.Method + " " + request.URL.Path
+
+		if request.Method != http.MethodGet {
+
+			site.mu.Lock()
+			site.changes = append(site.changes, key)
+			site.mu.Unlock()
+
+			if status, ok := site.failures[key]; ok {
+				writer.WriteHeader(status)
+				return
+			}
+		}
+
+		body, ok := siteMocked[key]
+		switch {
+		case ok:
+			writer.Header().Set("Content-Type", "application/json")
+			_, _ = writer.Write([]byte(body))
+		case request.Method == http.MethodGet:
+			writer.WriteHeader(http.StatusNotFound)
+		default:
+			writer.Header().Set("Content-Type", "application/json")
+			_, _ = writer.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := v3.New(server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	syncer, err := NewSyncer(&Services{
+		IssueType:              client.Issue.Type,
+		Field:                  client.Issue.Field,
+		ProjectRole:            client.Project.Role,
+		Screen:                 client.Screen,
+		ScreenTab:              client.Screen.Tab,
+		ScreenTabField:         client.Screen.Tab.Field,
+		FieldConfiguration:     client.Issue.Field.Configuration,
+		FieldConfigurationItem: client.Issue.Field.Configuration.Item,
+		PermissionScheme:       client.Permission.Scheme,
+		PermissionSchemeGrant:  client.Permission.Scheme.Grant,
+		TypeScheme:             client.Issue.Type.Scheme,
+		WorkflowScheme:         client.Workflow.Scheme,
+	}, &Options{Prune: true, PrunePrefix: "KP: "})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return syncer, site
+}
+
+func TestSyncer_Plan(t *testing.T) {
+
+	config, err := Read(strings.NewReader(configMocked))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	syncer, site := newSyncerMocked(t, nil)
+
+	plan, err := syncer.Plan(context.Background(), config)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	expected := `create screen              KP: Bug Screen: +tab Triage (1 fields)
+update screen              KP: Default Screen (10400): +tab Triage, -field Sprint (tab Field Tab), +field Description (tab Field Tab), +field Sprint (tab Triage)
+update field-configuration KP: Field Configuration (10600): field Description
+update permission-scheme   KP: Permission Scheme (10800): +grant ADMINISTER_PROJECTS projectRole:Administrators, -grant ADMINISTER_PROJECTS group:jira-administrators
+create issue-type-scheme   KP: Issue Type Scheme: 2 issue types
+update workflow-scheme     KP: Workflow Scheme (10700): default workflow, Bug: KP: Workflow -> KP: Bug Workflow
+delete issue-type-scheme   KP: Old Issue Type Scheme (10101)
+delete screen              KP: Old Screen (10402)
+2 to create, 4 to update, 2 to delete
+`
+
+	assert.Equal(t, expected, plan.String())
+	assert.Empty(t, site.changes, "the plan must not change the site")
+}
+
+func TestSyncer_Plan_Errors(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		config  *Config
+		wantErr error
+	}{
+		{
+			name:    "when the configuration is not provided",
+			wantErr: ErrNoConfig,
+		},
+
+		{
+			name: "when a field is not on the site",
+			config: &Config{FieldConfigurations: []*snapshot.FieldConfiguration{
+				{Name: "KP: Field Configuration", Items: []*snapshot.FieldConfigurationItem{{Field: "Story Points"}}},
+			}},
+			wantErr: ErrUnknownName,
+		},
+
+		{
+			name: "when a role is not on the site",
+			config: &Config{PermissionSchemes: []*snapshot.PermissionScheme{
+				{Name: "KP: Permission Scheme", Grants: []*snapshot.PermissionGrant{
+					{Permission: "BROWSE_PROJECTS", HolderType: "projectRole", Holder: "Users"}}},
+			}},
+			wantErr: ErrUnknownName,
+		},
+
+		{
+			name: "when a team-managed issue type has the same name",
+			config: &Config{IssueTypeSchemes: []*snapshot.IssueTypeScheme{
+				{Name: "KP: Issue Type Scheme", IssueTypes: []string{"Story"}, DefaultIssueType: "Story"},
+			}},
+		},
+
+		{
+			name:    "when two screens have the same name",
+			config:  &Config{Screens: []*snapshot.Screen{{Name: "KP: Screen"}, {Name: "KP: Screen"}}},
+			wantErr: ErrDuplicateName,
+		},
+	}
+
+	syncer, _ := newSyncerMocked(t, nil)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			_, err := syncer.Plan(context.Background(), testCase.config)
+			if testCase.wantErr != nil {
+				assert.True(t, errors.Is(err, testCase.wantErr), err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSyncer_Apply(t *testing.T) {
+
+	config, err := Read(strings.NewReader(configMocked))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	testCases := []struct {
+		name        string
+		failures    map[string]int
+		wantChanges []string
+		wantCounts  map[ResultStatus]int
+	}{
+		{
+			name: "when every change is applied",
+			wantChanges: []string{
+				"POST /rest/api/3/screens",
+				"POST /rest/api/3/screens/10403/tabs",
+				"DELETE /rest/api/3/screens/10403/tabs/3",
+				"POST /rest/api/3/screens/10403/tabs/4/fields",
+				"POST /rest/api/3/screens/10400/tabs",
+				"DELETE /rest/api/3/screens/10400/tabs/1/fields/customfield_10020",
+				"POST /rest/api/3/screens/10400/tabs/1/fields",
+				"POST /rest/api/3/screens/10400/tabs/2/fields",
+				"PUT /rest/api/3/fieldconfiguration/10600/fields",
+				"POST /rest/api/3/permissionscheme/10800/permission",
+				"DELETE /rest/api/3/permissionscheme/10800/permission/2",
+				"POST /rest/api/3/issuetypescheme",
+				"PUT /rest/api/3/workflowscheme/10700",
+				"DELETE /rest/api/3/issuetypescheme/10101",
+				"DELETE /rest/api/3/screens/10402",
+			},
+			wantCounts: map[ResultStatus]int{StatusApplied: 8},
+		},
+
+		{
+			name:     "when a change fails",
+			failures: map[string]int{"POST /rest/api/3/screens/10400/tabs/1/fields": http.StatusBadRequest},
+			wantChanges: []string{
+				"POST /rest/api/3/screens",
+				"POST /rest/api/3/screens/10403/tabs",
+				"DELETE /rest/api/3/screens/10403/tabs/3",
+				"POST /rest/api/3/screens/10403/tabs/4/fields",
+				"POST /rest/api/3/screens/10400/tabs",
+				"DELETE /rest/api/3/screens/10400/tabs/1/fields/customfield_10020",
+				"POST /rest/api/3/screens/10400/tabs/1/fields",
+			},
+			wantCounts: map[ResultStatus]int{StatusApplied: 1, StatusFailed: 1, StatusSkipped: 6},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			syncer, site := newSyncerMocked(t, testCase.failures)
+
+			plan, err := syncer.Plan(context.Background(), config)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			report, err := syncer.Apply(context.Background(), plan)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.wantChanges, site.changes)
+
+			for _, status := range []ResultStatus{StatusApplied, StatusFailed, StatusSkipped} {
+				assert.Equal(t, testCase.wantCounts[status], report.Count(status), status)
+			}
+
+			assert.Equal(t, testCase.wantCounts[StatusFailed] != 0, report.Failed())
+
+			if report.Failed() {
+				assert.Equal(t, 2, report.Results[1].Steps, "the failed change is partly applied")
+				assert.Error(t, report.Results[1].Err)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+
+	testCases := []struct {
+		name     string
+		document string
+		wantErr  error
+	}{
+		{
+			name:     "when the document is empty",
+			document: "",
+		},
+
+		{
+			name:     "when the version is newer",
+			document: "version: 2\n",
+			wantErr:  ErrUnsupportedVersion,
+		},
+
+		{
+			name:     "when a screen has no name",
+			document: "screens:\n  - description: The screen\n",
+			wantErr:  ErrNoName,
+		},
+
+		{
+			name:     "when a screen tab is duplicated",
+			document: "screens:\n  - name: KP\n    tabs:\n      - name: Tab\n      - name: Tab\n",
+			wantErr:  ErrDuplicateName,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			config, err := Read(strings.NewReader(testCase.document))
+			if testCase.wantErr != nil {
+				assert.True(t, errors.Is(err, testCase.wantErr), err)
+				assert.Nil(t, config)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, config)
+			}
+		})
+	}
+
+	_, err := Read(strings.NewReader("screen:\n  - name: KP\n"))
+	assert.Error(t, err, "the unknown keys must be rejected")
+}
@@ -18,6 +18,8 @@ type AuthenticationService struct {
 	userAgentProvided bool
 	agent             string
 
+	signer common.RequestSigner
+
 	experimentalProvided bool
 }
 
@@ -56,3 +58,15 @@ func (a *AuthenticationService) GetUserAgent() string {
 func (a *AuthenticationService) HasUserAgent() bool {
 	return a.userAgentProvided
 }
+
+func (a *AuthenticationService) SetRequestSigner(signer common.RequestSigner) {
+	a.signer = signer
+}
+
+func (a *AuthenticationService) GetRequestSigner() common.RequestSigner {
+	return a.signer
+}
+
+func (a *AuthenticationService) HasRequestSigner() bool {
+	return a.signer != nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/attachment"
 	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
 	"github.com/chrisccoy/go-atlassian/service"
 	"github.com/chrisccoy/go-atlassian/service/sm"
@@ -62,6 +63,16 @@ func (s *ServiceDeskService) Attach(ctx context.Context, serviceDeskID int, file
 	return s.internalClient.Attach(ctx, serviceDeskID, fileName, file)
 }
 
+// Upload attaches one or more temporary files to a service desk on a single multipart request,
+// the files are streamed from their readers.
+//
+// POST /rest/servicedeskapi/servicedesk/{serviceDeskId}/attachTemporaryFile
+//
+// https://docs.go-atlassian.io/jira-service-management-cloud/request/service-desk#attach-temporary-file
+func (s *ServiceDeskService) Upload(ctx context.Context, serviceDeskID int, files []*model.AttachmentFileScheme, progress model.AttachmentProgressFunc) (*model.ServiceDeskTemporaryFileScheme, *model.ResponseScheme, error) {
+	return s.internalClient.Upload(ctx, serviceDeskID, files, progress)
+}
+
 type internalServiceDeskImpl struct {
 	c       service.Client
 	version string
@@ -155,3 +166,32 @@ func (i *internalServiceDeskImpl) Attach(ctx context.Context, serviceDeskID int,
 
 	return attachmentID, response, nil
 }
+
+func (i *internalServiceDeskImpl) Upload(ctx context.Context, serviceDeskID int, files []*model.AttachmentFileScheme, progress model.AttachmentProgressFunc) (*model.ServiceDeskTemporaryFileScheme, *model.ResponseScheme, error) {
+
+	if serviceDeskID == 0 {
+		return nil, nil, model.ErrNoServiceDeskIDError
+	}
+
+	body, contentType, err := attachment.NewMultipart(files, nil, progress)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer body.Close()
+
+	endpoint := fmt.Sprintf("rest/servicedeskapi/servicedesk/%v/attachTemporaryFile", serviceDeskID)
+
+	request, err := i.c.NewFormRequest(ctx, http.MethodPost, endpoint, contentType, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachments := new(model.ServiceDeskTemporaryFileScheme)
+	response, err := i.c.Call(request, attachments)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return attachments, response, nil
+}
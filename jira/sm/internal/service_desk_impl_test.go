@@ -9,9 +9,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -459,3 +461,148 @@ func Test_internalServiceDeskImpl_Attach(t *testing.T) {
 		})
 	}
 }
+
+func Test_internalServiceDeskImpl_Upload(t *testing.T) {
+
+	type fields struct {
+		c       service.Client
+		version string
+	}
+
+	type args struct {
+		ctx           context.Context
+		serviceDeskID int
+		files         []*model.AttachmentFileScheme
+	}
+
+	testCases := []struct {
+		name    string
+		fields  fields
+		args    args
+		on      func(*fields)
+		wantErr bool
+		Err     error
+	}{
+		{
+			name: "when the parameters are correct",
+			args: args{
+				ctx:           context.Background(),
+				serviceDeskID: 10001,
+				files: []*model.AttachmentFileScheme{
+					{Name: "first.txt", Reader: strings.NewReader("first")},
+					{Name: "second.txt", Reader: strings.NewReader("second")},
+				},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				var payload io.Reader
+
+				client.On("NewFormRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/servicedeskapi/servicedesk/10001/attachTemporaryFile",
+					mock.Anything,
+					mock.Anything).
+					Run(func(args mock.Arguments) {
+						payload = args.Get(4).(io.Reader)
+					}).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ServiceDeskTemporaryFileScheme{}).
+					Run(func(args mock.Arguments) {
+
+						body, err := ioutil.ReadAll(payload)
+						assert.NoError(t, err)
+						assert.Contains(t, string(body), `filename="first.txt"`)
+						assert.Contains(t, string(body), `filename="second.txt"`)
+					}).
+					Return(&model.ResponseScheme{}, nil)
+
+				fields.c = client
+			},
+		},
+
+		{
+			name: "when the service desk id is not provided",
+			args: args{
+				ctx:   context.Background(),
+				files: []*model.AttachmentFileScheme{{Name: "first.txt", Reader: strings.NewReader("first")}},
+			},
+			wantErr: true,
+			Err:     model.ErrNoServiceDeskIDError,
+		},
+
+		{
+			name: "when the files are not provided",
+			args: args{
+				ctx:           context.Background(),
+				serviceDeskID: 10001,
+			},
+			wantErr: true,
+			Err:     model.ErrNoAttachmentFilesError,
+		},
+
+		{
+			name: "when the http call cannot be executed",
+			args: args{
+				ctx:           context.Background(),
+				serviceDeskID: 10001,
+				files:         []*model.AttachmentFileScheme{{Name: "first.txt", Reader: strings.NewReader("first")}},
+			},
+			on: func(fields *fields) {
+
+				client := mocks.NewClient(t)
+
+				client.On("NewFormRequest",
+					context.Background(),
+					http.MethodPost,
+					"rest/servicedeskapi/servicedesk/10001/attachTemporaryFile",
+					mock.Anything,
+					mock.Anything).
+					Return(&http.Request{}, nil)
+
+				client.On("Call",
+					&http.Request{},
+					&model.ServiceDeskTemporaryFileScheme{}).
+					Return(&model.ResponseScheme{}, errors.New("error, request failed"))
+
+				fields.c = client
+			},
+			wantErr: true,
+			Err:     errors.New("error, request failed"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			if testCase.on != nil {
+				testCase.on(&testCase.fields)
+			}
+
+			smService, err := NewServiceDeskService(testCase.fields.c, "latest", nil)
+			assert.NoError(t, err)
+
+			gotResult, gotResponse, err := smService.Upload(testCase.args.ctx, testCase.args.serviceDeskID, testCase.args.files, nil)
+
+			if testCase.wantErr {
+
+				if err != nil {
+					t.Logf("error returned: %v", err.Error())
+				}
+
+				assert.EqualError(t, err, testCase.Err.Error())
+
+			} else {
+
+				assert.NoError(t, err)
+				assert.NotEqual(t, gotResponse, nil)
+				assert.NotEqual(t, gotResult, nil)
+			}
+		})
+	}
+}
@@ -81,6 +81,17 @@ func (t *TypeService) Fields(ctx context.Context, serviceDeskID, requestTypeID i
 	return t.internalClient.Fields(ctx, serviceDeskID, requestTypeID)
 }
 
+// Validator returns a validator loaded with the fields of a service desk's customer request type.
+//
+// The validator type-checks the field values and resolves the option labels to IDs before creating the request.
+//
+// GET /rest/servicedeskapi/servicedesk/{serviceDeskId}/requesttype/{requestTypeId}/field
+//
+// https://docs.go-atlassian.io/jira-service-management-cloud/request/types#validate-request-type-fields
+func (t *TypeService) Validator(ctx context.Context, serviceDeskID, requestTypeID int) (*model.RequestFieldsValidator, *model.ResponseScheme, error) {
+	return t.internalClient.Validator(ctx, serviceDeskID, requestTypeID)
+}
+
 type internalTypeImpl struct {
 	c       service.Client
 	version string
@@ -252,3 +263,13 @@ func (i *internalTypeImpl) Fields(ctx context.Context, serviceDeskID, requestTyp
 
 	return fields, response, nil
 }
+
+func (i *internalTypeImpl) Validator(ctx context.Context, serviceDeskID, requestTypeID int) (*model.RequestFieldsValidator, *model.ResponseScheme, error) {
+
+	fields, response, err := i.Fields(ctx, serviceDeskID, requestTypeID)
+	if err != nil {
+		return nil, response, err
+	}
+
+	return model.NewRequestFieldsValidator(fields), response, nil
+}
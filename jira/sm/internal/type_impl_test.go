@@ -3,13 +3,16 @@ package internal
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
 	"github.com/chrisccoy/go-atlassian/service"
 	"github.com/chrisccoy/go-atlassian/service/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func Test_internalTypeImpl_Search(t *testing.T) {
@@ -911,3 +914,148 @@ func Test_internalTypeImpl_Create(t *testing.T) {
 		})
 	}
 }
+
+func Test_internalTypeImpl_Validator(t *testing.T) {
+
+	fieldsMocked := `{"requestTypeFields": [
+		{"fieldId": "summary", "name": "Summary", "required": true, "jiraSchema": {"type": "string", "system": "summary"}},
+		{"fieldId": "priority", "name": "Priority", "required": false, "jiraSchema": {"type": "priority", "system": "priority"},
+			"validValues": [{"value": "1", "label": "Highest"}, {"value": "3", "label": "Medium"}]},
+		{"fieldId": "customfield_10010", "name": "Location", "required": false, "jiraSchema": {"type": "option-with-child"},
+			"validValues": [{"value": "100", "label": "Spain", "children": [{"value": "101", "label": "Madrid"}]}]},
+		{"fieldId": "customfield_10011", "name": "Systems", "jiraSchema": {"type": "array", "items": "option"},
+			"validValues": [{"value": "200", "label": "VPN"}, {"value": "201", "label": "Email"}]},
+		{"fieldId": "customfield_10012", "name": "Due", "jiraSchema": {"type": "date"}},
+		{"fieldId": "customfield_10013", "name": "Cost", "jiraSchema": {"type": "number"}},
+		{"fieldId": "customfield_10014", "name": "Approver", "jiraSchema": {"type": "user"}},
+		{"fieldId": "customfield_10015", "name": "Link", "jiraSchema": {"type": "string",
+			"custom": "com.atlassian.jira.plugin.system.customfieldtypes:url"}},
+		{"fieldId": "description", "name": "Description", "required": true, "defaultValues": [{"value": "n/a"}],
+			"jiraSchema": {"type": "string", "system": "description"}}
+	]}`
+
+	testCases := []struct {
+		name     string
+		values   map[string]interface{}
+		want     []map[string]interface{}
+		problems []string
+	}{
+		{
+			name: "when the values are valid",
+			values: map[string]interface{}{
+				"Summary":           "Laptop broken",
+				"priority":          "medium",
+				"customfield_10010": []string{"Spain", "madrid"},
+				"Systems":           []string{"VPN", "201"},
+				"customfield_10012": time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC),
+				"customfield_10013": 150,
+				"customfield_10014": "5b10ac8d82e05b22cc7d4ef5",
+				"customfield_10015": "https://go-atlassian.io",
+			},
+			want: []map[string]interface{}{
+				{"summary": "Laptop broken"},
+				{"priority": map[string]interface{}{"id": "3"}},
+				{"customfield_10010": map[string]interface{}{"id": "100", "child": map[string]interface{}{"id": "101"}}},
+				{"customfield_10011": []interface{}{map[string]interface{}{"id": "200"}, map[string]interface{}{"id": "201"}}},
+				{"customfield_10012": "2022-03-01"},
+				{"customfield_10013": 150.0},
+				{"customfield_10014": map[string]interface{}{"accountId": "5b10ac8d82e05b22cc7d4ef5"}},
+				{"customfield_10015": "https://go-atlassian.io"},
+			},
+		},
+
+		{
+			name: "when the values are not valid",
+			values: map[string]interface{}{
+				"priority":          "Urgent",
+				"customfield_10011": "VPN",
+				"customfield_10012": "yesterday",
+				"customfield_10013": "150",
+				"customfield_10015": "not a url",
+				"customfield_99999": "unknown",
+			},
+			problems: []string{
+				"customfield_99999",
+				"summary",
+				"priority",
+				"customfield_10011",
+				"customfield_10012",
+				"customfield_10013",
+				"customfield_10015",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			client := mocks.NewClient(t)
+
+			client.On("NewRequest",
+				context.Background(),
+				http.MethodGet,
+				"rest/servicedeskapi/servicedesk/10001/requesttype/38383/field",
+				nil).
+				Return(&http.Request{}, nil)
+
+			client.On("Call",
+				&http.Request{},
+				&model.RequestTypeFieldsScheme{}).
+				Run(func(args mock.Arguments) {
+					assert.NoError(t, json.Unmarshal([]byte(fieldsMocked), args.Get(1)))
+				}).
+				Return(&model.ResponseScheme{}, nil)
+
+			smService, err := NewTypeService(client, "latest")
+			assert.NoError(t, err)
+
+			validator, gotResponse, err := smService.Validator(context.Background(), 10001, 38383)
+			assert.NoError(t, err)
+			assert.NotEqual(t, gotResponse, nil)
+
+			// The values are set on a fixed order, so the problems are reported deterministically
+			for _, key := range []string{"customfield_99999", "Summary", "priority", "customfield_10010", "Systems",
+				"customfield_10011", "customfield_10012", "customfield_10013", "customfield_10014", "customfield_10015"} {
+
+				if value, ok := testCase.values[key]; ok {
+					validator.Set(key, value)
+				}
+			}
+
+			fields, err := validator.Build()
+
+			if len(testCase.problems) != 0 {
+
+				var validationError *model.RequestFieldsValidationError
+				assert.True(t, errors.As(err, &validationError))
+				assert.True(t, errors.Is(err, model.ErrInvalidRequestFieldsError))
+
+				var gotProblems []string
+				for _, problem := range validationError.Problems {
+					gotProblems = append(gotProblems, problem.FieldID)
+				}
+
+				assert.Equal(t, testCase.problems, gotProblems)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			var gotFields []map[string]interface{}
+			for _, field := range fields.Fields {
+				gotFields = append(gotFields, field["requestFieldValues"].(map[string]interface{}))
+			}
+
+			assert.Equal(t, testCase.want, gotFields)
+		})
+	}
+
+	t.Run("when the service desk id is not provided", func(t *testing.T) {
+
+		smService, err := NewTypeService(mocks.NewClient(t), "latest")
+		assert.NoError(t, err)
+
+		_, _, err = smService.Validator(context.Background(), 0, 38383)
+		assert.EqualError(t, err, model.ErrNoServiceDeskIDError.Error())
+	})
+}
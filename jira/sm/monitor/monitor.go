@@ -0,0 +1,424 @@
+// Package monitor watches the SLAs of the customer requests in Jira Service Management queues,
+// emitting an event when an ongoing SLA cycle is about to breach or has breached.
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/chrisccoy/go-atlassian/service/sm"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultConcurrency = 5
+	defaultPageSize    = 50
+)
+
+var (
+	ErrNoServiceDesks = errors.New("monitor: no service desks set")
+	ErrNoThreshold    = errors.New("monitor: no at-risk threshold set")
+	ErrNoInterval     = errors.New("monitor: no poll interval set")
+)
+
+type EventType string
+
+const (
+	// EventAtRisk is emitted when the remaining time of an ongoing cycle drops below the threshold.
+	EventAtRisk EventType = "at-risk"
+
+	// EventBreached is emitted when an ongoing cycle breaches its goal.
+	EventBreached EventType = "breached"
+)
+
+type Event struct {
+	Type          EventType               `json:"type"`
+	ServiceDeskID int                     `json:"serviceDeskId"`
+	QueueID       int                     `json:"queueId"`
+	IssueKey      string                  `json:"issueKey"`
+	SLA           *model.RequestSLAScheme `json:"sla"`
+	Remaining     time.Duration           `json:"remaining"`
+	DetectedAt    time.Time               `json:"detectedAt"`
+}
+
+// Handler receives the events emitted on each poll, an error stops the poll: the events handled before it are marked
+// as emitted, the event failed and the ones after it are emitted again on the next poll.
+type Handler func(ctx context.Context, event *Event) error
+
+type Config struct {
+
+	// ServiceDesks are the IDs of the service desks monitored.
+	ServiceDesks []int
+
+	// Queues are the queue IDs monitored per service desk, every queue of the service desk is monitored when it's empty.
+	Queues map[int][]int
+
+	// SLAs are the names or IDs of the SLA metrics monitored, every SLA is monitored when it's empty.
+	SLAs []string
+
+	// Threshold is the remaining time under which an ongoing cycle is at risk.
+	Threshold time.Duration
+
+	// Concurrency is the number of requests whose SLAs are fetched at the same time, by default 5.
+	Concurrency int
+
+	// PageSize is the limit used on the paginated endpoints, by default 50.
+	PageSize int
+}
+
+// Monitor polls the service desk queues, the events already emitted are remembered on the state store,
+// so every breach is notified once even when the monitor is restarted.
+type Monitor struct {
+	queues sm.QueueConnector
+	slas   sm.ServiceLevelAgreementConnector
+	store  StateStore
+	config *Config
+	now    func() time.Time
+}
+
+// New returns a monitor using the queue and SLA services of the Service Management client,
+// e.g. client.ServiceDesk.Queue and client.Request.SLA. The state is kept on memory when the store is nil.
+func New(queues sm.QueueConnector, slas sm.ServiceLevelAgreementConnector, store StateStore, config *Config) (*Monitor, error) {
+
+	if config == nil || len(config.ServiceDesks) == 0 {
+		return nil, ErrNoServiceDesks
+	}
+
+	if config.Threshold <= 0 {
+		return nil, ErrNoThreshold
+	}
+
+	if store == nil {
+		store = NewMemoryStateStore()
+	}
+
+	return &Monitor{queues: queues, slas: slas, store: store, config: config, now: time.Now}, nil
+}
+
+// Run polls the queues on every interval until the context is cancelled, the poll errors are sent to onError
+// when it's not nil and the monitor keeps running.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration, handler Handler, onError func(error)) error {
+
+	if interval <= 0 {
+		return ErrNoInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+
+		if _, err := m.Poll(ctx, handler); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+type queuedRequest struct {
+	serviceDeskID, queueID int
+	issueKey               string
+}
+
+// Poll checks the SLAs once, the new events are sent to the handler and returned.
+//
+// The state keeps only the requests seen on the poll, so the state doesn't grow with the closed requests.
+func (m *Monitor) Poll(ctx context.Context, handler Handler) ([]*Event, error) {
+
+	state, err := m.store.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := m.requests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := m.evaluate(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events []*Event
+		seen   = make(map[string]EventType)
+	)
+
+	for _, event := range candidates {
+
+		key := eventKey(event)
+
+		emitted, ok := state.Emitted[key]
+		if ok && (emitted == event.Type || emitted == EventBreached) {
+			seen[key] = emitted
+			continue
+		}
+
+		// The state of the events handled is saved when a handler fails, so they aren't emitted again on the next poll.
+		if handler != nil {
+			if err := handler(ctx, event); err != nil {
+
+				if saveErr := m.save(ctx, state, seen, requests); saveErr != nil {
+					return events, fmt.Errorf("%w (saving the state: %v)", err, saveErr)
+				}
+
+				return events, err
+			}
+		}
+
+		seen[key] = event.Type
+		events = append(events, event)
+	}
+
+	return events, m.save(ctx, state, seen, requests)
+}
+
+// save stores the events seen on the poll. The requests still queued keep their emitted state, e.g. a paused cycle
+// isn't notified again when it resumes.
+func (m *Monitor) save(ctx context.Context, state *State, seen map[string]EventType, requests []*queuedRequest) error {
+
+	active := make(map[string]bool, len(requests))
+	for _, request := range requests {
+		active[request.issueKey] = true
+	}
+
+	for key, emitted := range state.Emitted {
+		if _, ok := seen[key]; !ok && active[strings.SplitN(key, "|", 2)[0]] {
+			seen[key] = emitted
+		}
+	}
+
+	state.Emitted, state.LastPoll = seen, m.now()
+
+	return m.store.Save(ctx, state)
+}
+
+// requests returns the customer requests of the monitored queues, a request on several queues is returned once.
+func (m *Monitor) requests(ctx context.Context) ([]*queuedRequest, error) {
+
+	var (
+		requests []*queuedRequest
+		seen     = make(map[string]bool)
+	)
+
+	for _, serviceDeskID := range m.config.ServiceDesks {
+
+		queueIDs, err := m.queueIDs(ctx, serviceDeskID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, queueID := range queueIDs {
+
+			for start := 0; ; {
+
+				page, _, err := m.queues.Issues(ctx, serviceDeskID, queueID, start, m.pageSize())
+				if err != nil {
+					return nil, err
+				}
+
+				for _, issue := range page.Values {
+					if !seen[issue.Key] {
+						seen[issue.Key] = true
+						requests = append(requests, &queuedRequest{serviceDeskID: serviceDeskID, queueID: queueID, issueKey: issue.Key})
+					}
+				}
+
+				start += len(page.Values)
+				if page.IsLastPage || len(page.Values) == 0 {
+					break
+				}
+			}
+		}
+	}
+
+	return requests, nil
+}
+
+func (m *Monitor) queueIDs(ctx context.Context, serviceDeskID int) ([]int, error) {
+
+	if queueIDs, ok := m.config.Queues[serviceDeskID]; ok && len(queueIDs) != 0 {
+		return queueIDs, nil
+	}
+
+	var queueIDs []int
+	for start := 0; ; {
+
+		page, _, err := m.queues.Gets(ctx, serviceDeskID, false, start, m.pageSize())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, queue := range page.Values {
+
+			queueID, err := strconv.Atoi(queue.ID)
+			if err != nil {
+				return nil, fmt.Errorf("monitor: invalid queue id %q: %w", queue.ID, err)
+			}
+
+			queueIDs = append(queueIDs, queueID)
+		}
+
+		start += len(page.Values)
+		if page.IsLastPage || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	return queueIDs, nil
+}
+
+// evaluate fetches the SLAs of the requests concurrently, returning the events in the requests order.
+func (m *Monitor) evaluate(ctx context.Context, requests []*queuedRequest) ([]*Event, error) {
+
+	concurrency := m.config.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results   = make([][]*Event, len(requests))
+		semaphore = make(chan struct{}, concurrency)
+		waitGroup sync.WaitGroup
+		once      sync.Once
+		firstErr  error
+	)
+
+	for index, request := range requests {
+
+		waitGroup.Add(1)
+		semaphore <- struct{}{}
+
+		go func(index int, request *queuedRequest) {
+
+			defer waitGroup.Done()
+			defer func() { <-semaphore }()
+
+			events, err := m.evaluateRequest(ctx, request)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+
+			results[index] = events
+		}(index, request)
+	}
+
+	waitGroup.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	var events []*Event
+	for _, result := range results {
+		events = append(events, result...)
+	}
+
+	return events, nil
+}
+
+func (m *Monitor) evaluateRequest(ctx context.Context, request *queuedRequest) ([]*Event, error) {
+
+	var events []*Event
+	for start := 0; ; {
+
+		page, _, err := m.slas.Gets(ctx, request.issueKey, start, m.pageSize())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sla := range page.Values {
+
+			if !m.monitored(sla) || sla.OngoingCycle == nil {
+				continue
+			}
+
+			cycle := sla.OngoingCycle
+
+			var remaining time.Duration
+			if cycle.RemainingTime != nil {
+				remaining = time.Duration(cycle.RemainingTime.Millis) * time.Millisecond
+			}
+
+			event := &Event{
+				ServiceDeskID: request.serviceDeskID,
+				QueueID:       request.queueID,
+				IssueKey:      request.issueKey,
+				SLA:           sla,
+				Remaining:     remaining,
+				DetectedAt:    m.now(),
+			}
+
+			// The cycles without a remaining time, e.g. without a goal, are only reported when they're breached.
+			switch {
+			case cycle.Breached || remaining < 0:
+				event.Type = EventBreached
+			case cycle.RemainingTime != nil && !cycle.Paused && remaining < m.config.Threshold:
+				event.Type = EventAtRisk
+			default:
+				continue
+			}
+
+			events = append(events, event)
+		}
+
+		start += len(page.Values)
+		if page.IsLastPage || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	return events, nil
+}
+
+func (m *Monitor) monitored(sla *model.RequestSLAScheme) bool {
+
+	if len(m.config.SLAs) == 0 {
+		return true
+	}
+
+	for _, wanted := range m.config.SLAs {
+		if sla.ID == wanted || strings.EqualFold(sla.Name, wanted) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *Monitor) pageSize() int {
+
+	if m.config.PageSize <= 0 {
+		return defaultPageSize
+	}
+
+	return m.config.PageSize
+}
+
+// eventKey identifies the SLA cycle, so a new cycle of the same SLA (e.g. after a reopening) is notified again.
+func eventKey(event *Event) string {
+
+	var cycleStart int
+	if event.SLA.OngoingCycle.StartTime != nil {
+		cycleStart = event.SLA.OngoingCycle.StartTime.EpochMillis
+	}
+
+	return fmt.Sprintf("%v|%v|%v", event.IssueKey, event.SLA.ID, cycleStart)
+}
@@ -0,0 +1,298 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeQueues struct {
+	queues []string
+	issues map[int][]string
+}
+
+func (f *fakeQueues) Gets(ctx context.Context, serviceDeskID int, includeCount bool, start, limit int) (*model.ServiceDeskQueuePageScheme, *model.ResponseScheme, error) {
+
+	page := &model.ServiceDeskQueuePageScheme{IsLastPage: true}
+	for _, id := range f.queues {
+		page.Values = append(page.Values, &model.ServiceDeskQueueScheme{ID: id})
+	}
+
+	return page, nil, nil
+}
+
+func (f *fakeQueues) Get(ctx context.Context, serviceDeskID, queueID int, includeCount bool) (*model.ServiceDeskQueueScheme, *model.ResponseScheme, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+func (f *fakeQueues) Issues(ctx context.Context, serviceDeskID, queueID, start, limit int) (*model.ServiceDeskIssueQueueScheme, *model.ResponseScheme, error) {
+
+	keys := f.issues[queueID]
+
+	end := start + limit
+	if end > len(keys) {
+		end = len(keys)
+	}
+
+	page := &model.ServiceDeskIssueQueueScheme{IsLastPage: end == len(keys)}
+	for _, key := range keys[start:end] {
+		page.Values = append(page.Values, &model.IssueSchemeV2{Key: key})
+	}
+
+	return page, nil, nil
+}
+
+type fakeSLAs struct {
+	mu   sync.Mutex
+	slas map[string][]*model.RequestSLAScheme
+	err  error
+}
+
+func (f *fakeSLAs) set(issueKey string, slas ...*model.RequestSLAScheme) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.slas[issueKey] = slas
+}
+
+func (f *fakeSLAs) Gets(ctx context.Context, issueKeyOrID string, start, limit int) (*model.RequestSLAPageScheme, *model.ResponseScheme, error) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+
+	return &model.RequestSLAPageScheme{IsLastPage: true, Values: f.slas[issueKeyOrID]}, nil, nil
+}
+
+func (f *fakeSLAs) Get(ctx context.Context, issueKeyOrID string, metricID int) (*model.RequestSLAScheme, *model.ResponseScheme, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+func slaMock(id, name string, cycleStart int, remaining time.Duration, breached, paused bool) *model.RequestSLAScheme {
+
+	return &model.RequestSLAScheme{
+		ID:   id,
+		Name: name,
+		OngoingCycle: &model.RequestSLAOngoingCycleScheme{
+			StartTime:     &model.CustomerRequestDateScheme{EpochMillis: cycleStart},
+			Breached:      breached,
+			Paused:        paused,
+			RemainingTime: &model.RequestSLADurationScheme{Millis: remaining.Milliseconds()},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+
+	_, err := New(nil, nil, nil, nil)
+	assert.True(t, errors.Is(err, ErrNoServiceDesks))
+
+	_, err = New(nil, nil, nil, &Config{ServiceDesks: []int{1}})
+	assert.True(t, errors.Is(err, ErrNoThreshold))
+
+	monitor, err := New(nil, nil, nil, &Config{ServiceDesks: []int{1}, Threshold: time.Hour})
+	assert.NoError(t, err)
+	assert.NotNil(t, monitor.store)
+
+	assert.True(t, errors.Is(monitor.Run(context.Background(), 0, nil, nil), ErrNoInterval))
+}
+
+func TestMonitor_Poll(t *testing.T) {
+
+	queues := &fakeQueues{
+		queues: []string{"10", "11"},
+		issues: map[int][]string{10: {"DESK-1", "DESK-2", "DESK-3"}, 11: {"DESK-2", "DESK-4"}},
+	}
+
+	slas := &fakeSLAs{slas: map[string][]*model.RequestSLAScheme{
+		"DESK-1": {slaMock("1", "Time to first response", 100, 30*time.Minute, false, false)},
+		"DESK-2": {slaMock("1", "Time to first response", 200, 5*time.Hour, false, false)},
+		"DESK-3": {
+			slaMock("1", "Time to first response", 300, -time.Minute, true, false),
+			slaMock("2", "Time to resolution", 300, 10*time.Minute, false, false),
+		},
+		"DESK-4": {slaMock("1", "Time to first response", 400, 10*time.Minute, false, true)},
+	}}
+
+	monitor, err := New(queues, slas, nil, &Config{
+		ServiceDesks: []int{1},
+		SLAs:         []string{"time to first response"},
+		Threshold:    time.Hour,
+		Concurrency:  2,
+		PageSize:     2,
+	})
+	assert.NoError(t, err)
+
+	var handled []string
+	handler := func(ctx context.Context, event *Event) error {
+		handled = append(handled, event.IssueKey+":"+string(event.Type))
+		return nil
+	}
+
+	events, err := monitor.Poll(context.Background(), handler)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, []string{"DESK-1:at-risk", "DESK-3:breached"}, handled)
+	assert.Equal(t, 10, events[0].QueueID)
+
+	// The events already emitted aren't notified again.
+	events, err = monitor.Poll(context.Background(), handler)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	// An at-risk cycle breaching is notified, a breached cycle isn't notified when it's at risk again.
+	slas.set("DESK-1", slaMock("1", "Time to first response", 100, -time.Second, true, false))
+	slas.set("DESK-3", slaMock("1", "Time to first response", 300, time.Minute, false, false))
+
+	events, err = monitor.Poll(context.Background(), handler)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, EventBreached, events[0].Type)
+	assert.Equal(t, "DESK-1", events[0].IssueKey)
+
+	// A new cycle of the same SLA is notified again.
+	slas.set("DESK-3", slaMock("1", "Time to first response", 900, time.Minute, false, false))
+
+	events, err = monitor.Poll(context.Background(), handler)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, EventAtRisk, events[0].Type)
+}
+
+func TestMonitor_Poll_NoRemainingTime(t *testing.T) {
+
+	// The cycles without a goal have no remaining time, they're only reported when they're breached.
+	noGoal := slaMock("1", "Time to first response", 100, 0, false, false)
+	noGoal.OngoingCycle.RemainingTime = nil
+
+	breached := slaMock("1", "Time to first response", 200, 0, true, false)
+	breached.OngoingCycle.RemainingTime = nil
+
+	queues := &fakeQueues{queues: []string{"10"}, issues: map[int][]string{10: {"DESK-1", "DESK-2"}}}
+	slas := &fakeSLAs{slas: map[string][]*model.RequestSLAScheme{"DESK-1": {noGoal}, "DESK-2": {breached}}}
+
+	monitor, err := New(queues, slas, nil, &Config{ServiceDesks: []int{1}, Threshold: time.Hour})
+	assert.NoError(t, err)
+
+	events, err := monitor.Poll(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "DESK-2", events[0].IssueKey)
+	assert.Equal(t, EventBreached, events[0].Type)
+
+	events, err = monitor.Poll(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestMonitor_Poll_Errors(t *testing.T) {
+
+	queues := &fakeQueues{queues: []string{"10"}, issues: map[int][]string{10: {"DESK-1"}}}
+	slas := &fakeSLAs{slas: map[string][]*model.RequestSLAScheme{
+		"DESK-1": {slaMock("1", "Time to first response", 100, time.Minute, false, false)},
+	}}
+
+	monitor, err := New(queues, slas, nil, &Config{ServiceDesks: []int{1}, Threshold: time.Hour})
+	assert.NoError(t, err)
+
+	// The events not handled are emitted on the next poll.
+	handlerErr := errors.New("webhook unavailable")
+	_, err = monitor.Poll(context.Background(), func(ctx context.Context, event *Event) error { return handlerErr })
+	assert.True(t, errors.Is(err, handlerErr))
+
+	events, err := monitor.Poll(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	slas.err = errors.New("client: no connection")
+	_, err = monitor.Poll(context.Background(), nil)
+	assert.EqualError(t, err, "client: no connection")
+
+	queues.queues = []string{"invalid"}
+	_, err = monitor.Poll(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestMonitor_Poll_PartialFailure(t *testing.T) {
+
+	queues := &fakeQueues{queues: []string{"10"}, issues: map[int][]string{10: {"DESK-1", "DESK-2"}}}
+	slas := &fakeSLAs{slas: map[string][]*model.RequestSLAScheme{
+		"DESK-1": {slaMock("1", "Time to first response", 100, time.Minute, false, false)},
+		"DESK-2": {slaMock("1", "Time to first response", 200, time.Minute, false, false)},
+	}}
+
+	monitor, err := New(queues, slas, nil, &Config{ServiceDesks: []int{1}, Threshold: time.Hour})
+	assert.NoError(t, err)
+
+	// The second handler call fails, the first event is saved as emitted.
+	handlerErr := errors.New("webhook unavailable")
+	var calls int
+	events, err := monitor.Poll(context.Background(), func(ctx context.Context, event *Event) error {
+
+		calls++
+		if calls == 2 {
+			return handlerErr
+		}
+
+		return nil
+	})
+	assert.True(t, errors.Is(err, handlerErr))
+	assert.Len(t, events, 1)
+	assert.Equal(t, "DESK-1", events[0].IssueKey)
+
+	var handled []string
+	events, err = monitor.Poll(context.Background(), func(ctx context.Context, event *Event) error {
+		handled = append(handled, event.IssueKey)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, []string{"DESK-2"}, handled)
+}
+
+func TestFileStateStore(t *testing.T) {
+
+	directory, err := ioutil.TempDir("", "monitor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	path := filepath.Join(directory, "state.json")
+
+	queues := &fakeQueues{issues: map[int][]string{10: {"DESK-1"}}}
+	slas := &fakeSLAs{slas: map[string][]*model.RequestSLAScheme{
+		"DESK-1": {slaMock("1", "Time to first response", 100, time.Minute, false, false)},
+	}}
+
+	config := &Config{ServiceDesks: []int{1}, Queues: map[int][]int{1: {10}}, Threshold: time.Hour}
+
+	first, err := New(queues, slas, NewFileStateStore(path), config)
+	assert.NoError(t, err)
+
+	events, err := first.Poll(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	// A restarted monitor resumes from the state file.
+	second, err := New(queues, slas, NewFileStateStore(path), config)
+	assert.NoError(t, err)
+
+	events, err = second.Poll(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	state, err := NewFileStateStore(path).Load(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]EventType{"DESK-1|1|100": EventAtRisk}, state.Emitted)
+	assert.False(t, state.LastPoll.IsZero())
+}
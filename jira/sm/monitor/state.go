@@ -0,0 +1,120 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// State is the monitor state persisted between polls.
+type State struct {
+
+	// Emitted are the last event types emitted per SLA cycle, keyed by issue key, SLA ID and cycle start.
+	Emitted map[string]EventType `json:"emitted"`
+
+	LastPoll time.Time `json:"lastPoll"`
+}
+
+// StateStore persists the monitor state, so the monitor can be resumed without notifying the same events again.
+type StateStore interface {
+	Load(ctx context.Context) (*State, error)
+	Save(ctx context.Context, state *State) error
+}
+
+type memoryStateStore struct {
+	mu    sync.Mutex
+	state *State
+}
+
+// NewMemoryStateStore returns a store keeping the state on memory, the state is lost when the process exits.
+func NewMemoryStateStore() StateStore {
+	return &memoryStateStore{}
+}
+
+func (m *memoryStateStore) Load(ctx context.Context) (*State, error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state := &State{Emitted: make(map[string]EventType)}
+	if m.state != nil {
+		state.LastPoll = m.state.LastPoll
+		for key, value := range m.state.Emitted {
+			state.Emitted[key] = value
+		}
+	}
+
+	return state, nil
+}
+
+func (m *memoryStateStore) Save(ctx context.Context, state *State) error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state = state
+	return nil
+}
+
+type fileStateStore struct {
+	path string
+}
+
+// NewFileStateStore returns a store keeping the state as a JSON file, the file is replaced atomically on every save.
+func NewFileStateStore(path string) StateStore {
+	return &fileStateStore{path: path}
+}
+
+func (f *fileStateStore) Load(ctx context.Context) (*State, error) {
+
+	state := &State{Emitted: make(map[string]EventType)}
+
+	content, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(content, state); err != nil {
+		return nil, err
+	}
+
+	if state.Emitted == nil {
+		state.Emitted = make(map[string]EventType)
+	}
+
+	return state, nil
+}
+
+func (f *fileStateStore) Save(ctx context.Context, state *State) error {
+
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	temporary, err := ioutil.TempFile(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err = temporary.Write(content); err != nil {
+		temporary.Close()
+		os.Remove(temporary.Name())
+		return err
+	}
+
+	if err = temporary.Close(); err != nil {
+		os.Remove(temporary.Name())
+		return err
+	}
+
+	return os.Rename(temporary.Name(), f.path)
+}
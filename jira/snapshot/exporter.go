@@ -0,0 +1,1056 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"github.com/chrisccoy/go-atlassian/service/jira"
+	"sort"
+	"strconv"
+)
+
+const defaultPageSize = 50
+
+var (
+	ErrNoProjectKey       = errors.New("snapshot: no project key set")
+	ErrMissingService     = errors.New("snapshot: missing service")
+	ErrUnknownFormat      = errors.New("snapshot: unknown document format")
+	ErrUnsupportedVersion = errors.New("snapshot: unsupported document version")
+)
+
+// Services are the Jira services read by the exporter, e.g. with a v3 client:
+//
+//	&snapshot.Services{
+//		Project:                  client.Project,
+//		ProjectPermissionScheme:  client.Project.Permission,
+//		ProjectComponent:         client.Project.Component,
+//		ProjectVersion:           client.Project.Version,
+//		ProjectRole:              client.Project.Role,
+//		IssueType:                client.Issue.Type,
+//		TypeScheme:               client.Issue.Type.Scheme,
+//		TypeScreenScheme:         client.Issue.Type.ScreenScheme,
+//		ScreenScheme:             client.Screen.Scheme,
+//		Screen:                   client.Screen,
+//		ScreenTab:                client.Screen.Tab,
+//		ScreenTabField:           client.Screen.Tab.Field,
+//		Field:                    client.Issue.Field,
+//		FieldConfiguration:       client.Issue.Field.Configuration,
+//		FieldConfigurationItem:   client.Issue.Field.Configuration.Item,
+//		FieldConfigurationScheme: client.Issue.Field.Configuration.Scheme,
+//		Workflow:                 client.Workflow,
+//		WorkflowScheme:           client.Workflow.Scheme,
+//		PermissionScheme:         client.Permission.Scheme,
+//	}
+type Services struct {
+	Project                  jira.ProjectConnector
+	ProjectPermissionScheme  jira.ProjectPermissionSchemeConnector
+	ProjectComponent         jira.ProjectComponentConnector
+	ProjectVersion           jira.ProjectVersionConnector
+	ProjectRole              jira.ProjectRoleConnector
+	IssueType                jira.TypeConnector
+	TypeScheme               jira.TypeSchemeConnector
+	TypeScreenScheme         jira.TypeScreenSchemeConnector
+	ScreenScheme             jira.ScreenSchemeConnector
+	Screen                   jira.ScreenConnector
+	ScreenTab                jira.ScreenTabConnector
+	ScreenTabField           jira.ScreenTabFieldConnector
+	Field                    jira.FieldConnector
+	FieldConfiguration       jira.FieldConfigConnector
+	FieldConfigurationItem   jira.FieldConfigItemConnector
+	FieldConfigurationScheme jira.FieldConfigSchemeConnector
+	Workflow                 jira.WorkflowConnector
+	WorkflowScheme           jira.WorkflowSchemeConnector
+	PermissionScheme         jira.PermissionSchemeConnector
+}
+
+func (s *Services) validate() error {
+
+	if s == nil {
+		return fmt.Errorf("%w: no services set", ErrMissingService)
+	}
+
+	required := map[string]bool{
+		"Project":                  s.Project == nil,
+		"ProjectPermissionScheme":  s.ProjectPermissionScheme == nil,
+		"ProjectComponent":         s.ProjectComponent == nil,
+		"ProjectVersion":           s.ProjectVersion == nil,
+		"ProjectRole":              s.ProjectRole == nil,
+		"IssueType":                s.IssueType == nil,
+		"TypeScheme":               s.TypeScheme == nil,
+		"TypeScreenScheme":         s.TypeScreenScheme == nil,
+		"ScreenScheme":             s.ScreenScheme == nil,
+		"Screen":                   s.Screen == nil,
+		"ScreenTab":                s.ScreenTab == nil,
+		"ScreenTabField":           s.ScreenTabField == nil,
+		"Field":                    s.Field == nil,
+		"FieldConfiguration":       s.FieldConfiguration == nil,
+		"FieldConfigurationItem":   s.FieldConfigurationItem == nil,
+		"FieldConfigurationScheme": s.FieldConfigurationScheme == nil,
+		"Workflow":                 s.Workflow == nil,
+		"WorkflowScheme":           s.WorkflowScheme == nil,
+		"PermissionScheme":         s.PermissionScheme == nil,
+	}
+
+	var missing []string
+	for name, isMissing := range required {
+		if isMissing {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("%w: %v", ErrMissingService, missing)
+	}
+
+	return nil
+}
+
+// Exporter reads the configuration of the projects, a new name resolver is built on each export.
+type Exporter struct {
+	services *Services
+}
+
+func NewExporter(services *Services) (*Exporter, error) {
+
+	if err := services.validate(); err != nil {
+		return nil, err
+	}
+
+	return &Exporter{services: services}, nil
+}
+
+// export holds the names resolved during an export, by ID.
+type export struct {
+	*Services
+
+	issueTypes map[string]string
+	fields     map[string]string
+	roles      map[string]string
+	screens    map[int]*model.ScreenScheme
+}
+
+// Export returns the snapshot of the project.
+func (e *Exporter) Export(ctx context.Context, projectKeyOrID string) (*Snapshot, error) {
+
+	if projectKeyOrID == "" {
+		return nil, ErrNoProjectKey
+	}
+
+	x := &export{
+		Services:   e.services,
+		issueTypes: make(map[string]string),
+		fields:     make(map[string]string),
+		roles:      make(map[string]string),
+		screens:    make(map[int]*model.ScreenScheme),
+	}
+
+	if err := x.loadNames(ctx); err != nil {
+		return nil, err
+	}
+
+	project, _, err := x.Project.Get(ctx, projectKeyOrID, []string{"description", "lead"})
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: project %v: %w", projectKeyOrID, err)
+	}
+
+	projectID, err := strconv.Atoi(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: project %v: invalid id %q", projectKeyOrID, project.ID)
+	}
+
+	snapshot := &Snapshot{
+		Version: Version,
+		Project: &Project{
+			Key:         project.Key,
+			Name:        project.Name,
+			Type:        project.ProjectTypeKey,
+			Description: project.Description,
+		},
+	}
+
+	if project.Lead != nil {
+		snapshot.Project.Lead = project.Lead.AccountID
+	}
+
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"issue type scheme", func() (err error) {
+			snapshot.IssueTypeScheme, err = x.issueTypeScheme(ctx, projectID)
+			return err
+		}},
+		{"issue type screen scheme", func() (err error) {
+			snapshot.IssueTypeScreenScheme, snapshot.Screens, err = x.issueTypeScreenScheme(ctx, projectID)
+			return err
+		}},
+		{"field configuration scheme", func() (err error) {
+			snapshot.FieldConfigurationScheme, snapshot.FieldConfigurations, err = x.fieldConfigurationScheme(ctx, projectID)
+			return err
+		}},
+		{"workflow scheme", func() (err error) {
+			snapshot.WorkflowScheme, snapshot.Workflows, err = x.workflowScheme(ctx, projectID)
+			return err
+		}},
+		{"permission scheme", func() (err error) {
+			snapshot.PermissionScheme, err = x.permissionScheme(ctx, project.Key)
+			return err
+		}},
+		{"notification scheme", func() (err error) {
+			snapshot.NotificationScheme, err = x.notificationScheme(ctx, project.Key)
+			return err
+		}},
+		{"components", func() (err error) {
+			snapshot.Components, err = x.components(ctx, project.Key)
+			return err
+		}},
+		{"versions", func() (err error) {
+			snapshot.Versions, err = x.versions(ctx, project.Key)
+			return err
+		}},
+		{"roles", func() (err error) {
+			snapshot.Roles, err = x.projectRoles(ctx, project.Key)
+			return err
+		}},
+	}
+
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			return nil, fmt.Errorf("snapshot: %v: %w", step.name, err)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// loadNames fetches the issue types, fields and roles of the site, they're referenced by ID across the schemes.
+func (x *export) loadNames(ctx context.Context) error {
+
+	issueTypes, _, err := x.IssueType.Gets(ctx)
+	if err != nil {
+		return fmt.Errorf("snapshot: issue types: %w", err)
+	}
+
+	for _, issueType := range issueTypes {
+		x.issueTypes[issueType.ID] = issueType.Name
+	}
+
+	fields, _, err := x.Field.Gets(ctx)
+	if err != nil {
+		return fmt.Errorf("snapshot: fields: %w", err)
+	}
+
+	for _, field := range fields {
+		x.fields[field.ID] = field.Name
+	}
+
+	roles, _, err := x.ProjectRole.Global(ctx)
+	if err != nil {
+		return fmt.Errorf("snapshot: roles: %w", err)
+	}
+
+	for _, role := range roles {
+		x.roles[strconv.Itoa(role.ID)] = role.Name
+	}
+
+	return nil
+}
+
+func (x *export) issueTypeName(id string) string {
+
+	if id == DefaultMapping || id == "0" {
+		return DefaultMapping
+	}
+
+	return nameOf(x.issueTypes, id)
+}
+
+// nameOf returns the name of the ID, or the ID itself when it's unknown so the snapshot still shows the reference.
+func nameOf(names map[string]string, id string) string {
+
+	if name, ok := names[id]; ok {
+		return name
+	}
+
+	return id
+}
+
+func (x *export) issueTypeScheme(ctx context.Context, projectID int) (*IssueTypeScheme, error) {
+
+	page, _, err := x.TypeScheme.Projects(ctx, []int{projectID}, 0, defaultPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(page.Values) == 0 || page.Values[0].IssueTypeScheme == nil {
+		return nil, nil
+	}
+
+	scheme := page.Values[0].IssueTypeScheme
+
+	schemeID, err := strconv.Atoi(scheme.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id %q", scheme.ID)
+	}
+
+	result := &IssueTypeScheme{
+		Name:        scheme.Name,
+		Description: scheme.Description,
+	}
+
+	if scheme.DefaultIssueTypeID != "" {
+		result.DefaultIssueType = x.issueTypeName(scheme.DefaultIssueTypeID)
+	}
+
+	for startAt := 0; ; {
+
+		items, _, err := x.TypeScheme.Items(ctx, []int{schemeID}, startAt, defaultPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items.Values {
+			result.IssueTypes = append(result.IssueTypes, x.issueTypeName(item.IssueTypeID))
+		}
+
+		startAt += len(items.Values)
+		if items.IsLast || len(items.Values) == 0 {
+			break
+		}
+	}
+
+	sort.Strings(result.IssueTypes)
+	return result, nil
+}
+
+func (x *export) issueTypeScreenScheme(ctx context.Context, projectID int) (*IssueTypeScreenScheme, []*Screen, error) {
+
+	page, _, err := x.TypeScreenScheme.Projects(ctx, []int{projectID}, 0, defaultPageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(page.Values) == 0 || page.Values[0].IssueTypeScreenScheme == nil {
+		return nil, nil, nil
+	}
+
+	scheme := page.Values[0].IssueTypeScreenScheme
+
+	schemeID, err := strconv.Atoi(scheme.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid id %q", scheme.ID)
+	}
+
+	result := &IssueTypeScreenScheme{
+		Name:        scheme.Name,
+		Description: scheme.Description,
+	}
+
+	var (
+		screenSchemeIDs []int
+		mappings        []*model.IssueTypeScreenSchemeItemScheme
+	)
+
+	for startAt := 0; ; {
+
+		items, _, err := x.TypeScreenScheme.Mapping(ctx, []int{schemeID}, startAt, defaultPageSize)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, item := range items.Values {
+
+			screenSchemeID, err := strconv.Atoi(item.ScreenSchemeID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid screen scheme id %q", item.ScreenSchemeID)
+			}
+
+			mappings = append(mappings, item)
+			screenSchemeIDs = appendUniqueInt(screenSchemeIDs, screenSchemeID)
+		}
+
+		startAt += len(items.Values)
+		if items.IsLast || len(items.Values) == 0 {
+			break
+		}
+	}
+
+	screenSchemes, err := x.screenSchemes(ctx, screenSchemeIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var screenIDs []int
+	for _, screenScheme := range screenSchemes {
+
+		if screenScheme.Screens == nil {
+			continue
+		}
+
+		for _, id := range []int{screenScheme.Screens.Default, screenScheme.Screens.Create, screenScheme.Screens.Edit, screenScheme.Screens.View} {
+			if id != 0 {
+				screenIDs = appendUniqueInt(screenIDs, id)
+			}
+		}
+	}
+
+	if err := x.loadScreens(ctx, screenIDs); err != nil {
+		return nil, nil, err
+	}
+
+	for _, mapping := range mappings {
+
+		target := mapping.ScreenSchemeID
+		if screenScheme, ok := screenSchemes[mapping.ScreenSchemeID]; ok {
+			target = screenScheme.Name
+		}
+
+		result.Mappings = append(result.Mappings, &SchemeMapping{IssueType: x.issueTypeName(mapping.IssueTypeID), Target: target})
+	}
+
+	for _, screenScheme := range screenSchemes {
+
+		item := &ScreenScheme{Name: screenScheme.Name, Description: screenScheme.Description}
+
+		if screenScheme.Screens != nil {
+			item.Screens = &ScreenTypeSet{
+				Default: x.screenName(screenScheme.Screens.Default),
+				Create:  x.screenName(screenScheme.Screens.Create),
+				Edit:    x.screenName(screenScheme.Screens.Edit),
+				View:    x.screenName(screenScheme.Screens.View),
+			}
+		}
+
+		result.ScreenSchemes = append(result.ScreenSchemes, item)
+	}
+
+	sortMappings(result.Mappings)
+	sort.Slice(result.ScreenSchemes, func(i, j int) bool { return result.ScreenSchemes[i].Name < result.ScreenSchemes[j].Name })
+
+	screens := make([]*Screen, 0, len(screenIDs))
+	for _, id := range screenIDs {
+
+		screen, err := x.screen(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		screens = append(screens, screen)
+	}
+
+	sort.Slice(screens, func(i, j int) bool { return screens[i].Name < screens[j].Name })
+	return result, screens, nil
+}
+
+// screenSchemes returns the screen schemes by ID.
+func (x *export) screenSchemes(ctx context.Context, ids []int) (map[string]*model.ScreenSchemeScheme, error) {
+
+	schemes := make(map[string]*model.ScreenSchemeScheme)
+	if len(ids) == 0 {
+		return schemes, nil
+	}
+
+	for startAt := 0; ; {
+
+		page, _, err := x.ScreenScheme.Gets(ctx, &model.ScreenSchemeParamsScheme{IDs: ids}, startAt, defaultPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, scheme := range page.Values {
+			schemes[strconv.Itoa(scheme.ID)] = scheme
+		}
+
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	return schemes, nil
+}
+
+// loadScreens caches the name and description of the screens not fetched yet.
+func (x *export) loadScreens(ctx context.Context, ids []int) error {
+
+	var missing []int
+	for _, id := range ids {
+		if _, ok := x.screens[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	for startAt := 0; ; {
+
+		page, _, err := x.Screen.Gets(ctx, missing, startAt, defaultPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, screen := range page.Values {
+			x.screens[screen.ID] = screen
+		}
+
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (x *export) screenName(id int) string {
+
+	if id == 0 {
+		return ""
+	}
+
+	if screen, ok := x.screens[id]; ok {
+		return screen.Name
+	}
+
+	return strconv.Itoa(id)
+}
+
+// screen returns the screen with its tabs and fields, in the order shown on the screen.
+func (x *export) screen(ctx context.Context, id int) (*Screen, error) {
+
+	result := &Screen{Name: x.screenName(id)}
+	if screen, ok := x.screens[id]; ok {
+		result.Description = screen.Description
+	}
+
+	tabs, _, err := x.ScreenTab.Gets(ctx, id, "")
+	if err != nil {
+		return nil, fmt.Errorf("screen %v: %w", result.Name, err)
+	}
+
+	for _, tab := range tabs {
+
+		fields, _, err := x.ScreenTabField.Gets(ctx, id, tab.ID)
+		if err != nil {
+			return nil, fmt.Errorf("screen %v, tab %v: %w", result.Name, tab.Name, err)
+		}
+
+		item := &ScreenTab{Name: tab.Name}
+		for _, field := range fields {
+
+			name := field.Name
+			if name == "" {
+				name = nameOf(x.fields, field.ID)
+			}
+
+			item.Fields = append(item.Fields, name)
+		}
+
+		result.Tabs = append(result.Tabs, item)
+	}
+
+	return result, nil
+}
+
+func (x *export) fieldConfigurationScheme(ctx context.Context, projectID int) (*FieldConfigurationScheme, []*FieldConfiguration, error) {
+
+	page, _, err := x.FieldConfigurationScheme.Project(ctx, []int{projectID}, 0, defaultPageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The projects without a field configuration scheme use the default field configuration for every issue type.
+	if len(page.Values) == 0 || page.Values[0].FieldConfigurationScheme == nil {
+
+		defaults, _, err := x.FieldConfiguration.Gets(ctx, nil, true, 0, defaultPageSize)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(defaults.Values) == 0 {
+			return nil, nil, nil
+		}
+
+		configuration, err := x.fieldConfiguration(ctx, defaults.Values[0])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		scheme := &FieldConfigurationScheme{
+			Name:     "Default Field Configuration Scheme",
+			Mappings: []*SchemeMapping{{IssueType: DefaultMapping, Target: configuration.Name}},
+		}
+
+		return scheme, []*FieldConfiguration{configuration}, nil
+	}
+
+	scheme := page.Values[0].FieldConfigurationScheme
+
+	schemeID, err := strconv.Atoi(scheme.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid id %q", scheme.ID)
+	}
+
+	var (
+		configurationIDs []int
+		mappings         []*model.FieldConfigurationIssueTypeItemScheme
+	)
+
+	for startAt := 0; ; {
+
+		items, _, err := x.FieldConfigurationScheme.Mapping(ctx, []int{schemeID}, startAt, defaultPageSize)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, item := range items.Values {
+
+			configurationID, err := strconv.Atoi(item.FieldConfigurationID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid field configuration id %q", item.FieldConfigurationID)
+			}
+
+			mappings = append(mappings, item)
+			configurationIDs = appendUniqueInt(configurationIDs, configurationID)
+		}
+
+		startAt += len(items.Values)
+		if items.IsLast || len(items.Values) == 0 {
+			break
+		}
+	}
+
+	names := make(map[string]string)
+	var configurations []*FieldConfiguration
+
+	if len(configurationIDs) != 0 {
+
+		for startAt := 0; ; {
+
+			configurationPage, _, err := x.FieldConfiguration.Gets(ctx, configurationIDs, false, startAt, defaultPageSize)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			for _, item := range configurationPage.Values {
+
+				configuration, err := x.fieldConfiguration(ctx, item)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				names[strconv.Itoa(item.ID)] = item.Name
+				configurations = append(configurations, configuration)
+			}
+
+			startAt += len(configurationPage.Values)
+			if configurationPage.IsLast || len(configurationPage.Values) == 0 {
+				break
+			}
+		}
+	}
+
+	result := &FieldConfigurationScheme{Name: scheme.Name, Description: scheme.Description}
+	for _, mapping := range mappings {
+		result.Mappings = append(result.Mappings, &SchemeMapping{
+			IssueType: x.issueTypeName(mapping.IssueTypeID),
+			Target:    nameOf(names, mapping.FieldConfigurationID),
+		})
+	}
+
+	sortMappings(result.Mappings)
+	sort.Slice(configurations, func(i, j int) bool { return configurations[i].Name < configurations[j].Name })
+	return result, configurations, nil
+}
+
+func (x *export) fieldConfiguration(ctx context.Context, configuration *model.FieldConfigurationScheme) (*FieldConfiguration, error) {
+
+	result := &FieldConfiguration{Name: configuration.Name, Description: configuration.Description}
+
+	for startAt := 0; ; {
+
+		page, _, err := x.FieldConfigurationItem.Gets(ctx, configuration.ID, startAt, defaultPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("field configuration %v: %w", configuration.Name, err)
+		}
+
+		for _, item := range page.Values {
+			result.Items = append(result.Items, &FieldConfigurationItem{
+				Field:       nameOf(x.fields, item.ID),
+				Description: item.Description,
+				Hidden:      item.IsHidden,
+				Required:    item.IsRequired,
+				Renderer:    item.Renderer,
+			})
+		}
+
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+
+	sort.Slice(result.Items, func(i, j int) bool { return result.Items[i].Field < result.Items[j].Field })
+	return result, nil
+}
+
+func (x *export) workflowScheme(ctx context.Context, projectID int) (*WorkflowScheme, []*Workflow, error) {
+
+	page, _, err := x.WorkflowScheme.Associations(ctx, []int{projectID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(page.Values) == 0 || page.Values[0].WorkflowScheme == nil {
+		return nil, nil, nil
+	}
+
+	scheme := page.Values[0].WorkflowScheme
+	result := &WorkflowScheme{
+		Name:            scheme.Name,
+		Description:     scheme.Description,
+		DefaultWorkflow: scheme.DefaultWorkflow,
+	}
+
+	var names []string
+	if scheme.DefaultWorkflow != "" {
+		names = append(names, scheme.DefaultWorkflow)
+	}
+
+	for issueTypeID, workflow := range scheme.IssueTypeMappings {
+		result.Mappings = append(result.Mappings, &SchemeMapping{IssueType: x.issueTypeName(issueTypeID), Target: workflow})
+		names = appendUniqueString(names, workflow)
+	}
+
+	sortMappings(result.Mappings)
+
+	if len(names) == 0 {
+		return result, nil, nil
+	}
+
+	options := &model.WorkflowSearchOptions{
+		WorkflowName: names,
+		Expand:       []string{"transitions", "statuses"},
+		IsActive:     true,
+	}
+
+	var workflows []*Workflow
+	for startAt := 0; ; {
+
+		workflowPage, _, err := x.Workflow.Gets(ctx, options, startAt, defaultPageSize)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, workflow := range workflowPage.Values {
+
+			item, err := x.workflow(ctx, workflow)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			workflows = append(workflows, item)
+		}
+
+		startAt += len(workflowPage.Values)
+		if workflowPage.IsLast || len(workflowPage.Values) == 0 {
+			break
+		}
+	}
+
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].Name < workflows[j].Name })
+	return result, workflows, nil
+}
+
+func (x *export) workflow(ctx context.Context, workflow *model.WorkflowScheme) (*Workflow, error) {
+
+	result := &Workflow{Description: workflow.Description}
+	if workflow.ID != nil {
+		result.Name = workflow.ID.Name
+	}
+
+	statuses := make(map[string]string)
+	for _, status := range workflow.Statuses {
+		statuses[status.ID] = status.Name
+		result.Statuses = append(result.Statuses, status.Name)
+	}
+
+	var screenIDs []int
+	for _, transition := range workflow.Transitions {
+
+		if transition.Screen == nil || transition.Screen.ID == "" {
+			continue
+		}
+
+		screenID, err := strconv.Atoi(transition.Screen.ID)
+		if err != nil {
+			return nil, fmt.Errorf("workflow %v: invalid screen id %q", result.Name, transition.Screen.ID)
+		}
+
+		screenIDs = appendUniqueInt(screenIDs, screenID)
+	}
+
+	if err := x.loadScreens(ctx, screenIDs); err != nil {
+		return nil, fmt.Errorf("workflow %v: %w", result.Name, err)
+	}
+
+	for _, transition := range workflow.Transitions {
+
+		item := &WorkflowTransition{
+			Name: transition.Name,
+			Type: transition.Type,
+			To:   nameOf(statuses, transition.To),
+		}
+
+		for _, from := range transition.From {
+			item.From = append(item.From, nameOf(statuses, from))
+		}
+
+		if transition.Screen != nil && transition.Screen.ID != "" {
+			screenID, _ := strconv.Atoi(transition.Screen.ID)
+			item.Screen = x.screenName(screenID)
+		}
+
+		sort.Strings(item.From)
+		result.Transitions = append(result.Transitions, item)
+	}
+
+	sort.Strings(result.Statuses)
+	sort.SliceStable(result.Transitions, func(i, j int) bool { return result.Transitions[i].Name < result.Transitions[j].Name })
+	return result, nil
+}
+
+func (x *export) permissionScheme(ctx context.Context, projectKey string) (*PermissionScheme, error) {
+
+	assigned, _, err := x.ProjectPermissionScheme.Get(ctx, projectKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, _, err := x.PermissionScheme.Get(ctx, assigned.ID, []string{"permissions"})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PermissionScheme{Name: scheme.Name, Description: scheme.Description}
+	for _, grant := range scheme.Permissions {
+
+		item := &PermissionGrant{Permission: grant.Permission}
+		if grant.Holder != nil {
+			item.HolderType = grant.Holder.Type
+			item.Holder = x.holderName(grant.Holder.Type, grant.Holder.Parameter)
+		}
+
+		result.Grants = append(result.Grants, item)
+	}
+
+	sort.Slice(result.Grants, func(i, j int) bool {
+
+		a, b := result.Grants[i], result.Grants[j]
+		if a.Permission != b.Permission {
+			return a.Permission < b.Permission
+		}
+
+		if a.HolderType != b.HolderType {
+			return a.HolderType < b.HolderType
+		}
+
+		return a.Holder < b.Holder
+	})
+
+	return result, nil
+}
+
+// holderName resolves the parameter of the holder types referencing a role or a field by ID.
+func (x *export) holderName(holderType, parameter string) string {
+
+	switch holderType {
+	case "projectRole":
+		return nameOf(x.roles, parameter)
+	case "userCustomField", "groupCustomField":
+		return nameOf(x.fields, parameter)
+	}
+
+	return parameter
+}
+
+func (x *export) notificationScheme(ctx context.Context, projectKey string) (*NotificationScheme, error) {
+
+	scheme, _, err := x.Project.NotificationScheme(ctx, projectKey, []string{"all"})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &NotificationScheme{Name: scheme.Name, Description: scheme.Description}
+	for _, event := range scheme.NotificationSchemeEvents {
+
+		if event.Event == nil {
+			continue
+		}
+
+		item := &NotificationEvent{Event: event.Event.Name}
+		for _, notification := range event.Notifications {
+			item.Notifications = append(item.Notifications, &Notification{
+				Type:      notification.NotificationType,
+				Recipient: x.recipientName(notification),
+			})
+		}
+
+		sort.Slice(item.Notifications, func(i, j int) bool {
+
+			a, b := item.Notifications[i], item.Notifications[j]
+			if a.Type != b.Type {
+				return a.Type < b.Type
+			}
+
+			return a.Recipient < b.Recipient
+		})
+
+		result.Events = append(result.Events, item)
+	}
+
+	sort.Slice(result.Events, func(i, j int) bool { return result.Events[i].Event < result.Events[j].Event })
+	return result, nil
+}
+
+func (x *export) recipientName(notification *model.EventNotificationScheme) string {
+
+	switch {
+	case notification.Group != nil && notification.Group.Name != "":
+		return notification.Group.Name
+	case notification.ProjectRole != nil && notification.ProjectRole.Name != "":
+		return notification.ProjectRole.Name
+	case notification.Field != nil && notification.Field.Name != "":
+		return notification.Field.Name
+	case notification.User != nil && notification.User.AccountID != "":
+		return notification.User.AccountID
+	case notification.EmailAddress != "":
+		return notification.EmailAddress
+	}
+
+	return x.holderName(notification.NotificationType, notification.Parameter)
+}
+
+func (x *export) components(ctx context.Context, projectKey string) ([]*Component, error) {
+
+	components, _, err := x.ProjectComponent.Gets(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Component, 0, len(components))
+	for _, component := range components {
+
+		item := &Component{
+			Name:         component.Name,
+			Description:  component.Description,
+			AssigneeType: component.AssigneeType,
+		}
+
+		if component.Lead != nil {
+			item.Lead = component.Lead.AccountID
+		}
+
+		result = append(result, item)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func (x *export) versions(ctx context.Context, projectKey string) ([]*ProjectVersion, error) {
+
+	versions, _, err := x.ProjectVersion.Gets(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*ProjectVersion, 0, len(versions))
+	for _, version := range versions {
+		result = append(result, &ProjectVersion{
+			Name:        version.Name,
+			Description: version.Description,
+			Archived:    version.Archived,
+			Released:    version.Released,
+			ReleaseDate: version.ReleaseDate,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func (x *export) projectRoles(ctx context.Context, projectKey string) ([]*Role, error) {
+
+	details, _, err := x.ProjectRole.Details(ctx, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Role, 0, len(details))
+	for _, detail := range details {
+
+		role, _, err := x.ProjectRole.Get(ctx, projectKey, detail.ID)
+		if err != nil {
+			return nil, fmt.Errorf("role %v: %w", detail.Name, err)
+		}
+
+		item := &Role{Name: detail.Name, Description: detail.Description}
+		for _, actor := range role.Actors {
+
+			switch {
+			case actor.ActorGroup != nil:
+				item.Groups = appendUniqueString(item.Groups, actor.ActorGroup.Name)
+			case actor.ActorUser != nil:
+				item.Users = appendUniqueString(item.Users, actor.ActorUser.AccountID)
+			}
+		}
+
+		sort.Strings(item.Groups)
+		sort.Strings(item.Users)
+		result = append(result, item)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// sortMappings sorts the mappings by issue type, the default mapping first.
+func sortMappings(mappings []*SchemeMapping) {
+
+	sort.Slice(mappings, func(i, j int) bool {
+
+		a, b := mappings[i].IssueType, mappings[j].IssueType
+		if (a == DefaultMapping) != (b == DefaultMapping) {
+			return a == DefaultMapping
+		}
+
+		return a < b
+	})
+}
+
+func appendUniqueInt(values []int, value int) []int {
+
+	for _, current := range values {
+		if current == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
+
+func appendUniqueString(values []string, value string) []string {
+
+	for _, current := range values {
+		if current == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
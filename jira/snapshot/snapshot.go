@@ -0,0 +1,251 @@
+// Package snapshot exports the configuration of a Jira project into a versioned document, e.g. to review it in git.
+//
+// The document only holds names: the issue types, screens, fields, statuses, roles and schemes are resolved from
+// their IDs, so the snapshots of the same project on different sites can be diffed. The lists are sorted by name.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"io/ioutil"
+)
+
+// Version is the version of the snapshot document format, it changes when a field is renamed or removed.
+const Version = 1
+
+// DefaultMapping is the issue type of the scheme mappings used by the issue types without their own mapping.
+const DefaultMapping = "default"
+
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+type Snapshot struct {
+	Version int `json:"version" yaml:"version"`
+
+	Project                  *Project                  `json:"project" yaml:"project"`
+	IssueTypeScheme          *IssueTypeScheme          `json:"issueTypeScheme,omitempty" yaml:"issueTypeScheme,omitempty"`
+	IssueTypeScreenScheme    *IssueTypeScreenScheme    `json:"issueTypeScreenScheme,omitempty" yaml:"issueTypeScreenScheme,omitempty"`
+	Screens                  []*Screen                 `json:"screens,omitempty" yaml:"screens,omitempty"`
+	FieldConfigurationScheme *FieldConfigurationScheme `json:"fieldConfigurationScheme,omitempty" yaml:"fieldConfigurationScheme,omitempty"`
+	FieldConfigurations      []*FieldConfiguration     `json:"fieldConfigurations,omitempty" yaml:"fieldConfigurations,omitempty"`
+	WorkflowScheme           *WorkflowScheme           `json:"workflowScheme,omitempty" yaml:"workflowScheme,omitempty"`
+	Workflows                []*Workflow               `json:"workflows,omitempty" yaml:"workflows,omitempty"`
+	PermissionScheme         *PermissionScheme         `json:"permissionScheme,omitempty" yaml:"permissionScheme,omitempty"`
+	NotificationScheme       *NotificationScheme       `json:"notificationScheme,omitempty" yaml:"notificationScheme,omitempty"`
+	Components               []*Component              `json:"components,omitempty" yaml:"components,omitempty"`
+	Versions                 []*ProjectVersion         `json:"versions,omitempty" yaml:"versions,omitempty"`
+	Roles                    []*Role                   `json:"roles,omitempty" yaml:"roles,omitempty"`
+}
+
+// Project holds the project details, the lead is an account id, it's the same on every site.
+type Project struct {
+	Key         string `json:"key" yaml:"key"`
+	Name        string `json:"name" yaml:"name"`
+	Type        string `json:"type,omitempty" yaml:"type,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Lead        string `json:"lead,omitempty" yaml:"lead,omitempty"`
+}
+
+type IssueTypeScheme struct {
+	Name             string   `json:"name" yaml:"name"`
+	Description      string   `json:"description,omitempty" yaml:"description,omitempty"`
+	DefaultIssueType string   `json:"defaultIssueType,omitempty" yaml:"defaultIssueType,omitempty"`
+	IssueTypes       []string `json:"issueTypes,omitempty" yaml:"issueTypes,omitempty"`
+}
+
+type IssueTypeScreenScheme struct {
+	Name          string           `json:"name" yaml:"name"`
+	Description   string           `json:"description,omitempty" yaml:"description,omitempty"`
+	Mappings      []*SchemeMapping `json:"mappings,omitempty" yaml:"mappings,omitempty"`
+	ScreenSchemes []*ScreenScheme  `json:"screenSchemes,omitempty" yaml:"screenSchemes,omitempty"`
+}
+
+// SchemeMapping maps an issue type, or DefaultMapping, to the named item of a scheme: a screen scheme, a field
+// configuration or a workflow.
+type SchemeMapping struct {
+	IssueType string `json:"issueType" yaml:"issueType"`
+	Target    string `json:"target" yaml:"target"`
+}
+
+type ScreenScheme struct {
+	Name        string         `json:"name" yaml:"name"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Screens     *ScreenTypeSet `json:"screens,omitempty" yaml:"screens,omitempty"`
+}
+
+// ScreenTypeSet holds the screen names of each issue operation, the empty operations use the default screen.
+type ScreenTypeSet struct {
+	Default string `json:"default,omitempty" yaml:"default,omitempty"`
+	Create  string `json:"create,omitempty" yaml:"create,omitempty"`
+	Edit    string `json:"edit,omitempty" yaml:"edit,omitempty"`
+	View    string `json:"view,omitempty" yaml:"view,omitempty"`
+}
+
+type Screen struct {
+	Name        string       `json:"name" yaml:"name"`
+	Description string       `json:"description,omitempty" yaml:"description,omitempty"`
+	Tabs        []*ScreenTab `json:"tabs,omitempty" yaml:"tabs,omitempty"`
+}
+
+// ScreenTab holds the field names of a tab, the tabs and the fields keep the order shown on the screen.
+type ScreenTab struct {
+	Name   string   `json:"name" yaml:"name"`
+	Fields []string `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+type FieldConfigurationScheme struct {
+	Name        string           `json:"name" yaml:"name"`
+	Description string           `json:"description,omitempty" yaml:"description,omitempty"`
+	Mappings    []*SchemeMapping `json:"mappings,omitempty" yaml:"mappings,omitempty"`
+}
+
+type FieldConfiguration struct {
+	Name        string                    `json:"name" yaml:"name"`
+	Description string                    `json:"description,omitempty" yaml:"description,omitempty"`
+	Items       []*FieldConfigurationItem `json:"items,omitempty" yaml:"items,omitempty"`
+}
+
+type FieldConfigurationItem struct {
+	Field       string `json:"field" yaml:"field"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Hidden      bool   `json:"hidden,omitempty" yaml:"hidden,omitempty"`
+	Required    bool   `json:"required,omitempty" yaml:"required,omitempty"`
+	Renderer    string `json:"renderer,omitempty" yaml:"renderer,omitempty"`
+}
+
+type WorkflowScheme struct {
+	Name            string           `json:"name" yaml:"name"`
+	Description     string           `json:"description,omitempty" yaml:"description,omitempty"`
+	DefaultWorkflow string           `json:"defaultWorkflow,omitempty" yaml:"defaultWorkflow,omitempty"`
+	Mappings        []*SchemeMapping `json:"mappings,omitempty" yaml:"mappings,omitempty"`
+}
+
+type Workflow struct {
+	Name        string                `json:"name" yaml:"name"`
+	Description string                `json:"description,omitempty" yaml:"description,omitempty"`
+	Statuses    []string              `json:"statuses,omitempty" yaml:"statuses,omitempty"`
+	Transitions []*WorkflowTransition `json:"transitions,omitempty" yaml:"transitions,omitempty"`
+}
+
+// WorkflowTransition holds the status names of a transition, an empty From means the transition is global.
+type WorkflowTransition struct {
+	Name   string   `json:"name" yaml:"name"`
+	Type   string   `json:"type,omitempty" yaml:"type,omitempty"`
+	From   []string `json:"from,omitempty" yaml:"from,omitempty"`
+	To     string   `json:"to" yaml:"to"`
+	Screen string   `json:"screen,omitempty" yaml:"screen,omitempty"`
+}
+
+type PermissionScheme struct {
+	Name        string             `json:"name" yaml:"name"`
+	Description string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Grants      []*PermissionGrant `json:"grants,omitempty" yaml:"grants,omitempty"`
+}
+
+// PermissionGrant grants a permission to a holder, the holder is the name of the role, group or field of the
+// holder type, or the account id of the users.
+type PermissionGrant struct {
+	Permission string `json:"permission" yaml:"permission"`
+	HolderType string `json:"holderType" yaml:"holderType"`
+	Holder     string `json:"holder,omitempty" yaml:"holder,omitempty"`
+}
+
+type NotificationScheme struct {
+	Name        string               `json:"name" yaml:"name"`
+	Description string               `json:"description,omitempty" yaml:"description,omitempty"`
+	Events      []*NotificationEvent `json:"events,omitempty" yaml:"events,omitempty"`
+}
+
+type NotificationEvent struct {
+	Event         string          `json:"event" yaml:"event"`
+	Notifications []*Notification `json:"notifications,omitempty" yaml:"notifications,omitempty"`
+}
+
+type Notification struct {
+	Type      string `json:"type" yaml:"type"`
+	Recipient string `json:"recipient,omitempty" yaml:"recipient,omitempty"`
+}
+
+type Component struct {
+	Name         string `json:"name" yaml:"name"`
+	Description  string `json:"description,omitempty" yaml:"description,omitempty"`
+	Lead         string `json:"lead,omitempty" yaml:"lead,omitempty"`
+	AssigneeType string `json:"assigneeType,omitempty" yaml:"assigneeType,omitempty"`
+}
+
+type ProjectVersion struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Archived    bool   `json:"archived,omitempty" yaml:"archived,omitempty"`
+	Released    bool   `json:"released,omitempty" yaml:"released,omitempty"`
+	ReleaseDate string `json:"releaseDate,omitempty" yaml:"releaseDate,omitempty"`
+}
+
+// Role holds the actors of a project role, the group names and the account ids of the users.
+type Role struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Groups      []string `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Users       []string `json:"users,omitempty" yaml:"users,omitempty"`
+}
+
+// Write writes the snapshot as an indented JSON or a YAML document.
+func (s *Snapshot) Write(w io.Writer, format Format) error {
+
+	switch format {
+	case FormatJSON:
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(s)
+
+	case FormatYAML:
+
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+
+		if err := encoder.Encode(s); err != nil {
+			return err
+		}
+
+		return encoder.Close()
+	}
+
+	return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+// Read reads a snapshot document written by Write, the documents of a newer format version are rejected.
+func Read(r io.Reader, format Format) (*Snapshot, error) {
+
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{}
+
+	switch format {
+	case FormatJSON:
+		err = json.Unmarshal(content, snapshot)
+	case FormatYAML:
+		err = yaml.Unmarshal(content, snapshot)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot.Version > Version {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedVersion, snapshot.Version)
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,269 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	v3 "github.com/chrisccoy/go-atlassian/jira/v3"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// siteMocked serves the JSON responses by request path, the unknown paths return 404.
+var siteMocked = map[string]string{
+	"/rest/api/3/issuetype": `[{"id": "10000", "name": "Epic"}, {"id": "10001", "name": "Story"}, {"id": "10002", "name": "Bug"}]`,
+	"/rest/api/3/field": `[{"id": "summary", "name": "Summary"}, {"id": "description", "name": "Description"},
+		{"id": "customfield_10020", "name": "Sprint"}]`,
+	"/rest/api/3/role": `[{"id": 10002, "name": "Administrators"}, {"id": 10003, "name": "Developers"}]`,
+	"/rest/api/3/project/KP": `{"id": "10000", "key": "KP", "name": "Kanban Project", "projectTypeKey": "software",
+		"description": "The kanban project", "lead": {"accountId": "5b10a2844c20165700ede21g"}}`,
+
+	"/rest/api/3/issuetypescheme/project": `{"isLast": true, "values": [{"projectIds": ["10000"],
+		"issueTypeScheme": {"id": "10100", "name": "KP: Scrum Issue Type Scheme", "defaultIssueTypeId": "10001"}}]}`,
+	"/rest/api/3/issuetypescheme/mapping": `{"isLast": true, "values": [{"issueTypeSchemeId": "10100", "issueTypeId": "10002"},
+		{"issueTypeSchemeId": "10100", "issueTypeId": "10001"}, {"issueTypeSchemeId": "10100", "issueTypeId": "10000"}]}`,
+
+	"/rest/api/3/issuetypescreenscheme/project": `{"isLast": true, "values": [{"projectIds": ["10000"],
+		"issueTypeScreenScheme": {"id": "10200", "name": "KP: Issue Type Screen Scheme"}}]}`,
+	"/rest/api/3/issuetypescreenscheme/mapping": `{"isLast": true, "values": [
+		{"issueTypeScreenSchemeId": "10200", "issueTypeId": "10002", "screenSchemeId": "10301"},
+		{"issueTypeScreenSchemeId": "10200", "issueTypeId": "default", "screenSchemeId": "10300"}]}`,
+	"/rest/api/3/screenscheme": `{"isLast": true, "values": [
+		{"id": 10300, "name": "KP: Default Screen Scheme", "screens": {"default": 10400}},
+		{"id": 10301, "name": "KP: Bug Screen Scheme", "screens": {"default": 10400, "create": 10401}}]}`,
+	"/rest/api/3/screens": `{"isLast": true, "values": [{"id": 10400, "name": "KP: Default Screen"},
+		{"id": 10401, "name": "KP: Bug Create Screen", "description": "Bug triage"}]}`,
+	"/rest/api/3/screens/10400/tabs":          `[{"id": 1, "name": "Field Tab"}]`,
+	"/rest/api/3/screens/10400/tabs/1/fields": `[{"id": "summary", "name": "Summary"}, {"id": "customfield_10020"}]`,
+	"/rest/api/3/screens/10401/tabs":          `[{"id": 2, "name": "Triage"}]`,
+	"/rest/api/3/screens/10401/tabs/2/fields": `[{"id": "description", "name": "Description"}]`,
+
+	"/rest/api/3/fieldconfigurationscheme/project": `{"isLast": true, "values": [{"projectIds": ["10000"],
+		"fieldConfigurationScheme": {"id": "10500", "name": "KP: Field Configuration Scheme"}}]}`,
+	"/rest/api/3/fieldconfigurationscheme/mapping": `{"isLast": true, "values": [
+		{"fieldConfigurationSchemeId": "10500", "issueTypeId": "default", "fieldConfigurationId": "10600"}]}`,
+	"/rest/api/3/fieldconfiguration": `{"isLast": true, "values": [{"id": 10600, "name": "KP: Field Configuration"}]}`,
+	"/rest/api/3/fieldconfiguration/10600/fields": `{"isLast": true, "values": [
+		{"id": "summary", "isRequired": true}, {"id": "description", "renderer": "wiki-renderer"}]}`,
+
+	"/rest/api/3/workflowscheme/project": `{"values": [{"projectIds": ["10000"], "workflowScheme": {"id": 10700,
+		"name": "KP: Workflow Scheme", "defaultWorkflow": "KP: Workflow", "issueTypeMappings": {"10002": "KP: Bug Workflow"}}}]}`,
+	"/rest/api/3/workflow/search": `{"isLast": true, "values": [
+		{"id": {"name": "KP: Workflow"}, "statuses": [{"id": "1", "name": "To Do"}, {"id": "3", "name": "Done"}],
+			"transitions": [{"name": "Create", "type": "initial", "to": "1"}, {"name": "Finish", "type": "directed", "from": ["1"], "to": "3",
+				"screen": {"id": "10401"}}]},
+		{"id": {"name": "KP: Bug Workflow"}, "statuses": [{"id": "1", "name": "To Do"}],
+			"transitions": [{"name": "Create", "type": "initial", "to": "1"}]}]}`,
+
+	"/rest/api/3/project/KP/permissionscheme": `{"id": 10800}`,
+	"/rest/api/3/permissionscheme/10800": `{"id": 10800, "name": "KP: Permission Scheme", "permissions": [
+		{"id": 1, "holder": {"type": "projectRole", "parameter": "10003"}, "permission": "BROWSE_PROJECTS"},
+		{"id": 2, "holder": {"type": "group", "parameter": "jira-administrators"}, "permission": "ADMINISTER_PROJECTS"},
+		{"id": 3, "holder": {"type": "userCustomField", "parameter": "customfield_10020"}, "permission": "BROWSE_PROJECTS"}]}`,
+
+	"/rest/api/3/project/KP/notificationscheme": `{"id": 10900, "name": "KP: Notification Scheme", "notificationSchemeEvents": [
+		{"event": {"id": 2, "name": "Issue updated"}, "notifications": [{"notificationType": "CurrentAssignee"}]},
+		{"event": {"id": 1, "name": "Issue created"}, "notifications": [
+			{"notificationType": "ProjectRole", "parameter": "10003", "projectRole": {"id": 10003, "name": "Developers"}},
+			{"notificationType": "Group", "parameter": "jira-users", "group": {"name": "jira-users"}}]}]}`,
+
+	"/rest/api/3/project/KP/components": `[{"id": "1", "name": "Frontend", "assigneeType": "PROJECT_LEAD"},
+		{"id": "2", "name": "Backend", "lead": {"accountId": "5b10a2844c20165700ede21g"}}]`,
+	"/rest/api/3/project/KP/versions":    `[{"id": "2", "name": "v2.0"}, {"id": "1", "name": "v1.0", "released": true, "releaseDate": "2022-01-10"}]`,
+	"/rest/api/3/project/KP/roledetails": `[{"id": 10003, "name": "Developers"}, {"id": 10002, "name": "Administrators"}]`,
+	"/rest/api/3/project/KP/role/10002": `{"id": 10002, "name": "Administrators", "actors": [
+		{"type": "atlassian-group-role-actor", "actorGroup": {"name": "jira-administrators"}}]}`,
+	"/rest/api/3/project/KP/role/10003": `{"id": 10003, "name": "Developers", "actors": [
+		{"type": "atlassian-user-role-actor", "actorUser": {"accountId": "5b10a2844c20165700ede21g"}},
+		{"type": "atlassian-group-role-actor", "actorGroup": {"name": "developers"}}]}`,
+}
+
+func newServicesMocked(t *testing.T, responses map[string]string) *Services {
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+
+		body, ok := responses[request.URL.Path]
+		if !ok {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := v3.New(server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Services{
+		Project:                  client.Project,
+		ProjectPermissionScheme:  client.Project.Permission,
+		ProjectComponent:         client.Project.Component,
+		ProjectVersion:           client.Project.Version,
+		ProjectRole:              client.Project.Role,
+		IssueType:                client.Issue.Type,
+		TypeScheme:               client.Issue.Type.Scheme,
+		TypeScreenScheme:         client.Issue.Type.ScreenScheme,
+		ScreenScheme:             client.Screen.Scheme,
+		Screen:                   client.Screen,
+		ScreenTab:                client.Screen.Tab,
+		ScreenTabField:           client.Screen.Tab.Field,
+		Field:                    client.Issue.Field,
+		FieldConfiguration:       client.Issue.Field.Configuration,
+		FieldConfigurationItem:   client.Issue.Field.Configuration.Item,
+		FieldConfigurationScheme: client.Issue.Field.Configuration.Scheme,
+		Workflow:                 client.Workflow,
+		WorkflowScheme:           client.Workflow.Scheme,
+		PermissionScheme:         client.Permission.Scheme,
+	}
+}
+
+func TestExporter_Export(t *testing.T) {
+
+	exporter, err := NewExporter(newServicesMocked(t, siteMocked))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := exporter.Export(context.Background(), "KP")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, Version, snapshot.Version)
+	assert.Equal(t, &Project{Key: "KP", Name: "Kanban Project", Type: "software", Description: "The kanban project",
+		Lead: "5b10a2844c20165700ede21g"}, snapshot.Project)
+
+	assert.Equal(t, &IssueTypeScheme{Name: "KP: Scrum Issue Type Scheme", DefaultIssueType: "Story",
+		IssueTypes: []string{"Bug", "Epic", "Story"}}, snapshot.IssueTypeScheme)
+
+	assert.Equal(t, []*SchemeMapping{
+		{IssueType: DefaultMapping, Target: "KP: Default Screen Scheme"},
+		{IssueType: "Bug", Target: "KP: Bug Screen Scheme"},
+	}, snapshot.IssueTypeScreenScheme.Mappings)
+
+	assert.Equal(t, []*ScreenScheme{
+		{Name: "KP: Bug Screen Scheme", Screens: &ScreenTypeSet{Default: "KP: Default Screen", Create: "KP: Bug Create Screen"}},
+		{Name: "KP: Default Screen Scheme", Screens: &ScreenTypeSet{Default: "KP: Default Screen"}},
+	}, snapshot.IssueTypeScreenScheme.ScreenSchemes)
+
+	assert.Equal(t, []*Screen{
+		{Name: "KP: Bug Create Screen", Description: "Bug triage", Tabs: []*ScreenTab{{Name: "Triage", Fields: []string{"Description"}}}},
+		{Name: "KP: Default Screen", Tabs: []*ScreenTab{{Name: "Field Tab", Fields: []string{"Summary", "Sprint"}}}},
+	}, snapshot.Screens)
+
+	assert.Equal(t, &FieldConfigurationScheme{Name: "KP: Field Configuration Scheme",
+		Mappings: []*SchemeMapping{{IssueType: DefaultMapping, Target: "KP: Field Configuration"}}}, snapshot.FieldConfigurationScheme)
+
+	assert.Equal(t, []*FieldConfiguration{{Name: "KP: Field Configuration", Items: []*FieldConfigurationItem{
+		{Field: "Description", Renderer: "wiki-renderer"},
+		{Field: "Summary", Required: true},
+	}}}, snapshot.FieldConfigurations)
+
+	assert.Equal(t, &WorkflowScheme{Name: "KP: Workflow Scheme", DefaultWorkflow: "KP: Workflow",
+		Mappings: []*SchemeMapping{{IssueType: "Bug", Target: "KP: Bug Workflow"}}}, snapshot.WorkflowScheme)
+
+	assert.Equal(t, []*Workflow{
+		{Name: "KP: Bug Workflow", Statuses: []string{"To Do"}, Transitions: []*WorkflowTransition{{Name: "Create", Type: "initial", To: "To Do"}}},
+		{Name: "KP: Workflow", Statuses: []string{"Done", "To Do"}, Transitions: []*WorkflowTransition{
+			{Name: "Create", Type: "initial", To: "To Do"},
+			{Name: "Finish", Type: "directed", From: []string{"To Do"}, To: "Done", Screen: "KP: Bug Create Screen"},
+		}},
+	}, snapshot.Workflows)
+
+	assert.Equal(t, &PermissionScheme{Name: "KP: Permission Scheme", Grants: []*PermissionGrant{
+		{Permission: "ADMINISTER_PROJECTS", HolderType: "group", Holder: "jira-administrators"},
+		{Permission: "BROWSE_PROJECTS", HolderType: "projectRole", Holder: "Developers"},
+		{Permission: "BROWSE_PROJECTS", HolderType: "userCustomField", Holder: "Sprint"},
+	}}, snapshot.PermissionScheme)
+
+	assert.Equal(t, &NotificationScheme{Name: "KP: Notification Scheme", Events: []*NotificationEvent{
+		{Event: "Issue created", Notifications: []*Notification{{Type: "Group", Recipient: "jira-users"}, {Type: "ProjectRole", Recipient: "Developers"}}},
+		{Event: "Issue updated", Notifications: []*Notification{{Type: "CurrentAssignee"}}},
+	}}, snapshot.NotificationScheme)
+
+	assert.Equal(t, []*Component{
+		{Name: "Backend", Lead: "5b10a2844c20165700ede21g"},
+		{Name: "Frontend", AssigneeType: "PROJECT_LEAD"},
+	}, snapshot.Components)
+
+	assert.Equal(t, []*ProjectVersion{{Name: "v1.0", Released: true, ReleaseDate: "2022-01-10"}, {Name: "v2.0"}}, snapshot.Versions)
+
+	assert.Equal(t, []*Role{
+		{Name: "Administrators", Groups: []string{"jira-administrators"}},
+		{Name: "Developers", Groups: []string{"developers"}, Users: []string{"5b10a2844c20165700ede21g"}},
+	}, snapshot.Roles)
+}
+
+func TestExporter_Export_Errors(t *testing.T) {
+
+	t.Run("when the services are not provided", func(t *testing.T) {
+
+		_, err := NewExporter(&Services{})
+		assert.True(t, errors.Is(err, ErrMissingService), err)
+	})
+
+	t.Run("when the project key is not provided", func(t *testing.T) {
+
+		exporter, err := NewExporter(newServicesMocked(t, siteMocked))
+		assert.NoError(t, err)
+
+		_, err = exporter.Export(context.Background(), "")
+		assert.True(t, errors.Is(err, ErrNoProjectKey), err)
+	})
+
+	t.Run("when a scheme can't be fetched", func(t *testing.T) {
+
+		responses := make(map[string]string)
+		for path, body := range siteMocked {
+			responses[path] = body
+		}
+
+		delete(responses, "/rest/api/3/workflow/search")
+
+		exporter, err := NewExporter(newServicesMocked(t, responses))
+		assert.NoError(t, err)
+
+		_, err = exporter.Export(context.Background(), "KP")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "snapshot: workflow scheme")
+	})
+}
+
+func TestSnapshot_Write(t *testing.T) {
+
+	snapshot := &Snapshot{
+		Version: Version,
+		Project: &Project{Key: "KP", Name: "Kanban Project"},
+		PermissionScheme: &PermissionScheme{Name: "KP: Permission Scheme", Grants: []*PermissionGrant{
+			{Permission: "BROWSE_PROJECTS", HolderType: "projectRole", Holder: "Developers"},
+		}},
+	}
+
+	for _, format := range []Format{FormatJSON, FormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+
+			var buffer bytes.Buffer
+			assert.NoError(t, snapshot.Write(&buffer, format))
+
+			got, err := Read(&buffer, format)
+			assert.NoError(t, err)
+			assert.Equal(t, snapshot, got)
+		})
+	}
+
+	var buffer bytes.Buffer
+	assert.NoError(t, snapshot.Write(&buffer, FormatYAML))
+	assert.True(t, strings.HasPrefix(buffer.String(), "version: 1\nproject:\n  key: KP\n"), buffer.String())
+
+	assert.True(t, errors.Is(snapshot.Write(&buffer, "xml"), ErrUnknownFormat))
+
+	_, err := Read(strings.NewReader("version: 2\n"), FormatYAML)
+	assert.True(t, errors.Is(err, ErrUnsupportedVersion), err)
+}
@@ -105,6 +105,11 @@ func New(httpClient common.HttpClient, site string) (*Client, error) {
 		return nil, err
 	}
 
+	hierarchy, err := internal.NewHierarchyService(client, "2")
+	if err != nil {
+		return nil, err
+	}
+
 	label, err := internal.NewLabelService(client, "2")
 	if err != nil {
 		return nil, err
@@ -174,6 +179,7 @@ func New(httpClient common.HttpClient, site string) (*Client, error) {
 		Attachment:      issueAttachmentService,
 		CommentRT:       commentService,
 		Field:           issueFieldService,
+		Hierarchy:       hierarchy,
 		Label:           label,
 		LinkRT:          link,
 		Metadata:        metadata,
@@ -318,6 +324,11 @@ func New(httpClient common.HttpClient, site string) (*Client, error) {
 		return nil, err
 	}
 
+	webhook, err := internal.NewWebhookService(client, "2")
+	if err != nil {
+		return nil, err
+	}
+
 	workflowScheme, err := internal.NewWorkflowSchemeService(client, "2")
 	if err != nil {
 		return nil, err
@@ -346,6 +357,7 @@ func New(httpClient common.HttpClient, site string) (*Client, error) {
 	client.Server = server
 	client.Task = task
 	client.User = user
+	client.Webhook = webhook
 	client.Workflow = workflow
 
 	return client, nil
@@ -367,6 +379,7 @@ type Client struct {
 	Task       *internal.TaskService
 	Server     *internal.ServerService
 	User       *internal.UserService
+	Webhook    *internal.WebhookService
 	Workflow   *internal.WorkflowService
 }
 
@@ -396,6 +409,12 @@ func (c *Client) NewFormRequest(ctx context.Context, method, apiEndpoint, conten
 		request.Header.Set("User-Agent", c.Auth.GetUserAgent())
 	}
 
+	if signer, ok := c.Auth.(common.RequestSignerSetter); ok && signer.HasRequestSigner() {
+		if err := signer.GetRequestSigner().Sign(request); err != nil {
+			return nil, err
+		}
+	}
+
 	return request, nil
 }
 
@@ -427,6 +446,12 @@ func (c *Client) NewRequest(ctx context.Context, method, apiEndpoint string, pay
 		request.Header.Set("User-Agent", c.Auth.GetUserAgent())
 	}
 
+	if signer, ok := c.Auth.(common.RequestSignerSetter); ok && signer.HasRequestSigner() {
+		if err := signer.GetRequestSigner().Sign(request); err != nil {
+			return nil, err
+		}
+	}
+
 	return request, nil
 }
 
@@ -440,6 +465,12 @@ func (c *Client) Call(request *http.Request, structure interface{}) (*models.Res
 	return c.TransformTheHTTPResponse(response, structure)
 }
 
+// Do sends the request returning the raw HTTP response, the caller must close the response body.
+// It implements service.Doer, used by the endpoints streaming the response, e.g. the attachment downloads.
+func (c *Client) Do(request *http.Request) (*http.Response, error) {
+	return c.HTTP.Do(request)
+}
+
 func (c *Client) TransformTheHTTPResponse(response *http.Response, structure interface{}) (*models.ResponseScheme, error) {
 
 	responseTransformed := &models.ResponseScheme{
@@ -2,6 +2,7 @@ package v3
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
@@ -225,6 +226,60 @@ func TestNew(t *testing.T) {
 	}
 }
 
+type signerFunc func(request *http.Request) error
+
+func (f signerFunc) Sign(request *http.Request) error {
+	return f(request)
+}
+
+func TestClient_NewRequest(t *testing.T) {
+
+	testCases := []struct {
+		name    string
+		signer  common.RequestSigner
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "when the request signer is set",
+			signer: signerFunc(func(request *http.Request) error {
+				request.Header.Set("Authorization", "JWT "+request.URL.RawQuery)
+				return nil
+			}),
+			want: "JWT maxResults=50",
+		},
+
+		{
+			name: "when the request signer returns an error",
+			signer: signerFunc(func(request *http.Request) error {
+				return errors.New("error, unable to sign the request")
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			client, err := New(nil, "https://ctreminiom.atlassian.net")
+			assert.NoError(t, err)
+
+			client.Auth.SetBasicAuth("mail", "token")
+			client.Auth.(common.RequestSignerSetter).SetRequestSigner(testCase.signer)
+
+			request, err := client.NewRequest(context.TODO(), http.MethodGet, "rest/api/3/project/search?maxResults=50", nil)
+
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, request)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, request.Header.Get("Authorization"))
+			}
+		})
+	}
+}
+
 func TestClient_TransformTheHTTPResponse(t *testing.T) {
 
 	expectedJsonResponse := `
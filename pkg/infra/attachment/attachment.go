@@ -0,0 +1,238 @@
+// Package attachment streams the attachment downloads and uploads of the Jira, Service Management and Confluence
+// clients, the bodies are never buffered on memory.
+package attachment
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"hash"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SetRange prepares the download request, setting the Range header when the download is resumed.
+func SetRange(request *http.Request, opts *models.AttachmentDownloadOptionsScheme) {
+
+	request.Header.Set("Accept", "*/*")
+
+	if opts != nil && opts.Offset > 0 {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%v-", opts.Offset))
+	}
+}
+
+// NewStream wraps the body of a successful download response, reporting the progress and verifying the
+// size and the checksum when the body is fully read, the verification errors are returned instead of io.EOF.
+//
+// When the server ignores the Range header and returns the whole attachment, the bytes before the offset are skipped.
+func NewStream(response *http.Response, opts *models.AttachmentDownloadOptionsScheme) (io.ReadCloser, error) {
+
+	if opts == nil {
+		opts = &models.AttachmentDownloadOptionsScheme{}
+	}
+
+	stream := &attachmentStream{
+		body:        response.Body,
+		opts:        opts,
+		transferred: opts.Offset,
+		total:       -1,
+	}
+
+	if opts.SHA256 != "" {
+
+		stream.hash = sha256.New()
+
+		if opts.Offset > 0 {
+
+			if opts.Partial == nil {
+				response.Body.Close()
+				return nil, models.ErrNoAttachmentPartialError
+			}
+
+			if _, err := io.CopyN(stream.hash, opts.Partial, opts.Offset); err != nil {
+				response.Body.Close()
+				return nil, err
+			}
+		}
+	}
+
+	if response.StatusCode == http.StatusPartialContent {
+
+		// e.g. Content-Range: bytes 100-999/1000
+		contentRange := response.Header.Get("Content-Range")
+		if index := strings.LastIndex(contentRange, "/"); index != -1 {
+			if total, err := strconv.ParseInt(contentRange[index+1:], 10, 64); err == nil {
+				stream.total = total
+			}
+		}
+
+	} else {
+
+		if response.ContentLength >= 0 {
+			stream.total = response.ContentLength
+		}
+
+		if opts.Offset > 0 {
+			if _, err := io.CopyN(ioutil.Discard, response.Body, opts.Offset); err != nil {
+				response.Body.Close()
+				return nil, err
+			}
+		}
+	}
+
+	if stream.total == -1 && opts.Size != 0 {
+		stream.total = opts.Size
+	}
+
+	return stream, nil
+}
+
+type attachmentStream struct {
+	body        io.ReadCloser
+	opts        *models.AttachmentDownloadOptionsScheme
+	hash        hash.Hash
+	transferred int64
+	total       int64
+	verified    bool
+}
+
+func (a *attachmentStream) Read(p []byte) (int, error) {
+
+	n, err := a.body.Read(p)
+	if n > 0 {
+
+		if a.hash != nil {
+			a.hash.Write(p[:n])
+		}
+
+		a.transferred += int64(n)
+		if a.opts.Progress != nil {
+			a.opts.Progress(a.transferred, a.total)
+		}
+	}
+
+	if err == io.EOF && !a.verified {
+
+		a.verified = true
+		if err := a.verify(); err != nil {
+			return n, err
+		}
+	}
+
+	return n, err
+}
+
+func (a *attachmentStream) Close() error {
+	return a.body.Close()
+}
+
+func (a *attachmentStream) verify() error {
+
+	if a.opts.Size != 0 && a.transferred != a.opts.Size {
+		return fmt.Errorf("%w: %v bytes read, %v bytes expected", models.ErrAttachmentSizeMismatchError, a.transferred, a.opts.Size)
+	}
+
+	if a.total != -1 && a.transferred != a.total {
+		return fmt.Errorf("%w: %v bytes read, %v bytes expected", models.ErrAttachmentSizeMismatchError, a.transferred, a.total)
+	}
+
+	if a.hash != nil {
+
+		checksum := hex.EncodeToString(a.hash.Sum(nil))
+		if !strings.EqualFold(checksum, a.opts.SHA256) {
+			return fmt.Errorf("%w: %v, %v expected", models.ErrAttachmentChecksumMismatchError, checksum, a.opts.SHA256)
+		}
+	}
+
+	return nil
+}
+
+// NewMultipart returns a multipart/form-data body and its content type, the fields and the files
+// are written while the body is read, so the files are never buffered.
+//
+// The body must be closed once the request is sent, so the writer goroutine exits when the request fails.
+func NewMultipart(files []*models.AttachmentFileScheme, fields map[string]string, progress models.AttachmentProgressFunc) (io.ReadCloser, string, error) {
+
+	if len(files) == 0 {
+		return nil, "", models.ErrNoAttachmentFilesError
+	}
+
+	total := int64(0)
+	for index, file := range files {
+
+		if file == nil || file.Name == "" || file.Reader == nil {
+			return nil, "", fmt.Errorf("%w: file %v", models.ErrInvalidAttachmentFileError, index)
+		}
+
+		if total != -1 && file.Size > 0 {
+			total += file.Size
+		} else {
+			total = -1
+		}
+	}
+
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+
+	go func() {
+		writer.CloseWithError(writeAttachmentMultipart(form, files, fields, &attachmentProgress{report: progress, total: total}))
+	}()
+
+	return reader, form.FormDataContentType(), nil
+}
+
+func writeAttachmentMultipart(form *multipart.Writer, files []*models.AttachmentFileScheme, fields map[string]string, progress *attachmentProgress) error {
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := form.WriteField(name, fields[name]); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range files {
+
+		part, err := form.CreateFormFile("file", file.Name)
+		if err != nil {
+			return err
+		}
+
+		progress.writer = part
+		if _, err = io.Copy(progress, file.Reader); err != nil {
+			return err
+		}
+	}
+
+	return form.Close()
+}
+
+type attachmentProgress struct {
+	writer      io.Writer
+	report      models.AttachmentProgressFunc
+	transferred int64
+	total       int64
+}
+
+func (a *attachmentProgress) Write(p []byte) (int, error) {
+
+	n, err := a.writer.Write(p)
+
+	a.transferred += int64(n)
+	if a.report != nil && n > 0 {
+		a.report(a.transferred, a.total)
+	}
+
+	return n, err
+}
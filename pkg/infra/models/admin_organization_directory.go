@@ -0,0 +1,110 @@
+package models
+
+type OrganizationDirectoryPageScheme struct {
+	Data  []*OrganizationDirectoryScheme `json:"data,omitempty"`
+	Links *LinkPageModelScheme           `json:"links,omitempty"`
+}
+
+type OrganizationDirectoryScheme struct {
+	DirectoryID string `json:"directoryId,omitempty"`
+	Name        string `json:"name,omitempty"`
+}
+
+type OrganizationDirectoryUserPageScheme struct {
+	Data  []*OrganizationDirectoryUserScheme `json:"data,omitempty"`
+	Links *LinkPageModelScheme               `json:"links,omitempty"`
+}
+
+type OrganizationDirectoryUserScheme struct {
+	AccountID        string `json:"accountId,omitempty"`
+	AccountType      string `json:"accountType,omitempty"`
+	Name             string `json:"name,omitempty"`
+	Nickname         string `json:"nickname,omitempty"`
+	Email            string `json:"email,omitempty"`
+	EmailVerified    bool   `json:"emailVerified,omitempty"`
+	Status           string `json:"status,omitempty"`
+	ClaimStatus      string `json:"claimStatus,omitempty"`
+	MembershipStatus string `json:"membershipStatus,omitempty"`
+	AddedToOrg       string `json:"addedToOrg,omitempty"`
+}
+
+type OrganizationGroupPageScheme struct {
+	Data  []*OrganizationGroupScheme `json:"data,omitempty"`
+	Links *LinkPageModelScheme       `json:"links,omitempty"`
+}
+
+type OrganizationGroupScheme struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Counts      *struct {
+		Members int `json:"members,omitempty"`
+	} `json:"counts,omitempty"`
+}
+
+type OrganizationGroupMembershipPageScheme struct {
+	Data  []*OrganizationGroupMembershipScheme `json:"data,omitempty"`
+	Links *LinkPageModelScheme                 `json:"links,omitempty"`
+}
+
+type OrganizationGroupMembershipScheme struct {
+	AccountID string `json:"accountId,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Status    string `json:"status,omitempty"`
+}
+
+type OrganizationGroupCreatedScheme struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type OrganizationUserActivityScheme struct {
+	Data  *OrganizationUserActivityDataScheme `json:"data,omitempty"`
+	Links *LinkPageModelScheme                `json:"links,omitempty"`
+}
+
+type OrganizationUserActivityDataScheme struct {
+	ProductAccess []*OrganizationUserActivityProductScheme `json:"product_access,omitempty"`
+	AddedToOrg    string                                   `json:"added_to_org,omitempty"`
+}
+
+type OrganizationUserActivityProductScheme struct {
+	ID         string `json:"id,omitempty"`
+	Key        string `json:"key,omitempty"`
+	LastActive string `json:"last_active,omitempty"`
+}
+
+type OrganizationWorkspaceSearchScheme struct {
+	Limit  int    `json:"limit,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
+}
+
+type OrganizationWorkspacePageScheme struct {
+	Data  []*OrganizationWorkspaceScheme `json:"data,omitempty"`
+	Links *LinkPageModelScheme           `json:"links,omitempty"`
+}
+
+type OrganizationWorkspaceScheme struct {
+	ID         string                                 `json:"id,omitempty"`
+	Type       string                                 `json:"type,omitempty"`
+	Attributes *OrganizationWorkspaceAttributesScheme `json:"attributes,omitempty"`
+	Links      *LinkSelfModelScheme                   `json:"links,omitempty"`
+}
+
+type OrganizationWorkspaceAttributesScheme struct {
+	Name      string   `json:"name,omitempty"`
+	TypeKey   string   `json:"typeKey,omitempty"`
+	Type      string   `json:"type,omitempty"`
+	Owner     string   `json:"owner,omitempty"`
+	Status    string   `json:"status,omitempty"`
+	HostURL   string   `json:"hostUrl,omitempty"`
+	Realm     string   `json:"realm,omitempty"`
+	Regions   []string `json:"regions,omitempty"`
+	CreatedAt string   `json:"createdAt,omitempty"`
+	CreatedBy string   `json:"createdBy,omitempty"`
+}
+
+type GenericActionSuccessScheme struct {
+	Message string `json:"message,omitempty"`
+}
@@ -12,3 +12,18 @@ type EpicScheme struct {
 type EpicColorScheme struct {
 	Key string `json:"key,omitempty"`
 }
+
+// EpicPayloadScheme represents the fields that can be partially updated on an epic.
+// Done is a pointer, so that an epic can be explicitly marked as not done.
+type EpicPayloadScheme struct {
+	Name    string           `json:"name,omitempty"`
+	Summary string           `json:"summary,omitempty"`
+	Color   *EpicColorScheme `json:"color,omitempty"`
+	Done    *bool            `json:"done,omitempty"`
+}
+
+type EpicRankPayloadScheme struct {
+	RankBeforeEpic    string `json:"rankBeforeEpic,omitempty"`
+	RankAfterEpic     string `json:"rankAfterEpic,omitempty"`
+	RankCustomFieldID int    `json:"rankCustomFieldId,omitempty"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "io"
+
+// AttachmentFileScheme is a file uploaded on a multipart request, the reader is streamed and never buffered.
+type AttachmentFileScheme struct {
+	Name   string
+	Reader io.Reader
+
+	// Size is the file size, it's optional and only used on the progress total.
+	Size int64
+}
+
+// AttachmentProgressFunc receives the bytes transferred so far and the total, the total is -1 when it's unknown.
+type AttachmentProgressFunc func(transferred, total int64)
+
+type AttachmentDownloadOptionsScheme struct {
+
+	// Offset is the first byte downloaded, the Range header is sent when it's set, so a download can be resumed.
+	Offset int64
+
+	// Partial are the bytes already downloaded, e.g. the file being resumed.
+	// It's required to verify the SHA256 checksum when the offset is set, the first offset bytes are hashed.
+	Partial io.Reader
+
+	// Size is the expected size of the attachment, it's verified when it's set.
+	Size int64
+
+	// SHA256 is the expected hex encoded checksum of the attachment, it's verified when it's set.
+	SHA256 string
+
+	Progress AttachmentProgressFunc
+}
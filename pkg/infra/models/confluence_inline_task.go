@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+const (
+	InlineTaskStatusComplete   = "complete"
+	InlineTaskStatusIncomplete = "incomplete"
+)
+
+// InlineTaskSearchOptionsScheme filters the inline tasks searched, the zero values aren't sent.
+type InlineTaskSearchOptionsScheme struct {
+	SpaceKey  string
+	PageID    string
+	Assignee  string // the account id of the assignee
+	Creator   string // the account id of the creator
+	Completer string // the account id of the user who completed the task
+	Status    string // complete or incomplete
+
+	DueDateFrom      time.Time
+	DueDateTo        time.Time
+	CreateDateFrom   time.Time
+	CreateDateTo     time.Time
+	CompleteDateFrom time.Time
+	CompleteDateTo   time.Time
+}
+
+type InlineTaskPageScheme struct {
+	Results []*InlineTaskScheme `json:"results,omitempty"`
+	Start   int                 `json:"start,omitempty"`
+	Limit   int                 `json:"limit,omitempty"`
+	Size    int                 `json:"size,omitempty"`
+	Links   *LinkScheme         `json:"_links,omitempty"`
+}
+
+// InlineTaskScheme is an inline task, the dates are epoch milliseconds, zero when they're not set.
+type InlineTaskScheme struct {
+	GlobalID     int64  `json:"globalId,omitempty"`
+	ID           int64  `json:"id,omitempty"`
+	ContentID    int64  `json:"contentId,omitempty"`
+	Status       string `json:"status,omitempty"`
+	Title        string `json:"title,omitempty"` // the title of the page of the task
+	Description  string `json:"description,omitempty"`
+	Body         string `json:"body,omitempty"` // the storage format of the task
+	Creator      string `json:"creator,omitempty"`
+	Assignee     string `json:"assignee,omitempty"`
+	CompleteUser string `json:"completeUser,omitempty"`
+	CreateDate   int64  `json:"createDate,omitempty"`
+	DueDate      int64  `json:"dueDate,omitempty"`
+	UpdateDate   int64  `json:"updateDate,omitempty"`
+	CompleteDate int64  `json:"completeDate,omitempty"`
+}
+
+type InlineTaskUpdatePayloadScheme struct {
+	Status string `json:"status"`
+}
@@ -0,0 +1,45 @@
+package models
+
+type ContentTemplateScheme struct {
+	TemplateID           string                         `json:"templateId,omitempty"`
+	OriginalTemplate     *ContentTemplateOriginalScheme `json:"originalTemplate,omitempty"`
+	ReferencingBlueprint string                         `json:"referencingBlueprint,omitempty"`
+	Name                 string                         `json:"name,omitempty"`
+	Description          string                         `json:"description,omitempty"`
+	Space                *SpaceScheme                   `json:"space,omitempty"`
+	Labels               []*ContentLabelScheme          `json:"labels,omitempty"`
+	TemplateType         string                         `json:"templateType,omitempty"`
+	EditorVersion        string                         `json:"editorVersion,omitempty"`
+	Body                 *BodyScheme                    `json:"body,omitempty"`
+	Links                *LinkScheme                    `json:"_links,omitempty"`
+}
+
+// ContentTemplateOriginalScheme is the plugin module of the blueprint templates.
+type ContentTemplateOriginalScheme struct {
+	PluginKey string `json:"pluginKey,omitempty"`
+	ModuleKey string `json:"moduleKey,omitempty"`
+}
+
+type ContentTemplatePageScheme struct {
+	Results []*ContentTemplateScheme `json:"results,omitempty"`
+	Start   int                      `json:"start,omitempty"`
+	Limit   int                      `json:"limit,omitempty"`
+	Size    int                      `json:"size,omitempty"`
+	Links   *LinkScheme              `json:"_links,omitempty"`
+}
+
+// ContentTemplatePayloadScheme creates a template, or updates the template of the TemplateID.
+// The template is a global template when the space is not set.
+type ContentTemplatePayloadScheme struct {
+	TemplateID   string                       `json:"templateId,omitempty"`
+	Name         string                       `json:"name,omitempty"`
+	TemplateType string                       `json:"templateType,omitempty"` // only the page templates can be created
+	Body         *ContentTemplateBodyScheme   `json:"body,omitempty"`
+	Description  string                       `json:"description,omitempty"`
+	Labels       []*ContentLabelPayloadScheme `json:"labels,omitempty"`
+	Space        *SpaceScheme                 `json:"space,omitempty"`
+}
+
+type ContentTemplateBodyScheme struct {
+	Storage *BodyNodeScheme `json:"storage,omitempty"`
+}
@@ -0,0 +1,204 @@
+package models
+
+// The schemes used by the Confluence REST API v2, e.g. /wiki/api/v2/pages.
+
+const (
+	ContentTypePagesV2     = "pages"
+	ContentTypeBlogPostsV2 = "blogposts"
+	ContentTypeSpacesV2    = "spaces"
+)
+
+type ChunkLinksSchemeV2 struct {
+	Next string `json:"next,omitempty"`
+	Base string `json:"base,omitempty"`
+}
+
+type ContentLinksSchemeV2 struct {
+	WebUI  string `json:"webui,omitempty"`
+	EditUI string `json:"editui,omitempty"`
+	TinyUI string `json:"tinyui,omitempty"`
+}
+
+type BodySchemeV2 struct {
+	Storage        *BodyNodeScheme `json:"storage,omitempty"`
+	AtlasDocFormat *BodyNodeScheme `json:"atlas_doc_format,omitempty"`
+	View           *BodyNodeScheme `json:"view,omitempty"`
+}
+
+type VersionSchemeV2 struct {
+	CreatedAt string `json:"createdAt,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Number    int    `json:"number,omitempty"`
+	MinorEdit bool   `json:"minorEdit,omitempty"`
+	AuthorID  string `json:"authorId,omitempty"`
+}
+
+type VersionChunkSchemeV2 struct {
+	Results []*VersionSchemeV2  `json:"results,omitempty"`
+	Links   *ChunkLinksSchemeV2 `json:"_links,omitempty"`
+}
+
+type VersionUpdateSchemeV2 struct {
+	Number  int    `json:"number,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type PageSchemeV2 struct {
+	ID         string                `json:"id,omitempty"`
+	Status     string                `json:"status,omitempty"`
+	Title      string                `json:"title,omitempty"`
+	SpaceID    string                `json:"spaceId,omitempty"`
+	ParentID   string                `json:"parentId,omitempty"`
+	ParentType string                `json:"parentType,omitempty"`
+	Position   int                   `json:"position,omitempty"`
+	AuthorID   string                `json:"authorId,omitempty"`
+	OwnerID    string                `json:"ownerId,omitempty"`
+	CreatedAt  string                `json:"createdAt,omitempty"`
+	Version    *VersionSchemeV2      `json:"version,omitempty"`
+	Body       *BodySchemeV2         `json:"body,omitempty"`
+	Links      *ContentLinksSchemeV2 `json:"_links,omitempty"`
+}
+
+type PageChunkSchemeV2 struct {
+	Results []*PageSchemeV2     `json:"results,omitempty"`
+	Links   *ChunkLinksSchemeV2 `json:"_links,omitempty"`
+}
+
+type BlogPostSchemeV2 struct {
+	ID        string                `json:"id,omitempty"`
+	Status    string                `json:"status,omitempty"`
+	Title     string                `json:"title,omitempty"`
+	SpaceID   string                `json:"spaceId,omitempty"`
+	AuthorID  string                `json:"authorId,omitempty"`
+	CreatedAt string                `json:"createdAt,omitempty"`
+	Version   *VersionSchemeV2      `json:"version,omitempty"`
+	Body      *BodySchemeV2         `json:"body,omitempty"`
+	Links     *ContentLinksSchemeV2 `json:"_links,omitempty"`
+}
+
+type BlogPostChunkSchemeV2 struct {
+	Results []*BlogPostSchemeV2 `json:"results,omitempty"`
+	Links   *ChunkLinksSchemeV2 `json:"_links,omitempty"`
+}
+
+// ContentGetsOptionsSchemeV2 filters the pages or the blog posts returned.
+type ContentGetsOptionsSchemeV2 struct {
+	SpaceIDs   []string
+	Title      string
+	Status     []string
+	BodyFormat string // storage or atlas_doc_format
+	Sort       string // e.g. -modified-date
+}
+
+type ContentGetOptionsSchemeV2 struct {
+	BodyFormat string
+	Version    int
+	GetDraft   bool
+}
+
+// ContentCreatePayloadSchemeV2 creates a page or a blog post, the parent is only used by the pages.
+type ContentCreatePayloadSchemeV2 struct {
+	SpaceID  string          `json:"spaceId,omitempty"`
+	Status   string          `json:"status,omitempty"`
+	Title    string          `json:"title,omitempty"`
+	ParentID string          `json:"parentId,omitempty"`
+	Body     *BodyNodeScheme `json:"body,omitempty"`
+}
+
+// ContentUpdatePayloadSchemeV2 updates a page or a blog post, the version number must be the current version plus one.
+type ContentUpdatePayloadSchemeV2 struct {
+	ID       string                 `json:"id,omitempty"`
+	Status   string                 `json:"status,omitempty"`
+	Title    string                 `json:"title,omitempty"`
+	SpaceID  string                 `json:"spaceId,omitempty"`
+	ParentID string                 `json:"parentId,omitempty"`
+	Body     *BodyNodeScheme        `json:"body,omitempty"`
+	Version  *VersionUpdateSchemeV2 `json:"version,omitempty"`
+}
+
+type SpaceSchemeV2 struct {
+	ID          string                `json:"id,omitempty"`
+	Key         string                `json:"key,omitempty"`
+	Name        string                `json:"name,omitempty"`
+	Type        string                `json:"type,omitempty"`
+	Status      string                `json:"status,omitempty"`
+	AuthorID    string                `json:"authorId,omitempty"`
+	CreatedAt   string                `json:"createdAt,omitempty"`
+	HomepageID  string                `json:"homepageId,omitempty"`
+	Description *BodySchemeV2         `json:"description,omitempty"`
+	Links       *ContentLinksSchemeV2 `json:"_links,omitempty"`
+}
+
+type SpaceChunkSchemeV2 struct {
+	Results []*SpaceSchemeV2    `json:"results,omitempty"`
+	Links   *ChunkLinksSchemeV2 `json:"_links,omitempty"`
+}
+
+type GetSpacesOptionSchemeV2 struct {
+	IDs               []string
+	Keys              []string
+	Type              string // global or personal
+	Status            string // current or archived
+	Labels            []string
+	Sort              string
+	DescriptionFormat string
+}
+
+type CommentSchemeV2 struct {
+	ID              string                `json:"id,omitempty"`
+	Status          string                `json:"status,omitempty"`
+	Title           string                `json:"title,omitempty"`
+	PageID          string                `json:"pageId,omitempty"`
+	BlogPostID      string                `json:"blogPostId,omitempty"`
+	ParentCommentID string                `json:"parentCommentId,omitempty"`
+	Version         *VersionSchemeV2      `json:"version,omitempty"`
+	Body            *BodySchemeV2         `json:"body,omitempty"`
+	Links           *ContentLinksSchemeV2 `json:"_links,omitempty"`
+}
+
+type CommentChunkSchemeV2 struct {
+	Results []*CommentSchemeV2  `json:"results,omitempty"`
+	Links   *ChunkLinksSchemeV2 `json:"_links,omitempty"`
+}
+
+// CommentCreatePayloadSchemeV2 creates a footer comment on a page, a blog post, or a reply to a comment.
+type CommentCreatePayloadSchemeV2 struct {
+	PageID          string          `json:"pageId,omitempty"`
+	BlogPostID      string          `json:"blogPostId,omitempty"`
+	ParentCommentID string          `json:"parentCommentId,omitempty"`
+	Body            *BodyNodeScheme `json:"body,omitempty"`
+}
+
+type CommentUpdatePayloadSchemeV2 struct {
+	Version *VersionUpdateSchemeV2 `json:"version,omitempty"`
+	Body    *BodyNodeScheme        `json:"body,omitempty"`
+}
+
+type LabelSchemeV2 struct {
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+}
+
+type LabelChunkSchemeV2 struct {
+	Results []*LabelSchemeV2    `json:"results,omitempty"`
+	Links   *ChunkLinksSchemeV2 `json:"_links,omitempty"`
+}
+
+type ContentPropertySchemeV2 struct {
+	ID      string           `json:"id,omitempty"`
+	Key     string           `json:"key,omitempty"`
+	Value   interface{}      `json:"value,omitempty"`
+	Version *VersionSchemeV2 `json:"version,omitempty"`
+}
+
+type ContentPropertyChunkSchemeV2 struct {
+	Results []*ContentPropertySchemeV2 `json:"results,omitempty"`
+	Links   *ChunkLinksSchemeV2        `json:"_links,omitempty"`
+}
+
+type ContentPropertyPayloadSchemeV2 struct {
+	Key     string                 `json:"key,omitempty"`
+	Value   interface{}            `json:"value,omitempty"`
+	Version *VersionUpdateSchemeV2 `json:"version,omitempty"`
+}
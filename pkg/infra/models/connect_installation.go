@@ -0,0 +1,17 @@
+package models
+
+// ConnectInstallationScheme is the payload posted by Atlassian Connect to the installed lifecycle callback of an app,
+// the shared secret signs the requests sent by the app to the site.
+type ConnectInstallationScheme struct {
+	Key            string `json:"key,omitempty"`
+	ClientKey      string `json:"clientKey,omitempty"`
+	OAuthClientID  string `json:"oauthClientId,omitempty"`
+	SharedSecret   string `json:"sharedSecret,omitempty"`
+	ServerVersion  string `json:"serverVersion,omitempty"`
+	PluginsVersion string `json:"pluginsVersion,omitempty"`
+	BaseURL        string `json:"baseUrl,omitempty"`
+	DisplayURL     string `json:"displayUrl,omitempty"`
+	ProductType    string `json:"productType,omitempty"`
+	Description    string `json:"description,omitempty"`
+	EventType      string `json:"eventType,omitempty"`
+}
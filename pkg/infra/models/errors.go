@@ -29,6 +29,7 @@ var (
 	ErrNoFileReaderError          = errors.New("sm: no io.Reader set")
 	ErrNoCustomRequestFieldsError = errors.New("sm: no customer request fields set")
 	ErrNoSLAMetricIDError         = errors.New("sm: no sla metric id set")
+	ErrInvalidRequestFieldsError  = errors.New("sm: invalid customer request fields")
 
 	ErrNoContentIDError             = errors.New("confluence: no content id set")
 	ErrNoCQLError                   = errors.New("confluence: no CQL query set")
@@ -42,10 +43,21 @@ var (
 	ErrNoContentRestrictionKeyError = errors.New("confluence: no content restriction operation key set")
 	ErrNoConfluenceGroupError       = errors.New("confluence: no group id or name set")
 	ErrNoLabelNameError             = errors.New("confluence: no label name set")
+	ErrNoSpaceIDError               = errors.New("confluence: no space id set")
+	ErrNoContentCommentIDError      = errors.New("confluence: no comment id set")
+	ErrNoContentPropertyIDError     = errors.New("confluence: no content property id set")
+	ErrInvalidContentTypeV2Error    = errors.New("confluence: invalid content type: (pages, blogposts)")
+	ErrNoTemplateIDError            = errors.New("confluence: no template id set")
+	ErrNoTemplateNameError          = errors.New("confluence: no template name set")
+	ErrNoTemplateVariableError      = errors.New("confluence: no template variable value set")
+	ErrNoInlineTaskIDError          = errors.New("confluence: no inline task id set")
+	ErrInvalidInlineTaskStatusError = errors.New("confluence: invalid inline task status: (complete, incomplete)")
 
 	ErrNoBoardIDError  = errors.New("agile: no board id set")
 	ErrNoFilterIDError = errors.New("agile: no filter id set")
 	ErrNoEpicIDError   = errors.New("agile: no epic id set")
+	ErrNoEpicRankError = errors.New("agile: no rankBeforeEpic or rankAfterEpic set")
+	ErrNoIssuesError   = errors.New("agile: no issues set")
 	ErrNoSprintIDError = errors.New("agile: no sprint id set")
 
 	ErrNoApplicationRoleError              = errors.New("jira: no application role key set")
@@ -77,6 +89,7 @@ var (
 	ErrNoPriorityIDError                   = errors.New("jira: no priority id set")
 	ErrNoResolutionIDError                 = errors.New("jira: no resolution id set")
 	ErrNoJQLError                          = errors.New("jira: no sql set")
+	ErrNoHierarchyRootError                = errors.New("jira: the hierarchy root issue was not found")
 	ErrNoIssueTypeIDError                  = errors.New("jira: no issue type id set")
 	ErrNoIssueTypeScreenSchemeIDError      = errors.New("jira: no issue type screen scheme id set")
 	ErrNoScreenSchemeIDError               = errors.New("jira: no screen scheme id set")
@@ -104,9 +117,23 @@ var (
 	ErrNoVersionProvided                   = errors.New("client: no module version set")
 	ErrNoIssueTypeSchemeIDError            = errors.New("jira: no issue type scheme id set")
 	ErrNoTaskIDError                       = errors.New("atlassian: no task id set")
+	ErrTaskFailedError                     = errors.New("atlassian: the task failed")
+	ErrTaskCancelledError                  = errors.New("atlassian: the task was cancelled")
+	ErrTaskDeadError                       = errors.New("atlassian: the task is dead")
 	ErrNoApprovalIDError                   = errors.New("jira: no approval id set")
+	ErrNoWebhookURLError                   = errors.New("jira: no webhook url set")
+	ErrNoWebhooksError                     = errors.New("jira: no webhooks set")
+	ErrNoWebhookIDsError                   = errors.New("jira: no webhook id's set")
+
+	ErrNoAttachmentFilesError          = errors.New("atlassian: no attachment files set")
+	ErrInvalidAttachmentFileError      = errors.New("atlassian: the attachment file requires a name and a reader")
+	ErrNoWriterError                   = errors.New("atlassian: no writer set")
+	ErrNoAttachmentPartialError        = errors.New("atlassian: no partial content set to verify the resumed download checksum")
+	ErrAttachmentSizeMismatchError     = errors.New("atlassian: the attachment size doesn't match")
+	ErrAttachmentChecksumMismatchError = errors.New("atlassian: the attachment sha-256 checksum doesn't match")
 
 	ErrInvalidStatusCodeError = errors.New("client: invalid http response status, please refer the response.body for more details")
 	ErrNilPayloadError        = errors.New("client: please provide the necessary payload struct")
+	ErrNoDoerError            = errors.New("client: the client doesn't send raw requests, it doesn't implement service.Doer")
 	ErrNonPayloadPointerError = errors.New("client: please provide a valid payload struct pointer (&)")
 )
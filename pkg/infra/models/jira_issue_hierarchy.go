@@ -0,0 +1,71 @@
+package models
+
+import "encoding/json"
+
+type IssueHierarchyOptionsScheme struct {
+
+	// EpicLinkField is the custom field ID of the Epic Link field, e.g. customfield_10014.
+	// When it's set, the company-managed epic children are fetched using the field as well as the parent field.
+	EpicLinkField string
+
+	// EstimateField is the field used on the estimate roll-ups, by default timeoriginalestimate.
+	// It can be a story points custom field, e.g. customfield_10016.
+	EstimateField string
+
+	// Fields are additional fields returned on each node of the tree.
+	Fields []string
+
+	// MaxDepth is the maximum number of levels fetched below the root issues, zero means no limit.
+	MaxDepth int
+
+	// BatchSize is the number of parent keys sent on each parent in (...) JQL query, by default 50.
+	BatchSize int
+}
+
+type IssueHierarchyScheme struct {
+	Roots  []*IssueHierarchyNodeScheme  `json:"roots,omitempty"`
+	Levels []*IssueHierarchyLevelScheme `json:"levels,omitempty"`
+}
+
+type IssueHierarchyNodeScheme struct {
+	ID        string                      `json:"id,omitempty"`
+	Key       string                      `json:"key,omitempty"`
+	Summary   string                      `json:"summary,omitempty"`
+	ParentKey string                      `json:"parentKey,omitempty"`
+	Level     int                         `json:"level"`
+	IssueType *IssueTypeScheme            `json:"issueType,omitempty"`
+	Status    *StatusScheme               `json:"status,omitempty"`
+	Estimate  float64                     `json:"estimate,omitempty"`
+	Fields    map[string]json.RawMessage  `json:"fields,omitempty"`
+	Children  []*IssueHierarchyNodeScheme `json:"children,omitempty"`
+	RollUp    *IssueHierarchyRollUpScheme `json:"rollUp,omitempty"`
+}
+
+// Walk calls fn for the node and every descendant, parents are visited before their children.
+func (n *IssueHierarchyNodeScheme) Walk(fn func(node *IssueHierarchyNodeScheme)) {
+
+	fn(n)
+
+	for _, child := range n.Children {
+		child.Walk(fn)
+	}
+}
+
+// IssueHierarchyRollUpScheme aggregates a node and all its descendants.
+type IssueHierarchyRollUpScheme struct {
+	Issues           int            `json:"issues"`
+	Estimate         float64        `json:"estimate"`
+	DoneEstimate     float64        `json:"doneEstimate"`
+	StatusCategories map[string]int `json:"statusCategories,omitempty"`
+
+	// Progress is the done estimate ratio, or the done issues ratio when nothing is estimated.
+	Progress float64 `json:"progress"`
+}
+
+type IssueHierarchyLevelScheme struct {
+	Level            int            `json:"level"`
+	IssueTypes       []string       `json:"issueTypes,omitempty"`
+	Issues           int            `json:"issues"`
+	Estimate         float64        `json:"estimate"`
+	StatusCategories map[string]int `json:"statusCategories,omitempty"`
+}
@@ -1,5 +1,12 @@
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
 type TaskScheme struct {
 	Self           string `json:"self"`
 	ID             string `json:"id"`
@@ -14,3 +21,130 @@ type TaskScheme struct {
 	Finished       int64  `json:"finished"`
 	LastUpdate     int64  `json:"lastUpdate"`
 }
+
+const (
+	TaskStatusEnqueued        = "ENQUEUED"
+	TaskStatusRunning         = "RUNNING"
+	TaskStatusComplete        = "COMPLETE"
+	TaskStatusFailed          = "FAILED"
+	TaskStatusCancelRequested = "CANCEL_REQUESTED"
+	TaskStatusCancelled       = "CANCELLED"
+	TaskStatusDead            = "DEAD"
+)
+
+// UnmarshalJSON decodes the task, the result is kept as the raw JSON text when it's not a JSON string.
+func (t *TaskScheme) UnmarshalJSON(data []byte) error {
+
+	type alias TaskScheme
+
+	raw := struct {
+		*alias
+		Result json.RawMessage `json:"result"`
+	}{alias: (*alias)(t)}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	t.Result = ""
+	if len(raw.Result) == 0 || string(raw.Result) == "null" {
+		return nil
+	}
+
+	if raw.Result[0] == '"' {
+		return json.Unmarshal(raw.Result, &t.Result)
+	}
+
+	t.Result = string(raw.Result)
+	return nil
+}
+
+// IsDone reports whether the task reached a terminal status.
+func (t *TaskScheme) IsDone() bool {
+
+	switch t.Status {
+	case TaskStatusComplete, TaskStatusFailed, TaskStatusCancelled, TaskStatusDead:
+		return true
+	}
+
+	return false
+}
+
+type TaskWaitOptionsScheme struct {
+
+	// Interval is the wait before the second poll, by default one second.
+	Interval time.Duration
+
+	// MaxInterval is the limit of the wait between polls, by default 30 seconds.
+	MaxInterval time.Duration
+
+	// Multiplier is the backoff factor applied to the interval after each poll, by default 1.5.
+	// Use 1 to poll on a fixed interval.
+	Multiplier float64
+
+	// Progress is called after each poll with the percentage completed and the task status.
+	Progress func(percentage int, status string)
+
+	// Result is decoded from the task result when the task is completed, e.g. a pointer to a struct.
+	// The Confluence long tasks have no result field, the whole finished task is decoded.
+	Result interface{}
+
+	// CancelOnContextDone cancels the task when the context is done while waiting, it's only supported by the Jira tasks.
+	CancelOnContextDone bool
+}
+
+// Delay returns the wait before the poll following the attempt number provided, starting at zero.
+func (t *TaskWaitOptionsScheme) Delay(attempt int) time.Duration {
+
+	interval, maxInterval, multiplier := time.Second, 30*time.Second, 1.5
+	if t != nil {
+
+		if t.Interval > 0 {
+			interval = t.Interval
+		}
+
+		if t.MaxInterval > 0 {
+			maxInterval = t.MaxInterval
+		}
+
+		if t.Multiplier >= 1 {
+			multiplier = t.Multiplier
+		}
+	}
+
+	delay := float64(interval) * math.Pow(multiplier, float64(attempt))
+	if delay > float64(maxInterval) {
+		return maxInterval
+	}
+
+	return time.Duration(delay)
+}
+
+// TaskError is returned when an asynchronous task finishes without completing,
+// it wraps ErrTaskFailedError, ErrTaskCancelledError or ErrTaskDeadError.
+type TaskError struct {
+	ID      string
+	Status  string
+	Message string
+}
+
+func (t *TaskError) Error() string {
+
+	if t.Message == "" {
+		return fmt.Sprintf("%v: %v", t.Unwrap().Error(), t.ID)
+	}
+
+	return fmt.Sprintf("%v: %v: %v", t.Unwrap().Error(), t.ID, t.Message)
+}
+
+func (t *TaskError) Unwrap() error {
+
+	switch t.Status {
+	case TaskStatusCancelled, TaskStatusCancelRequested:
+		return ErrTaskCancelledError
+	case TaskStatusDead:
+		return ErrTaskDeadError
+	}
+
+	return ErrTaskFailedError
+}
@@ -0,0 +1,88 @@
+package models
+
+import "time"
+
+type WebhookSubscriptionPayloadScheme struct {
+	Webhooks []*WebhookSubscriptionScheme `json:"webhooks,omitempty"`
+	URL      string                       `json:"url,omitempty"`
+}
+
+type WebhookSubscriptionScheme struct {
+	JqlFilter               string   `json:"jqlFilter,omitempty"`
+	FieldIdsFilter          []string `json:"fieldIdsFilter,omitempty"`
+	IssuePropertyKeysFilter []string `json:"issuePropertyKeysFilter,omitempty"`
+	Events                  []string `json:"events,omitempty"`
+}
+
+type WebhookRegistrationScheme struct {
+	WebhookRegistrationResult []*WebhookRegistrationResultScheme `json:"webhookRegistrationResult,omitempty"`
+}
+
+// WebhookRegistrationResultScheme is the result of a webhook registered, in the order of the payload webhooks,
+// the errors are set instead of the id when it's not registered.
+type WebhookRegistrationResultScheme struct {
+	CreatedWebhookID int      `json:"createdWebhookId,omitempty"`
+	Errors           []string `json:"errors,omitempty"`
+}
+
+type WebhookPageScheme struct {
+	Self       string           `json:"self,omitempty"`
+	MaxResults int              `json:"maxResults,omitempty"`
+	StartAt    int              `json:"startAt,omitempty"`
+	Total      int              `json:"total,omitempty"`
+	IsLast     bool             `json:"isLast,omitempty"`
+	Values     []*WebhookScheme `json:"values,omitempty"`
+}
+
+type WebhookScheme struct {
+	ID                      int      `json:"id,omitempty"`
+	JqlFilter               string   `json:"jqlFilter,omitempty"`
+	FieldIdsFilter          []string `json:"fieldIdsFilter,omitempty"`
+	IssuePropertyKeysFilter []string `json:"issuePropertyKeysFilter,omitempty"`
+	Events                  []string `json:"events,omitempty"`
+	ExpirationDate          int64    `json:"expirationDate,omitempty"`
+}
+
+// Expiration returns the expiration date of the webhook, the date is sent as milliseconds since the epoch.
+func (w *WebhookScheme) Expiration() time.Time {
+	return time.Unix(0, w.ExpirationDate*int64(time.Millisecond))
+}
+
+type WebhookRefreshScheme struct {
+	ExpirationDate int64 `json:"expirationDate,omitempty"`
+}
+
+// Expiration returns the new expiration date of the webhooks refreshed.
+func (w *WebhookRefreshScheme) Expiration() time.Time {
+	return time.Unix(0, w.ExpirationDate*int64(time.Millisecond))
+}
+
+type FailedWebhookPageScheme struct {
+	Values     []*FailedWebhookScheme `json:"values,omitempty"`
+	MaxResults int                    `json:"maxResults,omitempty"`
+	Next       string                 `json:"next,omitempty"`
+}
+
+type FailedWebhookScheme struct {
+	ID          string `json:"id,omitempty"`
+	Body        string `json:"body,omitempty"`
+	URL         string `json:"url,omitempty"`
+	FailureTime int64  `json:"failureTime,omitempty"`
+}
+
+// WebhookKeepAliveOptionsScheme sets the refresh of the webhooks registered by the app, see WebhookService.KeepAlive.
+type WebhookKeepAliveOptionsScheme struct {
+
+	// Interval is the wait between the checks of the webhook expirations, by default 12 hours.
+	Interval time.Duration
+
+	// Threshold is the remaining time under which a webhook is refreshed, by default 7 days.
+	// The webhooks registered expire after 30 days.
+	Threshold time.Duration
+
+	// OnRefresh is called with the ids of the webhooks refreshed and their new expiration date.
+	OnRefresh func(ids []int, expiration time.Time)
+
+	// OnError is called when a check fails, the webhooks are checked again after the interval.
+	OnError func(err error)
+}
@@ -18,15 +18,16 @@ type WorkflowSchemePageScheme struct {
 }
 
 type WorkflowSchemeScheme struct {
-	ID                  int         `json:"id,omitempty"`
-	Name                string      `json:"name,omitempty"`
-	Description         string      `json:"description,omitempty"`
-	DefaultWorkflow     string      `json:"defaultWorkflow,omitempty"`
-	Draft               bool        `json:"draft,omitempty"`
-	LastModifiedUser    *UserScheme `json:"lastModifiedUser,omitempty"`
-	LastModified        string      `json:"lastModified,omitempty"`
-	Self                string      `json:"self,omitempty"`
-	UpdateDraftIfNeeded bool        `json:"updateDraftIfNeeded,omitempty"`
+	ID                  int               `json:"id,omitempty"`
+	Name                string            `json:"name,omitempty"`
+	Description         string            `json:"description,omitempty"`
+	DefaultWorkflow     string            `json:"defaultWorkflow,omitempty"`
+	Draft               bool              `json:"draft,omitempty"`
+	IssueTypeMappings   map[string]string `json:"issueTypeMappings,omitempty"`
+	LastModifiedUser    *UserScheme       `json:"lastModifiedUser,omitempty"`
+	LastModified        string            `json:"lastModified,omitempty"`
+	Self                string            `json:"self,omitempty"`
+	UpdateDraftIfNeeded bool              `json:"updateDraftIfNeeded,omitempty"`
 }
 
 type WorkflowSchemeAssociationPageScheme struct {
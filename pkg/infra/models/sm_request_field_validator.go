@@ -0,0 +1,352 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// RequestFieldsValidator type-checks the customer request field values against the request type fields,
+// so the problems are reported before calling POST /rest/servicedeskapi/request.
+//
+// The values are set by field ID or field name, the option labels are resolved to the option IDs.
+type RequestFieldsValidator struct {
+	fields []*RequestTypeFieldScheme
+	values map[string]interface{}
+	order  []string
+	errors []*RequestFieldProblemScheme
+}
+
+type RequestFieldProblemScheme struct {
+	FieldID string `json:"fieldId,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// RequestFieldsValidationError contains every problem found on the customer request field values.
+type RequestFieldsValidationError struct {
+	Problems []*RequestFieldProblemScheme
+}
+
+func (r *RequestFieldsValidationError) Error() string {
+
+	messages := make([]string, 0, len(r.Problems))
+	for _, problem := range r.Problems {
+		messages = append(messages, fmt.Sprintf("%v: %v", problem.FieldID, problem.Message))
+	}
+
+	return fmt.Sprintf("%v: %v", ErrInvalidRequestFieldsError.Error(), strings.Join(messages, "; "))
+}
+
+func (r *RequestFieldsValidationError) Unwrap() error {
+	return ErrInvalidRequestFieldsError
+}
+
+func NewRequestFieldsValidator(fields *RequestTypeFieldsScheme) *RequestFieldsValidator {
+
+	validator := &RequestFieldsValidator{values: make(map[string]interface{})}
+	if fields != nil {
+		validator.fields = fields.RequestTypeFields
+	}
+
+	return validator
+}
+
+// Set sets the value of a field, using the field ID (e.g. customfield_10010) or the field name.
+//
+// The values expected by each field schema type are:
+//
+//  1. string: string.
+//  2. number: any integer or float.
+//  3. date and datetime: time.Time, or a string on the 2006-01-02 or RFC 3339 formats.
+//  4. option, priority and other fields with valid values: the option label or ID.
+//  5. option-with-child: a []string with the parent and the child option labels or IDs.
+//  6. user and group: the account ID or the group name.
+//  7. array: a []string, the items are checked using the item type.
+func (r *RequestFieldsValidator) Set(fieldIDOrName string, value interface{}) *RequestFieldsValidator {
+
+	field := r.field(fieldIDOrName)
+	if field == nil {
+		r.errors = append(r.errors, &RequestFieldProblemScheme{FieldID: fieldIDOrName, Message: "the field is not available on the request type"})
+		return r
+	}
+
+	if _, ok := r.values[field.FieldID]; !ok {
+		r.order = append(r.order, field.FieldID)
+	}
+
+	r.values[field.FieldID] = value
+	return r
+}
+
+// Validate returns a *RequestFieldsValidationError with all the problems found, or nil.
+func (r *RequestFieldsValidator) Validate() error {
+	_, err := r.Build()
+	return err
+}
+
+// Build returns the customer request fields with the values converted to the format expected by the API,
+// or a *RequestFieldsValidationError with all the problems found.
+func (r *RequestFieldsValidator) Build() (*CustomerRequestFields, error) {
+
+	problems := append([]*RequestFieldProblemScheme(nil), r.errors...)
+
+	for _, field := range r.fields {
+
+		if _, ok := r.values[field.FieldID]; ok || !field.Required || len(field.DefaultValues) != 0 {
+			continue
+		}
+
+		problems = append(problems, &RequestFieldProblemScheme{FieldID: field.FieldID, Name: field.Name, Message: "the field is required"})
+	}
+
+	fields := &CustomerRequestFields{}
+	for _, fieldID := range r.order {
+
+		field := r.field(fieldID)
+
+		value, err := convertRequestFieldValue(field, r.values[fieldID])
+		if err != nil {
+			problems = append(problems, &RequestFieldProblemScheme{FieldID: field.FieldID, Name: field.Name, Message: err.Error()})
+			continue
+		}
+
+		fields.Fields = append(fields.Fields, map[string]interface{}{
+			"requestFieldValues": map[string]interface{}{field.FieldID: value},
+		})
+	}
+
+	if len(problems) != 0 {
+		return nil, &RequestFieldsValidationError{Problems: problems}
+	}
+
+	if len(fields.Fields) == 0 {
+		return nil, ErrNoCustomRequestFieldsError
+	}
+
+	return fields, nil
+}
+
+func (r *RequestFieldsValidator) field(fieldIDOrName string) *RequestTypeFieldScheme {
+
+	for _, field := range r.fields {
+		if field.FieldID == fieldIDOrName {
+			return field
+		}
+	}
+
+	for _, field := range r.fields {
+		if strings.EqualFold(field.Name, fieldIDOrName) {
+			return field
+		}
+	}
+
+	return nil
+}
+
+func convertRequestFieldValue(field *RequestTypeFieldScheme, value interface{}) (interface{}, error) {
+
+	if value == nil {
+		return nil, fmt.Errorf("the value is nil")
+	}
+
+	schemaType, itemType := "", ""
+	if field.JiraSchema != nil {
+		schemaType, itemType = field.JiraSchema.Type, field.JiraSchema.Items
+	}
+
+	if schemaType == "array" {
+
+		items, ok := value.([]string)
+		if !ok {
+			return nil, fmt.Errorf("expected a []string value, got %T", value)
+		}
+
+		if field.Required && len(items) == 0 {
+			return nil, fmt.Errorf("the field is required")
+		}
+
+		converted := make([]interface{}, 0, len(items))
+		for _, item := range items {
+
+			itemValue, err := convertRequestFieldItem(field, itemType, item)
+			if err != nil {
+				return nil, err
+			}
+
+			converted = append(converted, itemValue)
+		}
+
+		return converted, nil
+	}
+
+	return convertRequestFieldItem(field, schemaType, value)
+}
+
+func convertRequestFieldItem(field *RequestTypeFieldScheme, schemaType string, value interface{}) (interface{}, error) {
+
+	switch schemaType {
+
+	case "number":
+
+		number, ok := toFloat(value)
+		if !ok {
+			return nil, fmt.Errorf("expected a number value, got %T", value)
+		}
+
+		return number, nil
+
+	case "date":
+		return convertRequestFieldTime(value, "2006-01-02")
+
+	case "datetime":
+		return convertRequestFieldTime(value, time.RFC3339)
+
+	case "option-with-child":
+
+		options, ok := value.([]string)
+		if !ok || len(options) == 0 || len(options) > 2 {
+			return nil, fmt.Errorf("expected a []string value with the parent and child options, got %v", value)
+		}
+
+		parent, err := resolveRequestFieldOption(field.ValidValues, options[0])
+		if err != nil {
+			return nil, err
+		}
+
+		node := map[string]interface{}{"id": parent.Value}
+		if len(options) == 2 {
+
+			child, err := resolveRequestFieldOption(childRequestFieldValues(parent), options[1])
+			if err != nil {
+				return nil, err
+			}
+
+			node["child"] = map[string]interface{}{"id": child.Value}
+		}
+
+		return node, nil
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a string value, got %T", value)
+	}
+
+	if field.Required && strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("the field is required")
+	}
+
+	switch {
+
+	case schemaType == "user":
+		return map[string]interface{}{"accountId": text}, nil
+
+	case schemaType == "group":
+		return map[string]interface{}{"name": text}, nil
+
+	case len(field.ValidValues) != 0:
+
+		option, err := resolveRequestFieldOption(field.ValidValues, text)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{"id": option.Value}, nil
+
+	case field.JiraSchema != nil && strings.HasSuffix(field.JiraSchema.Custom, ":url"):
+
+		parsed, err := url.Parse(text)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("the value %q is not a valid URL", text)
+		}
+	}
+
+	return text, nil
+}
+
+// resolveRequestFieldOption finds the option by ID, or by label ignoring the case.
+func resolveRequestFieldOption(options []*RequestTypeFieldValueScheme, value string) (*RequestTypeFieldValueScheme, error) {
+
+	for _, option := range options {
+		if option.Value == value {
+			return option, nil
+		}
+	}
+
+	for _, option := range options {
+		if strings.EqualFold(option.Label, value) {
+			return option, nil
+		}
+	}
+
+	labels := make([]string, 0, len(options))
+	for _, option := range options {
+		labels = append(labels, option.Label)
+	}
+
+	return nil, fmt.Errorf("the value %q is not a valid option (%v)", value, strings.Join(labels, ", "))
+}
+
+// childRequestFieldValues decodes the children of a cascading option, they're returned as generic maps.
+func childRequestFieldValues(parent *RequestTypeFieldValueScheme) []*RequestTypeFieldValueScheme {
+
+	var children []*RequestTypeFieldValueScheme
+	for _, child := range parent.Children {
+
+		node, ok := child.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		value, _ := node["value"].(string)
+		label, _ := node["label"].(string)
+		children = append(children, &RequestTypeFieldValueScheme{Value: value, Label: label})
+	}
+
+	return children
+}
+
+func convertRequestFieldTime(value interface{}, layout string) (interface{}, error) {
+
+	switch typed := value.(type) {
+
+	case time.Time:
+
+		if typed.IsZero() {
+			return nil, fmt.Errorf("the time value is zero")
+		}
+
+		return typed.Format(layout), nil
+
+	case string:
+
+		for _, candidate := range []string{layout, time.RFC3339, "2006-01-02"} {
+			if parsed, err := time.Parse(candidate, typed); err == nil {
+				return parsed.Format(layout), nil
+			}
+		}
+
+		return nil, fmt.Errorf("the value %q is not a valid date", typed)
+	}
+
+	return nil, fmt.Errorf("expected a time.Time or string value, got %T", value)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+
+	reflected := reflect.ValueOf(value)
+
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(reflected.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(reflected.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return reflected.Float(), true
+	}
+
+	return 0, false
+}
@@ -19,16 +19,37 @@ type RequestSLAPageLinkScheme struct {
 }
 
 type RequestSLAScheme struct {
-	ID           string                        `json:"id,omitempty"`
-	Name         string                        `json:"name,omitempty"`
-	OngoingCycle *RequestSLAOngoingCycleScheme `json:"ongoingCycle,omitempty"`
-	Links        *RequestSLALinkScheme         `json:"_links,omitempty"`
+	ID              string                            `json:"id,omitempty"`
+	Name            string                            `json:"name,omitempty"`
+	OngoingCycle    *RequestSLAOngoingCycleScheme     `json:"ongoingCycle,omitempty"`
+	CompletedCycles []*RequestSLACompletedCycleScheme `json:"completedCycles,omitempty"`
+	Links           *RequestSLALinkScheme             `json:"_links,omitempty"`
 }
 
 type RequestSLAOngoingCycleScheme struct {
-	Breached            bool `json:"breached,omitempty"`
-	Paused              bool `json:"paused,omitempty"`
-	WithinCalendarHours bool `json:"withinCalendarHours,omitempty"`
+	StartTime           *CustomerRequestDateScheme `json:"startTime,omitempty"`
+	BreachTime          *CustomerRequestDateScheme `json:"breachTime,omitempty"`
+	Breached            bool                       `json:"breached,omitempty"`
+	Paused              bool                       `json:"paused,omitempty"`
+	WithinCalendarHours bool                       `json:"withinCalendarHours,omitempty"`
+	GoalDuration        *RequestSLADurationScheme  `json:"goalDuration,omitempty"`
+	ElapsedTime         *RequestSLADurationScheme  `json:"elapsedTime,omitempty"`
+	RemainingTime       *RequestSLADurationScheme  `json:"remainingTime,omitempty"`
+}
+
+type RequestSLACompletedCycleScheme struct {
+	StartTime     *CustomerRequestDateScheme `json:"startTime,omitempty"`
+	StopTime      *CustomerRequestDateScheme `json:"stopTime,omitempty"`
+	BreachTime    *CustomerRequestDateScheme `json:"breachTime,omitempty"`
+	Breached      bool                       `json:"breached,omitempty"`
+	GoalDuration  *RequestSLADurationScheme  `json:"goalDuration,omitempty"`
+	ElapsedTime   *RequestSLADurationScheme  `json:"elapsedTime,omitempty"`
+	RemainingTime *RequestSLADurationScheme  `json:"remainingTime,omitempty"`
+}
+
+type RequestSLADurationScheme struct {
+	Millis   int64  `json:"millis,omitempty"`
+	Friendly string `json:"friendly,omitempty"`
 }
 
 type RequestSLALinkScheme struct {
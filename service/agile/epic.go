@@ -45,4 +45,73 @@ type EpicConnector interface {
 	//
 	// https://docs.go-atlassian.io/jira-agile/epics#move-issues-to-epic
 	Move(ctx context.Context, epicIdOrKey string, issues []string) (*model.ResponseScheme, error)
+
+	// Path performs a partial update of the epic.
+	//
+	// A partial update means that fields not present in the request payload will not be changed.
+	//
+	// The epic name, summary, color and done flag can be updated.
+	//
+	// Note: This operation does not work for epics in next-gen projects.
+	//
+	// POST /rest/agile/1.0/epic/{epicIdOrKey}
+	//
+	// https://docs.go-atlassian.io/jira-agile/epics#partially-update-epic
+	Path(ctx context.Context, epicIdOrKey string, payload *model.EpicPayloadScheme) (*model.EpicScheme, *model.ResponseScheme, error)
+
+	// Rank moves (ranks) an epic before or after a given epic.
+	//
+	// If rankCustomFieldId is not defined, the default rank field will be used.
+	//
+	// PUT /rest/agile/1.0/epic/{epicIdOrKey}/rank
+	//
+	// https://docs.go-atlassian.io/jira-agile/epics#rank-epics
+	Rank(ctx context.Context, epicIdOrKey string, payload *model.EpicRankPayloadScheme) (*model.ResponseScheme, error)
+
+	// IssuesWithoutEpic returns all issues that do not belong to any epic.
+	//
+	// This only includes issues that the user has permission to view.
+	//
+	// By default, the returned issues are ordered by rank.
+	//
+	// GET /rest/agile/1.0/epic/none/issue
+	//
+	// https://docs.go-atlassian.io/jira-agile/epics#get-issues-without-epic
+	IssuesWithoutEpic(ctx context.Context, opts *model.IssueOptionScheme, startAt, maxResults int) (*model.BoardIssuePageScheme,
+		*model.ResponseScheme, error)
+
+	// Remove removes issues from epics.
+	//
+	// The user needs to have the edit issue permission for all issue they want to remove from epics.
+	//
+	// The maximum number of issues that can be moved in one operation is 50.
+	//
+	// POST /rest/agile/1.0/epic/none/issue
+	//
+	// https://docs.go-atlassian.io/jira-agile/epics#remove-issues-from-epic
+	Remove(ctx context.Context, issues []string) (*model.ResponseScheme, error)
+
+	// Children returns the issues that belong to an epic of a team-managed project.
+	//
+	// Team-managed projects model the epic relation with the parent field instead of the Epic Link custom field.
+	//
+	// The issues are returned using the JQL query parent = "{epicIdOrKey}".
+	//
+	// GET /rest/api/2/search
+	Children(ctx context.Context, epicIdOrKey string, opts *model.IssueOptionScheme, startAt, maxResults int) (*model.BoardIssuePageScheme,
+		*model.ResponseScheme, error)
+
+	// Adopt moves issues to an epic of a team-managed project, setting the parent field of each issue.
+	//
+	// The issues are edited one by one, the process stops on the first issue that cannot be updated.
+	//
+	// PUT /rest/api/2/issue/{issueIdOrKey}
+	Adopt(ctx context.Context, epicIdOrKey string, issues []string) (*model.ResponseScheme, error)
+
+	// Orphan removes issues from their team-managed epic, clearing the parent field of each issue.
+	//
+	// The issues are edited one by one, the process stops on the first issue that cannot be updated.
+	//
+	// PUT /rest/api/2/issue/{issueIdOrKey}
+	Orphan(ctx context.Context, issues []string) (*model.ResponseScheme, error)
 }
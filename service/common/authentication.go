@@ -1,5 +1,7 @@
 package common
 
+import "net/http"
+
 type Authentication interface {
 	SetBasicAuth(mail, token string)
 	GetBasicAuth() (string, string)
@@ -12,3 +14,16 @@ type Authentication interface {
 	SetExperimentalFlag()
 	HasSetExperimentalFlag() bool
 }
+
+// RequestSignerSetter is implemented by the authentications signing the requests, the clients check it with a
+// type assertion, so the Authentication implementations outside the module aren't required to implement it.
+type RequestSignerSetter interface {
+	SetRequestSigner(signer RequestSigner)
+	GetRequestSigner() RequestSigner
+	HasRequestSigner() bool
+}
+
+// RequestSigner signs each outgoing request once its URL is final, e.g. the Atlassian Connect JWT.
+type RequestSigner interface {
+	Sign(request *http.Request) error
+}
@@ -14,3 +14,9 @@ type Client interface {
 	TransformTheHTTPResponse(response *http.Response, structure interface{}) (*models.ResponseScheme, error)
 	TransformStructToReader(structure interface{}) (io.Reader, error)
 }
+
+// Doer is implemented by the clients sending a request without transforming the response, it's used by the
+// endpoints streaming the response body, e.g. the attachment downloads. The caller must close the response body.
+type Doer interface {
+	Do(request *http.Request) (*http.Response, error)
+}
@@ -55,4 +55,28 @@ type AttachmentConnector interface {
 	//
 	// https://docs.go-atlassian.io/jira-software-cloud/issues/attachments#download-attachment
 	Download(ctx context.Context, attachmentID string, redirect bool) (*model.ResponseScheme, error)
+
+	// Stream returns the contents of an attachment as a stream, the body isn't buffered on memory.
+	//
+	// The Range header is sent when the options offset is set, the size and the SHA-256 checksum
+	// are verified when the stream is fully read. The caller must close the stream.
+	//
+	// GET /rest/api/{2-3}/attachment/content/{id}
+	//
+	// https://docs.go-atlassian.io/jira-software-cloud/issues/attachments#download-attachment
+	Stream(ctx context.Context, attachmentID string, opts *model.AttachmentDownloadOptionsScheme) (io.ReadCloser, *model.ResponseScheme, error)
+
+	// DownloadTo copies the contents of an attachment into the writer, returning the bytes written.
+	//
+	// GET /rest/api/{2-3}/attachment/content/{id}
+	//
+	// https://docs.go-atlassian.io/jira-software-cloud/issues/attachments#download-attachment
+	DownloadTo(ctx context.Context, attachmentID string, writer io.Writer, opts *model.AttachmentDownloadOptionsScheme) (int64, *model.ResponseScheme, error)
+
+	// Upload adds one or more attachments to an issue on a single multipart request, the files are streamed from their readers.
+	//
+	// POST /rest/api/{2-3}/issue/{issueIdOrKey}/attachments
+	//
+	// https://docs.go-atlassian.io/jira-software-cloud/issues/attachments#add-attachment
+	Upload(ctx context.Context, issueKeyOrId string, files []*model.AttachmentFileScheme, progress model.AttachmentProgressFunc) ([]*model.AttachmentScheme, *model.ResponseScheme, error)
 }
@@ -0,0 +1,32 @@
+package jira
+
+import (
+	"context"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+)
+
+type HierarchyConnector interface {
+
+	// Tree builds the hierarchy below an issue, e.g. Initiative → Epic → Story → Sub-task.
+	//
+	// The children are fetched level by level using batched parent in (...) JQL queries, the site issue type
+	// hierarchy levels decide which issues can have children.
+	//
+	// GET /rest/api/{2-3}/issuetype
+	//
+	// POST /rest/api/{2-3}/search
+	//
+	// https://docs.go-atlassian.io/jira-software-cloud/issues/hierarchy#get-issue-tree
+	Tree(ctx context.Context, issueKeyOrID string, opts *model.IssueHierarchyOptionsScheme) (*model.IssueHierarchyScheme, *model.ResponseScheme, error)
+
+	// Search builds the hierarchies below the issues returned by a JQL query.
+	//
+	// Issues returned by the query that belong to another returned issue are nested instead of being roots.
+	//
+	// GET /rest/api/{2-3}/issuetype
+	//
+	// POST /rest/api/{2-3}/search
+	//
+	// https://docs.go-atlassian.io/jira-software-cloud/issues/hierarchy#search-issue-trees
+	Search(ctx context.Context, jql string, opts *model.IssueHierarchyOptionsScheme) (*model.IssueHierarchyScheme, *model.ResponseScheme, error)
+}
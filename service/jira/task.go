@@ -26,4 +26,13 @@ type TaskConnector interface {
 	//
 	// https://docs.go-atlassian.io/jira-software-cloud/tasks#cancel-task
 	Cancel(ctx context.Context, taskId string) (*model.ResponseScheme, error)
+
+	// Wait polls a task until it reaches a terminal status, e.g. the task returned by Project.DeleteAsynchronously.
+	//
+	// The task is returned when it's completed, a *model.TaskError is returned when it's failed, cancelled or dead.
+	//
+	// GET /rest/api/{2-3}/task/{taskId}
+	//
+	// https://docs.go-atlassian.io/jira-software-cloud/tasks#get-task
+	Wait(ctx context.Context, taskId string, opts *model.TaskWaitOptionsScheme) (*model.TaskScheme, *model.ResponseScheme, error)
 }
@@ -0,0 +1,55 @@
+package jira
+
+import (
+	"context"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+)
+
+type WebhookConnector interface {
+
+	// Register registers webhooks, the events of each webhook are filtered by its JQL filter.
+	//
+	// The webhooks expire after 30 days, use Refresh to extend their expiration.
+	//
+	// Only the Connect and OAuth 2.0 apps can use this operation.
+	//
+	// POST /rest/api/{2-3}/webhook
+	//
+	// https://docs.go-atlassian.io/jira-software-cloud/webhooks#register-dynamic-webhooks
+	Register(ctx context.Context, payload *model.WebhookSubscriptionPayloadScheme) (*model.WebhookRegistrationScheme, *model.ResponseScheme, error)
+
+	// Gets returns a paginated list of the webhooks registered by the calling app.
+	//
+	// GET /rest/api/{2-3}/webhook
+	//
+	// https://docs.go-atlassian.io/jira-software-cloud/webhooks#get-dynamic-webhooks-for-app
+	Gets(ctx context.Context, startAt, maxResults int) (*model.WebhookPageScheme, *model.ResponseScheme, error)
+
+	// Delete removes webhooks by ID, only the webhooks registered by the calling app are removed.
+	//
+	// DELETE /rest/api/{2-3}/webhook
+	//
+	// https://docs.go-atlassian.io/jira-software-cloud/webhooks#delete-webhooks-by-id
+	Delete(ctx context.Context, ids []int) (*model.ResponseScheme, error)
+
+	// Refresh extends the life of the webhooks, their expiration is set to 30 days from now.
+	//
+	// PUT /rest/api/{2-3}/webhook/refresh
+	//
+	// https://docs.go-atlassian.io/jira-software-cloud/webhooks#extend-webhook-life
+	Refresh(ctx context.Context, ids []int) (*model.WebhookRefreshScheme, *model.ResponseScheme, error)
+
+	// Failed returns the webhooks that failed to be delivered in the last 72 hours, after is the failure time,
+	// in milliseconds since the epoch, the page starts after, use the failure time of the last webhook of a page.
+	//
+	// GET /rest/api/{2-3}/webhook/failed
+	//
+	// https://docs.go-atlassian.io/jira-software-cloud/webhooks#get-failed-webhooks
+	Failed(ctx context.Context, maxResults int, after int64) (*model.FailedWebhookPageScheme, *model.ResponseScheme, error)
+
+	// KeepAlive refreshes the webhooks registered by the calling app before they expire, it checks their expiration
+	// on each interval, and refreshes the webhooks expiring within the threshold. It returns when the context is done.
+	//
+	// It's meant to run on the background, e.g. go client.Webhook.KeepAlive(ctx, opts)
+	KeepAlive(ctx context.Context, opts *model.WebhookKeepAliveOptionsScheme) error
+}
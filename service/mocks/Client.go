@@ -41,6 +41,29 @@ func (_m *Client) Call(request *http.Request, structure interface{}) (*models.Re
 	return r0, r1
 }
 
+// Do provides a mock function with given fields: request, it implements service.Doer
+func (_m *Client) Do(request *http.Request) (*http.Response, error) {
+	ret := _m.Called(request)
+
+	var r0 *http.Response
+	if rf, ok := ret.Get(0).(func(*http.Request) *http.Response); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*http.Response)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewFormRequest provides a mock function with given fields: ctx, method, apiEndpoint, contentType, payload
 func (_m *Client) NewFormRequest(ctx context.Context, method string, apiEndpoint string, contentType string, payload io.Reader) (*http.Request, error) {
 	ret := _m.Called(ctx, method, apiEndpoint, contentType, payload)
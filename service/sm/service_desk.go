@@ -32,4 +32,12 @@ type ServiceDeskConnector interface {
 	//
 	// https://docs.go-atlassian.io/jira-service-management-cloud/request/service-desk#attach-temporary-file
 	Attach(ctx context.Context, serviceDeskID int, fileName string, file io.Reader) (*model.ServiceDeskTemporaryFileScheme, *model.ResponseScheme, error)
+
+	// Upload attaches one or more temporary files to a service desk on a single multipart request,
+	// the files are streamed from their readers.
+	//
+	// POST /rest/servicedeskapi/servicedesk/{serviceDeskId}/attachTemporaryFile
+	//
+	// https://docs.go-atlassian.io/jira-service-management-cloud/request/service-desk#attach-temporary-file
+	Upload(ctx context.Context, serviceDeskID int, files []*model.AttachmentFileScheme, progress model.AttachmentProgressFunc) (*model.ServiceDeskTemporaryFileScheme, *model.ResponseScheme, error)
 }
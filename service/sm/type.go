@@ -49,4 +49,13 @@ type TypeConnector interface {
 	//
 	// https://docs.go-atlassian.io/jira-service-management-cloud/request/types#get-request-type-fields
 	Fields(ctx context.Context, serviceDeskID, requestTypeID int) (*model.RequestTypeFieldsScheme, *model.ResponseScheme, error)
+
+	// Validator returns a validator loaded with the fields of a service desk's customer request type.
+	//
+	// The validator type-checks the field values and resolves the option labels to IDs before creating the request.
+	//
+	// GET /rest/servicedeskapi/servicedesk/{serviceDeskId}/requesttype/{requestTypeId}/field
+	//
+	// https://docs.go-atlassian.io/jira-service-management-cloud/request/types#validate-request-type-fields
+	Validator(ctx context.Context, serviceDeskID, requestTypeID int) (*model.RequestFieldsValidator, *model.ResponseScheme, error)
 }
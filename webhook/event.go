@@ -0,0 +1,301 @@
+// Package webhook receives the Jira and Confluence webhooks, it decodes their payloads into typed events, verifies
+// their signatures and skips the deliveries retried once they're handled.
+//
+// The Jira webhooks send the issues and the comments on the REST API v2 representation, their description and
+// body are wiki markup texts, so they're decoded into model.IssueSchemeV2 and model.IssueCommentSchemeV2.
+package webhook
+
+import (
+	"encoding/json"
+	model "github.com/chrisccoy/go-atlassian/pkg/infra/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is the envelope of every webhook delivery, it's embedded on the typed events.
+type Event struct {
+
+	// Name is the webhookEvent of the payload, e.g. jira:issue_updated, or the event type of the Confluence
+	// payloads, e.g. page_created.
+	Name string
+
+	Timestamp time.Time
+
+	// DeliveryID identifies the delivery, it's the same on the retries. It's the X-Atlassian-Webhook-Identifier
+	// header, or the SHA-256 of the payload when it's not set.
+	DeliveryID string
+
+	// Retry is the number of the retry, zero on the first delivery.
+	Retry int
+
+	Payload json.RawMessage
+}
+
+type IssueEvent struct {
+	*Event
+	IssueEventTypeName string
+	User               *model.UserScheme
+	Issue              *model.IssueSchemeV2
+	Changelog          *model.IssueChangelogHistoryScheme
+	Comment            *model.IssueCommentSchemeV2
+}
+
+type CommentEvent struct {
+	*Event
+	Comment *model.IssueCommentSchemeV2
+
+	// Issue is a summary of the issue commented, with its id, key and a subset of its fields.
+	Issue *model.IssueSchemeV2
+}
+
+type WorklogEvent struct {
+	*Event
+	Worklog *model.IssueWorklogScheme
+}
+
+type SprintEvent struct {
+	*Event
+	Sprint *model.SprintScheme
+
+	// OldValue is the sprint before the change on the sprint_updated events.
+	OldValue *model.SprintScheme
+}
+
+type BoardEvent struct {
+	*Event
+	Board *model.BoardScheme
+}
+
+type VersionEvent struct {
+	*Event
+	Version *model.VersionScheme
+}
+
+// PageEvent is a Confluence page event, the page is mapped into a content with its id, title, space key, version
+// and history set.
+type PageEvent struct {
+	*Event
+	UserAccountID string
+	Page          *model.ContentScheme
+}
+
+type kind int
+
+const (
+	kindUnknown kind = iota
+	kindIssue
+	kindComment
+	kindWorklog
+	kindSprint
+	kindBoard
+	kindVersion
+	kindPage
+)
+
+// kindOf returns the kind of an event by its name.
+func kindOf(name string) kind {
+
+	switch {
+	case strings.HasPrefix(name, "jira:issue_"):
+		return kindIssue
+	case strings.HasPrefix(name, "comment_"):
+		return kindComment
+	case strings.HasPrefix(name, "worklog_"):
+		return kindWorklog
+	case strings.HasPrefix(name, "sprint_"):
+		return kindSprint
+	case strings.HasPrefix(name, "board_"):
+		return kindBoard
+	case strings.HasPrefix(name, "jira:version_"):
+		return kindVersion
+	case strings.HasPrefix(name, "page_"):
+		return kindPage
+	}
+
+	return kindUnknown
+}
+
+// envelope is the part of the payloads shared by the events.
+type envelope struct {
+	WebhookEvent string `json:"webhookEvent"`
+	EventType    string `json:"eventType"`
+	Timestamp    int64  `json:"timestamp"`
+}
+
+func (e *envelope) name() string {
+
+	if e.WebhookEvent != "" {
+		return e.WebhookEvent
+	}
+
+	return e.EventType
+}
+
+type issuePayload struct {
+	IssueEventTypeName string                             `json:"issue_event_type_name"`
+	User               *model.UserScheme                  `json:"user"`
+	Issue              *model.IssueSchemeV2               `json:"issue"`
+	Changelog          *model.IssueChangelogHistoryScheme `json:"changelog"`
+	Comment            *model.IssueCommentSchemeV2        `json:"comment"`
+}
+
+type commentPayload struct {
+	Comment *model.IssueCommentSchemeV2 `json:"comment"`
+	Issue   *model.IssueSchemeV2        `json:"issue"`
+}
+
+type worklogPayload struct {
+	Worklog *model.IssueWorklogScheme `json:"worklog"`
+}
+
+type sprintPayload struct {
+	Sprint   *model.SprintScheme `json:"sprint"`
+	OldValue *model.SprintScheme `json:"oldValue"`
+}
+
+type boardPayload struct {
+	Board *model.BoardScheme `json:"board"`
+}
+
+type versionPayload struct {
+	Version *model.VersionScheme `json:"version"`
+}
+
+type pagePayload struct {
+	UserAccountID string `json:"userAccountId"`
+	Page          *struct {
+		ID                    json.Number `json:"id"`
+		Title                 string      `json:"title"`
+		SpaceKey              string      `json:"spaceKey"`
+		ContentType           string      `json:"contentType"`
+		Version               int         `json:"version"`
+		CreatorAccountID      string      `json:"creatorAccountId"`
+		LastModifierAccountID string      `json:"lastModifierAccountId"`
+		CreationDate          int64       `json:"creationDate"`
+		ModificationDate      int64       `json:"modificationDate"`
+		Self                  string      `json:"self"`
+	} `json:"page"`
+}
+
+// content maps the page of a Confluence payload, its ids and dates are numbers, into a content.
+func (p *pagePayload) content() *model.ContentScheme {
+
+	if p.Page == nil {
+		return nil
+	}
+
+	page := p.Page
+	content := &model.ContentScheme{
+		ID:     page.ID.String(),
+		Type:   page.ContentType,
+		Status: "current",
+		Title:  page.Title,
+		Space:  &model.SpaceScheme{Key: page.SpaceKey},
+		Version: &model.ContentVersionScheme{
+			Number: page.Version,
+			When:   formatMilliseconds(page.ModificationDate),
+			By:     &model.ContentUserScheme{AccountID: page.LastModifierAccountID},
+		},
+		History: &model.ContentHistoryScheme{
+			CreatedDate: formatMilliseconds(page.CreationDate),
+			CreatedBy:   &model.ContentUserScheme{AccountID: page.CreatorAccountID},
+		},
+	}
+
+	if content.Type == "" {
+		content.Type = "page"
+	}
+
+	return content
+}
+
+func formatMilliseconds(milliseconds int64) string {
+
+	if milliseconds == 0 {
+		return ""
+	}
+
+	return fromMilliseconds(milliseconds).Format(time.RFC3339Nano)
+}
+
+func fromMilliseconds(milliseconds int64) time.Time {
+	return time.Unix(0, milliseconds*int64(time.Millisecond)).UTC()
+}
+
+// decode decodes the payload of an event into its typed event, the unknown events are returned as *Event.
+func decode(event *Event) (interface{}, error) {
+
+	switch kindOf(event.Name) {
+	case kindIssue:
+
+		payload := new(issuePayload)
+		if err := json.Unmarshal(event.Payload, payload); err != nil {
+			return nil, err
+		}
+
+		return &IssueEvent{Event: event, IssueEventTypeName: payload.IssueEventTypeName, User: payload.User,
+			Issue: payload.Issue, Changelog: payload.Changelog, Comment: payload.Comment}, nil
+
+	case kindComment:
+
+		payload := new(commentPayload)
+		if err := json.Unmarshal(event.Payload, payload); err != nil {
+			return nil, err
+		}
+
+		return &CommentEvent{Event: event, Comment: payload.Comment, Issue: payload.Issue}, nil
+
+	case kindWorklog:
+
+		payload := new(worklogPayload)
+		if err := json.Unmarshal(event.Payload, payload); err != nil {
+			return nil, err
+		}
+
+		return &WorklogEvent{Event: event, Worklog: payload.Worklog}, nil
+
+	case kindSprint:
+
+		payload := new(sprintPayload)
+		if err := json.Unmarshal(event.Payload, payload); err != nil {
+			return nil, err
+		}
+
+		return &SprintEvent{Event: event, Sprint: payload.Sprint, OldValue: payload.OldValue}, nil
+
+	case kindBoard:
+
+		payload := new(boardPayload)
+		if err := json.Unmarshal(event.Payload, payload); err != nil {
+			return nil, err
+		}
+
+		return &BoardEvent{Event: event, Board: payload.Board}, nil
+
+	case kindVersion:
+
+		payload := new(versionPayload)
+		if err := json.Unmarshal(event.Payload, payload); err != nil {
+			return nil, err
+		}
+
+		return &VersionEvent{Event: event, Version: payload.Version}, nil
+
+	case kindPage:
+
+		payload := new(pagePayload)
+		if err := json.Unmarshal(event.Payload, payload); err != nil {
+			return nil, err
+		}
+
+		return &PageEvent{Event: event, UserAccountID: payload.UserAccountID, Page: payload.content()}, nil
+	}
+
+	return event, nil
+}
+
+func parseRetry(value string) int {
+	retry, _ := strconv.Atoi(value)
+	return retry
+}
@@ -0,0 +1,297 @@
+package webhook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultMaxBodySize = 10 << 20
+
+// Deduplicator remembers the deliveries handled, so the retries of a delivery are only handled once.
+type Deduplicator interface {
+
+	// Seen reports whether the delivery was handled.
+	Seen(deliveryID string) bool
+
+	// Mark records the delivery as handled.
+	Mark(deliveryID string)
+}
+
+// MemoryDeduplicator remembers the deliveries handled for a TTL, the deliveries are lost on restart.
+type MemoryDeduplicator struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	handled map[string]time.Time
+	now     func() time.Time
+}
+
+// NewMemoryDeduplicator returns a deduplicator remembering the deliveries for the TTL, by default 24 hours.
+func NewMemoryDeduplicator(ttl time.Duration) *MemoryDeduplicator {
+
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+
+	return &MemoryDeduplicator{ttl: ttl, handled: map[string]time.Time{}, now: time.Now}
+}
+
+func (m *MemoryDeduplicator) Seen(deliveryID string) bool {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	handled, ok := m.handled[deliveryID]
+	return ok && m.now().Sub(handled) < m.ttl
+}
+
+func (m *MemoryDeduplicator) Mark(deliveryID string) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	for id, handled := range m.handled {
+		if now.Sub(handled) >= m.ttl {
+			delete(m.handled, id)
+		}
+	}
+
+	m.handled[deliveryID] = now
+}
+
+type Options struct {
+
+	// Verifier verifies the deliveries, e.g. SharedSecret or ConnectJWT, they aren't verified when it's nil.
+	Verifier Verifier
+
+	// Deduplicator skips the retries of the deliveries handled, by default a MemoryDeduplicator of 24 hours.
+	Deduplicator Deduplicator
+
+	// MaxBodySize is the limit of the payloads, by default 10 MiB.
+	MaxBodySize int64
+
+	// ErrorLog is called with the errors of the deliveries rejected or failed.
+	ErrorLog func(request *http.Request, err error)
+}
+
+// Receiver is an http.Handler decoding the webhooks into typed events and dispatching them to the handlers
+// registered. It responds 401 when the verification fails, 400 when the payload can't be decoded, and 500 when
+// a handler fails, so the delivery is retried. The deliveries without handlers are acknowledged.
+type Receiver struct {
+	options *Options
+
+	issue   []func(ctx context.Context, event *IssueEvent) error
+	comment []func(ctx context.Context, event *CommentEvent) error
+	worklog []func(ctx context.Context, event *WorklogEvent) error
+	sprint  []func(ctx context.Context, event *SprintEvent) error
+	board   []func(ctx context.Context, event *BoardEvent) error
+	version []func(ctx context.Context, event *VersionEvent) error
+	page    []func(ctx context.Context, event *PageEvent) error
+	other   []func(ctx context.Context, event *Event) error
+}
+
+func NewReceiver(options *Options) *Receiver {
+
+	if options == nil {
+		options = &Options{}
+	}
+
+	if options.Deduplicator == nil {
+		options.Deduplicator = NewMemoryDeduplicator(0)
+	}
+
+	if options.MaxBodySize <= 0 {
+		options.MaxBodySize = defaultMaxBodySize
+	}
+
+	return &Receiver{options: options}
+}
+
+// OnIssue registers a handler of the jira:issue_* events.
+func (r *Receiver) OnIssue(handler func(ctx context.Context, event *IssueEvent) error) {
+	r.issue = append(r.issue, handler)
+}
+
+// OnComment registers a handler of the comment_* events.
+func (r *Receiver) OnComment(handler func(ctx context.Context, event *CommentEvent) error) {
+	r.comment = append(r.comment, handler)
+}
+
+// OnWorklog registers a handler of the worklog_* events.
+func (r *Receiver) OnWorklog(handler func(ctx context.Context, event *WorklogEvent) error) {
+	r.worklog = append(r.worklog, handler)
+}
+
+// OnSprint registers a handler of the sprint_* events.
+func (r *Receiver) OnSprint(handler func(ctx context.Context, event *SprintEvent) error) {
+	r.sprint = append(r.sprint, handler)
+}
+
+// OnBoard registers a handler of the board_* events.
+func (r *Receiver) OnBoard(handler func(ctx context.Context, event *BoardEvent) error) {
+	r.board = append(r.board, handler)
+}
+
+// OnVersion registers a handler of the jira:version_* events.
+func (r *Receiver) OnVersion(handler func(ctx context.Context, event *VersionEvent) error) {
+	r.version = append(r.version, handler)
+}
+
+// OnPage registers a handler of the Confluence page_* events.
+func (r *Receiver) OnPage(handler func(ctx context.Context, event *PageEvent) error) {
+	r.page = append(r.page, handler)
+}
+
+// OnOther registers a handler of the events without a typed event, e.g. the project or the user events.
+func (r *Receiver) OnOther(handler func(ctx context.Context, event *Event) error) {
+	r.other = append(r.other, handler)
+}
+
+func (r *Receiver) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+
+	if request.Method != http.MethodPost {
+		http.Error(writer, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(writer, request.Body, r.options.MaxBodySize))
+	if err != nil {
+		r.fail(writer, request, http.StatusBadRequest, err)
+		return
+	}
+
+	if r.options.Verifier != nil {
+		if err := r.options.Verifier.Verify(request, body); err != nil {
+			r.fail(writer, request, http.StatusUnauthorized, err)
+			return
+		}
+	}
+
+	event, err := newEvent(request, body)
+	if err != nil {
+		r.fail(writer, request, http.StatusBadRequest, err)
+		return
+	}
+
+	if r.options.Deduplicator.Seen(event.DeliveryID) {
+		writer.WriteHeader(http.StatusOK)
+		return
+	}
+
+	typed, err := decode(event)
+	if err != nil {
+		r.fail(writer, request, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := r.dispatch(request.Context(), typed); err != nil {
+		r.fail(writer, request, http.StatusInternalServerError, err)
+		return
+	}
+
+	r.options.Deduplicator.Mark(event.DeliveryID)
+	writer.WriteHeader(http.StatusOK)
+}
+
+// newEvent returns the envelope of a delivery, the event name is read from the payload, or from the event query
+// parameter, e.g. on the Confluence webhooks registered with a URL per event.
+func newEvent(request *http.Request, body []byte) (*Event, error) {
+
+	envelope := new(envelope)
+	if err := json.Unmarshal(body, envelope); err != nil {
+		return nil, err
+	}
+
+	event := &Event{
+		Name:       envelope.name(),
+		DeliveryID: request.Header.Get("X-Atlassian-Webhook-Identifier"),
+		Retry:      parseRetry(request.Header.Get("X-Atlassian-Webhook-Retry")),
+		Payload:    body,
+	}
+
+	if event.Name == "" {
+		event.Name = request.URL.Query().Get("event")
+	}
+
+	if envelope.Timestamp != 0 {
+		event.Timestamp = fromMilliseconds(envelope.Timestamp)
+	}
+
+	if event.DeliveryID == "" {
+		sum := sha256.Sum256(body)
+		event.DeliveryID = hex.EncodeToString(sum[:])
+	}
+
+	return event, nil
+}
+
+func (r *Receiver) dispatch(ctx context.Context, typed interface{}) error {
+
+	switch event := typed.(type) {
+	case *IssueEvent:
+		for _, handler := range r.issue {
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+		}
+	case *CommentEvent:
+		for _, handler := range r.comment {
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+		}
+	case *WorklogEvent:
+		for _, handler := range r.worklog {
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+		}
+	case *SprintEvent:
+		for _, handler := range r.sprint {
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+		}
+	case *BoardEvent:
+		for _, handler := range r.board {
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+		}
+	case *VersionEvent:
+		for _, handler := range r.version {
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+		}
+	case *PageEvent:
+		for _, handler := range r.page {
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+		}
+	case *Event:
+		for _, handler := range r.other {
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Receiver) fail(writer http.ResponseWriter, request *http.Request, code int, err error) {
+
+	if r.options.ErrorLog != nil {
+		r.options.ErrorLog(request, err)
+	}
+
+	http.Error(writer, http.StatusText(code), code)
+}
@@ -0,0 +1,157 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/chrisccoy/go-atlassian/connect"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoSignature      = errors.New("webhook: no signature set")
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	ErrTokenExpired     = errors.New("webhook: token expired")
+	ErrInvalidQSH       = errors.New("webhook: query string hash mismatch")
+)
+
+// Verifier verifies the signature of a delivery, the body is the payload read from the request.
+type Verifier interface {
+	Verify(request *http.Request, body []byte) error
+}
+
+type VerifierFunc func(request *http.Request, body []byte) error
+
+func (f VerifierFunc) Verify(request *http.Request, body []byte) error {
+	return f(request, body)
+}
+
+// SharedSecret verifies the X-Hub-Signature header of the webhooks registered with a secret, it's the
+// HMAC-SHA256 of the payload, formatted as sha256=<hex>.
+func SharedSecret(secret string) Verifier {
+
+	return VerifierFunc(func(request *http.Request, body []byte) error {
+
+		header := request.Header.Get("X-Hub-Signature")
+		if header == "" {
+			return ErrNoSignature
+		}
+
+		parts := strings.SplitN(header, "=", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], "sha256") {
+			return fmt.Errorf("%w: unsupported method %q", ErrInvalidSignature, parts[0])
+		}
+
+		signature, err := hex.DecodeString(parts[1])
+		if err != nil {
+			return ErrInvalidSignature
+		}
+
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return ErrInvalidSignature
+		}
+
+		return nil
+	})
+}
+
+// SecretFunc returns the shared secret of a Connect installation by its client key, the iss claim of the tokens.
+type SecretFunc func(clientKey string) (string, error)
+
+// ConnectJWT verifies the JWT sent by Atlassian Connect on the Authorization header, or the jwt parameter.
+// The token must be signed with HS256 by the shared secret of the installation, not expired, and its query string
+// hash must match the request. basePath is the path of the app base URL, stripped from the request path.
+func ConnectJWT(basePath string, secret SecretFunc) Verifier {
+
+	return VerifierFunc(func(request *http.Request, body []byte) error {
+
+		token := request.URL.Query().Get("jwt")
+		if header := request.Header.Get("Authorization"); strings.HasPrefix(header, "JWT ") {
+			token = strings.TrimPrefix(header, "JWT ")
+		}
+
+		if token == "" {
+			return ErrNoSignature
+		}
+
+		parts := strings.Split(token, ".")
+		if len(parts) != 3 {
+			return fmt.Errorf("%w: malformed token", ErrInvalidSignature)
+		}
+
+		var header struct {
+			Alg string `json:"alg"`
+		}
+
+		var claims struct {
+			Issuer    string `json:"iss"`
+			ExpiresAt int64  `json:"exp"`
+			QSH       string `json:"qsh"`
+		}
+
+		if err := decodeSegment(parts[0], &header); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+		}
+
+		if header.Alg != "HS256" {
+			return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, header.Alg)
+		}
+
+		if err := decodeSegment(parts[1], &claims); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+		}
+
+		key, err := secret(claims.Issuer)
+		if err != nil {
+			return err
+		}
+
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+		}
+
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(parts[0] + "." + parts[1]))
+
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return ErrInvalidSignature
+		}
+
+		if time.Now().Unix() > claims.ExpiresAt {
+			return ErrTokenExpired
+		}
+
+		if claims.QSH != QueryStringHash(request.Method, strings.TrimPrefix(request.URL.Path, basePath), request.URL.Query()) {
+			return ErrInvalidQSH
+		}
+
+		return nil
+	})
+}
+
+func decodeSegment(segment string, structure interface{}) error {
+
+	decoded, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(decoded, structure)
+}
+
+// QueryStringHash returns the Connect query string hash of a request, the SHA-256 of its canonical request:
+// the method, the path and the sorted query parameters, without the jwt parameter.
+func QueryStringHash(method, path string, query url.Values) string {
+	return connect.QueryStringHash(method, path, query)
+}
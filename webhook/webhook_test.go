@@ -0,0 +1,261 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const issueUpdated = `{"timestamp":1617282000000,"webhookEvent":"jira:issue_updated","issue_event_type_name":"issue_generic",` +
+	`"user":{"accountId":"5b10ac8d82e05b22cc7d4ef5","displayName":"Alice"},` +
+	`"issue":{"id":"10002","key":"KP-2","fields":{"summary":"Broken build","description":"h1. Steps"}},` +
+	`"changelog":{"id":"10100","items":[{"field":"status","fromString":"To Do","toString":"In Progress"}]}}`
+
+func deliver(receiver http.Handler, target, body string, headers map[string]string) *httptest.ResponseRecorder {
+
+	request := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	recorder := httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestReceiver_Dispatch(t *testing.T) {
+
+	receiver := NewReceiver(nil)
+
+	var issues []*IssueEvent
+	failures := 1
+
+	receiver.OnIssue(func(ctx context.Context, event *IssueEvent) error {
+
+		if failures > 0 {
+			failures--
+			return errors.New("downstream unavailable")
+		}
+
+		issues = append(issues, event)
+		return nil
+	})
+
+	headers := map[string]string{"X-Atlassian-Webhook-Identifier": "delivery-1"}
+
+	// The delivery failed is retried, and the retries of the delivery handled are skipped.
+	assert.Equal(t, http.StatusInternalServerError, deliver(receiver, "/webhook", issueUpdated, headers).Code)
+
+	headers["X-Atlassian-Webhook-Retry"] = "1"
+	assert.Equal(t, http.StatusOK, deliver(receiver, "/webhook", issueUpdated, headers).Code)
+
+	headers["X-Atlassian-Webhook-Retry"] = "2"
+	assert.Equal(t, http.StatusOK, deliver(receiver, "/webhook", issueUpdated, headers).Code)
+
+	if assert.Len(t, issues, 1) {
+
+		event := issues[0]
+		assert.Equal(t, "jira:issue_updated", event.Name)
+		assert.Equal(t, 1, event.Retry)
+		assert.Equal(t, "delivery-1", event.DeliveryID)
+		assert.True(t, event.Timestamp.Equal(time.Date(2021, 4, 1, 13, 0, 0, 0, time.UTC)))
+		assert.Equal(t, "KP-2", event.Issue.Key)
+		assert.Equal(t, "h1. Steps", event.Issue.Fields.Description)
+		assert.Equal(t, "Alice", event.User.DisplayName)
+		assert.Equal(t, "In Progress", event.Changelog.Items[0].ToString)
+	}
+
+	recorder := httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	assert.Equal(t, http.StatusBadRequest, deliver(receiver, "/webhook", "{", nil).Code)
+}
+
+func TestReceiver_Events(t *testing.T) {
+
+	receiver := NewReceiver(nil)
+
+	var got []string
+	receiver.OnComment(func(ctx context.Context, event *CommentEvent) error {
+		got = append(got, event.Name+" "+event.Issue.Key+" "+event.Comment.Body)
+		return nil
+	})
+
+	receiver.OnWorklog(func(ctx context.Context, event *WorklogEvent) error {
+		got = append(got, event.Name+" "+event.Worklog.TimeSpent)
+		return nil
+	})
+
+	receiver.OnSprint(func(ctx context.Context, event *SprintEvent) error {
+		got = append(got, event.Name+" "+event.Sprint.Name+" "+event.Sprint.StartDate.Format("2006-01-02"))
+		return nil
+	})
+
+	receiver.OnBoard(func(ctx context.Context, event *BoardEvent) error {
+		got = append(got, event.Name+" "+event.Board.Name)
+		return nil
+	})
+
+	receiver.OnVersion(func(ctx context.Context, event *VersionEvent) error {
+		got = append(got, event.Name+" "+event.Version.Name)
+		return nil
+	})
+
+	receiver.OnPage(func(ctx context.Context, event *PageEvent) error {
+		page := event.Page
+		got = append(got, event.Name+" "+page.ID+" "+page.Type+" "+page.Space.Key+" "+page.Version.When+" "+page.Version.By.AccountID)
+		return nil
+	})
+
+	receiver.OnOther(func(ctx context.Context, event *Event) error {
+		got = append(got, event.Name)
+		return nil
+	})
+
+	deliveries := []struct{ target, body string }{
+		{"/webhook", `{"webhookEvent":"comment_created","comment":{"id":"10000","body":"LGTM"},"issue":{"key":"KP-2"}}`},
+		{"/webhook", `{"webhookEvent":"worklog_created","worklog":{"id":"10000","timeSpent":"2h"}}`},
+		{"/webhook", `{"webhookEvent":"sprint_started","sprint":{"id":1,"name":"Sprint 1","startDate":"2021-04-01T09:00:00.000Z"}}`},
+		{"/webhook", `{"webhookEvent":"board_created","board":{"id":1,"name":"KP board","type":"scrum"}}`},
+		{"/webhook", `{"webhookEvent":"jira:version_released","version":{"id":"10000","name":"1.0"}}`},
+		{"/webhook?event=page_updated", `{"timestamp":1617282000000,"userAccountId":"5b10ac8d82e05b22cc7d4ef5",` +
+			`"page":{"id":65839,"title":"Runbook","spaceKey":"OPS","version":3,"lastModifierAccountId":"5b10ac8d82e05b22cc7d4ef5",` +
+			`"modificationDate":1617282000000}}`},
+		{"/webhook", `{"webhookEvent":"project_created","project":{"key":"KP"}}`},
+	}
+
+	for _, delivery := range deliveries {
+		assert.Equal(t, http.StatusOK, deliver(receiver, delivery.target, delivery.body, nil).Code, delivery.body)
+	}
+
+	assert.Equal(t, []string{
+		"comment_created KP-2 LGTM",
+		"worklog_created 2h",
+		"sprint_started Sprint 1 2021-04-01",
+		"board_created KP board",
+		"jira:version_released 1.0",
+		"page_updated 65839 page OPS 2021-04-01T13:00:00Z 5b10ac8d82e05b22cc7d4ef5",
+		"project_created",
+	}, got)
+}
+
+func TestSharedSecret(t *testing.T) {
+
+	receiver := NewReceiver(&Options{Verifier: SharedSecret("It's a Secret to Everybody")})
+
+	mac := hmac.New(sha256.New, []byte("It's a Secret to Everybody"))
+	mac.Write([]byte(issueUpdated))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, http.StatusOK, deliver(receiver, "/webhook", issueUpdated, map[string]string{"X-Hub-Signature": signature}).Code)
+	assert.Equal(t, http.StatusUnauthorized, deliver(receiver, "/webhook", issueUpdated+" ", map[string]string{"X-Hub-Signature": signature}).Code)
+	assert.Equal(t, http.StatusUnauthorized, deliver(receiver, "/webhook", issueUpdated, nil).Code)
+}
+
+func sign(t *testing.T, secret string, claims map[string]interface{}) string {
+
+	header, _ := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestConnectJWT(t *testing.T) {
+
+	verifier := ConnectJWT("/app", func(clientKey string) (string, error) {
+
+		if clientKey != "jira:client-key" {
+			return "", errors.New("unknown installation")
+		}
+
+		return "shared-secret", nil
+	})
+
+	target := "/app/webhook?event=issue&project=KP"
+	qsh := QueryStringHash(http.MethodPost, "/webhook", url.Values{"project": {"KP"}, "event": {"issue"}})
+	expires := time.Now().Add(time.Minute).Unix()
+
+	testCases := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{
+			name:  "when the token is valid",
+			token: sign(t, "shared-secret", map[string]interface{}{"iss": "jira:client-key", "exp": expires, "qsh": qsh}),
+		},
+
+		{
+			name:    "when the token is signed by another secret",
+			token:   sign(t, "another-secret", map[string]interface{}{"iss": "jira:client-key", "exp": expires, "qsh": qsh}),
+			wantErr: ErrInvalidSignature,
+		},
+
+		{
+			name:    "when the token is expired",
+			token:   sign(t, "shared-secret", map[string]interface{}{"iss": "jira:client-key", "exp": time.Now().Add(-time.Minute).Unix(), "qsh": qsh}),
+			wantErr: ErrTokenExpired,
+		},
+
+		{
+			name:    "when the query string hash is for another request",
+			token:   sign(t, "shared-secret", map[string]interface{}{"iss": "jira:client-key", "exp": expires, "qsh": QueryStringHash(http.MethodPost, "/webhook", nil)}),
+			wantErr: ErrInvalidQSH,
+		},
+
+		{
+			name:    "when there is no token",
+			wantErr: ErrNoSignature,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			request := httptest.NewRequest(http.MethodPost, target, strings.NewReader(issueUpdated))
+			if testCase.token != "" {
+				request.Header.Set("Authorization", "JWT "+testCase.token)
+			}
+
+			err := verifier.Verify(request, []byte(issueUpdated))
+			if testCase.wantErr != nil {
+				assert.True(t, errors.Is(err, testCase.wantErr), err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQueryStringHash(t *testing.T) {
+
+	canonical := "GET&/rest/api/3/search&fields=key%2Csummary,status&jql=project%20%3D%20KP"
+	sum := sha256.Sum256([]byte(canonical))
+
+	got := QueryStringHash("get", "/rest/api/3/search/", url.Values{
+		"jql":    {"project = KP"},
+		"fields": {"status", "key,summary"},
+		"jwt":    {"ignored"},
+	})
+
+	assert.Equal(t, hex.EncodeToString(sum[:]), got)
+}